@@ -3,49 +3,74 @@ package main
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 
+	"ghostdraft/ddragon"
+	"ghostdraft/internal/champselect"
 	"ghostdraft/internal/data"
+	"ghostdraft/internal/events"
+	"ghostdraft/internal/history"
 	"ghostdraft/internal/lcu"
-
-	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"ghostdraft/internal/scouting"
+	"ghostdraft/internal/settings"
 )
 
 // App struct
 type App struct {
 	ctx              context.Context
+	emitter          events.Emitter        // Frontend events (Wails at runtime, a Recorder in tests)
+	headless         bool                  // No window - events go to the companion server instead
 	lcuClient        *lcu.Client
 	wsClient         *lcu.WebSocketClient
 	liveClient       *lcu.LiveClient
+	ddragon          *ddragon.Client       // Disk-cached Data Dragon (names, items, icons)
+	staticPin        staticPin             // Patch and locale the registries below are loaded for
 	champions        *lcu.ChampionRegistry
 	items            *lcu.ItemRegistry
 	championDB       *data.ChampionDB
-	tursoClient      *data.TursoClient     // Turso database connection
-	statsProvider    *data.StatsProvider   // Stats queries (uses Turso with caching)
+	matchStore       *history.Store        // Local match history (all accounts on this PC)
+	historySync      *history.Syncer       // Pages LCU match history into matchStore
+	historySyncing   atomic.Bool           // A match history sync is running
+	statsConn        *data.StatsConn       // Turso connection; swaps the stats provider in and out
+	statsCache       *data.QueryCache      // LRU + on-disk cache shared by every stats provider
+	hovers           *data.HoverLog        // Champ select hovers, used to pick what to prefetch
+	settings         *settings.Store       // User settings (settings.json)
+	scouting         *scouting.Service     // Cached, parallel match history for scouting
+	scoutingSeq      atomic.Int64          // Latest scouting run; older runs stop emitting
+	allyMu           sync.Mutex            // Guards ally scouting state below
+	allySession      *lcu.ChampSelectSession
+	allyLookups      map[string]*allyLookup
+	allyEmitKey      string
+	champSelect      *champselect.Store    // Latest champ select snapshot and locked pick (passed to in-game)
+	fetches          *champselect.Pipeline // Champ select fetches; stale results are dropped
+	inGameBuild      atomic.Pointer[data.BuildPath] // Current game's recommended build; the Tab HUD's next purchase aims at it
+	statsQueue       atomic.Int64          // Queue the client is in (from the gameflow session); picks the stats dataset
+	statsBracket     atomic.Value          // string: rank bracket from the player's solo queue tier; picks the stats bracket
+	statsRegion      atomic.Value          // string: region the client is logged into; picks the stats region
+	liveRecordPath   string                // Where Live Client snapshots are recorded (-record-live); empty when off
 	stopPoll         chan struct{}
-	lastFetchedChamp    int
-	lastFetchedEnemy    int
-	lastBanFetchKey     string
-	lastItemFetchKey    string
-	lastCounterFetchKey string
-	windowVisible       bool
-
-	// Champ select state - passed to in-game
-	lockedChampionID   int
-	lockedChampionName string
-	lockedPosition     string
+	windowVisible    bool
 
 	// User identity - stored on LCU connection
-	currentPUUID string
+	userPUUID atomic.Value // string: the logged-in player's PUUID; read from syncs and bound getters
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
+	lcuClient := lcu.NewClient()
+	dd := openDDragon()
 	return &App{
-		lcuClient:     lcu.NewClient(),
+		lcuClient:     lcuClient,
 		wsClient:      lcu.NewWebSocketClient(),
 		liveClient:    lcu.NewLiveClient(),
-		champions:     lcu.NewChampionRegistry(),
-		items:         lcu.NewItemRegistry(),
+		ddragon:       dd,
+		champions:     lcu.NewChampionRegistry(dd, assetRoute),
+		items:         lcu.NewItemRegistry(dd, assetRoute),
+		scouting:      scouting.NewService(lcuClient, scouting.DefaultConcurrency, scouting.DefaultTTL),
+		emitter:       events.Discard,
+		champSelect:   champselect.NewStore(),
+		fetches:       champselect.NewPipeline(context.Background()),
 		stopPoll:      make(chan struct{}),
 		windowVisible: true,
 	}
@@ -54,6 +79,12 @@ func NewApp() *App {
 // startup is called when the app starts
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	if a.emitter == events.Discard {
+		a.emitter = events.NewWailsEmitter(ctx)
+	}
+
+	// Load user settings first - window layout and hotkeys depend on them
+	a.initSettings()
 
 	// Initialize champion database
 	if db, err := data.NewChampionDB(); err != nil {
@@ -63,34 +94,34 @@ func (a *App) startup(ctx context.Context) {
 		fmt.Println("Champion database initialized")
 	}
 
-	// Position and size window relative to screen
-	screens, err := runtime.ScreenGetAll(ctx)
-	if err == nil && len(screens) > 0 {
-		screen := screens[0]
-		// Size: ~20% width, ~55% height (roughly matches champ select sidebar)
-		width := screen.Size.Width * 20 / 100
-		height := screen.Size.Height * 55 / 100
-		runtime.WindowSetSize(ctx, width, height)
-		// Position at right edge, vertically centered
-		x := screen.Size.Width - width - 20
-		y := (screen.Size.Height - height) / 2
-		runtime.WindowSetPosition(ctx, x, y)
+	// Hover counts decide which champions' stats get prefetched
+	if hovers, err := data.NewHoverLog(); err != nil {
+		fmt.Printf("Failed to load hover log: %v\n", err)
+	} else {
+		a.hovers = hovers
+	}
+
+	// Initialize local match history database
+	if store, err := history.NewStore(); err != nil {
+		fmt.Printf("Failed to initialize match history DB: %v\n", err)
+	} else {
+		a.matchStore = store
+		a.historySync = history.NewSyncer(a.lcuClient, store)
+		a.scouting.SetStore(store)
+		fmt.Println("Match history database initialized")
 	}
 
-	// Load data from Data Dragon in parallel
-	go func() {
-		if err := a.champions.Load(); err != nil {
-			fmt.Printf("Failed to load champions: %v\n", err)
-		}
-	}()
-	go func() {
-		if err := a.items.Load(); err != nil {
-			fmt.Printf("Failed to load items: %v\n", err)
-		}
-	}()
-
-	// Initialize stats database and check for updates
-	go a.initStats()
+	// Position and size window from settings
+	if !a.headless {
+		a.applyWindowLayout(a.currentSettings().Window)
+	}
+
+	// Load champion and item names from Data Dragon (or its disk cache). They're
+	// reloaded for the stats patch once Turso answers, and for the client's language.
+	go a.syncStaticData("", "")
+
+	// Connect to the stats database (retries in the background)
+	a.initStats()
 
 	// Set up champ select handler
 	a.wsClient.SetChampSelectHandler(a.onChampSelectUpdate)
@@ -101,46 +132,66 @@ func (a *App) startup(ctx context.Context) {
 	// Start polling for League Client
 	go a.pollForLeagueClient()
 
-	// Register global hotkey (Ctrl+O to toggle visibility)
-	a.RegisterToggleHotkey()
-}
+	// Record games for replaying later, if asked to
+	a.startLiveRecorder()
 
-// initStats initializes the Turso connection and stats provider
-func (a *App) initStats() {
-	// Connect to Turso
-	tursoClient, err := data.NewTursoClient()
-	if err != nil {
-		fmt.Printf("Failed to connect to Turso: %v\n", err)
-		return
+	// Register global hotkey (Ctrl+O by default, configurable in settings)
+	if !a.headless {
+		a.RegisterToggleHotkey()
 	}
-	a.tursoClient = tursoClient
+}
 
-	// Create stats provider with caching
-	provider, err := data.NewStatsProvider(tursoClient)
-	if err != nil {
-		fmt.Printf("Stats provider not available: %v\n", err)
-		return
-	}
+// initStats starts the Turso connection manager. It keeps retrying in the background,
+// so stats become available whenever Turso is reachable.
+func (a *App) initStats() {
+	// Results are also kept on disk, so a restart doesn't start cold
+	a.statsCache = openStatsCache()
+
+	conn := data.NewStatsConn(data.OpenTurso, a.statsCache, data.ConnOptions{})
+	conn.OnConnect(func(provider *data.StatsProvider) {
+		matchups := a.currentSettings().Matchups
+		provider.SetMatchupThresholds(matchups.WinningThreshold, matchups.LosingThreshold)
+		provider.SetItemCatalog(a.items.Catalog)
+		provider.SetDataset(a.statsDataset())
+		provider.SetBracket(a.statsBracketName())
+		provider.SetRegion(a.statsRegionName())
+		go a.prefetchStats(provider)
+		go a.syncStaticData(provider.GetPatch(), "")
+	})
+	conn.OnStatus(func(status data.ConnStatus) {
+		a.emitter.Emit(events.StatsStatus(status))
+	})
+	a.statsConn = conn
+	conn.Start()
+
+	go a.watchStatsVersion()
+}
 
-	// Fetch current patch from Turso
-	if err := provider.FetchPatch(); err != nil {
-		fmt.Printf("No stats patch available: %v\n", err)
-		return
+// statsProvider returns the current stats provider, or nil while Turso is unreachable.
+// Read it once per operation; it can be swapped out at any time.
+func (a *App) statsProvider() *data.StatsProvider {
+	if a.statsConn == nil {
+		return nil
 	}
-
-	a.statsProvider = provider
-	fmt.Printf("Stats provider ready (patch %s)\n", provider.GetPatch())
+	return a.statsConn.Provider()
 }
 
 // shutdown is called when the app is closing
 func (a *App) shutdown(ctx context.Context) {
 	close(a.stopPoll)
+	a.fetches.CancelAll()
 	a.wsClient.Disconnect()
 	a.lcuClient.Disconnect()
 	if a.championDB != nil {
 		a.championDB.Close()
 	}
-	if a.tursoClient != nil {
-		a.tursoClient.Close()
+	if a.matchStore != nil {
+		a.matchStore.Close()
+	}
+	if a.statsConn != nil {
+		a.statsConn.Close()
+	}
+	if a.statsCache != nil {
+		a.statsCache.Close()
 	}
 }
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"ghostdraft/internal/advisor"
+	"ghostdraft/internal/data"
+	"ghostdraft/internal/events"
+	"ghostdraft/internal/lcu"
+)
+
+// nextPurchase suggests the active player's next buy toward the in-game build. It returns
+// nil without a build or item data, or once the whole build is bought.
+func (a *App) nextPurchase(me *lcu.LiveClientPlayer, gold int) *events.NextPurchase {
+	build := a.inGameBuild.Load()
+	if me == nil || build == nil {
+		return nil
+	}
+
+	inventory := make([]int, 0, len(me.Items))
+	for _, item := range me.Items {
+		inventory = append(inventory, item.ItemID)
+	}
+	purchase, ok := advisor.Next(a.items.Catalog(), buildOrder(build), inventory, gold)
+	if !ok {
+		return nil
+	}
+
+	next := &events.NextPurchase{
+		Target:      a.goldItem(purchase.Target),
+		Cost:        purchase.Cost,
+		CurrentGold: gold,
+		GoldMissing: purchase.GoldMissing,
+	}
+	if purchase.Item != 0 {
+		item := a.goldItem(purchase.Item)
+		next.Item = &item
+	}
+	return next
+}
+
+// buildOrder lists a build's items in buying order: core items, then the top 4th, 5th and 6th picks
+func buildOrder(build *data.BuildPath) []int {
+	order := append([]int{}, build.CoreItems...)
+	for _, options := range [][]data.ItemOption{build.FourthItemOptions, build.FifthItemOptions, build.SixthItemOptions} {
+		if len(options) > 0 {
+			order = append(order, options[0].ItemID)
+		}
+	}
+	return order
+}
+
+// goldItem describes an item for the Tab HUD
+func (a *App) goldItem(id int) events.GoldItem {
+	return events.GoldItem{
+		ID:      id,
+		Name:    a.items.GetName(id),
+		Gold:    a.items.GetGold(id),
+		IconURL: a.items.GetIconURL(id),
+	}
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ghostdraft/internal/events"
+	"ghostdraft/internal/lcu"
+	"ghostdraft/internal/scouting"
+)
+
+const (
+	// allyMaxAttempts caps how often a teammate's lookups are tried before giving up
+	allyMaxAttempts = 3
+	// allyRetryBackoff is the wait before retrying a failed lookup, doubling per attempt
+	allyRetryBackoff = 5 * time.Second
+)
+
+// allyLookup is what we've resolved about a teammate so far
+type allyLookup struct {
+	summoner *lcu.Summoner
+	ranked   *lcu.RankedStats
+	history  *lcu.MatchHistoryResponse
+	status   string
+	err      string
+	fetching bool      // A resolve is running
+	attempts int       // Resolves started so far
+	retryAt  time.Time // When the parts that failed may be fetched again
+}
+
+// complete reports whether every part of the lookup has resolved
+func (l *allyLookup) complete() bool {
+	return l.summoner != nil && l.ranked != nil && l.history != nil
+}
+
+// due reports whether the lookup should be (re)started: it isn't running, something is
+// still missing, and the backoff and attempt cap allow another try
+func (l *allyLookup) due(now time.Time) bool {
+	return !l.fetching && !l.complete() && l.attempts < allyMaxAttempts && !now.Before(l.retryAt)
+}
+
+// updateAllies refreshes the ally panel from a champ select session.
+// Lookups start once per teammate, and failed parts are retried with backoff; hover
+// changes re-emit from what's already resolved.
+func (a *App) updateAllies(session *lcu.ChampSelectSession) {
+	a.allyMu.Lock()
+
+	a.allySession = session
+	if a.allyLookups == nil {
+		a.allyLookups = make(map[string]*allyLookup)
+	}
+	now := time.Now()
+	for _, player := range session.MyTeam {
+		if player.PUUID == "" {
+			continue
+		}
+		lookup, ok := a.allyLookups[player.PUUID]
+		if !ok {
+			lookup = &allyLookup{status: scoutStatusLoading}
+			a.allyLookups[player.PUUID] = lookup
+		}
+		if lookup.due(now) {
+			lookup.fetching = true
+			lookup.attempts++
+			go a.resolveAlly(player.PUUID, lookup, lookup.summoner == nil, lookup.ranked == nil, lookup.history == nil)
+		}
+	}
+
+	// Only emit when someone's hover, lock or position changed
+	key := allyKey(session)
+	if key == a.allyEmitKey {
+		a.allyMu.Unlock()
+		return
+	}
+	a.allyEmitKey = key
+	profiles := a.buildAllyProfilesLocked()
+	a.allyMu.Unlock()
+
+	a.emitAllies(profiles)
+}
+
+// clearAllies resets ally scouting when champ select ends
+func (a *App) clearAllies() {
+	a.allyMu.Lock()
+	a.allySession = nil
+	a.allyLookups = nil
+	a.allyEmitKey = ""
+	a.allyMu.Unlock()
+
+	a.emitter.Emit(events.AlliesUpdate{
+		HasData: false,
+	})
+}
+
+// resolveAlly fetches the parts of a teammate's lookup that are missing (Riot ID, rank
+// and recent history), merges them in and re-emits
+func (a *App) resolveAlly(puuid string, lookup *allyLookup, wantSummoner, wantRanked, wantHistory bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var summoner *lcu.Summoner
+	if wantSummoner {
+		var err error
+		if summoner, err = a.lcuClient.GetSummonerByPUUID(ctx, puuid); err != nil {
+			fmt.Printf("[Allies] Summoner lookup failed for %s: %v\n", shortPUUID(puuid), err)
+		}
+	}
+
+	var ranked *lcu.RankedStats
+	if wantRanked {
+		var err error
+		if ranked, err = a.lcuClient.GetRankedStats(ctx, puuid); err != nil {
+			fmt.Printf("[Allies] Ranked lookup failed for %s: %v\n", shortPUUID(puuid), err)
+		}
+	}
+
+	// Shares the scouting cache, so in-game scouting reuses these fetches
+	var history *lcu.MatchHistoryResponse
+	var historyErr error
+	if wantHistory {
+		if history, historyErr = a.scouting.History(ctx, puuid); historyErr != nil {
+			fmt.Printf("[Allies] History lookup failed for %s: %v\n", shortPUUID(puuid), historyErr)
+		}
+	}
+
+	a.allyMu.Lock()
+	// Champ select ended (or restarted) while we were fetching
+	if a.allyLookups[puuid] != lookup || a.allySession == nil {
+		a.allyMu.Unlock()
+		return
+	}
+	if summoner != nil {
+		lookup.summoner = summoner
+	}
+	if ranked != nil {
+		lookup.ranked = ranked
+	}
+	if history != nil {
+		lookup.history = history
+	}
+	if lookup.history != nil {
+		lookup.status, lookup.err = scoutStatusReady, ""
+	} else if historyErr != nil {
+		lookup.status, lookup.err = scoutStatusError, historyErr.Error()
+	}
+	lookup.fetching = false
+	if !lookup.complete() {
+		lookup.retryAt = time.Now().Add(allyRetryBackoff << (lookup.attempts - 1))
+	}
+	profiles := a.buildAllyProfilesLocked()
+	a.allyMu.Unlock()
+
+	a.emitAllies(profiles)
+}
+
+// buildAllyProfilesLocked builds the ally cards from the latest session. Caller holds allyMu.
+func (a *App) buildAllyProfilesLocked() []events.AllyProfile {
+	session := a.allySession
+	locked := lockedCells(session)
+
+	var profiles []events.AllyProfile
+	for _, player := range session.MyTeam {
+		championID := player.GetHoveredChampion()
+		profile := events.AllyProfile{
+			CellID:     player.CellID,
+			PUUID:      player.PUUID,
+			GameName:   player.GameName,
+			TagLine:    player.TagLine,
+			IsMe:       player.CellID == session.LocalPlayerCellID,
+			Position:   player.GetPosition(),
+			ChampionID: championID,
+			IsLocked:   locked[player.CellID],
+			Status:     scoutStatusLoading,
+			RankLabel:  "Unranked",
+		}
+		if championID > 0 {
+			profile.ChampionName = a.champions.GetName(championID)
+			profile.ChampionIcon = a.champions.GetIconURL(championID)
+		}
+
+		lookup := a.allyLookups[player.PUUID]
+		if player.PUUID == "" {
+			// Hidden or bot teammates can't be looked up
+			profile.Status = scoutStatusError
+			profile.Error = "player hidden"
+		} else if lookup != nil {
+			applyAllyLookup(&profile, lookup)
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	return profiles
+}
+
+// applyAllyLookup fills a profile from resolved lookups
+func applyAllyLookup(profile *events.AllyProfile, lookup *allyLookup) {
+	profile.Status = lookup.status
+	profile.Error = lookup.err
+
+	if lookup.summoner != nil && profile.GameName == "" {
+		profile.GameName = lookup.summoner.GameName
+		profile.TagLine = lookup.summoner.TagLine
+	}
+
+	if lookup.ranked != nil {
+		solo := lookup.ranked.SoloQueue()
+		if solo.IsRanked() {
+			profile.RankTier = solo.Tier
+			profile.RankDivision = solo.Division
+		}
+		profile.RankLabel = solo.Label()
+	}
+
+	if lookup.history != nil {
+		summary := scouting.SummarizeHistory(lookup.history, profile.ChampionID, profile.Position)
+		profile.RecentGames = summary.RecentGames
+		profile.RecentWinRate = summary.RecentWinRate
+		profile.ChampionGames = summary.ChampionGames
+		profile.ChampionWinRate = summary.ChampionWinRate
+		profile.FirstTime = summary.FirstTime
+		profile.Autofilled = summary.Autofilled
+		profile.MainRoles = summary.MainRoles
+	}
+}
+
+// emitAllies sends the ally panel to the frontend
+func (a *App) emitAllies(profiles []events.AllyProfile) {
+	a.emitter.Emit(events.AlliesUpdate{
+		HasData: true,
+		Allies:  profiles,
+	})
+}
+
+// lockedCells returns which cells have completed their pick
+func lockedCells(session *lcu.ChampSelectSession) map[int]bool {
+	locked := make(map[int]bool)
+	for _, actionGroup := range session.Actions {
+		for _, action := range actionGroup {
+			if action.Type == "pick" && action.Completed {
+				locked[action.ActorCellID] = true
+			}
+		}
+	}
+	return locked
+}
+
+// allyKey summarizes each teammate's hover, lock and position for change detection
+func allyKey(session *lcu.ChampSelectSession) string {
+	locked := lockedCells(session)
+	var parts []string
+	for _, player := range session.MyTeam {
+		parts = append(parts, fmt.Sprintf("%d:%s:%d:%s:%v",
+			player.CellID, player.PUUID, player.GetHoveredChampion(), player.GetPosition(), locked[player.CellID]))
+	}
+	return strings.Join(parts, "|")
+}
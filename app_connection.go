@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"ghostdraft/internal/events"
 )
 
 // pollForLeagueClient continuously checks for League Client
@@ -36,15 +36,15 @@ func (a *App) pollForLeagueClient() {
 				// If we were connected before, emit disconnect event
 				if wasConnected {
 					a.wsClient.Disconnect()
-					runtime.EventsEmit(a.ctx, "lcu:status", map[string]interface{}{
-						"connected": false,
-						"message":   "League Disconnected. Waiting...",
+					a.emitter.Emit(events.LCUStatus{
+						Connected: false,
+						Message:   "League Disconnected. Waiting...",
 					})
-					runtime.EventsEmit(a.ctx, "champselect:update", map[string]interface{}{
-						"inChampSelect": false,
+					a.emitter.Emit(events.ChampSelectUpdate{
+						InChampSelect: false,
 					})
-					runtime.EventsEmit(a.ctx, "build:update", map[string]interface{}{
-						"hasBuild": false,
+					a.emitter.Emit(events.BuildUpdate{
+						HasBuild: false,
 					})
 					fmt.Println("League Disconnected. Waiting for reconnection...")
 					wasConnected = false
@@ -86,30 +86,48 @@ func (a *App) connectWebSocket() {
 func (a *App) tryConnect() {
 	err := a.lcuClient.Connect()
 	if err != nil {
-		runtime.EventsEmit(a.ctx, "lcu:status", map[string]interface{}{
-			"connected": false,
-			"message":   "Waiting for League...",
+		a.emitter.Emit(events.LCUStatus{
+			Connected: false,
+			Message:   "Waiting for League...",
 		})
 		return
 	}
 
 	// Successfully connected
-	runtime.EventsEmit(a.ctx, "lcu:status", map[string]interface{}{
-		"connected": true,
-		"message":   "League Connected!",
-		"port":      a.lcuClient.GetPort(),
+	a.emitter.Emit(events.LCUStatus{
+		Connected: true,
+		Message:   "League Connected!",
+		Port:      a.lcuClient.GetPort(),
 	})
 
 	// Store current user's PUUID for in-game identification
 	if puuid, err := a.lcuClient.GetCurrentSummonerPUUID(); err == nil && puuid != "" {
-		a.currentPUUID = puuid
+		a.userPUUID.Store(puuid)
 		if len(puuid) > 8 {
 			fmt.Printf("Stored user PUUID: %s...\n", puuid[:8])
 		} else {
 			fmt.Printf("Stored user PUUID: %s\n", puuid)
 		}
+
+		// Pull any games played since the last sync into the local history
+		go a.syncMatchHistory()
+
+		// Read stats from the player's own rank bracket
+		go a.updateStatsBracket(puuid)
 	}
 
+	// Show champion and item names in the client's language, and stats from its region
+	go func() {
+		regionLocale, err := a.lcuClient.GetRegionLocale()
+		if err != nil {
+			return
+		}
+		a.updateStatsRegion(regionLocale.Region)
+		if regionLocale.Locale != "" {
+			a.syncStaticData("", regionLocale.Locale)
+		}
+	}()
+
 	fmt.Printf("League Connected! Port: %s\n", a.lcuClient.GetPort())
 }
 
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"ghostdraft/ddragon"
+)
+
+// assetRoute is where the frontend loads Data Dragon images, served from the disk cache
+const assetRoute = "/ddragon"
+
+// staticLoadTimeout bounds one reload of champion and item data
+const staticLoadTimeout = 30 * time.Second
+
+// openDDragon creates the Data Dragon client, cached under GhostDraft/ddragon
+func openDDragon() *ddragon.Client {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		fmt.Printf("Data Dragon cache disabled: %v\n", err)
+		return ddragon.New(ddragon.Options{})
+	}
+	return ddragon.New(ddragon.Options{CacheDir: filepath.Join(configDir, "GhostDraft", "ddragon")})
+}
+
+// staticPin is the Data Dragon patch and locale champion and item names are loaded for
+type staticPin struct {
+	mu     sync.Mutex // Held for a whole reload, so reloads never interleave
+	patch  string     // Stats patch; empty until Turso answers, meaning the latest version
+	locale string     // League client language
+}
+
+// syncStaticData reloads champions and items when the stats patch or the client's
+// language changes. Empty arguments keep the current value.
+func (a *App) syncStaticData(patch, locale string) {
+	a.staticPin.mu.Lock()
+	defer a.staticPin.mu.Unlock()
+
+	if patch != "" {
+		a.staticPin.patch = patch
+	}
+	if locale != "" {
+		a.staticPin.locale = locale
+	}
+	if a.staticPin.locale == "" {
+		a.staticPin.locale = ddragon.DefaultLocale
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), staticLoadTimeout)
+	defer cancel()
+
+	version, err := a.ddragon.Resolve(ctx, a.staticPin.patch)
+	if err != nil {
+		fmt.Printf("Failed to resolve Data Dragon version: %v\n", err)
+		return
+	}
+	locale = a.staticPin.locale
+
+	var wg sync.WaitGroup
+	if !a.champions.IsLoaded() || a.champions.GetVersion() != version || a.champions.GetLocale() != locale {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loadLocalized(ctx, "champions", version, locale, a.champions.Load)
+		}()
+	}
+	if a.items.GetVersion() != version || a.items.GetLocale() != locale {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loadLocalized(ctx, "items", version, locale, a.items.Load)
+		}()
+	}
+	wg.Wait()
+}
+
+// loadLocalized runs a registry load, falling back to English when Data Dragon
+// doesn't have the client's language
+func loadLocalized(ctx context.Context, what, version, locale string, load func(context.Context, string, string) error) {
+	err := load(ctx, version, locale)
+	if errors.Is(err, ddragon.ErrNotFound) && locale != ddragon.DefaultLocale {
+		fmt.Printf("No %s data for %s, using %s\n", what, locale, ddragon.DefaultLocale)
+		err = load(ctx, version, ddragon.DefaultLocale)
+	}
+	if err != nil {
+		fmt.Printf("Failed to load %s: %v\n", what, err)
+	}
+}
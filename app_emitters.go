@@ -3,48 +3,51 @@ package main
 import (
 	"fmt"
 
+	"ghostdraft/internal/champselect"
 	"ghostdraft/internal/data"
-
-	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"ghostdraft/internal/events"
 )
 
 // fetchAndEmitBuild fetches matchup data from our database and emits it to frontend
-func (a *App) fetchAndEmitBuild(championID int, championName string, role string, enemyChampionIDs []int) {
+func (a *App) fetchAndEmitBuild(job *champselect.Job, championID int, championName string, role string, enemyChampionIDs []int) {
 	fmt.Printf("Fetching matchup for %s (%s) vs %d enemies...\n", championName, role, len(enemyChampionIDs))
 
-	patch := ""
-	if a.statsProvider != nil {
-		patch = a.statsProvider.GetPatch()
+	patch, bracket := "", ""
+	provider := a.statsProvider()
+	if provider != nil {
+		patch = provider.GetPatch()
+		bracket = data.BracketLabel(provider.Dataset().Bracket)
 	}
 
 	if len(enemyChampionIDs) == 0 {
-		runtime.EventsEmit(a.ctx, "build:update", map[string]interface{}{
-			"hasBuild":     true,
-			"championName": championName,
-			"role":         role,
-			"winRate":      "-",
-			"winRateLabel": "Waiting for enemy...",
-			"patch":        patch,
+		a.emitFor(job, events.BuildUpdate{
+			HasBuild:     true,
+			ChampionName: championName,
+			Role:         role,
+			WinRate:      "-",
+			WinRateLabel: "Waiting for enemy...",
+			Patch:        patch,
+			Bracket:      bracket,
 		})
 		fmt.Printf("No enemies detected yet for %s\n", championName)
 		return
 	}
 
-	if a.statsProvider == nil {
-		runtime.EventsEmit(a.ctx, "build:update", map[string]interface{}{
-			"hasBuild": false,
-			"error":    "Stats provider not available",
+	if provider == nil {
+		a.emitFor(job, events.BuildUpdate{
+			HasBuild: false,
+			Error:    "Stats provider not available",
 		})
 		fmt.Println("Stats provider not available for matchups")
 		return
 	}
 
 	// Fetch our matchups - this gives us all enemies we face in our role
-	matchups, err := a.statsProvider.FetchAllMatchups(championID, role)
+	matchups, err := provider.FetchAllMatchups(job.Context(), championID, role)
 	if err != nil {
-		runtime.EventsEmit(a.ctx, "build:update", map[string]interface{}{
-			"hasBuild": false,
-			"error":    err.Error(),
+		a.emitFor(job, events.BuildUpdate{
+			HasBuild: false,
+			Error:    err.Error(),
 		})
 		fmt.Printf("Failed to fetch matchups: %v\n", err)
 		return
@@ -68,13 +71,14 @@ func (a *App) fetchAndEmitBuild(championID int, championName string, role string
 	}
 
 	if laneOpponentID == 0 {
-		runtime.EventsEmit(a.ctx, "build:update", map[string]interface{}{
-			"hasBuild":     true,
-			"championName": championName,
-			"role":         role,
-			"winRate":      "-",
-			"winRateLabel": "No lane opponent found",
-			"patch":        patch,
+		a.emitFor(job, events.BuildUpdate{
+			HasBuild:     true,
+			ChampionName: championName,
+			Role:         role,
+			WinRate:      "-",
+			WinRateLabel: "No lane opponent found",
+			Patch:        patch,
+			Bracket:      bracket,
 		})
 		fmt.Printf("No lane opponent found in matchup data for %s\n", championName)
 		return
@@ -82,220 +86,233 @@ func (a *App) fetchAndEmitBuild(championID int, championName string, role string
 
 	enemyName := a.champions.GetName(laneOpponentID)
 
-	// Determine matchup status: winning (>51%), losing (<49%), even (49-51%)
-	var matchupStatus string
-	if matchupWR >= 51.0 {
-		matchupStatus = "winning"
-	} else if matchupWR <= 49.0 {
-		matchupStatus = "losing"
-	} else {
-		matchupStatus = "even"
-	}
+	// Determine matchup status: winning (>=51%), losing (<=49%), even - thresholds from settings
+	matchupStatus := a.matchupStatus(matchupWR)
 
 	fmt.Printf("Matchup: %s vs %s = %.1f%% (%s, %d games)\n", championName, enemyName, matchupWR, matchupStatus, matchupGames)
-	runtime.EventsEmit(a.ctx, "build:update", map[string]interface{}{
-		"hasBuild":      true,
-		"championName":  championName,
-		"role":          role,
-		"winRate":       fmt.Sprintf("%.1f%%", matchupWR),
-		"winRateLabel":  fmt.Sprintf("vs %s", enemyName),
-		"enemyName":     enemyName,
-		"matchupStatus": matchupStatus,
-		"patch":         patch,
+	a.emitFor(job, events.BuildUpdate{
+		HasBuild:      true,
+		ChampionName:  championName,
+		Role:          role,
+		WinRate:       fmt.Sprintf("%.1f%%", matchupWR),
+		WinRateLabel:  fmt.Sprintf("vs %s", enemyName),
+		EnemyName:     enemyName,
+		MatchupStatus: matchupStatus,
+		Patch:         patch,
+		Bracket:       bracket,
 	})
 }
 
 // fetchAndEmitCounterPicks fetches champions that counter the enemy laner
-func (a *App) fetchAndEmitCounterPicks(enemyChampionID int, role string) {
+func (a *App) fetchAndEmitCounterPicks(job *champselect.Job, enemyChampionID int, role string) {
 	enemyName := a.champions.GetName(enemyChampionID)
 	fmt.Printf("Fetching counter picks vs %s (%s)...\n", enemyName, role)
 
-	if a.statsProvider == nil {
+	provider := a.statsProvider()
+	if provider == nil {
 		fmt.Println("Stats provider not available for counter picks")
-		runtime.EventsEmit(a.ctx, "counterpicks:update", map[string]interface{}{
-			"hasData": false,
+		a.emitFor(job, events.CounterPicksUpdate{
+			HasData: false,
 		})
 		return
 	}
 
-	counterPicks, err := a.statsProvider.FetchCounterPicks(enemyChampionID, role, 6)
+	counterPicks, err := provider.FetchCounterPicks(job.Context(), enemyChampionID, role, 6)
 	if err != nil || len(counterPicks) == 0 {
 		fmt.Printf("No counter pick data vs %s: %v\n", enemyName, err)
-		runtime.EventsEmit(a.ctx, "counterpicks:update", map[string]interface{}{
-			"hasData":   true,
-			"enemyName": enemyName,
-			"enemyIcon": a.champions.GetIconURL(enemyChampionID),
-			"picks":     []map[string]interface{}{},
+		a.emitFor(job, events.CounterPicksUpdate{
+			HasData:   true,
+			EnemyName: enemyName,
+			EnemyIcon: a.champions.GetIconURL(enemyChampionID),
+			Picks:     []events.ChampionPick{},
 		})
 		return
 	}
 
 	// Convert to frontend format
-	var pickList []map[string]interface{}
+	var pickList []events.ChampionPick
 	for _, m := range counterPicks {
-		champName := a.champions.GetName(m.EnemyChampionID)
-		pickList = append(pickList, map[string]interface{}{
-			"championID":   m.EnemyChampionID,
-			"championName": champName,
-			"iconURL":      a.champions.GetIconURL(m.EnemyChampionID),
-			"winRate":      m.WinRate,
-			"games":        m.Matches,
+		pickList = append(pickList, events.ChampionPick{
+			ChampionID:   m.EnemyChampionID,
+			ChampionName: a.champions.GetName(m.EnemyChampionID),
+			IconURL:      a.champions.GetIconURL(m.EnemyChampionID),
+			WinRate:      m.WinRate,
+			Games:        m.Matches,
 		})
 	}
 
 	fmt.Printf("Counter picks vs %s: ", enemyName)
 	for _, p := range pickList {
-		fmt.Printf("%s (%.1f%%) ", p["championName"], p["winRate"])
+		fmt.Printf("%s (%.1f%%) ", p.ChampionName, p.WinRate)
 	}
 	fmt.Println()
 
-	runtime.EventsEmit(a.ctx, "counterpicks:update", map[string]interface{}{
-		"hasData":   true,
-		"enemyName": enemyName,
-		"enemyIcon": a.champions.GetIconURL(enemyChampionID),
-		"picks":     pickList,
+	a.emitFor(job, events.CounterPicksUpdate{
+		HasData:   true,
+		EnemyName: enemyName,
+		EnemyIcon: a.champions.GetIconURL(enemyChampionID),
+		Picks:     pickList,
 	})
 }
 
 // fetchAndEmitRecommendedBans fetches hardest counters and emits as recommended bans
-func (a *App) fetchAndEmitRecommendedBans(championID int, role string) {
+func (a *App) fetchAndEmitRecommendedBans(job *champselect.Job, championID int, role string) {
 	championName := a.champions.GetName(championID)
 	fmt.Printf("Fetching recommended bans for %s (%s)...\n", championName, role)
 
 	// Use our stats provider for counter matchups
-	if a.statsProvider == nil {
+	provider := a.statsProvider()
+	if provider == nil {
 		fmt.Println("Stats provider not available for bans")
-		runtime.EventsEmit(a.ctx, "bans:update", map[string]interface{}{
-			"hasBans":      true,
-			"championName": championName,
-			"role":         role,
-			"bans":         []map[string]interface{}{},
-			"noData":       true,
+		a.emitFor(job, events.BansUpdate{
+			HasBans:      true,
+			ChampionName: championName,
+			Role:         role,
+			Bans:         []events.ChampionPick{},
+			NoData:       true,
 		})
 		return
 	}
 
-	matchups, err := a.statsProvider.FetchCounterMatchups(championID, role, 5)
+	matchups, err := provider.FetchCounterMatchups(job.Context(), championID, role, data.RecommendedBans)
 	if err != nil || len(matchups) == 0 {
 		fmt.Printf("No matchup data for %s %s: %v\n", championName, role, err)
-		runtime.EventsEmit(a.ctx, "bans:update", map[string]interface{}{
-			"hasBans":      true,
-			"championName": championName,
-			"role":         role,
-			"bans":         []map[string]interface{}{},
-			"noData":       true,
+		a.emitFor(job, events.BansUpdate{
+			HasBans:      true,
+			ChampionName: championName,
+			Role:         role,
+			Bans:         []events.ChampionPick{},
+			NoData:       true,
 		})
 		return
 	}
 
 	// Convert to frontend format
-	var banList []map[string]interface{}
+	var banList []events.ChampionPick
 	for _, m := range matchups {
 		enemyName := a.champions.GetName(m.EnemyChampionID)
 		damageType := "Unknown"
 		if a.championDB != nil {
 			damageType = a.championDB.GetDamageType(enemyName)
 		}
-		banList = append(banList, map[string]interface{}{
-			"championID":   m.EnemyChampionID,
-			"championName": enemyName,
-			"iconURL":      a.champions.GetIconURL(m.EnemyChampionID),
-			"damageType":   damageType,
-			"winRate":      m.WinRate,
-			"games":        m.Matches,
+		banList = append(banList, events.ChampionPick{
+			ChampionID:   m.EnemyChampionID,
+			ChampionName: enemyName,
+			IconURL:      a.champions.GetIconURL(m.EnemyChampionID),
+			DamageType:   damageType,
+			WinRate:      m.WinRate,
+			Games:        m.Matches,
 		})
 	}
 
 	fmt.Printf("Counter matchups for %s: ", championName)
 	for _, b := range banList {
-		fmt.Printf("%s (%.1f%%) ", b["championName"], b["winRate"])
+		fmt.Printf("%s (%.1f%%) ", b.ChampionName, b.WinRate)
 	}
 	fmt.Println()
 
-	runtime.EventsEmit(a.ctx, "bans:update", map[string]interface{}{
-		"hasBans":      true,
-		"championName": championName,
-		"role":         role,
-		"bans":         banList,
+	a.emitFor(job, events.BansUpdate{
+		HasBans:      true,
+		ChampionName: championName,
+		Role:         role,
+		Bans:         banList,
 	})
 }
 
 // fetchAndEmitItems fetches item build from our stats database and emits to frontend
-func (a *App) fetchAndEmitItems(championID int, championName string, role string) {
+func (a *App) fetchAndEmitItems(job *champselect.Job, championID int, championName string, role string) {
 	fmt.Printf("Fetching items for %s (%s)...\n", championName, role)
 
-	if a.statsProvider == nil {
+	provider := a.statsProvider()
+	if provider == nil {
 		fmt.Println("Stats provider not available")
-		runtime.EventsEmit(a.ctx, "items:update", map[string]interface{}{
-			"hasItems": false,
-		})
-		return
-	}
-
-	buildData, err := a.statsProvider.FetchChampionData(championID, championName, role)
-	if err != nil {
-		fmt.Printf("No data for %s: %v\n", championName, err)
-		runtime.EventsEmit(a.ctx, "items:update", map[string]interface{}{
-			"hasItems": false,
+		a.emitFor(job, events.ItemsUpdate{
+			HasItems: false,
 		})
 		return
 	}
 
-	// Helper to convert item IDs to frontend format
-	convertItems := func(itemIDs []int) []map[string]interface{} {
-		var result []map[string]interface{}
-		for _, itemID := range itemIDs {
-			result = append(result, map[string]interface{}{
-				"id":      itemID,
-				"name":    a.items.GetName(itemID),
-				"iconURL": a.items.GetIconURL(itemID),
-			})
+	// A partial build is shown straight away; its slow slots keep loading in the
+	// background, so one more fetch fills them in
+	for attempt := 1; attempt <= 2; attempt++ {
+		buildData, err := provider.FetchChampionData(job.Context(), championID, championName, role)
+		if err != nil {
+			fmt.Printf("No data for %s: %v\n", championName, err)
+			if attempt == 1 {
+				a.emitFor(job, events.ItemsUpdate{
+					HasItems: false,
+				})
+			}
+			return
 		}
-		return result
-	}
 
-	// Helper to convert item options with win rates
-	convertItemOptions := func(options []data.ItemOption) []map[string]interface{} {
-		var result []map[string]interface{}
-		for _, opt := range options {
-			result = append(result, map[string]interface{}{
-				"id":      opt.ItemID,
-				"name":    a.items.GetName(opt.ItemID),
-				"iconURL": a.items.GetIconURL(opt.ItemID),
-				"winRate": opt.WinRate,
-				"games":   opt.Games,
+		// Convert all build paths
+		var builds []events.ItemBuild
+		for _, build := range buildData.Builds {
+			// Name the build after the first core item
+			buildName := "Build"
+			if len(build.CoreItems) > 0 {
+				buildName = a.items.GetName(build.CoreItems[0])
+			}
+
+			builds = append(builds, events.ItemBuild{
+				Name:          buildName,
+				WinRate:       build.WinRate,
+				Games:         build.Games,
+				StartingItems: a.itemRefs(build.StartingItems),
+				CoreItems:     a.itemRefs(build.CoreItems),
+				FourthItems:   a.itemOptions(build.FourthItemOptions),
+				FifthItems:    a.itemOptions(build.FifthItemOptions),
+				SixthItems:    a.itemOptions(build.SixthItemOptions),
 			})
 		}
-		return result
-	}
 
-	// Convert all build paths
-	var builds []map[string]interface{}
-	for _, build := range buildData.Builds {
-		// Name the build after the first core item
-		buildName := "Build"
-		if len(build.CoreItems) > 0 {
-			buildName = a.items.GetName(build.CoreItems[0])
+		fmt.Printf("Found %d build paths for %s (partial: %v)\n", len(builds), championName, buildData.Partial)
+
+		a.emitFor(job, events.ItemsUpdate{
+			HasItems:     true,
+			ChampionName: championName,
+			Role:         role,
+			Builds:       builds,
+		})
+
+		if !buildData.Partial || !job.Current() {
+			return
 		}
+	}
+}
 
-		builds = append(builds, map[string]interface{}{
-			"name":          buildName,
-			"winRate":       build.WinRate,
-			"games":         build.Games,
-			"startingItems": convertItems(build.StartingItems),
-			"coreItems":     convertItems(build.CoreItems),
-			"fourthItems":   convertItemOptions(build.FourthItemOptions),
-			"fifthItems":    convertItemOptions(build.FifthItemOptions),
-			"sixthItems":    convertItemOptions(build.SixthItemOptions),
+// itemRefs converts item IDs to frontend items
+func (a *App) itemRefs(itemIDs []int) []events.Item {
+	var result []events.Item
+	for _, itemID := range itemIDs {
+		result = append(result, events.Item{
+			ID:      itemID,
+			Name:    a.items.GetName(itemID),
+			IconURL: a.items.GetIconURL(itemID),
 		})
 	}
+	return result
+}
 
-	fmt.Printf("Found %d build paths for %s\n", len(builds), championName)
+// itemOptions converts item slot options to frontend items with their win rates
+func (a *App) itemOptions(options []data.ItemOption) []events.ItemOption {
+	var result []events.ItemOption
+	for _, opt := range options {
+		result = append(result, events.ItemOption{
+			ID:      opt.ItemID,
+			Name:    a.items.GetName(opt.ItemID),
+			IconURL: a.items.GetIconURL(opt.ItemID),
+			WinRate: opt.WinRate,
+			Games:   opt.Games,
+		})
+	}
+	return result
+}
 
-	runtime.EventsEmit(a.ctx, "items:update", map[string]interface{}{
-		"hasItems":     true,
-		"championName": championName,
-		"role":         role,
-		"builds":       builds,
-	})
+// emitFor emits e unless a newer champ select update has replaced job's fetch
+func (a *App) emitFor(job *champselect.Job, e events.Event) {
+	if !job.Commit(func() { a.emitter.Emit(e) }) {
+		fmt.Printf("[ChampSelect] Dropped stale %s\n", e.EventName())
+	}
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"ghostdraft/internal/lcu"
+)
+
+// liveRecordInterval is how often the Live Client recorder saves a snapshot
+const liveRecordInterval = 5 * time.Second
+
+// replayLiveGame serves a recording on a local port and reads in-game data from it
+// instead of the game, so in-game features can be worked on without League running
+func (a *App) replayLiveGame(path string) error {
+	snapshots, err := lcu.LoadLiveSnapshots(path)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	go http.Serve(listener, lcu.NewLiveReplay(snapshots))
+
+	url := "http://" + listener.Addr().String()
+	a.liveClient = lcu.NewLiveClientAt(url)
+	fmt.Printf("[LiveReplay] Replaying %d snapshots from %s at %s\n", len(snapshots), path, url)
+	return nil
+}
+
+// startLiveRecorder records every game to liveRecordPath until shutdown, if recording is on
+func (a *App) startLiveRecorder() {
+	if a.liveRecordPath == "" {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-a.stopPoll
+		cancel()
+	}()
+	go func() {
+		if err := lcu.NewLiveRecorder(a.liveClient, a.liveRecordPath, liveRecordInterval).Run(ctx); err != nil {
+			fmt.Printf("[LiveRecorder] %v\n", err)
+		}
+	}()
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"ghostdraft/internal/data"
@@ -19,6 +20,7 @@ type MetaChampion struct {
 // MetaData represents the top champions for all roles
 type MetaData struct {
 	Patch   string                    `json:"patch"`
+	Bracket string                    `json:"bracket"` // rank bracket the tier list comes from, e.g. "Diamond"
 	HasData bool                      `json:"hasData"`
 	Roles   map[string][]MetaChampion `json:"roles"`
 }
@@ -94,13 +96,15 @@ func (a *App) GetMetaChampions() MetaData {
 		Roles:   make(map[string][]MetaChampion),
 	}
 
-	if a.statsProvider == nil {
+	provider := a.statsProvider()
+	if provider == nil {
 		return result
 	}
 
-	result.Patch = a.statsProvider.GetPatch()
+	result.Patch = provider.GetPatch()
+	result.Bracket = data.BracketLabel(provider.Bracket())
 
-	roleData, err := a.statsProvider.FetchAllRolesTopChampions(5)
+	roleData, err := provider.FetchAllRolesTopChampions(context.Background(), 5)
 	if err != nil {
 		return result
 	}
@@ -140,11 +144,12 @@ func (a *App) GetChampionBuild(championID int, role string) ChampionBuildData {
 	result.IconURL = a.champions.GetIconURL(championID)
 	result.SplashURL = a.champions.GetSplashURL(championID)
 
-	if a.statsProvider == nil {
+	provider := a.statsProvider()
+	if provider == nil {
 		return result
 	}
 
-	buildData, err := a.statsProvider.FetchChampionData(championID, champName, role)
+	buildData, err := provider.FetchChampionData(context.Background(), championID, champName, role)
 	if err != nil || buildData == nil || len(buildData.Builds) == 0 {
 		return result
 	}
@@ -215,7 +220,8 @@ func (a *App) GetChampionDetails(championID int, role string) ChampionDetails {
 		GoodMatchups: []ChampionDetailMatchup{},
 	}
 
-	if a.statsProvider == nil {
+	provider := a.statsProvider()
+	if provider == nil {
 		return result
 	}
 
@@ -223,7 +229,7 @@ func (a *App) GetChampionDetails(championID int, role string) ChampionDetails {
 	result.ChampionName = champName
 
 	// Fetch build data
-	buildData, err := a.statsProvider.FetchChampionData(championID, champName, role)
+	buildData, err := provider.FetchChampionData(context.Background(), championID, champName, role)
 	if err == nil && buildData != nil && len(buildData.Builds) > 0 {
 		result.HasData = true
 		build := buildData.Builds[0]
@@ -272,7 +278,7 @@ func (a *App) GetChampionDetails(championID int, role string) ChampionDetails {
 	}
 
 	// Fetch counters (champions that beat you) - separate from allMatchups
-	counters, err := a.statsProvider.FetchCounterMatchups(championID, role, 6)
+	counters, err := provider.FetchCounterMatchups(context.Background(), championID, role, 6)
 	if err != nil {
 		fmt.Printf("Failed to fetch counters for %s: %v\n", champName, err)
 	} else {
@@ -295,7 +301,7 @@ func (a *App) GetChampionDetails(championID int, role string) ChampionDetails {
 	}
 
 	// Fetch good matchups (champions you beat)
-	allMatchups, err := a.statsProvider.FetchAllMatchups(championID, role)
+	allMatchups, err := provider.FetchAllMatchups(context.Background(), championID, role)
 	if err == nil && len(allMatchups) > 0 {
 		result.HasData = true
 
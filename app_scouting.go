@@ -0,0 +1,444 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"ghostdraft/internal/events"
+	"ghostdraft/internal/insights"
+	"ghostdraft/internal/lcu"
+	"ghostdraft/internal/scouting"
+)
+
+// Scouting status values for each player
+const (
+	scoutStatusLoading = "loading"
+	scoutStatusReady   = "ready"
+	scoutStatusError   = "error"
+)
+
+// PlayerStats represents calculated stats for a player
+type PlayerStats struct {
+	PUUID        string
+	GameName     string
+	TagLine      string
+	ChampionID   int
+	ChampionName string
+	ChampionIcon string
+	Team         int
+	IsMe         bool
+	Position     string // top, jungle, middle, bottom, utility ("" outside ranked)
+	Status       string // "loading", "ready", "error"
+	Error        string
+	// Ranked profile and champion proficiency
+	SummonerLevel   int
+	SoloRank        lcu.RankedQueueStats
+	FlexRank        lcu.RankedQueueStats
+	MasteryLevel    int
+	MasteryPoints   int
+	ChampionGames   int // recent games on this champion
+	ChampionWinRate float64
+	// Classification
+	IsSmurf    bool
+	IsOneTrick bool
+	IsOffRole  bool
+	// Stats from recent games
+	Games      int
+	Wins       int
+	WinRate    float64
+	AvgKills   float64
+	AvgDeaths  float64
+	AvgAssists float64
+	KDA        float64
+	AvgCS      float64
+	MainRoles  []string
+	// Tilt detection
+	RecentResults []bool // true = win, newest first
+	RecentLosses  int    // losses in last 3 games
+	WorstGame     string // e.g., "0/8/2 on Yasuo"
+	TiltLevel     string // "tilted", "warming_up", "on_fire", "smurf", ""
+	FunFact       string // top insight
+	Insights      []insights.Insight
+}
+
+// warmScouting prefetches match history for everyone in the game session
+func (a *App) warmScouting() {
+	players, _, err := a.lcuClient.GetGamePlayers()
+	if err != nil {
+		fmt.Printf("Scouting warm-up skipped: %v\n", err)
+		return
+	}
+
+	var puuids []string
+	for _, p := range players {
+		puuids = append(puuids, p.PUUID)
+	}
+	fmt.Printf("Warming scouting cache for %d players\n", len(puuids))
+	a.scouting.Warm(puuids)
+}
+
+// fetchAndEmitScouting fetches all players' recent stats in parallel,
+// emitting an updated snapshot as each player finishes
+func (a *App) fetchAndEmitScouting() {
+	fmt.Println("Fetching scouting data...")
+
+	// Newer runs supersede older ones - stale runs stop emitting
+	seq := a.scoutingSeq.Add(1)
+
+	players, myPUUID, err := a.lcuClient.GetGamePlayers()
+	if err != nil {
+		fmt.Printf("Failed to get game players: %v\n", err)
+		a.emitter.Emit(events.ScoutingUpdate{
+			HasData: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	fmt.Printf("Found %d players in game\n", len(players))
+
+	// Start everyone as loading so the panel renders immediately
+	var mu sync.Mutex
+	allStats := make([]PlayerStats, len(players))
+	index := make(map[string]int, len(players))
+	var puuids []string
+	for i, player := range players {
+		allStats[i] = a.newPlayerStats(player, myPUUID)
+		index[player.PUUID] = i
+		puuids = append(puuids, player.PUUID)
+	}
+	a.emitScouting(allStats, false)
+
+	// Reloaded every game so edits to the rules file apply without a restart
+	rules := insights.Load()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	a.scouting.Scout(ctx, puuids, func(puuid string, history *lcu.MatchHistoryResponse, err error) {
+		i := index[puuid]
+		player := players[i]
+		if err := a.lcuClient.ResolveGamePlayer(ctx, &player); err != nil {
+			fmt.Printf("Summoner lookup failed for %s: %v\n", shortPUUID(puuid), err)
+		}
+		ranked, mastery := a.fetchScoutProfile(ctx, player)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		allStats[i].GameName = player.GameName
+		allStats[i].TagLine = player.TagLine
+		allStats[i].SummonerLevel = player.SummonerLevel
+
+		if err != nil {
+			fmt.Printf("Failed to get match history for %s: %v\n", shortPUUID(puuid), err)
+			allStats[i].Status = scoutStatusError
+			allStats[i].Error = err.Error()
+			allStats[i].FunFact = "Mystery player - no history found"
+		} else {
+			a.applyMatchHistory(&allStats[i], history)
+		}
+		applyScoutProfile(&allStats[i], history, ranked, mastery)
+		if err == nil {
+			applyInsights(&allStats[i], rules)
+		}
+
+		if a.scoutingSeq.Load() == seq {
+			a.emitScouting(allStats, false)
+		}
+	})
+
+	if a.scoutingSeq.Load() != seq {
+		return
+	}
+	a.emitScouting(allStats, true)
+	a.scouting.Prune()
+	fmt.Printf("Scouting complete: %d players\n", len(allStats))
+}
+
+// emitScouting emits the current scouting snapshot split into teams
+func (a *App) emitScouting(allStats []PlayerStats, complete bool) {
+	var myTeam, enemyTeam []events.ScoutedPlayer
+	var myTeamNum int
+
+	for _, s := range allStats {
+		if s.IsMe {
+			myTeamNum = s.Team
+		}
+	}
+
+	for _, s := range allStats {
+		playerData := events.ScoutedPlayer{
+			PUUID:           s.PUUID,
+			GameName:        s.GameName,
+			TagLine:         s.TagLine,
+			ChampionID:      s.ChampionID,
+			ChampionName:    s.ChampionName,
+			ChampionIcon:    s.ChampionIcon,
+			IsMe:            s.IsMe,
+			Position:        s.Position,
+			Status:          s.Status,
+			Error:           s.Error,
+			SummonerLevel:   s.SummonerLevel,
+			SoloRank:        rankPayload(s.SoloRank),
+			FlexRank:        rankPayload(s.FlexRank),
+			MasteryLevel:    s.MasteryLevel,
+			MasteryPoints:   s.MasteryPoints,
+			ChampionGames:   s.ChampionGames,
+			ChampionWinRate: s.ChampionWinRate,
+			IsSmurf:         s.IsSmurf,
+			IsOneTrick:      s.IsOneTrick,
+			IsOffRole:       s.IsOffRole,
+			Games:           s.Games,
+			Wins:            s.Wins,
+			WinRate:         s.WinRate,
+			AvgKills:        s.AvgKills,
+			AvgDeaths:       s.AvgDeaths,
+			AvgAssists:      s.AvgAssists,
+			KDA:             s.KDA,
+			AvgCS:           s.AvgCS,
+			TiltLevel:       s.TiltLevel,
+			FunFact:         s.FunFact,
+			Insights:        s.Insights,
+		}
+
+		if s.Team == myTeamNum {
+			myTeam = append(myTeam, playerData)
+		} else {
+			enemyTeam = append(enemyTeam, playerData)
+		}
+	}
+
+	a.emitter.Emit(events.ScoutingUpdate{
+		HasData:   true,
+		Complete:  complete,
+		MyTeam:    myTeam,
+		EnemyTeam: enemyTeam,
+	})
+}
+
+// newPlayerStats creates the loading-state entry for a player
+func (a *App) newPlayerStats(player lcu.GamePlayer, myPUUID string) PlayerStats {
+	return PlayerStats{
+		PUUID:         player.PUUID,
+		GameName:      player.GameName,
+		TagLine:       player.TagLine,
+		ChampionID:    player.ChampionID,
+		ChampionName:  a.champions.GetName(player.ChampionID),
+		ChampionIcon:  a.champions.GetIconURL(player.ChampionID),
+		Team:          player.Team,
+		IsMe:          player.PUUID == myPUUID,
+		Position:      normalizePosition(player.Position),
+		Status:        scoutStatusLoading,
+		SummonerLevel: player.SummonerLevel,
+	}
+}
+
+// fetchScoutProfile fetches a player's ranked standings and mastery on their champion.
+// Failures are logged and leave the profile empty - scouting still shows history.
+func (a *App) fetchScoutProfile(ctx context.Context, player lcu.GamePlayer) (*lcu.RankedStats, lcu.ChampionMastery) {
+	ranked, err := a.lcuClient.GetRankedStats(ctx, player.PUUID)
+	if err != nil {
+		fmt.Printf("Failed to get ranked stats for %s: %v\n", shortPUUID(player.PUUID), err)
+	}
+
+	mastery := lcu.ChampionMastery{ChampionID: player.ChampionID}
+	if player.SummonerID != 0 {
+		if mastery, err = a.lcuClient.GetChampionMastery(ctx, player.SummonerID, player.ChampionID); err != nil {
+			fmt.Printf("Failed to get mastery for %s: %v\n", shortPUUID(player.PUUID), err)
+		}
+	}
+
+	return ranked, mastery
+}
+
+// applyScoutProfile adds rank, champion proficiency and smurf/one-trick/off-role flags
+func applyScoutProfile(stats *PlayerStats, history *lcu.MatchHistoryResponse, ranked *lcu.RankedStats, mastery lcu.ChampionMastery) {
+	stats.SoloRank = ranked.SoloQueue()
+	stats.FlexRank = ranked.FlexQueue()
+	stats.MasteryLevel = mastery.ChampionLevel
+	stats.MasteryPoints = mastery.ChampionPoints
+
+	summary := scouting.SummarizeHistory(history, stats.ChampionID, stats.Position)
+	stats.ChampionGames = summary.ChampionGames
+	stats.ChampionWinRate = summary.ChampionWinRate
+
+	class := scouting.Classify(scouting.Signals{
+		SummonerLevel: stats.SummonerLevel,
+		Solo:          stats.SoloRank,
+		Flex:          stats.FlexRank,
+		Mastery:       mastery,
+		History:       summary,
+		KDA:           stats.KDA,
+	})
+	stats.IsSmurf = class.Smurf
+	stats.IsOneTrick = class.OneTrick
+	stats.IsOffRole = class.OffRole
+	stats.MainRoles = summary.MainRoles
+}
+
+// applyInsights runs the insight rules and sets the tilt level and fun fact from the best matches
+func applyInsights(stats *PlayerStats, rules *insights.Engine) {
+	result := rules.Evaluate(playerFacts(*stats))
+	stats.Insights = result.Insights
+	stats.FunFact = result.Top()
+	stats.TiltLevel = result.TopTag("tilted", "on_fire", "warming_up", "smurf")
+}
+
+// playerFacts exposes a player's stats to the insight rules
+func playerFacts(stats PlayerStats) insights.Facts {
+	var winStreak, lossStreak int
+	for _, won := range stats.RecentResults {
+		if won && lossStreak == 0 {
+			winStreak++
+		} else if !won && winStreak == 0 {
+			lossStreak++
+		} else {
+			break
+		}
+	}
+
+	return insights.Facts{
+		Numbers: map[string]float64{
+			"games":           float64(stats.Games),
+			"wins":            float64(stats.Wins),
+			"winRate":         stats.WinRate,
+			"avgKills":        stats.AvgKills,
+			"avgDeaths":       stats.AvgDeaths,
+			"avgAssists":      stats.AvgAssists,
+			"kda":             stats.KDA,
+			"avgCS":           stats.AvgCS,
+			"recentLosses":    float64(stats.RecentLosses),
+			"winStreak":       float64(winStreak),
+			"lossStreak":      float64(lossStreak),
+			"summonerLevel":   float64(stats.SummonerLevel),
+			"soloWins":        float64(stats.SoloRank.Wins),
+			"soloLosses":      float64(stats.SoloRank.Losses),
+			"soloLP":          float64(stats.SoloRank.LeaguePoints),
+			"flexWins":        float64(stats.FlexRank.Wins),
+			"flexLosses":      float64(stats.FlexRank.Losses),
+			"masteryLevel":    float64(stats.MasteryLevel),
+			"masteryPoints":   float64(stats.MasteryPoints),
+			"championGames":   float64(stats.ChampionGames),
+			"championWinRate": stats.ChampionWinRate,
+			"isSmurf":         boolFact(stats.IsSmurf),
+			"isOneTrick":      boolFact(stats.IsOneTrick),
+			"isOffRole":       boolFact(stats.IsOffRole),
+			"isMe":            boolFact(stats.IsMe),
+		},
+		Strings: map[string]string{
+			"championName": stats.ChampionName,
+			"gameName":     stats.GameName,
+			"position":     stats.Position,
+			"worstGame":    stats.WorstGame,
+			"mainRoles":    strings.Join(stats.MainRoles, "/"),
+			"soloRank":     stats.SoloRank.Label(),
+		},
+	}
+}
+
+// boolFact converts a flag to the 0/1 the rules compare against
+func boolFact(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// rankPayload converts a ranked queue to the frontend shape
+func rankPayload(q lcu.RankedQueueStats) events.Rank {
+	return events.Rank{
+		Tier:     q.Tier,
+		Division: q.Division,
+		LP:       q.LeaguePoints,
+		Wins:     q.Wins,
+		Losses:   q.Losses,
+		Label:    q.Label(),
+		IsRanked: q.IsRanked(),
+	}
+}
+
+// applyMatchHistory calculates a player's stats from their recent match history
+func (a *App) applyMatchHistory(stats *PlayerStats, history *lcu.MatchHistoryResponse) {
+	stats.Status = scoutStatusReady
+
+	matches := history.Games.Games
+
+	// Calculate stats from ranked/normal games only
+	var totalKills, totalDeaths, totalAssists, totalCS int
+	var worstKDA float64 = 999
+	var worstGameStr string
+
+	for _, match := range matches {
+		// Skip custom games, ARAM, etc. - focus on SR
+		if match.GameMode != "CLASSIC" {
+			continue
+		}
+
+		// The first participant is the player we're looking at
+		if len(match.Participants) == 0 {
+			continue
+		}
+
+		p := match.Participants[0]
+		s := p.Stats
+
+		stats.Games++
+		if s.Win {
+			stats.Wins++
+		}
+
+		totalKills += s.Kills
+		totalDeaths += s.Deaths
+		totalAssists += s.Assists
+		totalCS += s.TotalMinionsKilled + s.NeutralMinionsKilled
+
+		stats.RecentResults = append(stats.RecentResults, s.Win)
+
+		// Track worst game
+		deaths := s.Deaths
+		if deaths == 0 {
+			deaths = 1
+		}
+		gameKDA := float64(s.Kills+s.Assists) / float64(deaths)
+		if gameKDA < worstKDA && s.Deaths >= 5 {
+			worstKDA = gameKDA
+			champName := a.champions.GetName(p.ChampionId)
+			worstGameStr = fmt.Sprintf("%d/%d/%d on %s", s.Kills, s.Deaths, s.Assists, champName)
+		}
+	}
+
+	if stats.Games > 0 {
+		stats.WinRate = float64(stats.Wins) / float64(stats.Games) * 100
+		stats.AvgKills = float64(totalKills) / float64(stats.Games)
+		stats.AvgDeaths = float64(totalDeaths) / float64(stats.Games)
+		stats.AvgAssists = float64(totalAssists) / float64(stats.Games)
+		stats.AvgCS = float64(totalCS) / float64(stats.Games)
+
+		avgDeaths := stats.AvgDeaths
+		if avgDeaths == 0 {
+			avgDeaths = 1
+		}
+		stats.KDA = (stats.AvgKills + stats.AvgAssists) / avgDeaths
+	}
+
+	// Count losses in the last 3 games
+	for i, won := range stats.RecentResults {
+		if i < 3 && !won {
+			stats.RecentLosses++
+		}
+	}
+
+	stats.WorstGame = worstGameStr
+}
+
+// shortPUUID truncates a PUUID for logging
+func shortPUUID(puuid string) string {
+	if len(puuid) > 8 {
+		return puuid[:8]
+	}
+	return puuid
+}
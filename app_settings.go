@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+
+	"ghostdraft/internal/events"
+	"ghostdraft/internal/settings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// initSettings loads the settings store and subscribes the parts of the app that react to changes
+func (a *App) initSettings() {
+	store, err := settings.NewStore()
+	if err != nil {
+		fmt.Printf("[Settings] Failed to load settings, using defaults: %v\n", err)
+		return
+	}
+	a.settings = store
+	store.Subscribe(a.onSettingsChanged)
+	fmt.Println("[Settings] Loaded")
+}
+
+// currentSettings returns the user's settings, or the defaults if the store failed to load
+func (a *App) currentSettings() settings.Settings {
+	if a.settings == nil {
+		return settings.Defaults()
+	}
+	return a.settings.Get()
+}
+
+// GetSettings returns the current settings - exposed to frontend
+func (a *App) GetSettings() settings.Settings {
+	return a.currentSettings()
+}
+
+// UpdateSettings validates, saves and applies new settings - exposed to frontend
+func (a *App) UpdateSettings(next settings.Settings) (settings.Settings, error) {
+	if a.settings == nil {
+		return settings.Defaults(), fmt.Errorf("settings store not available")
+	}
+	return a.settings.Update(next)
+}
+
+// SaveWindowPosition stores where the user dragged the overlay - exposed to frontend
+func (a *App) SaveWindowPosition() {
+	if a.ctx == nil || a.headless || a.settings == nil {
+		return
+	}
+
+	next := a.settings.Get()
+	next.Window.X, next.Window.Y = runtime.WindowGetPosition(a.ctx)
+	next.Window.CustomPosition = true
+	if next == a.settings.Get() {
+		return
+	}
+	if _, err := a.settings.Update(next); err != nil {
+		fmt.Printf("[Settings] Failed to save window position: %v\n", err)
+	}
+}
+
+// onSettingsChanged applies new settings to the running app and notifies the frontend
+func (a *App) onSettingsChanged(old, next settings.Settings) {
+	if old.Window != next.Window && !a.headless {
+		a.applyWindowLayout(next.Window)
+	}
+	if old.Hotkeys != next.Hotkeys && !a.headless {
+		a.applyHotkeySettings(next.Hotkeys)
+	}
+	provider := a.statsProvider()
+	if old.Matchups != next.Matchups && provider != nil {
+		provider.SetMatchupThresholds(next.Matchups.WinningThreshold, next.Matchups.LosingThreshold)
+	}
+
+	a.emitter.Emit(events.SettingsChanged(next))
+}
+
+// applyWindowLayout sizes and positions the overlay. Zero sizes and the default
+// position are relative to the primary screen.
+func (a *App) applyWindowLayout(w settings.WindowSettings) {
+	screens, err := runtime.ScreenGetAll(a.ctx)
+	if err != nil || len(screens) == 0 {
+		return
+	}
+	screen := screens[0]
+
+	// Default size: ~20% width, ~55% height (roughly matches champ select sidebar)
+	width, height := w.Width, w.Height
+	if width == 0 {
+		width = screen.Size.Width * 20 / 100
+	}
+	if height == 0 {
+		height = screen.Size.Height * 55 / 100
+	}
+	runtime.WindowSetSize(a.ctx, width, height)
+
+	// Default position: right edge, vertically centered
+	x := screen.Size.Width - width - 20
+	y := (screen.Size.Height - height) / 2
+	if w.CustomPosition {
+		x, y = w.X, w.Y
+	}
+	runtime.WindowSetPosition(a.ctx, x, y)
+}
+
+// matchupStatus classifies a matchup win rate using the user's thresholds
+func (a *App) matchupStatus(winRate float64) string {
+	m := a.currentSettings().Matchups
+	if winRate >= m.WinningThreshold {
+		return "winning"
+	} else if winRate <= m.LosingThreshold {
+		return "losing"
+	}
+	return "even"
+}
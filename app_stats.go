@@ -1,29 +1,218 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"ghostdraft/internal/data"
+	"ghostdraft/internal/events"
+	"ghostdraft/internal/history"
 	"ghostdraft/internal/lcu"
 )
 
+const (
+	// statsVersionInterval is how often Turso is checked for newly uploaded stats
+	statsVersionInterval = 15 * time.Minute
+	// prefetchChampions is how many of the most hovered champion/role pairs get prefetched
+	prefetchChampions = 5
+)
+
 // ForceStatsUpdate clears the cache and refreshes stats data from Turso
 func (a *App) ForceStatsUpdate() string {
-	if a.statsProvider == nil {
-		return "Stats provider not initialized"
+	provider := a.statsProvider()
+	if provider == nil {
+		if a.statsConn == nil {
+			return "Stats provider not initialized"
+		}
+		a.statsConn.Reconnect()
+		return "Stats offline, reconnecting..."
 	}
 
 	// Clear the query cache
-	a.statsProvider.ClearCache()
+	provider.ClearCache()
 
 	// Refetch patch info
-	if err := a.statsProvider.FetchPatch(); err != nil {
+	if err := provider.FetchPatch(context.Background()); err != nil {
 		return fmt.Sprintf("Failed to refresh: %v", err)
 	}
 
-	return fmt.Sprintf("Cache cleared, using patch %s", a.statsProvider.GetPatch())
+	return fmt.Sprintf("Cache cleared, using patch %s", provider.GetPatch())
+}
+
+// GetCacheStats returns stats cache hit/miss counters for diagnostics
+func (a *App) GetCacheStats() data.CacheStats {
+	provider := a.statsProvider()
+	if provider == nil {
+		return data.CacheStats{}
+	}
+	return provider.CacheStats()
+}
+
+// GetStatsStatus returns the stats database connection status
+func (a *App) GetStatsStatus() data.ConnStatus {
+	if a.statsConn == nil {
+		return data.ConnStatus{State: data.StatsOffline, Message: "Stats not initialized"}
+	}
+	return a.statsConn.Status()
+}
+
+// openStatsCache opens the on-disk stats cache, falling back to memory only
+func openStatsCache() *data.QueryCache {
+	path, err := data.UserCachePath()
+	if err == nil {
+		var cache *data.QueryCache
+		if cache, err = data.OpenQueryCache(data.CacheOptions{Path: path}); err == nil {
+			return cache
+		}
+	}
+	fmt.Printf("Stats cache is memory only: %v\n", err)
+	return data.NewQueryCache()
+}
+
+// prefetchStats warms the stats cache for the champions the player hovers most
+func (a *App) prefetchStats(provider *data.StatsProvider) {
+	if provider == nil || a.hovers == nil {
+		return
+	}
+	provider.Prefetch(context.Background(), a.hovers.Top(prefetchChampions))
+}
+
+// updateStatsQueue reads the queue from the gameflow session and points the stats
+// provider at that queue's dataset
+func (a *App) updateStatsQueue() {
+	session, err := a.lcuClient.GetGameSession()
+	if err != nil {
+		return
+	}
+	queue := session.GameData.Queue
+	if previous := a.statsQueue.Swap(int64(queue.ID)); previous != int64(queue.ID) {
+		fmt.Printf("[Stats] Queue %d (%s): using %s stats\n", queue.ID, queue.GameMode, a.statsDataset().Name)
+	}
+	if provider := a.statsProvider(); provider != nil {
+		provider.SetDataset(a.statsDataset())
+	}
+}
+
+// statsDataset returns the stats dataset for the queue the client is in
+func (a *App) statsDataset() data.Dataset {
+	return data.DatasetForQueue(int(a.statsQueue.Load()))
+}
+
+// updateStatsBracket reads the player's solo queue tier and points the stats provider at
+// that rank bracket. Unranked players, or a failed lookup, read every bracket.
+func (a *App) updateStatsBracket(puuid string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tier := ""
+	if ranked, err := a.lcuClient.GetRankedStats(ctx, puuid); err != nil {
+		fmt.Printf("[Stats] Ranked lookup failed, using every rank bracket: %v\n", err)
+	} else if solo := ranked.SoloQueue(); solo.IsRanked() {
+		tier = solo.Tier
+	}
+
+	bracket := data.BracketForTier(tier)
+	a.statsBracket.Store(bracket)
+	fmt.Printf("[Stats] Solo queue tier %q: using %s stats\n", tier, data.BracketLabel(bracket))
+	if provider := a.statsProvider(); provider != nil {
+		provider.SetBracket(bracket)
+	}
+}
+
+// updateStatsRegion points the stats provider at the client's region
+func (a *App) updateStatsRegion(lcuRegion string) {
+	region := data.RegionForLCU(lcuRegion)
+	a.statsRegion.Store(region)
+	if region == data.RegionAll {
+		fmt.Printf("[Stats] Region %q: using every region's stats\n", lcuRegion)
+	} else {
+		fmt.Printf("[Stats] Region %q: using %s stats\n", lcuRegion, region)
+	}
+	if provider := a.statsProvider(); provider != nil {
+		provider.SetRegion(region)
+	}
+}
+
+// statsRegionName returns the stats region for the client; all regions until it's known
+func (a *App) statsRegionName() string {
+	region, _ := a.statsRegion.Load().(string)
+	return region
+}
+
+// statsBracketName returns the stats bracket for the player's rank; all brackets until
+// the rank is known
+func (a *App) statsBracketName() string {
+	bracket, _ := a.statsBracket.Load().(string)
+	return bracket
+}
+
+// recordHover counts a champ select hover towards future prefetches
+func (a *App) recordHover(championID int, championName, role string) {
+	if a.hovers == nil {
+		return
+	}
+	if err := a.hovers.Record(championID, championName, role); err != nil {
+		fmt.Printf("Failed to save hover log: %v\n", err)
+	}
+}
+
+// watchStatsVersion periodically re-reads the patch and data version so new uploads
+// invalidate the cache without a restart, and prunes expired entries
+func (a *App) watchStatsVersion() {
+	ticker := time.NewTicker(statsVersionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.stopPoll:
+			return
+		case <-ticker.C:
+			a.statsCache.Prune()
+			provider := a.statsProvider()
+			if provider == nil {
+				continue
+			}
+			if err := provider.FetchPatch(context.Background()); err != nil {
+				fmt.Printf("Failed to check stats version: %v\n", err)
+				continue
+			}
+			a.syncStaticData(provider.GetPatch(), "")
+		}
+	}
+}
+
+// currentPUUID returns the logged-in player's PUUID, or "" until the client connects
+func (a *App) currentPUUID() string {
+	puuid, _ := a.userPUUID.Load().(string)
+	return puuid
+}
+
+// syncMatchHistory copies any new games for the current account into the local store and
+// emits history:synced if there were any. A backfill can take a while, so callers run it in
+// the background; a call while another sync is running does nothing.
+func (a *App) syncMatchHistory() {
+	puuid := a.currentPUUID()
+	if a.historySync == nil || puuid == "" || !a.lcuClient.IsConnected() {
+		return
+	}
+	if !a.historySyncing.CompareAndSwap(false, true) {
+		return
+	}
+	defer a.historySyncing.Store(false)
+
+	added, err := a.historySync.Sync(puuid)
+	if err != nil {
+		fmt.Printf("Failed to sync match history: %v\n", err)
+	}
+	if added > 0 {
+		a.emitter.Emit(events.HistorySynced{Added: added})
+	}
 }
 
-// GetPersonalStats returns aggregated personal stats from recent match history
+// GetPersonalStats returns aggregated ranked stats from the local match history,
+// falling back to the LCU's recent match history if the store is unavailable. New games
+// are synced in the background; history:synced tells the frontend to ask again.
 func (a *App) GetPersonalStats() *lcu.PersonalStats {
 	emptyStats := &lcu.PersonalStats{HasData: false}
 
@@ -31,11 +220,85 @@ func (a *App) GetPersonalStats() *lcu.PersonalStats {
 		return emptyStats
 	}
 
-	history, err := a.lcuClient.FetchMatchHistory(20)
+	if puuid := a.currentPUUID(); a.matchStore != nil && puuid != "" {
+		go a.syncMatchHistory()
+		stats, err := a.matchStore.PersonalStats(puuid, history.Filter{QueueIDs: history.RankedQueues}, a.champions)
+		if err == nil {
+			if len(stats.ChampionStats) > 5 {
+				stats.ChampionStats = stats.ChampionStats[:5]
+			}
+			return stats
+		}
+		fmt.Printf("Failed to read local match history: %v\n", err)
+	}
+
+	recent, err := a.lcuClient.FetchMatchHistory(20)
 	if err != nil {
 		fmt.Printf("Failed to fetch match history: %v\n", err)
 		return emptyStats
 	}
 
-	return lcu.CalculatePersonalStats(history, a.champions)
+	return lcu.CalculatePersonalStats(recent, a.champions)
+}
+
+// GetPersonalStatsFiltered returns personal stats over every stored game matching the
+// filter, starting a background sync like GetPersonalStats
+func (a *App) GetPersonalStatsFiltered(filter history.Filter) *lcu.PersonalStats {
+	puuid := a.currentPUUID()
+	if a.matchStore == nil || puuid == "" {
+		return &lcu.PersonalStats{HasData: false, ChampionStats: []lcu.ChampionPersonalStats{}}
+	}
+
+	go a.syncMatchHistory()
+	stats, err := a.matchStore.PersonalStats(puuid, filter, a.champions)
+	if err != nil {
+		fmt.Printf("Failed to read local match history: %v\n", err)
+		return &lcu.PersonalStats{HasData: false, ChampionStats: []lcu.ChampionPersonalStats{}}
+	}
+	return stats
+}
+
+// GetChampionPool returns stats for every champion the current account has played
+func (a *App) GetChampionPool(filter history.Filter) []lcu.ChampionPersonalStats {
+	puuid := a.currentPUUID()
+	if a.matchStore == nil || puuid == "" {
+		return []lcu.ChampionPersonalStats{}
+	}
+
+	pool, err := a.matchStore.ChampionPool(puuid, filter, a.champions)
+	if err != nil {
+		fmt.Printf("Failed to read champion pool: %v\n", err)
+		return []lcu.ChampionPersonalStats{}
+	}
+	return pool
+}
+
+// GetMatchupHistory returns the current account's record against each lane opponent
+// (set filter.ChampionID to restrict to one of your champions)
+func (a *App) GetMatchupHistory(filter history.Filter) []history.MatchupStats {
+	puuid := a.currentPUUID()
+	if a.matchStore == nil || puuid == "" {
+		return []history.MatchupStats{}
+	}
+
+	matchups, err := a.matchStore.Matchups(puuid, filter, a.champions)
+	if err != nil {
+		fmt.Printf("Failed to read matchup history: %v\n", err)
+		return []history.MatchupStats{}
+	}
+	return matchups
+}
+
+// GetHistoryPatches returns the patches present in the current account's stored history
+func (a *App) GetHistoryPatches() []string {
+	puuid := a.currentPUUID()
+	if a.matchStore == nil || puuid == "" {
+		return []string{}
+	}
+
+	patches, err := a.matchStore.Patches(puuid)
+	if err != nil {
+		return []string{}
+	}
+	return patches
 }
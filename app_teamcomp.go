@@ -4,9 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"ghostdraft/internal/events"
 	"ghostdraft/internal/lcu"
-
-	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // TeamCompData holds analyzed team composition data
@@ -83,16 +82,16 @@ func (a *App) analyzeTeamComp(session *lcu.ChampSelectSession, localChampID int)
 
 	// Don't show recommendation if local player already locked
 	if localHasLocked {
-		runtime.EventsEmit(a.ctx, "teamcomp:update", map[string]interface{}{
-			"show": false,
+		a.emitter.Emit(events.TeamCompUpdate{
+			Show: false,
 		})
 		return
 	}
 
 	// Need at least 1 teammate to assess balance
 	if totalDmgChamps < 1 {
-		runtime.EventsEmit(a.ctx, "teamcomp:update", map[string]interface{}{
-			"show": false,
+		a.emitter.Emit(events.TeamCompUpdate{
+			Show: false,
 		})
 		return
 	}
@@ -102,17 +101,18 @@ func (a *App) analyzeTeamComp(session *lcu.ChampSelectSession, localChampID int)
 
 	apRatio := float64(apCount) / float64(totalDmgChamps)
 	adRatio := float64(adCount) / float64(totalDmgChamps)
+	ratios := a.currentSettings().TeamComp
 
-	if apRatio >= 0.75 {
+	if apRatio >= ratios.WarningRatio {
 		recommendation = "Team is AP heavy - consider picking AD"
-		if apRatio >= 0.9 {
+		if apRatio >= ratios.CriticalRatio {
 			severity = "critical"
 		} else {
 			severity = "warning"
 		}
-	} else if adRatio >= 0.75 {
+	} else if adRatio >= ratios.WarningRatio {
 		recommendation = "Team is AD heavy - consider picking AP"
-		if adRatio >= 0.9 {
+		if adRatio >= ratios.CriticalRatio {
 			severity = "critical"
 		} else {
 			severity = "warning"
@@ -121,16 +121,16 @@ func (a *App) analyzeTeamComp(session *lcu.ChampSelectSession, localChampID int)
 
 	if recommendation != "" {
 		fmt.Printf("Team comp: AP=%d, AD=%d, Mixed=%d - %s\n", apCount, adCount, mixedCount, recommendation)
-		runtime.EventsEmit(a.ctx, "teamcomp:update", map[string]interface{}{
-			"show":           true,
-			"recommendation": recommendation,
-			"severity":       severity,
-			"apCount":        apCount,
-			"adCount":        adCount,
+		a.emitter.Emit(events.TeamCompUpdate{
+			Show:           true,
+			Recommendation: recommendation,
+			Severity:       severity,
+			APCount:        apCount,
+			ADCount:        adCount,
 		})
 	} else {
-		runtime.EventsEmit(a.ctx, "teamcomp:update", map[string]interface{}{
-			"show": false,
+		a.emitter.Emit(events.TeamCompUpdate{
+			Show: false,
 		})
 	}
 }
@@ -157,8 +157,8 @@ func (a *App) analyzeFullComp(session *lcu.ChampSelectSession) {
 	}
 
 	if !allLocked {
-		runtime.EventsEmit(a.ctx, "fullcomp:update", map[string]interface{}{
-			"ready": false,
+		a.emitter.Emit(events.FullCompUpdate{
+			Ready: false,
 		})
 		return
 	}
@@ -185,16 +185,16 @@ func (a *App) analyzeFullComp(session *lcu.ChampSelectSession) {
 	fmt.Printf("Full comp: Ally=%s (AP=%d%% AD=%d%%), Enemy=%s (AP=%d%% AD=%d%%)\n",
 		allyComp.Archetype, allyAPPct, allyADPct, enemyComp.Archetype, enemyAPPct, enemyADPct)
 
-	runtime.EventsEmit(a.ctx, "fullcomp:update", map[string]interface{}{
-		"ready":          true,
-		"allyArchetype":  allyComp.Archetype,
-		"allyTags":       formatTagCounts(allyComp.Tags),
-		"allyAP":         allyAPPct,
-		"allyAD":         allyADPct,
-		"enemyArchetype": enemyComp.Archetype,
-		"enemyTags":      formatTagCounts(enemyComp.Tags),
-		"enemyAP":        enemyAPPct,
-		"enemyAD":        enemyADPct,
+	a.emitter.Emit(events.FullCompUpdate{
+		Ready:          true,
+		AllyArchetype:  allyComp.Archetype,
+		AllyTags:       formatTagCounts(allyComp.Tags),
+		AllyAP:         allyAPPct,
+		AllyAD:         allyADPct,
+		EnemyArchetype: enemyComp.Archetype,
+		EnemyTags:      formatTagCounts(enemyComp.Tags),
+		EnemyAP:        enemyAPPct,
+		EnemyAD:        enemyADPct,
 	})
 }
 
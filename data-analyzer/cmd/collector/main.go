@@ -9,12 +9,15 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
 
+	"data-analyzer/internal/collector"
 	"data-analyzer/internal/riot"
 	"data-analyzer/internal/storage"
+	"ghostdraft/ddragon"
 
 	"github.com/bits-and-blooms/bloom/v3"
 	"github.com/joho/godotenv"
@@ -42,6 +45,7 @@ func main() {
 	puuid := flag.String("puuid", "", "Starting PUUID")
 	matchCount := flag.Int("count", 20, "Number of matches to fetch per player")
 	maxPlayers := flag.Int("max-players", 100, "Maximum unique players to collect")
+	platform := flag.String("platform", riot.PlatformNA1, "Platform to crawl (e.g. 'na1', 'euw1', 'kr')")
 	flag.Parse()
 
 	// Get blob storage path from env (required)
@@ -80,29 +84,36 @@ func main() {
 	}()
 
 	// Create Riot API client
-	client, err := riot.NewClient()
+	client, err := riot.NewClientForPlatform(*platform)
 	if err != nil {
 		log.Fatalf("Failed to create Riot client: %v", err)
 	}
 
 	// Fetch current patch from Data Dragon
-	currentPatch, err := riot.GetCurrentPatch(ctx)
+	dd := ddragon.New(ddragon.Options{CacheDir: filepath.Join(dataDir, "ddragon")})
+	currentPatch, err := riot.GetCurrentPatch(ctx, dd)
 	if err != nil {
 		log.Fatalf("Failed to get current patch: %v", err)
 	}
 	fmt.Printf("Current patch: %s (only collecting matches from this patch)\n", currentPatch)
 
-	// Get starting PUUID
-	var startingPUUID string
+	// Load the patch's item catalog, so build orders only keep completed items
+	items, err := riot.LoadItemCatalog(ctx, dd, currentPatch)
+	if err != nil {
+		log.Fatalf("Failed to load item data: %v", err)
+	}
+
+	// Get starting PUUIDs
+	var startingPUUIDs []string
 	if autoSeed {
-		// Auto-seed from Challenger leaderboard
-		fmt.Println("No --riot-id provided, auto-seeding from Challenger leaderboard...")
-		topPUUID, err := client.GetTopChallengerPUUID(ctx)
+		// Auto-seed across the ranked ladder, each bracket by its default quota
+		fmt.Println("No --riot-id provided, auto-seeding from the ranked ladder...")
+		seeds, err := collector.LadderSeeds(ctx, client, collector.DefaultBracketQuotas)
 		if err != nil {
-			log.Fatalf("Failed to get top Challenger player: %v", err)
+			log.Fatalf("Failed to seed from the ladder: %v", err)
 		}
-		fmt.Printf("  Using top Challenger player: %s...\n", topPUUID[:20])
-		startingPUUID = topPUUID
+		fmt.Printf("  Seeded %d ladder players\n", len(seeds))
+		startingPUUIDs = seeds
 	} else if *riotID != "" {
 		parts := strings.SplitN(*riotID, "#", 2)
 		if len(parts) != 2 {
@@ -118,9 +129,9 @@ func main() {
 			log.Fatalf("Failed to lookup %s: %v", *riotID, err)
 		}
 		fmt.Printf("  Found PUUID: %s\n", account.PUUID)
-		startingPUUID = account.PUUID
+		startingPUUIDs = []string{account.PUUID}
 	} else {
-		startingPUUID = *puuid
+		startingPUUIDs = []string{*puuid}
 	}
 
 	// Bloom filters for deduplication (space-efficient for large datasets)
@@ -129,8 +140,10 @@ func main() {
 	visitedPUUIDs := bloom.NewWithEstimates(1000000, 0.001)
 
 	// Queue of PUUIDs to process
-	queue := []string{startingPUUID}
-	visitedPUUIDs.AddString(startingPUUID)
+	queue := append([]string{}, startingPUUIDs...)
+	for _, seed := range startingPUUIDs {
+		visitedPUUIDs.AddString(seed)
+	}
 
 	playerCount := 0
 	totalMatchesWritten := 0
@@ -172,6 +185,13 @@ func main() {
 		consecutiveFailures = 0 // Reset on success
 		fmt.Printf("  Found %d matches\n", len(matchIDs))
 
+		// Record the player's rank as the spider does, so the reducer can bracket their matches
+		// (without one they only feed the unsplit dataset)
+		sourceTier, sourceDivision, _, err := client.GetSoloQueueRank(ctx, currentPUUID)
+		if err != nil {
+			log.Printf("  Failed to get rank: %v (matches will be unbracketed)", err)
+		}
+
 		// Process each match
 		matchesThisPlayer := 0
 		currentPatchMatches := 0
@@ -208,16 +228,21 @@ func main() {
 			}
 			currentPatchMatches++
 
+			// Only keep queues we have stats for (still counts toward the player's current patch games)
+			if !riot.IsStatsQueue(match.Info.QueueID) {
+				continue
+			}
+
 			// Fetch timeline for 20% of matches (statistical sampling for build order data)
 			var buildOrders map[int][]int
-			if rand.Float64() < timelineSamplingRate {
+			if match.Info.QueueID != riot.QueueArena && rand.Float64() < timelineSamplingRate {
 				timeline, err := client.GetTimeline(ctx, matchID)
 				if err != nil {
 					log.Printf("    [Timeline] Failed to fetch: %v", err)
 				} else {
 					buildOrders = make(map[int][]int)
 					for _, p := range match.Info.Participants {
-						buildOrder := riot.ExtractBuildOrder(timeline, p.ParticipantID)
+						buildOrder := riot.ExtractBuildOrder(timeline, p.ParticipantID, items)
 						if len(buildOrder) > 0 {
 							buildOrders[p.ParticipantID] = buildOrder
 						}
@@ -225,51 +250,26 @@ func main() {
 				}
 			}
 
-			// Write each participant as a separate record
-			for _, participant := range match.Info.Participants {
-				rawMatch := &storage.RawMatch{
-					MatchID:      matchID,
-					GameVersion:  match.Info.GameVersion,
-					GameDuration: match.Info.GameDuration,
-					GameCreation: match.Info.GameCreation,
-					PUUID:        participant.PUUID,
-					GameName:     participant.RiotIdGameName,
-					TagLine:      participant.RiotIdTagline,
-					ChampionID:   participant.ChampionID,
-					ChampionName: participant.ChampionName,
-					TeamPosition: participant.TeamPosition,
-					Win:          participant.Win,
-					Item0:        participant.Item0,
-					Item1:        participant.Item1,
-					Item2:        participant.Item2,
-					Item3:        participant.Item3,
-					Item4:        participant.Item4,
-					Item5:        participant.Item5,
-				}
-
-				// Include build order if timeline was fetched for this match
-				if buildOrders != nil {
-					if bo, ok := buildOrders[participant.ParticipantID]; ok {
-						rawMatch.BuildOrder = bo
-					}
-				}
-
-				if err := rotator.WriteLine(rawMatch); err != nil {
-					log.Printf("    Failed to write record: %v", err)
-					continue
-				}
+			// Write each participant as a separate record, in one block
+			result := &collector.MatchResult{
+				Match:          match,
+				MatchID:        matchID,
+				BuildOrders:    buildOrders,
+				SourceTier:     sourceTier,
+				SourceDivision: sourceDivision,
+			}
+			if err := rotator.WriteMatch(collector.RawMatches(result, client.Platform())); err != nil {
+				log.Printf("    Failed to write match: %v", err)
+				continue
+			}
 
-				// Buffer new players (will add to queue only if user has mostly current patch matches)
+			// Buffer new players (will add to queue only if user has mostly current patch matches)
+			for _, participant := range match.Info.Participants {
 				if !visitedPUUIDs.TestString(participant.PUUID) {
 					newPlayersFromThisUser = append(newPlayersFromThisUser, participant.PUUID)
 				}
 			}
 
-			// Signal match complete (increments counter, may trigger rotation)
-			if err := rotator.MatchComplete(); err != nil {
-				log.Printf("    Failed to complete match: %v", err)
-			}
-
 			matchesThisPlayer++
 			totalMatchesWritten++
 		}
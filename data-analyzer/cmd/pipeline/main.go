@@ -16,6 +16,7 @@ import (
 	"data-analyzer/internal/discord"
 	"data-analyzer/internal/riot"
 	"data-analyzer/internal/storage"
+	"ghostdraft/ddragon"
 
 	"github.com/joho/godotenv"
 )
@@ -66,11 +67,11 @@ func main() {
 			fmt.Sprintf("--max-players=%d", *maxPlayers),
 		}
 
-		// Only add --riot-id if explicitly provided (otherwise collector auto-seeds from Challenger)
+		// Only add --riot-id if explicitly provided (otherwise collector auto-seeds from the ladder)
 		if *riotID != "" {
 			collectorArgs = append(collectorArgs, "--riot-id="+*riotID)
 		} else {
-			fmt.Println("No --riot-id provided, collector will auto-seed from Challenger leaderboard")
+			fmt.Println("No --riot-id provided, collector will auto-seed from the ranked ladder")
 		}
 
 		if err := runCommand(analyzerDir, "go", collectorArgs...); err != nil {
@@ -157,20 +158,30 @@ func runContinuousMode() {
 		log.Println("Turso: disabled (set TURSO_DATABASE_URL to enable)")
 	}
 
-	// Create Riot API client
-	riotClient, err := riot.NewClient()
+	// Platforms to crawl, each with its own client and rate limiter
+	platforms, err := riot.ParsePlatforms(getEnvString("PLATFORMS", riot.PlatformNA1))
 	if err != nil {
-		log.Fatalf("Failed to create Riot client: %v", err)
+		log.Fatalf("Invalid PLATFORMS: %v", err)
 	}
+	mergeRegions := getEnvString("MERGE_REGIONS", "false") == "true"
+	log.Printf("Platforms: %s (regions merged: %v)", strings.Join(platforms, ", "), mergeRegions)
 
 	// Get current patch
 	ctx := context.Background()
-	currentPatch, err := riot.GetCurrentPatch(ctx)
+	dd := ddragon.New(ddragon.Options{CacheDir: filepath.Join(storagePath, "ddragon")})
+	currentPatch, err := riot.GetCurrentPatch(ctx, dd)
 	if err != nil {
 		log.Fatalf("Failed to get current patch: %v", err)
 	}
 	log.Printf("Current patch: %s", currentPatch)
 
+	// Load the patch's item catalog, so build orders and item stats only count completed items
+	items, err := riot.LoadItemCatalog(ctx, dd, currentPatch)
+	if err != nil {
+		log.Fatalf("Failed to load item data: %v", err)
+	}
+	log.Printf("Loaded %d items (Data Dragon %s)", items.Len(), items.Version())
+
 	// Create file rotator
 	rotator, err := storage.NewFileRotator(storagePath)
 	if err != nil {
@@ -184,17 +195,49 @@ func runContinuousMode() {
 	maxPlayers := getEnvInt("MAX_PLAYERS", 10000)
 	workerCount := getEnvInt("WORKER_COUNT", 1)
 	timelineSamplingRate := getEnvFloat("TIMELINE_SAMPLING_RATE", 0.20)
+	coverageTarget := getEnvInt("COVERAGE_TARGET", collector.DefaultCoverageTarget)
+	bracketQuotas, err := collector.ParseBracketQuotas(getEnvString("BRACKET_QUOTAS", ""))
+	if err != nil {
+		log.Fatalf("Invalid BRACKET_QUOTAS: %v", err)
+	}
+	rankCacheTTL := time.Duration(getEnvInt("RANK_CACHE_TTL_HOURS", 24)) * time.Hour
 
-	log.Printf("Config: matches_per_player=%d, max_players=%d, workers=%d, timeline_rate=%.2f",
-		matchesPerPlayer, maxPlayers, workerCount, timelineSamplingRate)
+	log.Printf("Config: matches_per_player=%d, max_players=%d, workers=%d, timeline_rate=%.2f, coverage_target=%d, bracket_quotas=%s, rank_cache_ttl=%s",
+		matchesPerPlayer, maxPlayers, workerCount, timelineSamplingRate, coverageTarget, bracketQuotas, rankCacheTTL)
+
+	// Crawl frontier and visited sets are checkpointed here, so restarts carry on the crawl
+	frontier, err := storage.NewFrontierStore(filepath.Join(storagePath, "frontier"))
+	if err != nil {
+		log.Fatalf("Failed to create frontier store: %v", err)
+	}
 
 	spiderConfig := collector.SpiderConfig{
 		MatchesPerPlayer:     matchesPerPlayer,
 		MaxPlayers:           maxPlayers,
 		WorkerCount:          workerCount,
 		TimelineSamplingRate: timelineSamplingRate,
+		Items:                items,
+		CoverageTarget:       coverageTarget,
+		BracketQuotas:        bracketQuotas,
+		RankCacheTTL:         rankCacheTTL,
+		Frontier:             frontier,
+	}
+	// newSpider creates a spider per platform with fresh clients (which read RIOT_API_KEY)
+	newSpider := func() (*collector.PlatformSpiders, error) {
+		spiders := make([]*collector.Spider, 0, len(platforms))
+		for _, platform := range platforms {
+			client, err := riot.NewClientForPlatform(platform)
+			if err != nil {
+				return nil, err
+			}
+			spiders = append(spiders, collector.NewSpider(client, rotator, currentPatch, spiderConfig))
+		}
+		return collector.NewPlatformSpiders(spiders...), nil
+	}
+	spider, err := newSpider()
+	if err != nil {
+		log.Fatalf("Failed to create Riot client: %v", err)
 	}
-	spider := collector.NewSpider(riotClient, rotator, currentPatch, spiderConfig)
 
 	// Create API key validator
 	keyValidator := riot.NewKeyValidator()
@@ -262,18 +305,15 @@ func runContinuousMode() {
 
 				// Send success notification
 				if discordBot != nil {
-					payload := discord.NewSessionStartedPayload(newKey, "Challenger #1")
+					payload := discord.NewSessionStartedPayload(newKey, "Ranked ladder")
 					discordBot.SendEmbed(ctx, payload)
 				}
 
-				// Recreate Riot client with new key
-				riotClient, err = riot.NewClient()
+				// Recreate the spiders with clients using the new key
+				spider, err = newSpider()
 				if err != nil {
 					log.Fatalf("Failed to create Riot client with new key: %v", err)
 				}
-
-				// Recreate spider with new client
-				spider = collector.NewSpider(riotClient, rotator, currentPatch, spiderConfig)
 				break
 			}
 		} else if !valid {
@@ -321,7 +361,7 @@ func runContinuousMode() {
 			payload := discord.NewKeyExpiredPayload(matchesCollected, runtime, lastReduceAgo)
 			return discordBot.SendEmbed(notifyCtx, payload)
 		} else if strings.Contains(message, "started") {
-			payload := discord.NewSessionStartedPayload("validated", "Challenger #1")
+			payload := discord.NewSessionStartedPayload("validated", "Ranked ladder")
 			return discordBot.SendEmbed(notifyCtx, payload)
 		}
 
@@ -362,11 +402,14 @@ func runContinuousMode() {
 
 		// Aggregate warm files
 		log.Println("[Reduce] Aggregating warm files...")
-		agg, err := collector.AggregateWarmFiles(warmDir, riot.IsCompletedItem)
+		agg, err := collector.AggregateWarmFiles(warmDir, items.IsCompleted)
 		if err != nil {
 			log.Printf("[Reduce] ERROR: Aggregation failed: %v", err)
 			return fmt.Errorf("aggregation failed: %w", err)
 		}
+		if mergeRegions {
+			agg.MergeRegions()
+		}
 
 		log.Printf("[Reduce] Aggregated %d files, %d records, patch %s",
 			agg.FilesProcessed, agg.TotalRecords, agg.DetectedPatch)
@@ -500,6 +543,14 @@ func findAnalyzerDir() string {
 	return ""
 }
 
+// getEnvString reads a string from an environment variable, returning defaultVal if not set
+func getEnvString(key string, defaultVal string) string {
+	if val := strings.Trim(os.Getenv(key), "\""); val != "" {
+		return val
+	}
+	return defaultVal
+}
+
 // getEnvInt reads an integer from an environment variable, returning defaultVal if not set or invalid
 func getEnvInt(key string, defaultVal int) int {
 	val := os.Getenv(key)
@@ -11,183 +11,70 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"data-analyzer/internal/collector"
 	"data-analyzer/internal/db"
+	"data-analyzer/internal/riot"
+	"data-analyzer/internal/storage"
+	"ghostdraft/ddragon"
 
 	"github.com/joho/godotenv"
 )
 
 // CLI flags
 var (
-	outputDir   = flag.String("output-dir", "./export", "Directory to output data.json")
-	skipTurso   = flag.Bool("skip-turso", false, "Skip pushing to Turso")
-	skipJSON    = flag.Bool("skip-json", false, "Skip JSON export")
-	skipRelease = flag.Bool("skip-release", false, "Skip GitHub release")
+	outputDir    = flag.String("output-dir", "./export", "Directory to output data.json")
+	skipTurso    = flag.Bool("skip-turso", false, "Skip pushing to Turso")
+	skipJSON     = flag.Bool("skip-json", false, "Skip JSON export")
+	skipRelease  = flag.Bool("skip-release", false, "Skip GitHub release")
+	mergeRegions = flag.Bool("merge-regions", false, "Merge every region's stats into one worldwide dataset")
 )
 
-const DDRAGON_VERSION = "14.24.1"
-
-// completedItems is a set of item IDs that are completed (not components)
-var completedItems map[int]bool
-
-// DDragonItem represents an item from Data Dragon
-type DDragonItem struct {
-	Name string   `json:"name"`
-	Into []string `json:"into,omitempty"` // Items this builds into (as string IDs)
-	From []string `json:"from,omitempty"` // Items this is built from (as string IDs)
-	Gold struct {
-		Total       int  `json:"total"`
-		Purchasable bool `json:"purchasable"`
-	} `json:"gold"`
-	Maps map[string]bool `json:"maps"` // Map availability
-}
-
-// loadCompletedItems fetches item.json from Data Dragon and identifies completed items
-func loadCompletedItems() error {
-	url := fmt.Sprintf("https://ddragon.leagueoflegends.com/cdn/%s/data/en_US/item.json", DDRAGON_VERSION)
-
-	fmt.Printf("Fetching item data from Data Dragon (%s)...\n", DDRAGON_VERSION)
+// items is the item catalog for the patch being reduced
+var items *ddragon.Catalog
 
-	resp, err := http.Get(url)
+// filePatch returns the patch of the first match in a warm file, or "" if none is found
+func filePatch(filePath string) string {
+	file, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to fetch item data: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("Data Dragon returned status %d", resp.StatusCode)
-	}
-
-	var result struct {
-		Data map[string]DDragonItem `json:"data"`
+		return ""
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to parse item data: %w", err)
-	}
-
-	completedItems = make(map[int]bool)
-
-	for idStr, item := range result.Data {
-		var id int
-		fmt.Sscanf(idStr, "%d", &id)
-
-		// Skip items that aren't on Summoner's Rift (map 11)
-		if available, exists := item.Maps["11"]; exists && !available {
-			continue
-		}
+	defer file.Close()
 
-		// Completed item = has no "into" (doesn't build into anything else)
-		// Also filter out cheap items (< 1000 gold) which are usually components/consumables
-		if len(item.Into) == 0 && item.Gold.Total >= 1000 && item.Gold.Purchasable {
-			completedItems[id] = true
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		var match storage.RawMatch
+		if json.Unmarshal(scanner.Bytes(), &match) == nil && match.GameVersion != "" {
+			return normalizePatch(match.GameVersion)
 		}
 	}
-
-	fmt.Printf("Loaded %d completed items\n", len(completedItems))
-	return nil
-}
-
-// isCompletedItem checks if an item is a completed item
-func isCompletedItem(itemID int) bool {
-	return completedItems[itemID]
-}
-
-// RawMatch mirrors the structure from the collector
-type RawMatch struct {
-	MatchID      string `json:"matchId"`
-	GameVersion  string `json:"gameVersion"`
-	GameDuration int    `json:"gameDuration"`
-	GameCreation int64  `json:"gameCreation"`
-	PUUID        string `json:"puuid"`
-	GameName     string `json:"gameName,omitempty"`
-	TagLine      string `json:"tagLine,omitempty"`
-	ChampionID   int    `json:"championId"`
-	ChampionName string `json:"championName"`
-	TeamPosition string `json:"teamPosition"`
-	Win          bool   `json:"win"`
-	Item0        int    `json:"item0"`
-	Item1        int    `json:"item1"`
-	Item2        int    `json:"item2"`
-	Item3        int    `json:"item3"`
-	Item4        int    `json:"item4"`
-	Item5        int    `json:"item5"`
-	BuildOrder   []int  `json:"buildOrder"`
-}
-
-// ChampionStatsKey is the composite key for champion stats
-type ChampionStatsKey struct {
-	Patch        string
-	ChampionID   int
-	TeamPosition string
-}
-
-// ChampionStats holds aggregated champion statistics
-type ChampionStats struct {
-	Wins    int
-	Matches int
-}
-
-// ItemStatsKey is the composite key for item stats
-type ItemStatsKey struct {
-	Patch        string
-	ChampionID   int
-	TeamPosition string
-	ItemID       int
-}
-
-// ItemStats holds aggregated item statistics
-type ItemStats struct {
-	Wins    int
-	Matches int
-}
-
-// MatchupStatsKey is the composite key for matchup stats
-type MatchupStatsKey struct {
-	Patch           string
-	ChampionID      int
-	TeamPosition    string
-	EnemyChampionID int
-}
-
-// MatchupStats holds aggregated matchup statistics
-type MatchupStats struct {
-	Wins    int
-	Matches int
-}
-
-// ItemSlotStatsKey is the composite key for item slot stats
-type ItemSlotStatsKey struct {
-	Patch        string
-	ChampionID   int
-	TeamPosition string
-	ItemID       int
-	BuildSlot    int // 1-6 for first through sixth completed item
-}
-
-// ItemSlotStats holds aggregated item slot statistics
-type ItemSlotStats struct {
-	Wins    int
-	Matches int
+	return ""
 }
 
 // JSON export types
 type DataExport struct {
-	Patch            string                  `json:"patch"`
-	GeneratedAt      string                  `json:"generatedAt"`
-	ChampionStats    []ChampionStatJSON      `json:"championStats"`
-	ChampionItems    []ChampionItemJSON      `json:"championItems"`
+	Patch             string                 `json:"patch"`
+	GeneratedAt       string                 `json:"generatedAt"`
+	ChampionStats     []ChampionStatJSON     `json:"championStats"`
+	ChampionItems     []ChampionItemJSON     `json:"championItems"`
 	ChampionItemSlots []ChampionItemSlotJSON `json:"championItemSlots"`
-	ChampionMatchups []ChampionMatchupJSON   `json:"championMatchups"`
+	ChampionMatchups  []ChampionMatchupJSON  `json:"championMatchups"`
+	ArenaStats        []ArenaStatJSON        `json:"arenaStats"`
+	ArenaAugments     []ArenaStatJSON        `json:"arenaAugments"`
+	ArenaItems        []ArenaStatJSON        `json:"arenaItems"`
 }
 
 type ChampionStatJSON struct {
 	Patch        string `json:"patch"`
+	QueueID      int    `json:"queueId"`
+	Bracket      string `json:"bracket"`
+	Region       string `json:"region"`
 	ChampionID   int    `json:"championId"`
 	TeamPosition string `json:"teamPosition"`
 	Wins         int    `json:"wins"`
@@ -196,6 +83,9 @@ type ChampionStatJSON struct {
 
 type ChampionItemJSON struct {
 	Patch        string `json:"patch"`
+	QueueID      int    `json:"queueId"`
+	Bracket      string `json:"bracket"`
+	Region       string `json:"region"`
 	ChampionID   int    `json:"championId"`
 	TeamPosition string `json:"teamPosition"`
 	ItemID       int    `json:"itemId"`
@@ -205,6 +95,9 @@ type ChampionItemJSON struct {
 
 type ChampionMatchupJSON struct {
 	Patch           string `json:"patch"`
+	QueueID         int    `json:"queueId"`
+	Bracket         string `json:"bracket"`
+	Region          string `json:"region"`
 	ChampionID      int    `json:"championId"`
 	TeamPosition    string `json:"teamPosition"`
 	EnemyChampionID int    `json:"enemyChampionId"`
@@ -214,6 +107,9 @@ type ChampionMatchupJSON struct {
 
 type ChampionItemSlotJSON struct {
 	Patch        string `json:"patch"`
+	QueueID      int    `json:"queueId"`
+	Bracket      string `json:"bracket"`
+	Region       string `json:"region"`
 	ChampionID   int    `json:"championId"`
 	TeamPosition string `json:"teamPosition"`
 	ItemID       int    `json:"itemId"`
@@ -222,6 +118,18 @@ type ChampionItemSlotJSON struct {
 	Matches      int    `json:"matches"`
 }
 
+// ArenaStatJSON is an Arena champion, augment or item row; Wins counts top four finishes
+type ArenaStatJSON struct {
+	Patch        string `json:"patch"`
+	ChampionID   int    `json:"championId"`
+	AugmentID    int    `json:"augmentId,omitempty"`
+	ItemID       int    `json:"itemId,omitempty"`
+	Wins         int    `json:"wins"`
+	Matches      int    `json:"matches"`
+	Firsts       int    `json:"firsts"`
+	PlacementSum int    `json:"placementSum"`
+}
+
 func main() {
 	flag.Parse()
 
@@ -249,11 +157,6 @@ func main() {
 		log.Fatalf("Failed to create cold directory: %v", err)
 	}
 
-	// Load completed items from Data Dragon
-	if err := loadCompletedItems(); err != nil {
-		log.Fatalf("Failed to load item data: %v", err)
-	}
-
 	// Scan warm directory for .jsonl files
 	files, err := filepath.Glob(filepath.Join(warmDir, "*.jsonl"))
 	if err != nil {
@@ -267,74 +170,31 @@ func main() {
 
 	fmt.Printf("Found %d files to process\n", len(files))
 
-	// Aggregate ALL files together into global maps
-	allChampionStats := make(map[ChampionStatsKey]*ChampionStats)
-	allItemStats := make(map[ItemStatsKey]*ItemStats)
-	allItemSlotStats := make(map[ItemSlotStatsKey]*ItemSlotStats)
-	allMatchupStats := make(map[MatchupStatsKey]*MatchupStats)
-	var detectedPatch string
-
-	// Process each file and accumulate stats
-	for i, filePath := range files {
-		fmt.Printf("\n[%d/%d] Processing: %s\n", i+1, len(files), filepath.Base(filePath))
-
-		championStats, itemStats, itemSlotStats, matchupStats, patch, err := aggregateFile(filePath)
-		if err != nil {
-			log.Printf("  Error processing file: %v", err)
-			continue
-		}
-
-		// Track the patch (use the last one seen)
-		if patch != "" {
-			detectedPatch = patch
-		}
-
-		// Merge into global maps
-		for k, v := range championStats {
-			if existing, ok := allChampionStats[k]; ok {
-				existing.Wins += v.Wins
-				existing.Matches += v.Matches
-			} else {
-				allChampionStats[k] = v
-			}
-		}
-
-		for k, v := range itemStats {
-			if existing, ok := allItemStats[k]; ok {
-				existing.Wins += v.Wins
-				existing.Matches += v.Matches
-			} else {
-				allItemStats[k] = v
-			}
-		}
-
-		for k, v := range itemSlotStats {
-			if existing, ok := allItemSlotStats[k]; ok {
-				existing.Wins += v.Wins
-				existing.Matches += v.Matches
-			} else {
-				allItemSlotStats[k] = v
-			}
-		}
-
-		for k, v := range matchupStats {
-			if existing, ok := allMatchupStats[k]; ok {
-				existing.Wins += v.Wins
-				existing.Matches += v.Matches
-			} else {
-				allMatchupStats[k] = v
-			}
-		}
+	// Load the item catalog from Data Dragon (cached under BLOB_STORAGE_PATH/ddragon),
+	// pinned to the patch of the newest file
+	dd := ddragon.New(ddragon.Options{CacheDir: filepath.Join(storagePath, "ddragon")})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	items, err = riot.LoadItemCatalog(ctx, dd, filePatch(files[len(files)-1]))
+	cancel()
+	if err != nil {
+		log.Fatalf("Failed to load item data: %v", err)
+	}
+	fmt.Printf("Loaded %d items from Data Dragon (%s)\n", items.Len(), items.Version())
 
-		fmt.Printf("  Aggregated: %d champion stats, %d item stats, %d item slot stats, %d matchup stats\n",
-			len(championStats), len(itemStats), len(itemSlotStats), len(matchupStats))
+	// Aggregate ALL files together
+	agg := collector.AggregateFiles(files, items.IsCompleted)
+	if *mergeRegions {
+		agg.MergeRegions()
 	}
+	detectedPatch := agg.DetectedPatch
 
 	fmt.Printf("\n=== Total Aggregated ===\n")
-	fmt.Printf("Champion stats: %d\n", len(allChampionStats))
-	fmt.Printf("Item stats: %d\n", len(allItemStats))
-	fmt.Printf("Item slot stats: %d\n", len(allItemSlotStats))
-	fmt.Printf("Matchup stats: %d\n", len(allMatchupStats))
+	fmt.Printf("Files: %d, records: %d\n", agg.FilesProcessed, agg.TotalRecords)
+	fmt.Printf("Champion stats: %d\n", len(agg.ChampionStats))
+	fmt.Printf("Item stats: %d\n", len(agg.ItemStats))
+	fmt.Printf("Item slot stats: %d\n", len(agg.ItemSlotStats))
+	fmt.Printf("Matchup stats: %d\n", len(agg.MatchupStats))
+	fmt.Printf("Arena stats: %d champions, %d augments, %d items\n", len(agg.ArenaStats), len(agg.ArenaAugments), len(agg.ArenaItems))
 	fmt.Printf("Detected patch: %s\n", detectedPatch)
 
 	// Calculate min patch for cleanup
@@ -346,7 +206,7 @@ func main() {
 	// Push to Turso first to get the versioned patch (default: enabled if TURSO_DATABASE_URL is set)
 	if !*skipTurso && os.Getenv("TURSO_DATABASE_URL") != "" {
 		fmt.Printf("\n=== Pushing to Turso ===\n")
-		version, err := pushToTurso(detectedPatch, minPatch, allChampionStats, allItemStats, allItemSlotStats, allMatchupStats)
+		version, err := pushToTurso(detectedPatch, minPatch, agg)
 		if err != nil {
 			log.Fatalf("Failed to push to Turso: %v", err)
 		}
@@ -359,7 +219,7 @@ func main() {
 	// Export to JSON with versioned patch (default: enabled)
 	if !*skipJSON {
 		fmt.Printf("\n=== Exporting JSON ===\n")
-		if err := exportToJSON(*outputDir, versionedPatch, allChampionStats, allItemStats, allItemSlotStats, allMatchupStats); err != nil {
+		if err := exportToJSON(*outputDir, versionedPatch, agg); err != nil {
 			log.Fatalf("Failed to export JSON: %v", err)
 		}
 		fmt.Printf("Exported to: %s\n", *outputDir)
@@ -387,193 +247,6 @@ func main() {
 	fmt.Println("\n=== Reducer Complete ===")
 }
 
-func aggregateFile(filePath string) (map[ChampionStatsKey]*ChampionStats, map[ItemStatsKey]*ItemStats, map[ItemSlotStatsKey]*ItemSlotStats, map[MatchupStatsKey]*MatchupStats, string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, nil, nil, nil, "", err
-	}
-	defer file.Close()
-
-	championStats := make(map[ChampionStatsKey]*ChampionStats)
-	itemStats := make(map[ItemStatsKey]*ItemStats)
-	itemSlotStats := make(map[ItemSlotStatsKey]*ItemSlotStats)
-	matchupStats := make(map[MatchupStatsKey]*MatchupStats)
-	var detectedPatch string
-
-	// First pass: group all participants by matchId
-	matchParticipants := make(map[string][]RawMatch)
-
-	scanner := bufio.NewScanner(file)
-	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
-
-	lineNum := 0
-	for scanner.Scan() {
-		lineNum++
-		line := scanner.Bytes()
-
-		var match RawMatch
-		if err := json.Unmarshal(line, &match); err != nil {
-			log.Printf("  Warning: failed to parse line %d: %v", lineNum, err)
-			continue
-		}
-
-		// Skip if no position
-		if match.TeamPosition == "" {
-			continue
-		}
-
-		// Normalize patch version
-		patch := normalizePatch(match.GameVersion)
-		if detectedPatch == "" {
-			detectedPatch = patch
-		}
-
-		// Aggregate champion stats
-		champKey := ChampionStatsKey{
-			Patch:        patch,
-			ChampionID:   match.ChampionID,
-			TeamPosition: match.TeamPosition,
-		}
-
-		if _, exists := championStats[champKey]; !exists {
-			championStats[champKey] = &ChampionStats{}
-		}
-		championStats[champKey].Matches++
-		if match.Win {
-			championStats[champKey].Wins++
-		}
-
-		// ITEM STATS: Always use final inventory (item0-5) for 100% of matches
-		// This ensures accurate item win rates across all games
-		finalItems := []int{match.Item0, match.Item1, match.Item2, match.Item3, match.Item4, match.Item5}
-		seenItems := make(map[int]bool)
-		for _, itemID := range finalItems {
-			// Skip empty, duplicates, and non-completed items
-			if itemID == 0 || seenItems[itemID] || !isCompletedItem(itemID) {
-				continue
-			}
-			seenItems[itemID] = true
-
-			// Regular item stats (which items are built)
-			itemKey := ItemStatsKey{
-				Patch:        patch,
-				ChampionID:   match.ChampionID,
-				TeamPosition: match.TeamPosition,
-				ItemID:       itemID,
-			}
-
-			if _, exists := itemStats[itemKey]; !exists {
-				itemStats[itemKey] = &ItemStats{}
-			}
-			itemStats[itemKey].Matches++
-			if match.Win {
-				itemStats[itemKey].Wins++
-			}
-		}
-
-		// ITEM SLOT STATS: Only process when BuildOrder exists (sampled matches)
-		// BuildOrder comes from timeline data, which is only fetched for ~20% of matches
-		// This gives us accurate build path statistics without fetching timeline for every game
-		if len(match.BuildOrder) > 0 {
-			seenSlotItems := make(map[int]bool)
-			buildSlot := 0
-			for _, itemID := range match.BuildOrder {
-				// Skip empty, duplicates, and non-completed items
-				if itemID == 0 || seenSlotItems[itemID] || !isCompletedItem(itemID) {
-					continue
-				}
-				seenSlotItems[itemID] = true
-				buildSlot++
-
-				// Item slot stats (which items are built in which slot)
-				// Only track slots 1-6
-				if buildSlot <= 6 {
-					slotKey := ItemSlotStatsKey{
-						Patch:        patch,
-						ChampionID:   match.ChampionID,
-						TeamPosition: match.TeamPosition,
-						ItemID:       itemID,
-						BuildSlot:    buildSlot,
-					}
-
-					if _, exists := itemSlotStats[slotKey]; !exists {
-						itemSlotStats[slotKey] = &ItemSlotStats{}
-					}
-					itemSlotStats[slotKey].Matches++
-					if match.Win {
-						itemSlotStats[slotKey].Wins++
-					}
-				}
-			}
-		}
-
-		// Group by matchId for matchup calculation
-		matchParticipants[match.MatchID] = append(matchParticipants[match.MatchID], match)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, nil, nil, nil, "", err
-	}
-
-	// Second pass: calculate matchups from grouped participants
-	for _, participants := range matchParticipants {
-		// Group by position
-		byPosition := make(map[string][]RawMatch)
-		for _, p := range participants {
-			byPosition[p.TeamPosition] = append(byPosition[p.TeamPosition], p)
-		}
-
-		// For each position, find the two opponents (one winner, one loser)
-		for _, posPlayers := range byPosition {
-			if len(posPlayers) != 2 {
-				continue // Skip if not exactly 2 players in this position
-			}
-
-			p1, p2 := posPlayers[0], posPlayers[1]
-
-			// They should be on opposite teams (one won, one lost)
-			if p1.Win == p2.Win {
-				continue // Same result = probably same team, skip
-			}
-
-			patch := normalizePatch(p1.GameVersion)
-
-			// Record matchup for p1 vs p2
-			key1 := MatchupStatsKey{
-				Patch:           patch,
-				ChampionID:      p1.ChampionID,
-				TeamPosition:    p1.TeamPosition,
-				EnemyChampionID: p2.ChampionID,
-			}
-			if _, exists := matchupStats[key1]; !exists {
-				matchupStats[key1] = &MatchupStats{}
-			}
-			matchupStats[key1].Matches++
-			if p1.Win {
-				matchupStats[key1].Wins++
-			}
-
-			// Record matchup for p2 vs p1
-			key2 := MatchupStatsKey{
-				Patch:           patch,
-				ChampionID:      p2.ChampionID,
-				TeamPosition:    p2.TeamPosition,
-				EnemyChampionID: p1.ChampionID,
-			}
-			if _, exists := matchupStats[key2]; !exists {
-				matchupStats[key2] = &MatchupStats{}
-			}
-			matchupStats[key2].Matches++
-			if p2.Win {
-				matchupStats[key2].Wins++
-			}
-		}
-	}
-
-	fmt.Printf("  Processed %d lines, %d unique matches\n", lineNum, len(matchParticipants))
-	return championStats, itemStats, itemSlotStats, matchupStats, detectedPatch, nil
-}
-
 // normalizePatch truncates version to first two segments (e.g., 14.23.448 -> 14.23)
 func normalizePatch(version string) string {
 	parts := strings.Split(version, ".")
@@ -700,11 +373,7 @@ type ManifestJSON struct {
 }
 
 // exportToJSON exports aggregated data to data.json and manifest.json
-func exportToJSON(outputDir, patch string,
-	championStats map[ChampionStatsKey]*ChampionStats,
-	itemStats map[ItemStatsKey]*ItemStats,
-	itemSlotStats map[ItemSlotStatsKey]*ItemSlotStats,
-	matchupStats map[MatchupStatsKey]*MatchupStats) error {
+func exportToJSON(outputDir, patch string, agg *collector.AggData) error {
 
 	// Ensure output directory exists
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
@@ -716,9 +385,12 @@ func exportToJSON(outputDir, patch string,
 
 	// Convert maps to JSON arrays
 	var champStatsJSON []ChampionStatJSON
-	for k, v := range championStats {
+	for k, v := range agg.ChampionStats {
 		champStatsJSON = append(champStatsJSON, ChampionStatJSON{
 			Patch:        k.Patch,
+			QueueID:      k.QueueID,
+			Bracket:      k.Bracket,
+			Region:       k.Region,
 			ChampionID:   k.ChampionID,
 			TeamPosition: k.TeamPosition,
 			Wins:         v.Wins,
@@ -727,9 +399,12 @@ func exportToJSON(outputDir, patch string,
 	}
 
 	var itemStatsJSON []ChampionItemJSON
-	for k, v := range itemStats {
+	for k, v := range agg.ItemStats {
 		itemStatsJSON = append(itemStatsJSON, ChampionItemJSON{
 			Patch:        k.Patch,
+			QueueID:      k.QueueID,
+			Bracket:      k.Bracket,
+			Region:       k.Region,
 			ChampionID:   k.ChampionID,
 			TeamPosition: k.TeamPosition,
 			ItemID:       k.ItemID,
@@ -739,9 +414,12 @@ func exportToJSON(outputDir, patch string,
 	}
 
 	var itemSlotStatsJSON []ChampionItemSlotJSON
-	for k, v := range itemSlotStats {
+	for k, v := range agg.ItemSlotStats {
 		itemSlotStatsJSON = append(itemSlotStatsJSON, ChampionItemSlotJSON{
 			Patch:        k.Patch,
+			QueueID:      k.QueueID,
+			Bracket:      k.Bracket,
+			Region:       k.Region,
 			ChampionID:   k.ChampionID,
 			TeamPosition: k.TeamPosition,
 			ItemID:       k.ItemID,
@@ -752,9 +430,12 @@ func exportToJSON(outputDir, patch string,
 	}
 
 	var matchupStatsJSON []ChampionMatchupJSON
-	for k, v := range matchupStats {
+	for k, v := range agg.MatchupStats {
 		matchupStatsJSON = append(matchupStatsJSON, ChampionMatchupJSON{
 			Patch:           k.Patch,
+			QueueID:         k.QueueID,
+			Bracket:         k.Bracket,
+			Region:          k.Region,
 			ChampionID:      k.ChampionID,
 			TeamPosition:    k.TeamPosition,
 			EnemyChampionID: k.EnemyChampionID,
@@ -763,6 +444,21 @@ func exportToJSON(outputDir, patch string,
 		})
 	}
 
+	var arenaStatsJSON, arenaAugmentsJSON, arenaItemsJSON []ArenaStatJSON
+	for k, v := range agg.ArenaStats {
+		arenaStatsJSON = append(arenaStatsJSON, arenaStatJSON(k.Patch, k.ChampionID, v))
+	}
+	for k, v := range agg.ArenaAugments {
+		row := arenaStatJSON(k.Patch, k.ChampionID, v)
+		row.AugmentID = k.AugmentID
+		arenaAugmentsJSON = append(arenaAugmentsJSON, row)
+	}
+	for k, v := range agg.ArenaItems {
+		row := arenaStatJSON(k.Patch, k.ChampionID, v)
+		row.ItemID = k.ItemID
+		arenaItemsJSON = append(arenaItemsJSON, row)
+	}
+
 	export := DataExport{
 		Patch:             patch,
 		GeneratedAt:       time.Now().UTC().Format(time.RFC3339),
@@ -770,6 +466,9 @@ func exportToJSON(outputDir, patch string,
 		ChampionItems:     itemStatsJSON,
 		ChampionItemSlots: itemSlotStatsJSON,
 		ChampionMatchups:  matchupStatsJSON,
+		ArenaStats:        arenaStatsJSON,
+		ArenaAugments:     arenaAugmentsJSON,
+		ArenaItems:        arenaItemsJSON,
 	}
 
 	// Write data.json
@@ -792,8 +491,9 @@ func exportToJSON(outputDir, patch string,
 
 	dataSha256 := hex.EncodeToString(hasher.Sum(nil))
 
-	fmt.Printf("  Wrote data.json: %d champion stats, %d item stats, %d item slot stats, %d matchup stats\n",
-		len(champStatsJSON), len(itemStatsJSON), len(itemSlotStatsJSON), len(matchupStatsJSON))
+	fmt.Printf("  Wrote data.json: %d champion stats, %d item stats, %d item slot stats, %d matchup stats, %d arena stats\n",
+		len(champStatsJSON), len(itemStatsJSON), len(itemSlotStatsJSON), len(matchupStatsJSON),
+		len(arenaStatsJSON)+len(arenaAugmentsJSON)+len(arenaItemsJSON))
 	fmt.Printf("  SHA256: %s\n", dataSha256)
 
 	// Write manifest.json
@@ -825,6 +525,18 @@ func exportToJSON(outputDir, patch string,
 	return nil
 }
 
+// arenaStatJSON converts an Arena aggregate into its export row
+func arenaStatJSON(patch string, championID int, v *collector.ArenaStats) ArenaStatJSON {
+	return ArenaStatJSON{
+		Patch:        patch,
+		ChampionID:   championID,
+		Wins:         v.Wins,
+		Matches:      v.Matches,
+		Firsts:       v.Firsts,
+		PlacementSum: v.PlacementSum,
+	}
+}
+
 // createGitHubRelease creates a GitHub release and uploads data.json
 func createGitHubRelease(outputDir, patch string) error {
 	repo := os.Getenv("GITHUB_REPO")
@@ -868,11 +580,7 @@ func createGitHubRelease(outputDir, patch string) error {
 
 // pushToTurso pushes aggregated data to Turso database and cleans up old patches
 // Returns the versioned patch string (e.g., "15.24.3") for use in manifest
-func pushToTurso(patch, minPatch string,
-	championStats map[ChampionStatsKey]*ChampionStats,
-	itemStats map[ItemStatsKey]*ItemStats,
-	itemSlotStats map[ItemSlotStatsKey]*ItemSlotStats,
-	matchupStats map[MatchupStatsKey]*MatchupStats) (string, error) {
+func pushToTurso(patch, minPatch string, agg *collector.AggData) (string, error) {
 
 	// Get Turso credentials from environment
 	tursoURL := os.Getenv("TURSO_DATABASE_URL")
@@ -917,72 +625,12 @@ func pushToTurso(patch, minPatch string,
 		return "", fmt.Errorf("failed to set data version: %w", err)
 	}
 
-	// Insert champion stats
-	fmt.Printf("Inserting %d champion stats...\n", len(championStats))
-	champStatsList := make([]db.ChampionStat, 0, len(championStats))
-	for k, v := range championStats {
-		champStatsList = append(champStatsList, db.ChampionStat{
-			Patch:        k.Patch,
-			ChampionID:   k.ChampionID,
-			TeamPosition: k.TeamPosition,
-			Wins:         v.Wins,
-			Matches:      v.Matches,
-		})
-	}
-	if err := client.InsertChampionStats(ctx, champStatsList); err != nil {
-		return "", fmt.Errorf("failed to insert champion stats: %w", err)
-	}
-
-	// Insert champion items
-	fmt.Printf("Inserting %d champion items...\n", len(itemStats))
-	itemStatsList := make([]db.ChampionItem, 0, len(itemStats))
-	for k, v := range itemStats {
-		itemStatsList = append(itemStatsList, db.ChampionItem{
-			Patch:        k.Patch,
-			ChampionID:   k.ChampionID,
-			TeamPosition: k.TeamPosition,
-			ItemID:       k.ItemID,
-			Wins:         v.Wins,
-			Matches:      v.Matches,
-		})
-	}
-	if err := client.InsertChampionItems(ctx, itemStatsList); err != nil {
-		return "", fmt.Errorf("failed to insert champion items: %w", err)
-	}
-
-	// Insert champion item slots
-	fmt.Printf("Inserting %d champion item slots...\n", len(itemSlotStats))
-	slotStatsList := make([]db.ChampionItemSlot, 0, len(itemSlotStats))
-	for k, v := range itemSlotStats {
-		slotStatsList = append(slotStatsList, db.ChampionItemSlot{
-			Patch:        k.Patch,
-			ChampionID:   k.ChampionID,
-			TeamPosition: k.TeamPosition,
-			ItemID:       k.ItemID,
-			BuildSlot:    k.BuildSlot,
-			Wins:         v.Wins,
-			Matches:      v.Matches,
-		})
-	}
-	if err := client.InsertChampionItemSlots(ctx, slotStatsList); err != nil {
-		return "", fmt.Errorf("failed to insert champion item slots: %w", err)
-	}
-
-	// Insert champion matchups
-	fmt.Printf("Inserting %d champion matchups...\n", len(matchupStats))
-	matchupStatsList := make([]db.ChampionMatchup, 0, len(matchupStats))
-	for k, v := range matchupStats {
-		matchupStatsList = append(matchupStatsList, db.ChampionMatchup{
-			Patch:           k.Patch,
-			ChampionID:      k.ChampionID,
-			TeamPosition:    k.TeamPosition,
-			EnemyChampionID: k.EnemyChampionID,
-			Wins:            v.Wins,
-			Matches:         v.Matches,
-		})
-	}
-	if err := client.InsertChampionMatchups(ctx, matchupStatsList); err != nil {
-		return "", fmt.Errorf("failed to insert champion matchups: %w", err)
+	// Insert all aggregates
+	fmt.Printf("Inserting %d champion stats, %d items, %d item slots, %d matchups, %d arena stats...\n",
+		len(agg.ChampionStats), len(agg.ItemStats), len(agg.ItemSlotStats), len(agg.MatchupStats),
+		len(agg.ArenaStats)+len(agg.ArenaAugments)+len(agg.ArenaItems))
+	if err := collector.InsertAggData(ctx, client, agg); err != nil {
+		return "", err
 	}
 
 	// Recreate indexes after bulk inserts
@@ -5,10 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"data-analyzer/internal/riot"
 )
 
 // SpiderRunner is the interface for the match fetching component.
@@ -17,58 +18,25 @@ type SpiderRunner interface {
 	// RunContinuous starts the spider's collection loop. It should respect the context
 	// for cancellation and return when the context is cancelled.
 	RunContinuous(ctx context.Context) error
-	// Reset clears internal state (bloom filters, player queue)
+	// Reset clears internal state (bloom filters, counters)
 	Reset()
-	// SeedFromChallenger seeds the spider with the top Challenger player
-	SeedFromChallenger(ctx context.Context) error
+	// ResetCounters clears the session counters but keeps the bloom filters
+	ResetCounters()
+	// SeedFromLadder seeds the spider with ranked ladder players when it has no frontier to continue
+	SeedFromLadder(ctx context.Context) error
 	// SetAPIKey updates the API key used by the spider's riot client
 	SetAPIKey(key string)
 }
 
-// API Key error types
+// API Key error types. Both match riot.ErrKeyExpired, like the riot client's 401 and 403 errors.
 var (
-	ErrAPIKeyExpired   = errors.New("api key expired (401)")
-	ErrAPIKeyForbidden = errors.New("api key forbidden (403)")
+	ErrAPIKeyExpired   = fmt.Errorf("api key expired (401): %w", riot.ErrKeyExpired)
+	ErrAPIKeyForbidden = fmt.Errorf("api key forbidden (403): %w", riot.ErrKeyExpired)
 )
 
 // IsAPIKeyError checks if an error indicates API key expiration (401 or 403)
 func IsAPIKeyError(err error) bool {
-	if err == nil {
-		return false
-	}
-	if errors.Is(err, ErrAPIKeyExpired) || errors.Is(err, ErrAPIKeyForbidden) {
-		return true
-	}
-	// Check for HTTP status codes in error message
-	errStr := err.Error()
-	return contains(errStr, "401") || contains(errStr, "403") ||
-		contains(errStr, "Unauthorized") || contains(errStr, "Forbidden")
-}
-
-// contains is a simple string contains helper
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsImpl(s, substr))
-}
-
-func containsImpl(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
-}
-
-// WrapHTTPError wraps an HTTP response status code as an appropriate error
-func WrapHTTPError(statusCode int, message string) error {
-	switch statusCode {
-	case http.StatusUnauthorized:
-		return fmt.Errorf("%s: %w", message, ErrAPIKeyExpired)
-	case http.StatusForbidden:
-		return fmt.Errorf("%s: %w", message, ErrAPIKeyForbidden)
-	default:
-		return fmt.Errorf("%s: status %d", message, statusCode)
-	}
+	return errors.Is(err, riot.ErrKeyExpired)
 }
 
 // ReducerFunc is the function signature for the reduce operation
@@ -239,12 +207,12 @@ func (cc *ContinuousCollector) Run(ctx context.Context) error {
 	}
 }
 
-// seedAndStartCollecting seeds from Challenger and starts the spider
+// seedAndStartCollecting seeds from the ladder and starts the spider
 func (cc *ContinuousCollector) seedAndStartCollecting(ctx context.Context) error {
-	// Seed from Challenger #1
+	// Seed from the ranked ladder
 	if cc.spider != nil {
-		if err := cc.spider.SeedFromChallenger(ctx); err != nil {
-			return fmt.Errorf("failed to seed from Challenger: %w", err)
+		if err := cc.spider.SeedFromLadder(ctx); err != nil {
+			return fmt.Errorf("failed to seed from the ladder: %w", err)
 		}
 	}
 
@@ -263,6 +231,7 @@ func (cc *ContinuousCollector) seedAndStartCollecting(ctx context.Context) error
 // runSpider runs the spider loop, respecting state machine state
 func (cc *ContinuousCollector) runSpider(ctx context.Context) {
 	defer cc.wg.Done()
+	defer cc.checkpointSpider()
 
 	for {
 		select {
@@ -289,8 +258,17 @@ func (cc *ContinuousCollector) runSpider(ctx context.Context) {
 					// Key expired, spider should stop
 					return
 				}
-				// Retry on other errors
-				time.Sleep(time.Second)
+				// Retry on other errors, unless stopped while waiting
+				timer := time.NewTimer(spiderRetryDelay(err))
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-cc.shutdownCh:
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
 			}
 		}
 	}
@@ -305,10 +283,38 @@ func (cc *ContinuousCollector) handleSpiderError(ctx context.Context, err error)
 		return true // Stop spider, wait for new key
 	}
 	// Log other errors but continue
-	log.Printf("[ContinuousCollector] Spider error (will retry): %v", err)
+	switch {
+	case errors.Is(err, riot.ErrRateLimited):
+		log.Printf("[ContinuousCollector] Rate limited (will retry in %s): %v", spiderRetryDelay(err), err)
+	case errors.Is(err, riot.ErrServer):
+		log.Printf("[ContinuousCollector] Riot API server error (will retry): %v", err)
+	default:
+		log.Printf("[ContinuousCollector] Spider error (will retry): %v", err)
+	}
 	return false
 }
 
+// checkpointSpider saves the spider's crawl state, if it can, once its loop has stopped
+func (cc *ContinuousCollector) checkpointSpider() {
+	cp, ok := cc.spider.(Checkpointer)
+	if !ok {
+		return
+	}
+	if err := cp.Checkpoint(); err != nil {
+		log.Printf("[ContinuousCollector] Failed to checkpoint spider: %v", err)
+	}
+}
+
+// spiderRetryDelay returns how long to wait before the next batch after a spider error:
+// a rate limit's Retry-After, or a second
+func spiderRetryDelay(err error) time.Duration {
+	var apiErr *riot.APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > time.Second {
+		return apiErr.RetryAfter
+	}
+	return time.Second
+}
+
 // onWarmFileThreshold is called when warm file count reaches threshold
 func (cc *ContinuousCollector) onWarmFileThreshold() {
 	log.Println("[ContinuousCollector] Warm file threshold reached, triggering reduce...")
@@ -464,13 +470,14 @@ func (cc *ContinuousCollector) handleWaitingForKey(ctx context.Context) {
 	}
 }
 
-// handleFreshRestart clears state and returns to STARTUP
+// handleFreshRestart clears the session's counters and returns to STARTUP
 func (cc *ContinuousCollector) handleFreshRestart(ctx context.Context) {
 	log.Println("[ContinuousCollector] Executing fresh restart...")
 
-	// Clear all state
+	// Start new counters; the bloom filters are kept so this patch's matches aren't
+	// collected again (they are only cleared every BloomResetInterval cycles)
 	if cc.spider != nil {
-		cc.spider.Reset()
+		cc.spider.ResetCounters()
 	}
 
 	// Reset warm file counter
@@ -514,7 +521,7 @@ func (cc *ContinuousCollector) handleFreshRestart(ctx context.Context) {
 
 	// Send success notification
 	if cc.notifyFunc != nil {
-		if err := cc.notifyFunc(ctx, "New session started! Fresh crawl beginning from top of ladder."); err != nil {
+		if err := cc.notifyFunc(ctx, "New session started! Collection resuming."); err != nil {
 			log.Printf("[ContinuousCollector] Failed to send success notification: %v", err)
 		}
 	}
@@ -590,11 +597,26 @@ func (cc *ContinuousCollector) onStateTransition(from, to State) {
 	log.Printf("[ContinuousCollector] State transition: %s → %s", from, to)
 }
 
+// CoverageReporter is implemented by spiders that track sample coverage per champion
+type CoverageReporter interface {
+	CoverageReports() []CoverageReport
+}
+
+// RankCacheReporter is implemented by spiders that cache rank lookups
+type RankCacheReporter interface {
+	RankCacheStats() (hits, misses int64)
+}
+
 // CollectorStats contains statistics about the collection run
 type CollectorStats struct {
 	MatchesCollected int64
 	RuntimeSeconds   int64
-	LastReduceAgo    int64 // seconds since last reduce, -1 if never reduced
+	LastReduceAgo    int64            // seconds since last reduce, -1 if never reduced
+	Coverage         []CoverageReport // per platform, empty if the spider doesn't track it
+
+	// Rank lookups answered from the rank cache and those that went to the API
+	RankCacheHits   int64
+	RankCacheMisses int64
 }
 
 // GetStats returns current collection statistics
@@ -611,6 +633,13 @@ func (cc *ContinuousCollector) GetStats() CollectorStats {
 		}
 	}
 
+	if reporter, ok := cc.spider.(CoverageReporter); ok {
+		stats.Coverage = reporter.CoverageReports()
+	}
+	if reporter, ok := cc.spider.(RankCacheReporter); ok {
+		stats.RankCacheHits, stats.RankCacheMisses = reporter.RankCacheStats()
+	}
+
 	return stats
 }
 
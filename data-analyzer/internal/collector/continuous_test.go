@@ -3,10 +3,14 @@ package collector
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"data-analyzer/internal/riot"
 )
 
 // MockSpider is a test double for the spider component
@@ -254,6 +258,35 @@ func TestContinuousCollector_APIErrorTriggersReduce(t *testing.T) {
 	}
 }
 
+// TestIsAPIKeyError_RiotErrors tests that key errors from the riot client are recognised
+// through wrapping and joined platform errors, and other categories are not
+func TestIsAPIKeyError_RiotErrors(t *testing.T) {
+	keyErr := fmt.Errorf("rank check failed: %w", &riot.APIError{StatusCode: http.StatusForbidden, Endpoint: riot.MethodLeague})
+	if !IsAPIKeyError(keyErr) {
+		t.Error("wrapped 403 should be a key error")
+	}
+	if !IsAPIKeyError(errors.Join(errors.New("euw1: connection reset"), keyErr)) {
+		t.Error("a key error on any platform should be a key error")
+	}
+
+	for _, status := range []int{http.StatusNotFound, http.StatusTooManyRequests, http.StatusInternalServerError} {
+		if IsAPIKeyError(&riot.APIError{StatusCode: status}) {
+			t.Errorf("status %d should not be a key error", status)
+		}
+	}
+}
+
+// TestSpiderRetryDelay tests that a rate limited batch waits out Retry-After
+func TestSpiderRetryDelay(t *testing.T) {
+	limited := fmt.Errorf("match fetch failed: %w", &riot.APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 10 * time.Second})
+	if got := spiderRetryDelay(limited); got != 10*time.Second {
+		t.Errorf("delay = %v, want 10s", got)
+	}
+	if got := spiderRetryDelay(errors.New("connection timeout")); got != time.Second {
+		t.Errorf("delay = %v, want 1s", got)
+	}
+}
+
 func TestContinuousCollector_NonAPIErrorDoesNotTriggerReduce(t *testing.T) {
 	sm := NewStateMachine()
 	sm.TransitionTo(StateCollecting)
@@ -0,0 +1,193 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"data-analyzer/internal/riot"
+	"data-analyzer/internal/storage"
+)
+
+const (
+	// DefaultCoverageTarget is the samples a champion needs in a position; the app only
+	// shows champion stats with at least 100 matches
+	DefaultCoverageTarget = 100
+
+	// How much a player's own champion weighs in their crawl priority against the rest
+	// of the match they were found in
+	ownChampionWeight = 0.75
+
+	// Least covered champions listed in a coverage report
+	coverageReportLowest = 5
+)
+
+// coverageKey is one champion in one position of one queue
+type coverageKey struct {
+	queueID    int
+	championID int
+	position   string // empty outside Summoner's Rift
+}
+
+// Coverage counts the samples collected per champion and position, so the crawl can go
+// after the picks that are short of the target first
+type Coverage struct {
+	mu     sync.Mutex
+	target int
+	counts map[coverageKey]int
+	names  map[int]string // champion names by ID, for reports
+}
+
+// NewCoverage creates a coverage tracker aiming for target samples per champion and position
+func NewCoverage(target int) *Coverage {
+	if target <= 0 {
+		target = DefaultCoverageTarget
+	}
+	return &Coverage{
+		target: target,
+		counts: make(map[coverageKey]int),
+		names:  make(map[int]string),
+	}
+}
+
+// participantKey returns a participant's coverage key
+func participantKey(queueID int, p riot.MatchParticipant) coverageKey {
+	return coverageKey{queueID: queueID, championID: p.ChampionID, position: p.TeamPosition}
+}
+
+// Record counts a collected match's participants
+func (c *Coverage) Record(match *riot.MatchResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, p := range match.Info.Participants {
+		c.counts[participantKey(match.Info.QueueID, p)]++
+		c.names[p.ChampionID] = p.ChampionName
+	}
+}
+
+// deficit returns how far a key is from the target, from 0 (covered) to 1 (no samples);
+// the caller holds c.mu
+func (c *Coverage) deficit(key coverageKey) float64 {
+	missing := c.target - c.counts[key]
+	if missing <= 0 {
+		return 0
+	}
+	return float64(missing) / float64(c.target)
+}
+
+// Priorities returns a crawl priority from 0 to 1 for each participant of a match. A
+// player's last game is the best guess at what they play, so players on under-covered
+// champions come first; a match full of over-covered champions lowers everyone in it.
+func (c *Coverage) Priorities(match *riot.MatchResponse) map[string]float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	participants := match.Info.Participants
+	if len(participants) == 0 {
+		return nil
+	}
+	deficits := make([]float64, len(participants))
+	var total float64
+	for i, p := range participants {
+		deficits[i] = c.deficit(participantKey(match.Info.QueueID, p))
+		total += deficits[i]
+	}
+	matchDeficit := total / float64(len(participants))
+
+	priorities := make(map[string]float64, len(participants))
+	for i, p := range participants {
+		priorities[p.PUUID] = ownChampionWeight*deficits[i] + (1-ownChampionWeight)*matchDeficit
+	}
+	return priorities
+}
+
+// CoverageEntry is the sample count of one champion in one position
+type CoverageEntry struct {
+	QueueID  int
+	Champion string
+	Position string
+	Matches  int
+}
+
+// String formats the entry as e.g. "Anivia MIDDLE 12"
+func (e CoverageEntry) String() string {
+	if e.Position == "" {
+		return fmt.Sprintf("%s %d", e.Champion, e.Matches)
+	}
+	return fmt.Sprintf("%s %s %d", e.Champion, e.Position, e.Matches)
+}
+
+// CoverageReport summarizes a crawl's coverage
+type CoverageReport struct {
+	Platform string
+	Target   int
+	Tracked  int             // champion/position pairs with any samples
+	Covered  int             // pairs at or over the target
+	Lowest   []CoverageEntry // least covered pairs, fewest first
+}
+
+// String formats the report for logs
+func (r CoverageReport) String() string {
+	summary := fmt.Sprintf("%s: %d/%d champion positions at %d+ samples", r.Platform, r.Covered, r.Tracked, r.Target)
+	if len(r.Lowest) == 0 {
+		return summary
+	}
+	lowest := make([]string, len(r.Lowest))
+	for i, e := range r.Lowest {
+		lowest[i] = e.String()
+	}
+	return summary + " (lowest: " + strings.Join(lowest, ", ") + ")"
+}
+
+// Report summarizes coverage for a platform
+func (c *Coverage) Report(platform string) CoverageReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := CoverageReport{Platform: platform, Target: c.target, Tracked: len(c.counts)}
+	entries := make([]CoverageEntry, 0, len(c.counts))
+	for key, n := range c.counts {
+		if n >= c.target {
+			report.Covered++
+			continue
+		}
+		entries = append(entries, CoverageEntry{QueueID: key.queueID, Champion: c.names[key.championID], Position: key.position, Matches: n})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Matches != entries[j].Matches {
+			return entries[i].Matches < entries[j].Matches
+		}
+		return entries[i].Champion < entries[j].Champion
+	})
+	report.Lowest = entries[:min(coverageReportLowest, len(entries))]
+	return report
+}
+
+// snapshot returns the sample counts for a frontier snapshot
+func (c *Coverage) snapshot() []storage.CoverageCount {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := make([]storage.CoverageCount, 0, len(c.counts))
+	for key, n := range c.counts {
+		counts = append(counts, storage.CoverageCount{
+			QueueID:      key.queueID,
+			ChampionID:   key.championID,
+			ChampionName: c.names[key.championID],
+			Position:     key.position,
+			Matches:      n,
+		})
+	}
+	return counts
+}
+
+// restore replaces the sample counts with a snapshot's
+func (c *Coverage) restore(counts []storage.CoverageCount) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts = make(map[coverageKey]int, len(counts))
+	for _, count := range counts {
+		c.counts[coverageKey{queueID: count.QueueID, championID: count.ChampionID, position: count.Position}] = count.Matches
+		c.names[count.ChampionID] = count.ChampionName
+	}
+}
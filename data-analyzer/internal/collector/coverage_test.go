@@ -0,0 +1,87 @@
+package collector
+
+import (
+	"testing"
+
+	"data-analyzer/internal/riot"
+)
+
+// testChampionIDs are the champion IDs coverage tests use
+var testChampionIDs = map[string]int{"Ahri": 103, "LeeSin": 64, "Ivern": 427}
+
+// coverageMatch builds a ranked match with one participant per champion, in position order
+func coverageMatch(champions ...string) *riot.MatchResponse {
+	positions := []string{"TOP", "JUNGLE", "MIDDLE", "BOTTOM", "UTILITY"}
+	match := &riot.MatchResponse{}
+	match.Info.QueueID = riot.QueueRankedSolo
+	for i, name := range champions {
+		match.Info.Participants = append(match.Info.Participants, riot.MatchParticipant{
+			PUUID:        "puuid-" + name,
+			ChampionID:   testChampionIDs[name],
+			ChampionName: name,
+			TeamPosition: positions[i%len(positions)],
+		})
+	}
+	return match
+}
+
+// TestCoverage_Priorities tests that players on under-covered champions, and matches
+// short of samples, rank first
+func TestCoverage_Priorities(t *testing.T) {
+	c := NewCoverage(4)
+	popular := coverageMatch("Ahri", "LeeSin")
+	for range 4 {
+		c.Record(popular)
+	}
+
+	// Ahri and Lee Sin are covered, Ivern has no samples
+	priorities := c.Priorities(coverageMatch("Ahri", "Ivern"))
+	if priorities["puuid-Ivern"] <= priorities["puuid-Ahri"] {
+		t.Errorf("under-covered Ivern %.2f should outrank covered Ahri %.2f", priorities["puuid-Ivern"], priorities["puuid-Ahri"])
+	}
+
+	// The same covered champion ranks lower in a match full of covered champions
+	inCoveredMatch := c.Priorities(popular)["puuid-Ahri"]
+	if inCoveredMatch >= priorities["puuid-Ahri"] {
+		t.Errorf("Ahri in a covered match %.2f should rank below Ahri next to Ivern %.2f", inCoveredMatch, priorities["puuid-Ahri"])
+	}
+	if inCoveredMatch != 0 {
+		t.Errorf("fully covered match priority = %.2f, want 0", inCoveredMatch)
+	}
+}
+
+// TestCoverage_Report tests the covered count and the least covered list
+func TestCoverage_Report(t *testing.T) {
+	c := NewCoverage(2)
+	c.Record(coverageMatch("Ahri", "LeeSin"))
+	c.Record(coverageMatch("Ahri"))
+
+	report := c.Report(riot.PlatformNA1)
+	if report.Target != 2 || report.Tracked != 2 || report.Covered != 1 {
+		t.Errorf("report = %+v, want 1 of 2 tracked covered at target 2", report)
+	}
+	if len(report.Lowest) != 1 || report.Lowest[0].Champion != "LeeSin" || report.Lowest[0].Matches != 1 {
+		t.Errorf("lowest = %v, want LeeSin with 1 match", report.Lowest)
+	}
+}
+
+// TestSpider_QueueOrder tests that the queue pops by priority, then in arrival order,
+// and that rate limited players go back to the front
+func TestSpider_QueueOrder(t *testing.T) {
+	s := newFrontierSpider(t, nil, "15.1")
+	s.addPlayer("low", 0.1)
+	s.addPlayer("high-1", 0.9)
+	s.addPlayer("high-2", 0.9)
+	s.addPlayer("mid", 0.5)
+	s.requeueOnRateLimit("retry", &riot.APIError{StatusCode: 429})
+
+	want := []string{"retry", "high-1", "high-2", "mid", "low"}
+	for _, w := range want {
+		if got := s.popPlayer(); got != w {
+			t.Fatalf("popped %q, want %q (order %v)", got, w, want)
+		}
+	}
+	if !s.isQueueEmpty() {
+		t.Error("queue should be empty")
+	}
+}
@@ -0,0 +1,191 @@
+package collector
+
+import (
+	"container/heap"
+	"log"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"data-analyzer/internal/storage"
+)
+
+// Checkpointer is implemented by spiders that can save their crawl state. The continuous
+// collector checkpoints when the spider loop stops (shutdown or key expiry).
+type Checkpointer interface {
+	Checkpoint() error
+}
+
+// snapshot copies the spider's crawl state
+func (s *Spider) snapshot() *storage.FrontierSnapshot {
+	snap := &storage.FrontierSnapshot{
+		Platform:           s.client.Platform(),
+		Patch:              s.currentPatch,
+		SavedAt:            time.Now(),
+		Players:            atomic.LoadInt64(&s.activePlayerCount),
+		Matches:            atomic.LoadInt64(&s.totalMatches),
+		Timelines:          atomic.LoadInt64(&s.timelinesCollected),
+		PlayersSkippedRank: atomic.LoadInt64(&s.playersSkippedRank),
+	}
+
+	s.playerQueueMu.Lock()
+	queue := s.playerQueue.sorted()
+	s.playerQueueMu.Unlock()
+	snap.Queue = make([]string, len(queue))
+	snap.QueuePriority = make([]float64, len(queue))
+	for i, p := range queue {
+		snap.Queue[i] = p.puuid
+		snap.QueuePriority[i] = p.priority
+	}
+	snap.Coverage = s.coverage.snapshot()
+	snap.BracketMatches = s.brackets.counts()
+	snap.PlayersSkippedQuota = atomic.LoadInt64(&s.playersSkippedQuota)
+
+	s.matchesMu.Lock()
+	snap.VisitedMatches = s.visitedMatches.Copy()
+	s.matchesMu.Unlock()
+
+	s.puuidsMu.Lock()
+	snap.VisitedPUUIDs = s.visitedPUUIDs.Copy()
+	s.puuidsMu.Unlock()
+
+	return snap
+}
+
+// Checkpoint saves the crawl state to the frontier store, if the spider has one.
+// Implements Checkpointer.
+func (s *Spider) Checkpoint() error {
+	if s.frontier == nil {
+		return nil
+	}
+	s.checkpointMu.Lock()
+	defer s.checkpointMu.Unlock()
+
+	snap := s.snapshot()
+	if err := s.frontier.Save(snap); err != nil {
+		return err
+	}
+	if err := s.rankCache.Save(); err != nil {
+		return err
+	}
+	s.lastCheckpoint = snap.SavedAt
+	log.Printf("[Spider] %s: checkpointed %d queued players, %d matches", snap.Platform, len(snap.Queue), snap.Matches)
+	log.Printf("[Spider] Coverage %s", s.coverage.Report(snap.Platform))
+	return nil
+}
+
+// maybeCheckpoint checkpoints if the checkpoint interval has passed since the last one
+func (s *Spider) maybeCheckpoint() {
+	if s.frontier == nil {
+		return
+	}
+	s.checkpointMu.Lock()
+	due := time.Since(s.lastCheckpoint) >= s.checkpointInterval
+	s.checkpointMu.Unlock()
+	if !due {
+		return
+	}
+	if err := s.Checkpoint(); err != nil {
+		log.Printf("[Spider] Failed to checkpoint: %v", err)
+	}
+}
+
+// restore loads the saved crawl state. It returns false, leaving the spider as it is,
+// when there is no snapshot, it is from another patch or no players are left in it.
+func (s *Spider) restore() (bool, error) {
+	if s.frontier == nil {
+		return false, nil
+	}
+	snap, err := s.frontier.Load(s.client.Platform())
+	if err != nil || snap == nil {
+		return false, err
+	}
+	if snap.Patch != s.currentPatch {
+		log.Printf("[Spider] %s: checkpoint is from patch %s, not %s (starting over)", snap.Platform, snap.Patch, s.currentPatch)
+		return false, nil
+	}
+	if len(snap.Queue) == 0 || snap.VisitedMatches == nil || snap.VisitedPUUIDs == nil {
+		return false, nil
+	}
+
+	// Older snapshots have no priorities; their queue order is kept
+	queue := make(playerHeap, len(snap.Queue))
+	for i, puuid := range snap.Queue {
+		queue[i] = queuedPlayer{puuid: puuid, seq: int64(i)}
+		if i < len(snap.QueuePriority) {
+			queue[i].priority = snap.QueuePriority[i]
+		}
+	}
+	heap.Init(&queue)
+	s.playerQueueMu.Lock()
+	s.playerQueue = queue
+	s.queueSeq = int64(len(queue))
+	s.playerQueueMu.Unlock()
+	s.coverage.restore(snap.Coverage)
+	s.brackets.restore(snap.BracketMatches)
+
+	s.matchesMu.Lock()
+	s.visitedMatches = snap.VisitedMatches
+	s.matchesMu.Unlock()
+
+	s.puuidsMu.Lock()
+	s.visitedPUUIDs = snap.VisitedPUUIDs
+	s.puuidsMu.Unlock()
+
+	atomic.StoreInt64(&s.activePlayerCount, snap.Players)
+	atomic.StoreInt64(&s.totalMatches, snap.Matches)
+	atomic.StoreInt64(&s.timelinesCollected, snap.Timelines)
+	atomic.StoreInt64(&s.playersSkippedRank, snap.PlayersSkippedRank)
+	atomic.StoreInt64(&s.playersSkippedQuota, snap.PlayersSkippedQuota)
+
+	s.checkpointMu.Lock()
+	s.lastCheckpoint = time.Now()
+	s.checkpointMu.Unlock()
+
+	log.Printf("[Spider] %s: restored checkpoint from %s (%d queued players, %d matches)",
+		snap.Platform, snap.SavedAt.Format(time.RFC3339), len(snap.Queue), snap.Matches)
+	return true, nil
+}
+
+// queuedPlayer is a player waiting to be crawled
+type queuedPlayer struct {
+	puuid    string
+	priority float64 // higher is crawled sooner
+	seq      int64   // queue order among equal priorities
+}
+
+// playerHeap orders queued players by priority, then first come first served.
+// Implements heap.Interface.
+type playerHeap []queuedPlayer
+
+// Len returns the number of queued players
+func (h playerHeap) Len() int { return len(h) }
+
+// Less orders higher priorities first, then earlier arrivals
+func (h playerHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+// Swap swaps two queued players
+func (h playerHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+// Push adds a queuedPlayer; use heap.Push
+func (h *playerHeap) Push(x any) { *h = append(*h, x.(queuedPlayer)) }
+
+// Pop removes the last player; use heap.Pop
+func (h *playerHeap) Pop() any {
+	old := *h
+	p := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return p
+}
+
+// sorted returns the queued players in crawl order
+func (h playerHeap) sorted() []queuedPlayer {
+	players := append([]queuedPlayer(nil), h...)
+	sort.Slice(players, func(i, j int) bool { return playerHeap(players).Less(i, j) })
+	return players
+}
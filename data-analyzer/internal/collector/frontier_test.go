@@ -0,0 +1,186 @@
+package collector
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"data-analyzer/internal/riot"
+	"data-analyzer/internal/storage"
+)
+
+// newFrontierSpider returns an offline spider for a patch that checkpoints to store
+func newFrontierSpider(t *testing.T, store *storage.FrontierStore, patch string) *Spider {
+	t.Helper()
+	t.Setenv("RIOT_API_KEY", "RGAPI-00000000-0000-0000-0000-000000000000")
+	client, err := riot.NewClientForPlatform(riot.PlatformEUW1)
+	if err != nil {
+		t.Fatalf("NewClientForPlatform: %v", err)
+	}
+	return NewSpider(client, nil, patch, SpiderConfig{Frontier: store})
+}
+
+// TestSpider_CheckpointRestore tests that a new spider picks up the saved frontier
+// instead of seeding from the ladder
+func TestSpider_CheckpointRestore(t *testing.T) {
+	store, err := storage.NewFrontierStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFrontierStore: %v", err)
+	}
+
+	first := newFrontierSpider(t, store, "15.1")
+	first.addPlayer("puuid-a", seedPriority)
+	first.addPlayer("puuid-b", seedPriority)
+	first.markMatchVisited("EUW1_1")
+	first.rankCache.Put("puuid-a", "DIAMOND", "II")
+	if err := first.Checkpoint(); err != nil {
+		t.Fatalf("Checkpoint: %v", err)
+	}
+
+	// Restored from the checkpoint, so no Challenger request is made
+	second := newFrontierSpider(t, store, "15.1")
+	if err := second.SeedFromLadder(context.Background()); err != nil {
+		t.Fatalf("SeedFromLadder: %v", err)
+	}
+	if got := second.popPlayer(); got != "puuid-a" {
+		t.Errorf("first queued player = %q, want puuid-a", got)
+	}
+	if !second.hasVisitedMatch("EUW1_1") || !second.hasVisitedPUUID("puuid-b") {
+		t.Error("visited sets weren't restored")
+	}
+	if _, ok := second.rankCache.Get("puuid-a"); !ok {
+		t.Error("cached ranks weren't restored")
+	}
+
+	// A checkpoint from an older patch is ignored
+	newPatch := newFrontierSpider(t, store, "15.2")
+	if restored, err := newPatch.restore(); err != nil || restored {
+		t.Errorf("restore on a new patch = %v, %v; want false", restored, err)
+	}
+	if !newPatch.isQueueEmpty() {
+		t.Error("stale checkpoint shouldn't fill the queue")
+	}
+}
+
+// TestSpider_ResetKeepsFrontier tests that a reset clears what was seen but keeps the queue
+func TestSpider_ResetKeepsFrontier(t *testing.T) {
+	store, err := storage.NewFrontierStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFrontierStore: %v", err)
+	}
+
+	s := newFrontierSpider(t, store, "15.1")
+	s.addPlayer("puuid-a", seedPriority)
+	s.markMatchVisited("EUW1_1")
+	s.Reset()
+
+	if s.hasVisitedMatch("EUW1_1") {
+		t.Error("visited matches should be cleared")
+	}
+	if s.isQueueEmpty() {
+		t.Fatal("queue should be kept")
+	}
+	// Still queued, so not queued twice
+	s.addPlayer("puuid-a", seedPriority)
+	if s.popPlayer() != "puuid-a" || !s.isQueueEmpty() {
+		t.Error("queued player was queued again after reset")
+	}
+
+	// The reset state was checkpointed
+	snap, err := store.Load(riot.PlatformEUW1)
+	if err != nil || snap == nil || len(snap.Queue) != 1 {
+		t.Errorf("checkpoint after reset = %+v, %v; want one queued player", snap, err)
+	}
+}
+
+// TestFreshRestart_KeepsVisitedMatches tests that a key renewal's fresh restart keeps the
+// visited matches, in memory and in the checkpoint, so they aren't collected again
+func TestFreshRestart_KeepsVisitedMatches(t *testing.T) {
+	store, err := storage.NewFrontierStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFrontierStore: %v", err)
+	}
+
+	s := newFrontierSpider(t, store, "15.1")
+	s.addPlayer("puuid-a", seedPriority)
+	s.markMatchVisited("EUW1_1")
+	s.markPUUIDVisited("puuid-b")
+	atomic.StoreInt64(&s.totalMatches, 3)
+
+	cc := NewContinuousCollector(s, nil, nil, nil, nil, DefaultConfig())
+	cc.handleFreshRestart(context.Background())
+
+	if !s.hasVisitedMatch("EUW1_1") || !s.hasVisitedPUUID("puuid-b") {
+		t.Error("fresh restart cleared the visited sets")
+	}
+	if got := atomic.LoadInt64(&s.totalMatches); got != 0 {
+		t.Errorf("matches after fresh restart = %d, want 0", got)
+	}
+
+	restored := newFrontierSpider(t, store, "15.1")
+	if ok, err := restored.restore(); err != nil || !ok {
+		t.Fatalf("restore = %v, %v; want the checkpoint", ok, err)
+	}
+	if !restored.hasVisitedMatch("EUW1_1") {
+		t.Error("checkpoint after fresh restart lost the visited matches")
+	}
+}
+
+// TestSpider_RankCache tests that cached ranks answer rank checks without the API and are
+// saved beside the frontier
+func TestSpider_RankCache(t *testing.T) {
+	store, err := storage.NewFrontierStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFrontierStore: %v", err)
+	}
+
+	first := newFrontierSpider(t, store, "15.1")
+	first.rankCache.Put("puuid-ranked", "DIAMOND", "II")
+	first.rankCache.Put("puuid-unranked", "", "")
+
+	// The offline client would fail these if they went to the API
+	tier, division, hasRank, err := first.soloQueueRank(context.Background(), "puuid-ranked")
+	if err != nil || !hasRank || tier != "DIAMOND" || division != "II" {
+		t.Errorf("soloQueueRank(ranked) = %s %s, %v, %v", tier, division, hasRank, err)
+	}
+	if _, _, hasRank, err := first.soloQueueRank(context.Background(), "puuid-unranked"); err != nil || hasRank {
+		t.Errorf("soloQueueRank(unranked) = %v, %v; want no rank from the cache", hasRank, err)
+	}
+	if hits, misses := first.RankCacheStats(); hits != 2 || misses != 0 {
+		t.Errorf("RankCacheStats = %d hits, %d misses; want 2, 0", hits, misses)
+	}
+
+	first.addPlayer("puuid-queued", seedPriority)
+	if err := first.Checkpoint(); err != nil {
+		t.Fatalf("Checkpoint: %v", err)
+	}
+
+	second := newFrontierSpider(t, store, "15.1")
+	if second.rankCache.Len() != 2 {
+		t.Errorf("rank cache has %d players after a restart, want 2", second.rankCache.Len())
+	}
+}
+
+// TestSpider_LobbyTier tests that the lobby tier is averaged from the cached ranks,
+// leaving out unranked and unknown players, without counting cache hits or misses
+func TestSpider_LobbyTier(t *testing.T) {
+	s := newFrontierSpider(t, nil, "15.1")
+	match := &riot.MatchResponse{Info: riot.MatchInfo{Participants: []riot.MatchParticipant{
+		{PUUID: "puuid-a"}, {PUUID: "puuid-b"}, {PUUID: "puuid-c"}, {PUUID: "puuid-d"},
+	}}}
+	if got := s.lobbyTier(match); got != "" {
+		t.Errorf("lobbyTier with no cached ranks = %q, want none", got)
+	}
+
+	s.rankCache.Put("puuid-a", "DIAMOND", "I")
+	s.rankCache.Put("puuid-b", "DIAMOND", "IV")
+	s.rankCache.Put("puuid-c", "", "")
+	if got := s.lobbyTier(match); got != "DIAMOND" {
+		t.Errorf("lobbyTier = %q, want DIAMOND", got)
+	}
+
+	// Lobby lookups never replace an API call, so they stay out of the cache stats
+	if hits, misses := s.rankCache.Stats(); hits != 0 || misses != 0 {
+		t.Errorf("rank cache Stats after lobbyTier = %d hits, %d misses; want 0, 0", hits, misses)
+	}
+}
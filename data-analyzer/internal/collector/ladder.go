@@ -0,0 +1,391 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"data-analyzer/internal/riot"
+)
+
+const (
+	// Ladder players queued per seeding, split between brackets by quota
+	ladderSeedsPerBatch = 30
+
+	// Matches collected before quotas are enforced, so the first few players don't
+	// decide which brackets get skipped
+	quotaWarmupMatches = 100
+)
+
+// DefaultBracketQuotas is the share of matches each rank bracket gets by default
+var DefaultBracketQuotas = BracketQuotas{
+	riot.BracketEmerald:    0.45,
+	riot.BracketDiamond:    0.35,
+	riot.BracketMasterPlus: 0.20,
+}
+
+// bracketTiers lists the solo queue tiers seeded for each bracket
+var bracketTiers = map[string][]string{
+	riot.BracketEmerald:    {"EMERALD"},
+	riot.BracketDiamond:    {"DIAMOND"},
+	riot.BracketMasterPlus: {"CHALLENGER", "GRANDMASTER", "MASTER"},
+}
+
+// ladderDivisions are the divisions of tiers below Master
+var ladderDivisions = []string{"I", "II", "III", "IV"}
+
+// BracketQuotas is the share of collected matches each rank bracket should get, by
+// bracket name (see riot.Brackets). Shares are relative; they are scaled to add up to 1.
+type BracketQuotas map[string]float64
+
+// ParseBracketQuotas parses quotas like "emerald=0.45,diamond=0.35,master_plus=0.2".
+// Brackets left out get no matches. An empty string returns the default quotas.
+func ParseBracketQuotas(s string) (BracketQuotas, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultBracketQuotas, nil
+	}
+	quotas := make(BracketQuotas)
+	for _, pair := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid bracket quota %q (want bracket=share)", pair)
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, known := bracketTiers[name]; !known {
+			return nil, fmt.Errorf("unknown bracket %q (want one of %s)", name, strings.Join(riot.Brackets, ", "))
+		}
+		share, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || share < 0 {
+			return nil, fmt.Errorf("invalid share for %s: %q", name, value)
+		}
+		quotas[name] = share
+	}
+	return quotas.normalized()
+}
+
+// normalized scales the quotas to add up to 1
+func (q BracketQuotas) normalized() (BracketQuotas, error) {
+	var total float64
+	for _, share := range q {
+		total += share
+	}
+	if total <= 0 {
+		return nil, errors.New("bracket quotas add up to 0")
+	}
+	result := make(BracketQuotas, len(q))
+	for name, share := range q {
+		result[name] = share / total
+	}
+	return result, nil
+}
+
+// String formats the quotas as e.g. "emerald=45% diamond=35% master_plus=20%"
+func (q BracketQuotas) String() string {
+	parts := make([]string, 0, len(q))
+	for _, name := range riot.Brackets {
+		if share, ok := q[name]; ok {
+			parts = append(parts, fmt.Sprintf("%s=%.0f%%", name, share*100))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// bracketCounter counts collected matches per bracket against the quotas
+type bracketCounter struct {
+	mu      sync.Mutex
+	quotas  BracketQuotas
+	matches map[string]int64
+}
+
+// newBracketCounter creates a counter for quotas, or the default quotas if nil
+func newBracketCounter(quotas BracketQuotas) *bracketCounter {
+	if len(quotas) == 0 {
+		quotas = DefaultBracketQuotas
+	}
+	if normalized, err := quotas.normalized(); err == nil {
+		quotas = normalized
+	} else {
+		quotas = DefaultBracketQuotas
+	}
+	return &bracketCounter{quotas: quotas, matches: make(map[string]int64)}
+}
+
+// record counts a collected match in a bracket; matches outside the brackets are ignored
+func (c *bracketCounter) record(bracket string) {
+	if bracket == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.matches[bracket]++
+}
+
+// overQuota reports whether a bracket has more than its share of the collected matches.
+// A bracket without a quota always is; the others only once the warmup is collected.
+func (c *bracketCounter) overQuota(bracket string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	quota := c.quotas[bracket]
+	if quota <= 0 {
+		return true
+	}
+	var total int64
+	for _, n := range c.matches {
+		total += n
+	}
+	if total < quotaWarmupMatches {
+		return false
+	}
+	return float64(c.matches[bracket])/float64(total) > quota
+}
+
+// counts returns a copy of the match counts by bracket
+func (c *bracketCounter) counts() map[string]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := make(map[string]int64, len(c.matches))
+	for name, n := range c.matches {
+		counts[name] = n
+	}
+	return counts
+}
+
+// restore replaces the match counts (nil clears them)
+func (c *bracketCounter) restore(counts map[string]int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.matches = make(map[string]int64, len(counts))
+	for name, n := range counts {
+		c.matches[name] = n
+	}
+}
+
+// lines formats each bracket's matches against its quota, e.g.
+// "diamond: 350 matches (35.0%, quota 35%)"
+func (c *bracketCounter) lines() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var total int64
+	for _, n := range c.matches {
+		total += n
+	}
+	lines := make([]string, 0, len(riot.Brackets))
+	for _, name := range riot.Brackets {
+		share := 0.0
+		if total > 0 {
+			share = float64(c.matches[name]) / float64(total) * 100
+		}
+		lines = append(lines, fmt.Sprintf("%s: %d matches (%.1f%%, quota %.0f%%)", name, c.matches[name], share, c.quotas[name]*100))
+	}
+	return lines
+}
+
+// ladderPlayer is a ranked player found on the ladder
+type ladderPlayer struct {
+	puuid    string
+	tier     string
+	division string
+}
+
+// readLadder fetches a page of a bracket's ladder: the whole Master, Grandmaster and
+// Challenger leagues, or the given page of a division of the bracket's tier
+func readLadder(ctx context.Context, client *riot.Client, bracket, division string, page int) ([]ladderPlayer, error) {
+	var players []ladderPlayer
+	if bracket == riot.BracketMasterPlus {
+		var errs []error
+		for _, tier := range bracketTiers[bracket] {
+			league, err := client.GetApexLeague(ctx, tier)
+			if err != nil {
+				if abortOnError(err) {
+					return nil, err
+				}
+				errs = append(errs, fmt.Errorf("%s: %w", tier, err))
+				continue
+			}
+			for _, e := range league.Entries {
+				players = append(players, ladderPlayer{puuid: e.PUUID, tier: tier, division: e.Rank})
+			}
+		}
+		if len(players) == 0 {
+			return nil, errors.Join(errs...)
+		}
+		return players, nil
+	}
+
+	tier := bracketTiers[bracket][0]
+	entries, err := client.GetLeagueEntries(ctx, tier, division, page)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s page %d: %w", tier, division, page, err)
+	}
+	for _, e := range entries {
+		players = append(players, ladderPlayer{puuid: e.PUUID, tier: e.Tier, division: e.Rank})
+	}
+	return players, nil
+}
+
+// ladderPlayers fetches the ladder players of a bracket: the apex leagues, or the next
+// page of a random division of the bracket's tier
+func (s *Spider) ladderPlayers(ctx context.Context, bracket string) ([]ladderPlayer, error) {
+	if bracket == riot.BracketMasterPlus {
+		return readLadder(ctx, s.client, bracket, "", 0)
+	}
+
+	s.rngMu.Lock()
+	division := ladderDivisions[s.rng.Intn(len(ladderDivisions))]
+	s.rngMu.Unlock()
+
+	// Each seeding reads the division's next page, back to the first past the end
+	key := bracketTiers[bracket][0] + " " + division
+	s.ladderMu.Lock()
+	page := max(s.ladderPages[key], 1)
+	s.ladderMu.Unlock()
+
+	players, err := readLadder(ctx, s.client, bracket, division, page)
+	if err == nil && len(players) == 0 && page > 1 {
+		page = 1
+		players, err = readLadder(ctx, s.client, bracket, division, page)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.ladderMu.Lock()
+	s.ladderPages[key] = page + 1
+	s.ladderMu.Unlock()
+	return players, nil
+}
+
+// LadderSeeds picks a batch of random ladder players from every bracket with a quota,
+// each bracket getting seeds in proportion to its quota, for crawls that start without
+// a player. A bracket whose ladder can't be read is skipped, unless the key is bad or no
+// bracket could be seeded.
+func LadderSeeds(ctx context.Context, client *riot.Client, quotas BracketQuotas) ([]string, error) {
+	quotas, err := quotas.normalized()
+	if err != nil {
+		return nil, err
+	}
+
+	var seeds []string
+	var errs []error
+	for _, bracket := range riot.Brackets {
+		quota := quotas[bracket]
+		if quota <= 0 {
+			continue
+		}
+
+		division := ladderDivisions[rand.Intn(len(ladderDivisions))]
+		players, err := readLadder(ctx, client, bracket, division, 1)
+		if err != nil {
+			if abortOnError(err) {
+				return nil, err
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", bracket, err))
+			continue
+		}
+
+		rand.Shuffle(len(players), func(i, j int) { players[i], players[j] = players[j], players[i] })
+		want := max(int(float64(ladderSeedsPerBatch)*quota+0.5), 1)
+		added := 0
+		for _, p := range players {
+			if added == want {
+				break
+			}
+			if p.puuid != "" {
+				seeds = append(seeds, p.puuid)
+				added++
+			}
+		}
+	}
+
+	if len(seeds) == 0 {
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("no ladder players seeded: %w", errors.Join(errs...))
+		}
+		return nil, errors.New("no ladder players to seed")
+	}
+	return seeds, nil
+}
+
+// seedFromLadder queues random ladder players from every bracket under its quota, each
+// bracket getting seeds in proportion to its quota. A bracket whose ladder can't be read
+// is skipped, unless the key is bad or no bracket could be seeded.
+func (s *Spider) seedFromLadder(ctx context.Context) error {
+	platform := s.client.Platform()
+	var errs []error
+	seeded := 0
+	for _, bracket := range riot.Brackets {
+		quota := s.brackets.quotas[bracket]
+		if quota <= 0 || s.brackets.overQuota(bracket) {
+			continue
+		}
+
+		players, err := s.ladderPlayers(ctx, bracket)
+		if err != nil {
+			if abortOnError(err) {
+				return err
+			}
+			log.Printf("[Spider] %s: failed to read the %s ladder: %v", platform, bracket, err)
+			errs = append(errs, err)
+			continue
+		}
+
+		// Every player on the page has their rank now, seeded or not
+		for _, p := range players {
+			if p.puuid != "" {
+				s.rankCache.Put(p.puuid, p.tier, p.division)
+			}
+		}
+
+		s.rngMu.Lock()
+		s.rng.Shuffle(len(players), func(i, j int) { players[i], players[j] = players[j], players[i] })
+		s.rngMu.Unlock()
+
+		want := max(int(float64(ladderSeedsPerBatch)*quota+0.5), 1)
+		added := 0
+		for _, p := range players {
+			if added == want {
+				break
+			}
+			if p.puuid == "" || s.hasVisitedPUUID(p.puuid) {
+				continue
+			}
+			s.addPlayer(p.puuid, seedPriority)
+			added++
+		}
+		log.Printf("[Spider] %s: seeded %d %s players from the ladder", platform, added, bracket)
+		seeded += added
+	}
+
+	if seeded == 0 {
+		if len(errs) > 0 {
+			return fmt.Errorf("no ladder players seeded: %w", errors.Join(errs...))
+		}
+		return errors.New("no ladder players left to seed")
+	}
+	return nil
+}
+
+// skipOverQuota reports whether a player's bracket already has its share of matches,
+// counting the player as skipped if so
+func (s *Spider) skipOverQuota(tier string) bool {
+	if !s.brackets.overQuota(riot.TierBracket(tier)) {
+		return false
+	}
+	atomic.AddInt64(&s.playersSkippedQuota, 1)
+	return true
+}
+
+// matchBracket returns the bracket a written match counts toward: its lobby's estimated
+// tier, or the source player's when no lobby rank is known
+func matchBracket(result *MatchResult) string {
+	if result.LobbyTier != "" {
+		return riot.TierBracket(result.LobbyTier)
+	}
+	return riot.TierBracket(result.SourceTier)
+}
@@ -0,0 +1,80 @@
+package collector
+
+import (
+	"math"
+	"testing"
+
+	"data-analyzer/internal/riot"
+)
+
+// TestParseBracketQuotas tests parsing and normalizing bracket quotas
+func TestParseBracketQuotas(t *testing.T) {
+	quotas, err := ParseBracketQuotas("emerald=2, Diamond=1,master_plus=1")
+	if err != nil {
+		t.Fatalf("ParseBracketQuotas: %v", err)
+	}
+	want := BracketQuotas{riot.BracketEmerald: 0.5, riot.BracketDiamond: 0.25, riot.BracketMasterPlus: 0.25}
+	for name, share := range want {
+		if math.Abs(quotas[name]-share) > 1e-9 {
+			t.Errorf("quota %s = %v, want %v", name, quotas[name], share)
+		}
+	}
+
+	quotas, err = ParseBracketQuotas("")
+	if err != nil || quotas[riot.BracketEmerald] != DefaultBracketQuotas[riot.BracketEmerald] {
+		t.Errorf("Expected the default quotas for an empty string, got %v (%v)", quotas, err)
+	}
+
+	for _, bad := range []string{"gold=1", "emerald", "emerald=x", "emerald=-1", "emerald=0"} {
+		if _, err := ParseBracketQuotas(bad); err == nil {
+			t.Errorf("ParseBracketQuotas(%q): expected an error", bad)
+		}
+	}
+}
+
+// TestBracketCounter_OverQuota tests that brackets are held to their share after the warmup
+func TestBracketCounter_OverQuota(t *testing.T) {
+	c := newBracketCounter(BracketQuotas{riot.BracketEmerald: 0.5, riot.BracketDiamond: 0.5})
+
+	if !c.overQuota(riot.BracketMasterPlus) {
+		t.Error("A bracket without a quota should always be over it")
+	}
+
+	// All emerald so far, but still in the warmup
+	for i := 0; i < quotaWarmupMatches-1; i++ {
+		c.record(riot.BracketEmerald)
+	}
+	if c.overQuota(riot.BracketEmerald) {
+		t.Error("Quotas should not be enforced during the warmup")
+	}
+
+	c.record(riot.BracketEmerald)
+	if !c.overQuota(riot.BracketEmerald) {
+		t.Error("Expected emerald over its 50% quota")
+	}
+	if c.overQuota(riot.BracketDiamond) {
+		t.Error("Expected diamond under its 50% quota")
+	}
+
+	for i := 0; i < quotaWarmupMatches; i++ {
+		c.record(riot.BracketDiamond)
+	}
+	if c.overQuota(riot.BracketEmerald) || c.overQuota(riot.BracketDiamond) {
+		t.Error("Expected both brackets at their quota")
+	}
+
+	c.record("")
+	if counts := c.counts(); counts[riot.BracketEmerald] != quotaWarmupMatches || len(counts) != 2 {
+		t.Errorf("Unexpected counts %v", counts)
+	}
+}
+
+// TestMatchBracket tests that matches count toward the lobby's bracket, else the source player's
+func TestMatchBracket(t *testing.T) {
+	if got := matchBracket(&MatchResult{LobbyTier: "MASTER", SourceTier: "EMERALD"}); got != riot.BracketMasterPlus {
+		t.Errorf("matchBracket with lobby tier = %q", got)
+	}
+	if got := matchBracket(&MatchResult{SourceTier: "DIAMOND"}); got != riot.BracketDiamond {
+		t.Errorf("matchBracket without lobby tier = %q", got)
+	}
+}
@@ -0,0 +1,106 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+)
+
+// PlatformSpiders crawls several platforms at once: one Spider per platform, each with
+// its own riot client, all writing to the same rotator. Platforms in the same cluster
+// share its match and account rate limits.
+// Implements SpiderRunner.
+type PlatformSpiders struct {
+	spiders []*Spider
+}
+
+// NewPlatformSpiders groups per-platform spiders into one SpiderRunner
+func NewPlatformSpiders(spiders ...*Spider) *PlatformSpiders {
+	return &PlatformSpiders{spiders: spiders}
+}
+
+// RunContinuous runs one batch on every platform concurrently and returns once all of
+// them are done. Errors are joined, so an API key error on any platform is reported.
+func (m *PlatformSpiders) RunContinuous(ctx context.Context) error {
+	errs := make([]error, len(m.spiders))
+	var wg sync.WaitGroup
+	for i, s := range m.spiders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.RunContinuous(ctx)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// Reset clears every platform's state
+func (m *PlatformSpiders) Reset() {
+	for _, s := range m.spiders {
+		s.Reset()
+	}
+}
+
+// ResetCounters clears every platform's counters, keeping their bloom filters
+func (m *PlatformSpiders) ResetCounters() {
+	for _, s := range m.spiders {
+		s.ResetCounters()
+	}
+}
+
+// SeedFromLadder seeds each platform from its own ranked ladder. A platform
+// that can't be seeded is skipped, unless the key is bad or no platform could be seeded.
+func (m *PlatformSpiders) SeedFromLadder(ctx context.Context) error {
+	var errs []error
+	for _, s := range m.spiders {
+		if err := s.SeedFromLadder(ctx); err != nil {
+			if IsAPIKeyError(err) {
+				return err
+			}
+			log.Printf("[Spider] %s: %v (skipping platform)", s.client.Platform(), err)
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == len(m.spiders) {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// Checkpoint saves every platform's crawl state. Implements Checkpointer.
+func (m *PlatformSpiders) Checkpoint() error {
+	var errs []error
+	for _, s := range m.spiders {
+		errs = append(errs, s.Checkpoint())
+	}
+	return errors.Join(errs...)
+}
+
+// CoverageReports returns every platform's sample coverage. Implements CoverageReporter.
+func (m *PlatformSpiders) CoverageReports() []CoverageReport {
+	var reports []CoverageReport
+	for _, s := range m.spiders {
+		reports = append(reports, s.CoverageReports()...)
+	}
+	return reports
+}
+
+// RankCacheStats returns the rank cache hits and misses of every platform together.
+// Implements RankCacheReporter.
+func (m *PlatformSpiders) RankCacheStats() (hits, misses int64) {
+	for _, s := range m.spiders {
+		spiderHits, spiderMisses := s.RankCacheStats()
+		hits += spiderHits
+		misses += spiderMisses
+	}
+	return hits, misses
+}
+
+// SetAPIKey updates the API key on every platform's client
+func (m *PlatformSpiders) SetAPIKey(key string) {
+	for _, s := range m.spiders {
+		s.SetAPIKey(key)
+	}
+}
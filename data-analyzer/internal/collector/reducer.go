@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"data-analyzer/internal/riot"
 	"data-analyzer/internal/storage"
 
 	json "github.com/goccy/go-json"
@@ -16,8 +17,11 @@ import (
 // ChampionStatsKey is the composite key for champion stats
 type ChampionStatsKey struct {
 	Patch        string
+	QueueID      int
+	Bracket      string // riot.Bracket* of the lobby, empty if unknown
+	Region       string // riot.Region* the match was played in, empty when merged
 	ChampionID   int
-	TeamPosition string
+	TeamPosition string // Empty in ARAM
 }
 
 // ChampionStats holds aggregated champion statistics
@@ -29,6 +33,9 @@ type ChampionStats struct {
 // ItemStatsKey is the composite key for item stats
 type ItemStatsKey struct {
 	Patch        string
+	QueueID      int
+	Bracket      string
+	Region       string
 	ChampionID   int
 	TeamPosition string
 	ItemID       int
@@ -43,6 +50,9 @@ type ItemStats struct {
 // MatchupStatsKey is the composite key for matchup stats
 type MatchupStatsKey struct {
 	Patch           string
+	QueueID         int
+	Bracket         string
+	Region          string
 	ChampionID      int
 	TeamPosition    string
 	EnemyChampionID int
@@ -57,6 +67,9 @@ type MatchupStats struct {
 // ItemSlotStatsKey is the composite key for item slot stats
 type ItemSlotStatsKey struct {
 	Patch        string
+	QueueID      int
+	Bracket      string
+	Region       string
 	ChampionID   int
 	TeamPosition string
 	ItemID       int
@@ -69,12 +82,57 @@ type ItemSlotStats struct {
 	Matches int
 }
 
-// AggData holds all aggregated statistics from warm files
+// ArenaStatsKey is the composite key for Arena champion stats
+type ArenaStatsKey struct {
+	Patch      string
+	ChampionID int
+}
+
+// ArenaAugmentKey is the composite key for Arena augment stats
+type ArenaAugmentKey struct {
+	Patch      string
+	ChampionID int
+	AugmentID  int
+}
+
+// ArenaItemKey is the composite key for Arena item stats
+type ArenaItemKey struct {
+	Patch      string
+	ChampionID int
+	ItemID     int
+}
+
+// ArenaStats holds aggregated Arena results. Arena has no wins and losses, so Wins
+// counts top four finishes.
+type ArenaStats struct {
+	Wins         int
+	Matches      int
+	Firsts       int
+	PlacementSum int // Average placement is PlacementSum / Matches
+}
+
+// add counts one Arena game finished in placement
+func (s *ArenaStats) add(placement int) {
+	s.Matches++
+	s.PlacementSum += placement
+	if placement <= 4 {
+		s.Wins++
+	}
+	if placement == 1 {
+		s.Firsts++
+	}
+}
+
+// AggData holds all aggregated statistics from warm files. Summoner's Rift and ARAM
+// stats are keyed by queue; Arena, with placements and augments, has its own.
 type AggData struct {
 	ChampionStats  map[ChampionStatsKey]*ChampionStats
 	ItemStats      map[ItemStatsKey]*ItemStats
 	ItemSlotStats  map[ItemSlotStatsKey]*ItemSlotStats
 	MatchupStats   map[MatchupStatsKey]*MatchupStats
+	ArenaStats     map[ArenaStatsKey]*ArenaStats
+	ArenaAugments  map[ArenaAugmentKey]*ArenaStats
+	ArenaItems     map[ArenaItemKey]*ArenaStats
 	DetectedPatch  string
 	FilesProcessed int
 	TotalRecords   int
@@ -83,103 +141,172 @@ type AggData struct {
 // ItemFilter is a function that determines if an item should be included in stats
 type ItemFilter func(itemID int) bool
 
-// AggregateWarmFiles reads all JSONL files from the warm directory and aggregates stats
-func AggregateWarmFiles(warmDir string, itemFilter ItemFilter) (*AggData, error) {
-	agg := &AggData{
+// newAggData returns an AggData with empty maps
+func newAggData() *AggData {
+	return &AggData{
 		ChampionStats: make(map[ChampionStatsKey]*ChampionStats),
 		ItemStats:     make(map[ItemStatsKey]*ItemStats),
 		ItemSlotStats: make(map[ItemSlotStatsKey]*ItemSlotStats),
 		MatchupStats:  make(map[MatchupStatsKey]*MatchupStats),
+		ArenaStats:    make(map[ArenaStatsKey]*ArenaStats),
+		ArenaAugments: make(map[ArenaAugmentKey]*ArenaStats),
+		ArenaItems:    make(map[ArenaItemKey]*ArenaStats),
 	}
+}
 
+// AggregateWarmFiles reads all JSONL files from the warm directory and aggregates stats
+func AggregateWarmFiles(warmDir string, itemFilter ItemFilter) (*AggData, error) {
 	// Scan warm directory for .jsonl files
 	files, err := filepath.Glob(filepath.Join(warmDir, "*.jsonl"))
 	if err != nil {
 		return nil, err
 	}
 
-	if len(files) == 0 {
-		return agg, nil
-	}
+	return AggregateFiles(files, itemFilter), nil
+}
+
+// AggregateFiles aggregates stats from the given JSONL files, skipping unreadable ones
+func AggregateFiles(files []string, itemFilter ItemFilter) *AggData {
+	agg := newAggData()
 
 	// Process each file and accumulate stats
 	for _, filePath := range files {
-		championStats, itemStats, itemSlotStats, matchupStats, patch, records, err := aggregateFile(filePath, itemFilter)
+		fileAgg, err := aggregateFile(filePath, itemFilter)
 		if err != nil {
 			continue // Skip files with errors
 		}
 
 		agg.FilesProcessed++
-		agg.TotalRecords += records
+		agg.TotalRecords += fileAgg.TotalRecords
 
 		// Track the patch (use the last one seen)
-		if patch != "" {
-			agg.DetectedPatch = patch
+		if fileAgg.DetectedPatch != "" {
+			agg.DetectedPatch = fileAgg.DetectedPatch
 		}
 
-		// Merge champion stats
-		for k, v := range championStats {
-			if existing, ok := agg.ChampionStats[k]; ok {
-				existing.Wins += v.Wins
-				existing.Matches += v.Matches
-			} else {
-				agg.ChampionStats[k] = v
-			}
-		}
+		agg.merge(fileAgg)
+	}
 
-		// Merge item stats
-		for k, v := range itemStats {
-			if existing, ok := agg.ItemStats[k]; ok {
-				existing.Wins += v.Wins
-				existing.Matches += v.Matches
-			} else {
-				agg.ItemStats[k] = v
-			}
-		}
+	return agg
+}
 
-		// Merge item slot stats
-		for k, v := range itemSlotStats {
-			if existing, ok := agg.ItemSlotStats[k]; ok {
-				existing.Wins += v.Wins
-				existing.Matches += v.Matches
-			} else {
-				agg.ItemSlotStats[k] = v
-			}
+// recordRegion returns the regional cluster a record's match was played in, from its
+// platform or, in files written before platforms were stored, its match ID
+func recordRegion(match storage.RawMatch) string {
+	platform := match.Platform
+	if platform == "" {
+		platform = riot.MatchPlatform(match.MatchID)
+	}
+	region, _ := riot.RegionForPlatform(platform)
+	return region
+}
+
+// MergeRegions folds every region's stats together, for a single worldwide dataset
+func (agg *AggData) MergeRegions() {
+	agg.ChampionStats = rekey(agg.ChampionStats, func(k ChampionStatsKey) ChampionStatsKey {
+		k.Region = ""
+		return k
+	}, func(a, b *ChampionStats) {
+		a.Wins += b.Wins
+		a.Matches += b.Matches
+	})
+	agg.ItemStats = rekey(agg.ItemStats, func(k ItemStatsKey) ItemStatsKey {
+		k.Region = ""
+		return k
+	}, func(a, b *ItemStats) {
+		a.Wins += b.Wins
+		a.Matches += b.Matches
+	})
+	agg.ItemSlotStats = rekey(agg.ItemSlotStats, func(k ItemSlotStatsKey) ItemSlotStatsKey {
+		k.Region = ""
+		return k
+	}, func(a, b *ItemSlotStats) {
+		a.Wins += b.Wins
+		a.Matches += b.Matches
+	})
+	agg.MatchupStats = rekey(agg.MatchupStats, func(k MatchupStatsKey) MatchupStatsKey {
+		k.Region = ""
+		return k
+	}, func(a, b *MatchupStats) {
+		a.Wins += b.Wins
+		a.Matches += b.Matches
+	})
+}
+
+// rekey returns m with every key passed through key, combining entries that collide
+func rekey[K comparable, V any](m map[K]*V, key func(K) K, add func(a, b *V)) map[K]*V {
+	out := make(map[K]*V, len(m))
+	for k, v := range m {
+		if existing, ok := out[key(k)]; ok {
+			add(existing, v)
+		} else {
+			out[key(k)] = v
 		}
+	}
+	return out
+}
 
-		// Merge matchup stats
-		for k, v := range matchupStats {
-			if existing, ok := agg.MatchupStats[k]; ok {
-				existing.Wins += v.Wins
-				existing.Matches += v.Matches
-			} else {
-				agg.MatchupStats[k] = v
-			}
+// merge adds another file's stats into agg
+func (agg *AggData) merge(other *AggData) {
+	mergeCounts(agg.ChampionStats, other.ChampionStats, func(a, b *ChampionStats) {
+		a.Wins += b.Wins
+		a.Matches += b.Matches
+	})
+	mergeCounts(agg.ItemStats, other.ItemStats, func(a, b *ItemStats) {
+		a.Wins += b.Wins
+		a.Matches += b.Matches
+	})
+	mergeCounts(agg.ItemSlotStats, other.ItemSlotStats, func(a, b *ItemSlotStats) {
+		a.Wins += b.Wins
+		a.Matches += b.Matches
+	})
+	mergeCounts(agg.MatchupStats, other.MatchupStats, func(a, b *MatchupStats) {
+		a.Wins += b.Wins
+		a.Matches += b.Matches
+	})
+	mergeCounts(agg.ArenaStats, other.ArenaStats, (*ArenaStats).merge)
+	mergeCounts(agg.ArenaAugments, other.ArenaAugments, (*ArenaStats).merge)
+	mergeCounts(agg.ArenaItems, other.ArenaItems, (*ArenaStats).merge)
+}
+
+// merge adds other's games into s
+func (s *ArenaStats) merge(other *ArenaStats) {
+	s.Wins += other.Wins
+	s.Matches += other.Matches
+	s.Firsts += other.Firsts
+	s.PlacementSum += other.PlacementSum
+}
+
+// mergeCounts adds src's entries into dst, combining entries both have with add
+func mergeCounts[K comparable, V any](dst, src map[K]*V, add func(a, b *V)) {
+	for k, v := range src {
+		if existing, ok := dst[k]; ok {
+			add(existing, v)
+		} else {
+			dst[k] = v
 		}
 	}
+}
 
-	return agg, nil
+// counter returns the entry for key, creating it if needed
+func counter[K comparable, V any](m map[K]*V, key K) *V {
+	v, ok := m[key]
+	if !ok {
+		v = new(V)
+		m[key] = v
+	}
+	return v
 }
 
-// aggregateFile processes a single JSONL file and returns per-file stats
-func aggregateFile(filePath string, itemFilter ItemFilter) (
-	map[ChampionStatsKey]*ChampionStats,
-	map[ItemStatsKey]*ItemStats,
-	map[ItemSlotStatsKey]*ItemSlotStats,
-	map[MatchupStatsKey]*MatchupStats,
-	string, int, error,
-) {
+// aggregateFile processes a single JSONL file and returns its stats
+func aggregateFile(filePath string, itemFilter ItemFilter) (*AggData, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return nil, nil, nil, nil, "", 0, err
+		return nil, err
 	}
 	defer file.Close()
 
-	championStats := make(map[ChampionStatsKey]*ChampionStats)
-	itemStats := make(map[ItemStatsKey]*ItemStats)
-	itemSlotStats := make(map[ItemSlotStatsKey]*ItemSlotStats)
-	matchupStats := make(map[MatchupStatsKey]*MatchupStats)
-	var detectedPatch string
+	agg := newAggData()
 
 	// First pass: group all participants by matchId
 	matchParticipants := make(map[string][]storage.RawMatch)
@@ -187,7 +314,6 @@ func aggregateFile(filePath string, itemFilter ItemFilter) (
 	scanner := bufio.NewScanner(file)
 	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
 
-	recordCount := 0
 	for scanner.Scan() {
 		line := scanner.Bytes()
 
@@ -196,32 +322,47 @@ func aggregateFile(filePath string, itemFilter ItemFilter) (
 			continue
 		}
 
-		recordCount++
+		agg.TotalRecords++
 
-		// Skip if no position
-		if match.TeamPosition == "" {
+		queue := riot.RecordQueue(match.QueueID)
+		if !riot.IsStatsQueue(queue) {
+			continue
+		}
+		match.QueueID = queue
+
+		// Skip if no position (Summoner's Rift only; ARAM and Arena have none)
+		if match.TeamPosition == "" && riot.HasPositions(queue) {
 			continue
 		}
 
 		// Normalize patch version
 		patch := normalizePatch(match.GameVersion)
-		if detectedPatch == "" {
-			detectedPatch = patch
+		if agg.DetectedPatch == "" {
+			agg.DetectedPatch = patch
+		}
+
+		if queue == riot.QueueArena {
+			aggregateArena(agg, match, patch)
+			continue
 		}
 
+		// Records from before ranks were stored have no bracket; they only count
+		// towards the all-brackets totals
+		bracket := riot.TierBracket(match.Tier())
+		region := recordRegion(match)
+
 		// Aggregate champion stats
-		champKey := ChampionStatsKey{
+		champStats := counter(agg.ChampionStats, ChampionStatsKey{
 			Patch:        patch,
+			QueueID:      queue,
+			Bracket:      bracket,
+			Region:       region,
 			ChampionID:   match.ChampionID,
 			TeamPosition: match.TeamPosition,
-		}
-
-		if _, exists := championStats[champKey]; !exists {
-			championStats[champKey] = &ChampionStats{}
-		}
-		championStats[champKey].Matches++
+		})
+		champStats.Matches++
 		if match.Win {
-			championStats[champKey].Wins++
+			champStats.Wins++
 		}
 
 		// ITEM STATS: Always use final inventory (item0-5) for 100% of matches
@@ -234,19 +375,18 @@ func aggregateFile(filePath string, itemFilter ItemFilter) (
 			}
 			seenItems[itemID] = true
 
-			itemKey := ItemStatsKey{
+			itemStats := counter(agg.ItemStats, ItemStatsKey{
 				Patch:        patch,
+				QueueID:      queue,
+				Bracket:      bracket,
+				Region:       region,
 				ChampionID:   match.ChampionID,
 				TeamPosition: match.TeamPosition,
 				ItemID:       itemID,
-			}
-
-			if _, exists := itemStats[itemKey]; !exists {
-				itemStats[itemKey] = &ItemStats{}
-			}
-			itemStats[itemKey].Matches++
+			})
+			itemStats.Matches++
 			if match.Win {
-				itemStats[itemKey].Wins++
+				itemStats.Wins++
 			}
 		}
 
@@ -264,31 +404,32 @@ func aggregateFile(filePath string, itemFilter ItemFilter) (
 
 				// Only track slots 1-6
 				if buildSlot <= 6 {
-					slotKey := ItemSlotStatsKey{
+					slotStats := counter(agg.ItemSlotStats, ItemSlotStatsKey{
 						Patch:        patch,
+						QueueID:      queue,
+						Bracket:      bracket,
+						Region:       region,
 						ChampionID:   match.ChampionID,
 						TeamPosition: match.TeamPosition,
 						ItemID:       itemID,
 						BuildSlot:    buildSlot,
-					}
-
-					if _, exists := itemSlotStats[slotKey]; !exists {
-						itemSlotStats[slotKey] = &ItemSlotStats{}
-					}
-					itemSlotStats[slotKey].Matches++
+					})
+					slotStats.Matches++
 					if match.Win {
-						itemSlotStats[slotKey].Wins++
+						slotStats.Wins++
 					}
 				}
 			}
 		}
 
-		// Group by matchId for matchup calculation
-		matchParticipants[match.MatchID] = append(matchParticipants[match.MatchID], match)
+		// Group by matchId for matchup calculation (lane opponents only exist on Summoner's Rift)
+		if riot.HasPositions(queue) {
+			matchParticipants[match.MatchID] = append(matchParticipants[match.MatchID], match)
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, nil, nil, nil, "", 0, err
+		return nil, err
 	}
 
 	// Second pass: calculate matchups from grouped participants
@@ -313,40 +454,69 @@ func aggregateFile(filePath string, itemFilter ItemFilter) (
 			}
 
 			patch := normalizePatch(p1.GameVersion)
+			bracket := riot.TierBracket(p1.Tier())
+			region := recordRegion(p1)
 
 			// Record matchup for p1 vs p2
-			key1 := MatchupStatsKey{
+			m1 := counter(agg.MatchupStats, MatchupStatsKey{
 				Patch:           patch,
+				QueueID:         p1.QueueID,
+				Bracket:         bracket,
+				Region:          region,
 				ChampionID:      p1.ChampionID,
 				TeamPosition:    p1.TeamPosition,
 				EnemyChampionID: p2.ChampionID,
-			}
-			if _, exists := matchupStats[key1]; !exists {
-				matchupStats[key1] = &MatchupStats{}
-			}
-			matchupStats[key1].Matches++
+			})
+			m1.Matches++
 			if p1.Win {
-				matchupStats[key1].Wins++
+				m1.Wins++
 			}
 
 			// Record matchup for p2 vs p1
-			key2 := MatchupStatsKey{
+			m2 := counter(agg.MatchupStats, MatchupStatsKey{
 				Patch:           patch,
+				QueueID:         p2.QueueID,
+				Bracket:         bracket,
+				Region:          region,
 				ChampionID:      p2.ChampionID,
 				TeamPosition:    p2.TeamPosition,
 				EnemyChampionID: p1.ChampionID,
-			}
-			if _, exists := matchupStats[key2]; !exists {
-				matchupStats[key2] = &MatchupStats{}
-			}
-			matchupStats[key2].Matches++
+			})
+			m2.Matches++
 			if p2.Win {
-				matchupStats[key2].Wins++
+				m2.Wins++
 			}
 		}
 	}
 
-	return championStats, itemStats, itemSlotStats, matchupStats, detectedPatch, recordCount, nil
+	return agg, nil
+}
+
+// aggregateArena counts an Arena record towards its champion, augment and item stats.
+// Arena items are their own versions, so every final item counts.
+func aggregateArena(agg *AggData, match storage.RawMatch, patch string) {
+	if match.Placement <= 0 {
+		return
+	}
+	counter(agg.ArenaStats, ArenaStatsKey{Patch: patch, ChampionID: match.ChampionID}).add(match.Placement)
+
+	seenAugments := make(map[int]bool)
+	for _, augmentID := range match.Augments {
+		if augmentID == 0 || seenAugments[augmentID] {
+			continue
+		}
+		seenAugments[augmentID] = true
+		counter(agg.ArenaAugments, ArenaAugmentKey{Patch: patch, ChampionID: match.ChampionID, AugmentID: augmentID}).add(match.Placement)
+	}
+
+	seenItems := make(map[int]bool)
+	for _, itemID := range match.GetFinalItems() {
+		if seenItems[itemID] {
+			continue
+		}
+		seenItems[itemID] = true
+		counter(agg.ArenaItems, ArenaItemKey{Patch: patch, ChampionID: match.ChampionID, ItemID: itemID}).add(match.Placement)
+	}
 }
 
 // normalizePatch truncates version to first two segments (e.g., 14.23.448 -> 14.23)
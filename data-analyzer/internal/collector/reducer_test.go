@@ -44,7 +44,7 @@ func TestAggregateWarmFiles_Basic(t *testing.T) {
 
 	// Verify champion stats
 	// Ahri MID: 2 matches, 1 win
-	ahriKey := ChampionStatsKey{Patch: "15.24", ChampionID: 103, TeamPosition: "MIDDLE"}
+	ahriKey := ChampionStatsKey{Patch: "15.24", QueueID: 420, Region: "americas", ChampionID: 103, TeamPosition: "MIDDLE"}
 	ahriStats, ok := agg.ChampionStats[ahriKey]
 	if !ok {
 		t.Errorf("Expected Ahri MIDDLE stats to exist")
@@ -58,7 +58,7 @@ func TestAggregateWarmFiles_Basic(t *testing.T) {
 	}
 
 	// Zed MID: 1 match, 0 wins
-	zedKey := ChampionStatsKey{Patch: "15.24", ChampionID: 238, TeamPosition: "MIDDLE"}
+	zedKey := ChampionStatsKey{Patch: "15.24", QueueID: 420, Region: "americas", ChampionID: 238, TeamPosition: "MIDDLE"}
 	zedStats, ok := agg.ChampionStats[zedKey]
 	if !ok {
 		t.Errorf("Expected Zed MIDDLE stats to exist")
@@ -72,7 +72,7 @@ func TestAggregateWarmFiles_Basic(t *testing.T) {
 	}
 
 	// LeBlanc MID: 1 match, 1 win
-	lbKey := ChampionStatsKey{Patch: "15.24", ChampionID: 7, TeamPosition: "MIDDLE"}
+	lbKey := ChampionStatsKey{Patch: "15.24", QueueID: 420, Region: "americas", ChampionID: 7, TeamPosition: "MIDDLE"}
 	lbStats, ok := agg.ChampionStats[lbKey]
 	if !ok {
 		t.Errorf("Expected LeBlanc MIDDLE stats to exist")
@@ -125,6 +125,8 @@ func TestAggregateWarmFiles_ItemStats(t *testing.T) {
 	// Verify Ahri + Rabadon (3089) stats
 	ahriRabadonKey := ItemStatsKey{
 		Patch:        "15.24",
+		QueueID:      420,
+		Region:       "americas",
 		ChampionID:   103,
 		TeamPosition: "MIDDLE",
 		ItemID:       3089,
@@ -173,6 +175,8 @@ func TestAggregateWarmFiles_MatchupStats(t *testing.T) {
 	// Ahri vs Zed: 1 match, 1 win (Ahri won)
 	ahriVsZedKey := MatchupStatsKey{
 		Patch:           "15.24",
+		QueueID:         420,
+		Region:          "americas",
 		ChampionID:      103,
 		TeamPosition:    "MIDDLE",
 		EnemyChampionID: 238,
@@ -192,6 +196,8 @@ func TestAggregateWarmFiles_MatchupStats(t *testing.T) {
 	// Zed vs Ahri: 1 match, 0 wins (Zed lost)
 	zedVsAhriKey := MatchupStatsKey{
 		Patch:           "15.24",
+		QueueID:         420,
+		Region:          "americas",
 		ChampionID:      238,
 		TeamPosition:    "MIDDLE",
 		EnemyChampionID: 103,
@@ -211,6 +217,8 @@ func TestAggregateWarmFiles_MatchupStats(t *testing.T) {
 	// Ahri vs LeBlanc: 1 match, 0 wins (Ahri lost)
 	ahriVsLBKey := MatchupStatsKey{
 		Patch:           "15.24",
+		QueueID:         420,
+		Region:          "americas",
 		ChampionID:      103,
 		TeamPosition:    "MIDDLE",
 		EnemyChampionID: 7,
@@ -256,6 +264,8 @@ func TestAggregateWarmFiles_ItemSlotStats(t *testing.T) {
 	// Ahri slot 1 = Rabadon (3089): 1 match, 1 win
 	ahriSlot1Key := ItemSlotStatsKey{
 		Patch:        "15.24",
+		QueueID:      420,
+		Region:       "americas",
 		ChampionID:   103,
 		TeamPosition: "MIDDLE",
 		ItemID:       3089,
@@ -276,6 +286,8 @@ func TestAggregateWarmFiles_ItemSlotStats(t *testing.T) {
 	// Ahri slot 2 = Zhonya (3157): 1 match, 1 win
 	ahriSlot2Key := ItemSlotStatsKey{
 		Patch:        "15.24",
+		QueueID:      420,
+		Region:       "americas",
 		ChampionID:   103,
 		TeamPosition: "MIDDLE",
 		ItemID:       3157,
@@ -327,7 +339,7 @@ func TestAggregateWarmFiles_MultipleFiles(t *testing.T) {
 	}
 
 	// Ahri MID: 2 matches (from 2 files), 2 wins
-	ahriKey := ChampionStatsKey{Patch: "15.24", ChampionID: 103, TeamPosition: "MIDDLE"}
+	ahriKey := ChampionStatsKey{Patch: "15.24", QueueID: 420, Region: "americas", ChampionID: 103, TeamPosition: "MIDDLE"}
 	ahriStats, ok := agg.ChampionStats[ahriKey]
 	if !ok {
 		t.Errorf("Expected Ahri MIDDLE stats to exist")
@@ -395,7 +407,7 @@ func TestAggregateWarmFiles_SkipsEmptyPosition(t *testing.T) {
 	}
 
 	// Ahri should not be counted (empty teamPosition)
-	ahriKey := ChampionStatsKey{Patch: "15.24", ChampionID: 103, TeamPosition: ""}
+	ahriKey := ChampionStatsKey{Patch: "15.24", QueueID: 420, Region: "americas", ChampionID: 103, TeamPosition: ""}
 	if _, ok := agg.ChampionStats[ahriKey]; ok {
 		t.Errorf("Expected Ahri with empty teamPosition to be skipped")
 	}
@@ -406,6 +418,159 @@ func TestAggregateWarmFiles_SkipsEmptyPosition(t *testing.T) {
 	}
 }
 
+// Queues are aggregated separately; ARAM has no positions or matchups and unsupported
+// queues are skipped
+func TestAggregateWarmFiles_Queues(t *testing.T) {
+	warmDir := t.TempDir()
+
+	sampleData := `{"matchId":"NA1_1","gameVersion":"15.24.1","puuid":"p1","championId":103,"teamPosition":"MIDDLE","win":true,"item0":3089}
+{"matchId":"NA1_2","queueId":420,"gameVersion":"15.24.1","puuid":"p1","championId":103,"teamPosition":"MIDDLE","win":false,"item0":3089}
+{"matchId":"NA1_3","queueId":400,"gameVersion":"15.24.1","puuid":"p1","championId":103,"teamPosition":"MIDDLE","win":true,"item0":3089}
+{"matchId":"NA1_4","queueId":450,"gameVersion":"15.24.1","puuid":"p1","championId":103,"teamPosition":"","win":true,"item0":3089}
+{"matchId":"NA1_4","queueId":450,"gameVersion":"15.24.1","puuid":"p2","championId":238,"teamPosition":"","win":false,"item0":3142}
+{"matchId":"NA1_5","queueId":830,"gameVersion":"15.24.1","puuid":"p1","championId":103,"teamPosition":"MIDDLE","win":true,"item0":3089}
+`
+	if err := os.WriteFile(filepath.Join(warmDir, "test_001.jsonl"), []byte(sampleData), 0644); err != nil {
+		t.Fatalf("Failed to write sample JSONL: %v", err)
+	}
+
+	agg, err := AggregateWarmFiles(warmDir, func(itemID int) bool { return itemID >= 3000 })
+	if err != nil {
+		t.Fatalf("AggregateWarmFiles failed: %v", err)
+	}
+
+	// Records without a queue are Ranked Solo/Duo
+	ranked := agg.ChampionStats[ChampionStatsKey{Patch: "15.24", QueueID: 420, Region: "americas", ChampionID: 103, TeamPosition: "MIDDLE"}]
+	if ranked == nil || ranked.Matches != 2 || ranked.Wins != 1 {
+		t.Errorf("Expected 2 ranked Ahri matches with 1 win, got %+v", ranked)
+	}
+	draft := agg.ChampionStats[ChampionStatsKey{Patch: "15.24", QueueID: 400, Region: "americas", ChampionID: 103, TeamPosition: "MIDDLE"}]
+	if draft == nil || draft.Matches != 1 {
+		t.Errorf("Expected 1 draft Ahri match, got %+v", draft)
+	}
+	aram := agg.ChampionStats[ChampionStatsKey{Patch: "15.24", QueueID: 450, Region: "americas", ChampionID: 103}]
+	if aram == nil || aram.Matches != 1 || aram.Wins != 1 {
+		t.Errorf("Expected 1 ARAM Ahri win, got %+v", aram)
+	}
+	if _, ok := agg.ItemStats[ItemStatsKey{Patch: "15.24", QueueID: 450, Region: "americas", ChampionID: 103, ItemID: 3089}]; !ok {
+		t.Errorf("Expected ARAM item stats for Ahri")
+	}
+
+	// Queue 830 (Co-op vs AI) is not collected
+	if len(agg.ChampionStats) != 4 {
+		t.Errorf("Expected 4 champion stats, got %d", len(agg.ChampionStats))
+	}
+	for k := range agg.MatchupStats {
+		if k.QueueID == 450 {
+			t.Errorf("Expected no ARAM matchups, got %+v", k)
+		}
+	}
+}
+
+// Records are bracketed by the lobby's estimated tier, falling back to the source player's
+func TestAggregateWarmFiles_Brackets(t *testing.T) {
+	warmDir := t.TempDir()
+
+	sampleData := `{"matchId":"NA1_1","queueId":420,"sourceTier":"EMERALD","sourceDivision":"I","lobbyTier":"DIAMOND","gameVersion":"15.24.1","puuid":"p1","championId":103,"teamPosition":"MIDDLE","win":true,"item0":3089}
+{"matchId":"NA1_1","queueId":420,"sourceTier":"EMERALD","sourceDivision":"I","lobbyTier":"DIAMOND","gameVersion":"15.24.1","puuid":"p2","championId":238,"teamPosition":"MIDDLE","win":false,"item0":3142}
+{"matchId":"NA1_2","queueId":420,"sourceTier":"GRANDMASTER","gameVersion":"15.24.1","puuid":"p1","championId":103,"teamPosition":"MIDDLE","win":false,"item0":3089}
+{"matchId":"NA1_3","queueId":420,"gameVersion":"15.24.1","puuid":"p1","championId":103,"teamPosition":"MIDDLE","win":true,"item0":3089}
+`
+	if err := os.WriteFile(filepath.Join(warmDir, "test_001.jsonl"), []byte(sampleData), 0644); err != nil {
+		t.Fatalf("Failed to write sample JSONL: %v", err)
+	}
+
+	agg, err := AggregateWarmFiles(warmDir, func(itemID int) bool { return itemID >= 3000 })
+	if err != nil {
+		t.Fatalf("AggregateWarmFiles failed: %v", err)
+	}
+
+	for bracket, want := range map[string]int{"diamond": 1, "master_plus": 1, "": 1} {
+		stats := agg.ChampionStats[ChampionStatsKey{Patch: "15.24", QueueID: 420, Bracket: bracket, Region: "americas", ChampionID: 103, TeamPosition: "MIDDLE"}]
+		if stats == nil || stats.Matches != want {
+			t.Errorf("Expected %d Ahri match in bracket %q, got %+v", want, bracket, stats)
+		}
+	}
+	matchup := agg.MatchupStats[MatchupStatsKey{Patch: "15.24", QueueID: 420, Bracket: "diamond", Region: "americas", ChampionID: 103, TeamPosition: "MIDDLE", EnemyChampionID: 238}]
+	if matchup == nil || matchup.Wins != 1 {
+		t.Errorf("Expected the Diamond Ahri vs Zed win, got %+v", matchup)
+	}
+}
+
+// Records are split by the region of their platform, or of the match ID in older files,
+// until the regions are merged
+func TestAggregateWarmFiles_Regions(t *testing.T) {
+	warmDir := t.TempDir()
+
+	sampleData := `{"matchId":"EUW1_1","platform":"euw1","queueId":420,"gameVersion":"15.24.1","puuid":"p1","championId":103,"teamPosition":"MIDDLE","win":true}
+{"matchId":"EUW1_1","platform":"euw1","queueId":420,"gameVersion":"15.24.1","puuid":"p2","championId":238,"teamPosition":"MIDDLE","win":false}
+{"matchId":"KR_2","queueId":420,"gameVersion":"15.24.1","puuid":"p3","championId":103,"teamPosition":"MIDDLE","win":false}
+{"matchId":"3","queueId":420,"gameVersion":"15.24.1","puuid":"p4","championId":103,"teamPosition":"MIDDLE","win":true}
+`
+	if err := os.WriteFile(filepath.Join(warmDir, "test_001.jsonl"), []byte(sampleData), 0644); err != nil {
+		t.Fatalf("Failed to write sample JSONL: %v", err)
+	}
+
+	agg, err := AggregateWarmFiles(warmDir, func(itemID int) bool { return itemID >= 3000 })
+	if err != nil {
+		t.Fatalf("AggregateWarmFiles failed: %v", err)
+	}
+
+	for region, want := range map[string]int{"europe": 1, "asia": 1, "": 1} {
+		stats := agg.ChampionStats[ChampionStatsKey{Patch: "15.24", QueueID: 420, Region: region, ChampionID: 103, TeamPosition: "MIDDLE"}]
+		if stats == nil || stats.Matches != want {
+			t.Errorf("Expected %d Ahri match in region %q, got %+v", want, region, stats)
+		}
+	}
+	if m := agg.MatchupStats[MatchupStatsKey{Patch: "15.24", QueueID: 420, Region: "europe", ChampionID: 103, TeamPosition: "MIDDLE", EnemyChampionID: 238}]; m == nil || m.Wins != 1 {
+		t.Errorf("Expected the EUW Ahri vs Zed win, got %+v", m)
+	}
+
+	agg.MergeRegions()
+	stats := agg.ChampionStats[ChampionStatsKey{Patch: "15.24", QueueID: 420, ChampionID: 103, TeamPosition: "MIDDLE"}]
+	if len(agg.ChampionStats) != 2 || stats == nil || stats.Matches != 3 || stats.Wins != 2 {
+		t.Errorf("Expected Ahri's 3 games merged, got %+v (%d keys)", stats, len(agg.ChampionStats))
+	}
+}
+
+// Arena records count placements, augments and all final items
+func TestAggregateWarmFiles_Arena(t *testing.T) {
+	warmDir := t.TempDir()
+
+	sampleData := `{"matchId":"NA1_1","queueId":1700,"gameVersion":"15.24.1","puuid":"p1","championId":103,"placement":1,"augments":[1,2,2,0],"item0":1001,"item1":3089}
+{"matchId":"NA1_2","queueId":1700,"gameVersion":"15.24.1","puuid":"p1","championId":103,"placement":6,"augments":[2],"item0":3089}
+{"matchId":"NA1_3","queueId":1700,"gameVersion":"15.24.1","puuid":"p1","championId":103,"placement":0,"augments":[1]}
+`
+	if err := os.WriteFile(filepath.Join(warmDir, "test_001.jsonl"), []byte(sampleData), 0644); err != nil {
+		t.Fatalf("Failed to write sample JSONL: %v", err)
+	}
+
+	agg, err := AggregateWarmFiles(warmDir, func(itemID int) bool { return itemID >= 3000 })
+	if err != nil {
+		t.Fatalf("AggregateWarmFiles failed: %v", err)
+	}
+
+	if len(agg.ChampionStats) != 0 || len(agg.ItemStats) != 0 {
+		t.Errorf("Expected Arena to stay out of the Summoner's Rift stats")
+	}
+
+	champ := agg.ArenaStats[ArenaStatsKey{Patch: "15.24", ChampionID: 103}]
+	want := ArenaStats{Wins: 1, Matches: 2, Firsts: 1, PlacementSum: 7}
+	if champ == nil || *champ != want {
+		t.Errorf("Expected Arena Ahri %+v, got %+v", want, champ)
+	}
+
+	if aug := agg.ArenaAugments[ArenaAugmentKey{Patch: "15.24", ChampionID: 103, AugmentID: 2}]; aug == nil || aug.Matches != 2 {
+		t.Errorf("Expected augment 2 counted once per game, got %+v", aug)
+	}
+	if aug := agg.ArenaAugments[ArenaAugmentKey{Patch: "15.24", ChampionID: 103, AugmentID: 1}]; aug == nil || aug.Matches != 1 || aug.Firsts != 1 {
+		t.Errorf("Expected augment 1 from the first place game only, got %+v", aug)
+	}
+	if item := agg.ArenaItems[ArenaItemKey{Patch: "15.24", ChampionID: 103, ItemID: 1001}]; item == nil || item.Matches != 1 {
+		t.Errorf("Expected Arena items to ignore the item filter, got %+v", item)
+	}
+}
+
 // =============================================================================
 // Test 3.2: Archive warm to cold with gzip
 // =============================================================================
@@ -2,12 +2,15 @@ package collector
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"runtime"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"data-analyzer/internal/riot"
 )
 
 // TestSetupSignalHandler tests that the signal handler context works
@@ -367,11 +370,67 @@ func TestShutdown_Integration_MultipleSignals(t *testing.T) {
 	}
 }
 
+// TestShutdown_DuringRateLimitWait tests that shutdown doesn't wait out a long Retry-After
+// the spider loop is sleeping on
+func TestShutdown_DuringRateLimitWait(t *testing.T) {
+	spider := &mockSpiderForTest{
+		err: &riot.APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 10 * time.Minute},
+	}
+	reduceFunc := func(ctx context.Context) error { return nil }
+	keyValidator := &mockKeyValidatorForTest{valid: true}
+
+	config := DefaultConfig()
+	config.ShutdownTimeout = 5 * time.Second
+
+	cc := NewContinuousCollector(spider, reduceFunc, keyValidator, nil, nil, config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+
+	go func() {
+		_ = cc.Run(ctx)
+		close(done)
+	}()
+
+	// Wait for the spider to be rate limited
+	deadline := time.Now().Add(2 * time.Second)
+	for spider.runCalls.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+
+	start := time.Now()
+	stopped := make(chan struct{})
+	go func() {
+		cc.Shutdown(shutdownCtx)
+		<-done
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Collector did not shut down while the spider waited out Retry-After")
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("shutdown took %v, want it not to wait out Retry-After", elapsed)
+	}
+	if got := spider.runCalls.Load(); got != 1 {
+		t.Errorf("spider ran %d times, want 1 before shutdown", got)
+	}
+}
+
 // mockSpiderForTest is a test helper
 type mockSpiderForTest struct {
-	runCalls   atomic.Int32
-	resetCalls atomic.Int32
-	seedCalls  atomic.Int32
+	runCalls          atomic.Int32
+	resetCalls        atomic.Int32
+	counterResetCalls atomic.Int32
+	seedCalls         atomic.Int32
+	err               error // returned by every run, if set
 }
 
 func (m *mockSpiderForTest) RunContinuous(ctx context.Context) error {
@@ -380,7 +439,7 @@ func (m *mockSpiderForTest) RunContinuous(ctx context.Context) error {
 	case <-ctx.Done():
 		return ctx.Err()
 	case <-time.After(10 * time.Millisecond):
-		return nil
+		return m.err
 	}
 }
 
@@ -388,7 +447,11 @@ func (m *mockSpiderForTest) Reset() {
 	m.resetCalls.Add(1)
 }
 
-func (m *mockSpiderForTest) SeedFromChallenger(ctx context.Context) error {
+func (m *mockSpiderForTest) ResetCounters() {
+	m.counterResetCalls.Add(1)
+}
+
+func (m *mockSpiderForTest) SeedFromLadder(ctx context.Context) error {
 	m.seedCalls.Add(1)
 	return nil
 }
@@ -1,9 +1,12 @@
 package collector
 
 import (
+	"container/heap"
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"math/rand"
 	"sync"
 	"sync/atomic"
@@ -11,6 +14,7 @@ import (
 
 	"data-analyzer/internal/riot"
 	"data-analyzer/internal/storage"
+	"ghostdraft/ddragon"
 
 	"github.com/bits-and-blooms/bloom/v3"
 )
@@ -20,12 +24,18 @@ const (
 	DefaultWorkerCount          = 10
 	MatchChannelBuffer          = 100
 	DefaultTimelineSamplingRate = 0.20 // 20% of matches get timeline data
+	DefaultCheckpointInterval   = 5 * time.Minute
+
+	// Crawl priority of seed players, above any player found through coverage
+	seedPriority = 1.0
 )
 
 // MatchJob represents a match to be fetched by workers
 type MatchJob struct {
-	MatchID string
-	PUUID   string // Source player PUUID for tracking
+	MatchID  string
+	PUUID    string // Source player PUUID for tracking
+	Tier     string // Source player's solo queue tier and division
+	Division string
 }
 
 // Spider crawls match data using a producer-consumer pattern
@@ -33,6 +43,7 @@ type Spider struct {
 	client       *riot.Client
 	rotator      *storage.FileRotator
 	currentPatch string
+	items        *ddragon.Catalog // Current patch's items; build orders keep completed ones
 
 	// Configuration
 	matchesPerPlayer     int
@@ -50,20 +61,39 @@ type Spider struct {
 	matchesMu      sync.Mutex
 	puuidsMu       sync.Mutex
 
-	// Queue of players to process
-	playerQueue   []string
+	// Players to process, those on under-covered champions first
+	playerQueue   playerHeap
+	queueSeq      int64 // next queue order number
 	playerQueueMu sync.Mutex
+	coverage      *Coverage
+
+	// Ranks looked up or seen on the ladder, so players aren't looked up again within the
+	// TTL and lobby ranks can be estimated
+	rankCache *storage.RankCache
+
+	// Matches per rank bracket against the quotas, and the ladder page to seed from next
+	// per tier and division
+	brackets    *bracketCounter
+	ladderPages map[string]int
+	ladderMu    sync.Mutex
+
+	// Crawl state checkpoints (nil frontier: not persisted)
+	frontier           *storage.FrontierStore
+	checkpointInterval time.Duration
+	lastCheckpoint     time.Time
+	checkpointMu       sync.Mutex
 
 	// Channels for producer-consumer
 	matchJobs chan MatchJob
 	results   chan *MatchResult
 
 	// Stats (atomic for thread safety)
-	activePlayerCount  int64
-	totalMatches       int64
-	timelinesCollected int64 // Track how many timelines we fetched
-	playersSkippedRank int64 // Players skipped due to low rank
-	startTime          time.Time
+	activePlayerCount   int64
+	totalMatches        int64
+	timelinesCollected  int64 // Track how many timelines we fetched
+	playersSkippedRank  int64 // Players skipped due to low rank
+	playersSkippedQuota int64 // Players skipped because their bracket had its share of matches
+	startTime           time.Time
 
 	// Shutdown
 	wg     sync.WaitGroup
@@ -75,9 +105,15 @@ type MatchResult struct {
 	Match        *riot.MatchResponse
 	MatchID      string
 	NewPUUIDs    []string
+	Priorities   map[string]float64 // crawl priority of each participant, from coverage
 	CurrentPatch bool
 	BuildOrders  map[int][]int // participantID -> build order (nil if timeline not fetched)
 	Error        error
+
+	// Source player's rank and the lobby's estimated average tier
+	SourceTier     string
+	SourceDivision string
+	LobbyTier      string
 }
 
 // SpiderConfig holds configuration for the spider
@@ -85,7 +121,22 @@ type SpiderConfig struct {
 	MatchesPerPlayer     int
 	MaxPlayers           int
 	WorkerCount          int
-	TimelineSamplingRate float64 // 0.0-1.0, default 0.20 (20%)
+	TimelineSamplingRate float64          // 0.0-1.0, default 0.20 (20%)
+	Items                *ddragon.Catalog // Item catalog for the current patch, used to extract build orders
+
+	// CoverageTarget is the samples per champion and position the crawl aims for (default 100)
+	CoverageTarget int
+
+	// BracketQuotas is the share of matches each rank bracket gets (default DefaultBracketQuotas)
+	BracketQuotas BracketQuotas
+
+	// Frontier checkpoints the crawl state so it survives restarts (nil: kept in memory only)
+	Frontier           *storage.FrontierStore
+	CheckpointInterval time.Duration // default 5 minutes
+
+	// RankCacheTTL is how long a player's rank is reused before it is looked up again
+	// (default 24 hours). The cache is saved beside the frontier.
+	RankCacheTTL time.Duration
 }
 
 // NewSpider creates a new spider with worker pool
@@ -103,18 +154,40 @@ func NewSpider(client *riot.Client, rotator *storage.FileRotator, currentPatch s
 		samplingRate = 1.0
 	}
 
+	checkpointInterval := cfg.CheckpointInterval
+	if checkpointInterval <= 0 {
+		checkpointInterval = DefaultCheckpointInterval
+	}
+
+	// Known ranks are kept beside the frontier, so restarts don't look players up again
+	rankCachePath := ""
+	if cfg.Frontier != nil {
+		rankCachePath = cfg.Frontier.RanksPath(client.Platform())
+	}
+	rankCache, err := storage.NewRankCache(rankCachePath, cfg.RankCacheTTL)
+	if err != nil {
+		log.Printf("[Spider] %v (starting with an empty rank cache)", err)
+	}
+
 	return &Spider{
 		client:               client,
 		rotator:              rotator,
 		currentPatch:         currentPatch,
+		items:                cfg.Items,
 		matchesPerPlayer:     cfg.MatchesPerPlayer,
 		maxPlayers:           cfg.MaxPlayers,
 		workerCount:          cfg.WorkerCount,
 		timelineSamplingRate: samplingRate,
+		frontier:             cfg.Frontier,
+		checkpointInterval:   checkpointInterval,
 		rng:                  rand.New(rand.NewSource(time.Now().UnixNano())),
 		visitedMatches:       bloom.NewWithEstimates(500000, 0.001),
 		visitedPUUIDs:        bloom.NewWithEstimates(1000000, 0.001),
-		playerQueue:          make([]string, 0, 1000),
+		playerQueue:          make(playerHeap, 0, 1000),
+		coverage:             NewCoverage(cfg.CoverageTarget),
+		rankCache:            rankCache,
+		brackets:             newBracketCounter(cfg.BracketQuotas),
+		ladderPages:          make(map[string]int),
 		matchJobs:            make(chan MatchJob, MatchChannelBuffer),
 		results:              make(chan *MatchResult, MatchChannelBuffer),
 	}
@@ -126,7 +199,7 @@ func (s *Spider) Run(ctx context.Context, startingPUUID string) error {
 	s.startTime = time.Now()
 
 	// Add starting player to queue
-	s.addPlayer(startingPUUID)
+	s.addPlayer(startingPUUID, seedPriority)
 
 	// Start worker pool (consumers)
 	for i := 0; i < s.workerCount; i++ {
@@ -139,22 +212,27 @@ func (s *Spider) Run(ctx context.Context, startingPUUID string) error {
 	go s.processResults(ctx)
 
 	// Producer loop - manages queue and dispatches match IDs
-	s.producerLoop(ctx)
+	err := s.producerLoop(ctx)
+	if err != nil {
+		// Stop the workers too: their requests would fail the same way
+		s.cancel()
+	}
 
 	// Wait for all workers to finish
 	close(s.matchJobs)
 	s.wg.Wait()
 
 	s.printSummary()
-	return nil
+	return err
 }
 
-// producerLoop is the main producer that traverses players and dispatches match IDs
-func (s *Spider) producerLoop(ctx context.Context) {
+// producerLoop is the main producer that traverses players and dispatches match IDs.
+// It returns an error when a request fails in a way that ends the crawl (see abortOnError).
+func (s *Spider) producerLoop(ctx context.Context) error {
 	for {
 		select {
 		case <-ctx.Done():
-			return
+			return nil
 		default:
 		}
 
@@ -173,14 +251,18 @@ func (s *Spider) producerLoop(ctx context.Context) {
 
 			// If queue is still empty and no jobs in flight, we're done
 			if s.isQueueEmpty() && len(s.matchJobs) == 0 {
-				return
+				return nil
 			}
 			continue
 		}
 
 		// Check player rank - skip if below Emerald 4
-		tier, division, hasRank, err := s.client.GetSoloQueueRank(ctx, puuid)
+		tier, division, hasRank, err := s.soloQueueRank(ctx, puuid)
 		if err != nil {
+			if abortOnError(err) {
+				s.requeueOnRateLimit(puuid, err)
+				return fmt.Errorf("rank check failed: %w", err)
+			}
 			log.Printf("[Producer] Failed to get rank for %s: %v (skipping)", puuid[:16], err)
 			atomic.AddInt64(&s.playersSkippedRank, 1)
 			continue
@@ -195,10 +277,18 @@ func (s *Spider) producerLoop(ctx context.Context) {
 			atomic.AddInt64(&s.playersSkippedRank, 1)
 			continue
 		}
+		if s.skipOverQuota(tier) {
+			log.Printf("[Producer] Player %s is %s - %s has its share of matches (skipping)", puuid[:16], tier, riot.TierBracket(tier))
+			continue
+		}
 
 		// Fetch match history for this player
 		matchIDs, err := s.client.GetMatchHistory(ctx, puuid, s.matchesPerPlayer)
 		if err != nil {
+			if abortOnError(err) {
+				s.requeueOnRateLimit(puuid, err)
+				return fmt.Errorf("match history failed: %w", err)
+			}
 			log.Printf("[Producer] Failed to fetch match history for %s: %v", puuid[:16], err)
 			continue
 		}
@@ -216,7 +306,7 @@ func (s *Spider) producerLoop(ctx context.Context) {
 		for _, matchID := range matchIDs {
 			select {
 			case <-ctx.Done():
-				return
+				return nil
 			default:
 			}
 
@@ -228,10 +318,10 @@ func (s *Spider) producerLoop(ctx context.Context) {
 
 			// Dispatch to workers
 			select {
-			case s.matchJobs <- MatchJob{MatchID: matchID, PUUID: puuid}:
+			case s.matchJobs <- MatchJob{MatchID: matchID, PUUID: puuid, Tier: tier, Division: division}:
 				dispatchedCount++
 			case <-ctx.Done():
-				return
+				return nil
 			}
 		}
 
@@ -278,8 +368,10 @@ func (s *Spider) shouldFetchTimeline() bool {
 // fetchMatch fetches match details and optionally timeline based on sampling rate
 func (s *Spider) fetchMatch(ctx context.Context, job MatchJob) *MatchResult {
 	result := &MatchResult{
-		MatchID:   job.MatchID,
-		NewPUUIDs: make([]string, 0),
+		MatchID:        job.MatchID,
+		NewPUUIDs:      make([]string, 0),
+		SourceTier:     job.Tier,
+		SourceDivision: job.Division,
 	}
 
 	// Always fetch match details (for accurate win rates)
@@ -295,11 +387,14 @@ func (s *Spider) fetchMatch(ctx context.Context, job MatchJob) *MatchResult {
 	matchPatch := riot.NormalizePatch(match.Info.GameVersion)
 	result.CurrentPatch = (matchPatch == s.currentPatch)
 
-	// If not current patch, don't collect new players or timeline
-	if !result.CurrentPatch {
+	// If not current patch or a queue we keep stats for, don't collect new players or timeline
+	if !result.CurrentPatch || !riot.IsStatsQueue(match.Info.QueueID) {
 		return result
 	}
 
+	result.LobbyTier = s.lobbyTier(match)
+	result.Priorities = s.coverage.Priorities(match)
+
 	// Collect new PUUIDs from participants
 	for _, p := range match.Info.Participants {
 		if !s.hasVisitedPUUID(p.PUUID) {
@@ -308,7 +403,8 @@ func (s *Spider) fetchMatch(ctx context.Context, job MatchJob) *MatchResult {
 	}
 
 	// Statistical sampling: only fetch timeline for a percentage of matches
-	if s.shouldFetchTimeline() {
+	// (Arena has no build slots, so its timelines aren't needed)
+	if match.Info.QueueID != riot.QueueArena && s.shouldFetchTimeline() {
 		timeline, err := s.client.GetTimeline(ctx, job.MatchID)
 		if err != nil {
 			// Log but don't fail - timeline is optional for sampling
@@ -317,7 +413,7 @@ func (s *Spider) fetchMatch(ctx context.Context, job MatchJob) *MatchResult {
 			// Extract build orders for all participants
 			result.BuildOrders = make(map[int][]int)
 			for _, p := range match.Info.Participants {
-				buildOrder := riot.ExtractBuildOrder(timeline, p.ParticipantID)
+				buildOrder := riot.ExtractBuildOrder(timeline, p.ParticipantID, s.items)
 				if len(buildOrder) > 0 {
 					result.BuildOrders[p.ParticipantID] = buildOrder
 				}
@@ -358,56 +454,20 @@ func (s *Spider) processResults(ctx context.Context) {
 			// Track counts for source player
 			// (Note: we don't have source PUUID in result, tracking by match for simplicity)
 
-			// If not current patch, skip writing but still process
-			if !result.CurrentPatch {
+			// If not current patch or not a stats queue, skip writing but still process
+			if !result.CurrentPatch || !riot.IsStatsQueue(result.Match.Info.QueueID) {
 				continue
 			}
 
-			// Write participants to storage
-			for _, p := range result.Match.Info.Participants {
-				rawMatch := &storage.RawMatch{
-					MatchID:      result.MatchID,
-					GameVersion:  result.Match.Info.GameVersion,
-					GameDuration: result.Match.Info.GameDuration,
-					GameCreation: result.Match.Info.GameCreation,
-					PUUID:        p.PUUID,
-					GameName:     p.RiotIdGameName,
-					TagLine:      p.RiotIdTagline,
-					ChampionID:   p.ChampionID,
-					ChampionName: p.ChampionName,
-					TeamPosition: p.TeamPosition,
-					Win:          p.Win,
-					Item0:        p.Item0,
-					Item1:        p.Item1,
-					Item2:        p.Item2,
-					Item3:        p.Item3,
-					Item4:        p.Item4,
-					Item5:        p.Item5,
-					BuildOrder:   []int{}, // Default to empty (will be omitted in JSON)
-				}
-
-				// Include build order if timeline was sampled for this match
-				if result.BuildOrders != nil {
-					if buildOrder, ok := result.BuildOrders[p.ParticipantID]; ok {
-						rawMatch.BuildOrder = buildOrder
-					}
-				}
-
-				if err := s.rotator.WriteLine(rawMatch); err != nil {
-					log.Printf("  [Writer] Failed to write: %v", err)
-				}
-			}
-
-			// Signal match complete
-			if err := s.rotator.MatchComplete(); err != nil {
-				log.Printf("  [Writer] Failed to complete match: %v", err)
+			if err := s.writeMatch(result); err != nil {
+				log.Printf("  [Writer] Failed to write %s: %v", result.MatchID, err)
 			}
 
 			atomic.AddInt64(&s.totalMatches, 1)
 
 			// Add new players to queue
 			for _, puuid := range result.NewPUUIDs {
-				s.addPlayer(puuid)
+				s.addPlayer(puuid, result.Priorities[puuid])
 			}
 
 			// Increment active player count (simplified: count per match for now)
@@ -418,6 +478,74 @@ func (s *Spider) processResults(ctx context.Context) {
 	}
 }
 
+// writeMatch writes a fetched match, one record per participant
+func (s *Spider) writeMatch(result *MatchResult) error {
+	records := RawMatches(result, s.client.Platform())
+
+	// Written as one block, so spiders for other platforms sharing the rotator can't split it
+	if err := s.rotator.WriteMatch(records); err != nil {
+		return err
+	}
+	s.coverage.Record(result.Match)
+	s.brackets.record(matchBracket(result))
+	return nil
+}
+
+// RawMatches builds a fetched match's records, one per participant. The platform is the
+// one the match ID names, or fallbackPlatform if the ID has no known prefix.
+func RawMatches(result *MatchResult, fallbackPlatform string) []interface{} {
+	platform := riot.MatchPlatform(result.MatchID)
+	if platform == "" {
+		platform = fallbackPlatform
+	}
+
+	records := make([]interface{}, 0, len(result.Match.Info.Participants))
+	for _, p := range result.Match.Info.Participants {
+		rawMatch := &storage.RawMatch{
+			MatchID:        result.MatchID,
+			GameVersion:    result.Match.Info.GameVersion,
+			GameDuration:   result.Match.Info.GameDuration,
+			GameCreation:   result.Match.Info.GameCreation,
+			QueueID:        result.Match.Info.QueueID,
+			GameMode:       result.Match.Info.GameMode,
+			Platform:       platform,
+			SourceTier:     result.SourceTier,
+			SourceDivision: result.SourceDivision,
+			LobbyTier:      result.LobbyTier,
+			PUUID:          p.PUUID,
+			GameName:       p.RiotIdGameName,
+			TagLine:        p.RiotIdTagline,
+			ChampionID:     p.ChampionID,
+			ChampionName:   p.ChampionName,
+			TeamPosition:   p.TeamPosition,
+			Win:            p.Win,
+			Item0:          p.Item0,
+			Item1:          p.Item1,
+			Item2:          p.Item2,
+			Item3:          p.Item3,
+			Item4:          p.Item4,
+			Item5:          p.Item5,
+			BuildOrder:     []int{}, // Default to empty (will be omitted in JSON)
+			Placement:      p.Placement,
+			Augments:       p.Augments(),
+		}
+
+		// Include build order if timeline was sampled for this match
+		if result.BuildOrders != nil {
+			if buildOrder, ok := result.BuildOrders[p.ParticipantID]; ok {
+				rawMatch.BuildOrder = buildOrder
+			}
+		}
+		records = append(records, rawMatch)
+	}
+	return records
+}
+
+// CoverageReports returns the crawl's sample coverage. Implements CoverageReporter.
+func (s *Spider) CoverageReports() []CoverageReport {
+	return []CoverageReport{s.coverage.Report(s.client.Platform())}
+}
+
 // Bloom filter helpers with mutex protection
 func (s *Spider) hasVisitedMatch(matchID string) bool {
 	s.matchesMu.Lock()
@@ -443,8 +571,49 @@ func (s *Spider) markPUUIDVisited(puuid string) {
 	s.visitedPUUIDs.AddString(puuid)
 }
 
+// soloQueueRank returns a player's solo queue rank from the rank cache, or looks it up and
+// caches it (including having no rank)
+func (s *Spider) soloQueueRank(ctx context.Context, puuid string) (tier, division string, hasRank bool, err error) {
+	if e, ok := s.rankCache.Get(puuid); ok {
+		return e.Tier, e.Division, e.Tier != "", nil
+	}
+	tier, division, hasRank, err = s.client.GetSoloQueueRank(ctx, puuid)
+	if err != nil {
+		return "", "", false, err
+	}
+	s.rankCache.Put(puuid, tier, division)
+	return tier, division, hasRank, nil
+}
+
+// RankCacheStats returns the rank lookups answered from the cache and those that went to
+// the API. Implements RankCacheReporter.
+func (s *Spider) RankCacheStats() (hits, misses int64) {
+	return s.rankCache.Stats()
+}
+
+// lobbyTier estimates a match's average tier from the participants whose rank is
+// cached, or returns "" if none is. It peeks so the cache stats only count lookups
+// that stand in for an API call.
+func (s *Spider) lobbyTier(match *riot.MatchResponse) string {
+	total, known := 0, 0
+	for _, p := range match.Info.Participants {
+		e, ok := s.rankCache.Peek(p.PUUID)
+		if !ok {
+			continue
+		}
+		if score, ok := riot.RankScore(e.Tier, e.Division); ok {
+			total += score
+			known++
+		}
+	}
+	if known == 0 {
+		return ""
+	}
+	return riot.ScoreTier(total / known)
+}
+
 // Queue helpers
-func (s *Spider) addPlayer(puuid string) {
+func (s *Spider) addPlayer(puuid string, priority float64) {
 	s.puuidsMu.Lock()
 	if s.visitedPUUIDs.TestString(puuid) {
 		s.puuidsMu.Unlock()
@@ -454,7 +623,8 @@ func (s *Spider) addPlayer(puuid string) {
 	s.puuidsMu.Unlock()
 
 	s.playerQueueMu.Lock()
-	s.playerQueue = append(s.playerQueue, puuid)
+	heap.Push(&s.playerQueue, queuedPlayer{puuid: puuid, priority: priority, seq: s.queueSeq})
+	s.queueSeq++
 	s.playerQueueMu.Unlock()
 
 	atomic.AddInt64(&s.activePlayerCount, 1)
@@ -468,9 +638,7 @@ func (s *Spider) popPlayer() string {
 		return ""
 	}
 
-	puuid := s.playerQueue[0]
-	s.playerQueue = s.playerQueue[1:]
-	return puuid
+	return heap.Pop(&s.playerQueue).(queuedPlayer).puuid
 }
 
 func (s *Spider) isQueueEmpty() bool {
@@ -499,16 +667,19 @@ func (s *Spider) RunContinuous(ctx context.Context) error {
 	// Get next player from queue
 	puuid := s.popPlayer()
 	if puuid == "" {
-		// Queue empty, caller should wait for more
+		// Frontier exhausted (or every queued player was over quota): draw more from the ladder
+		if err := s.seedFromLadder(ctx); err != nil {
+			return fmt.Errorf("reseeding from the ladder failed: %w", err)
+		}
 		return nil
 	}
 
 	// Check player rank - skip if below Emerald 4
-	tier, division, hasRank, err := s.client.GetSoloQueueRank(ctx, puuid)
+	tier, division, hasRank, err := s.soloQueueRank(ctx, puuid)
 	if err != nil {
-		// Check if this is an API key error
-		if isHTTPError(err, 401) || isHTTPError(err, 403) {
-			return fmt.Errorf("rank check failed: %w", WrapHTTPError(getHTTPStatus(err), "API key error"))
+		if abortOnError(err) {
+			s.requeueOnRateLimit(puuid, err)
+			return fmt.Errorf("rank check failed: %w", err)
 		}
 		log.Printf("[Spider] Failed to get rank for %s: %v (skipping)", puuid[:min(16, len(puuid))], err)
 		atomic.AddInt64(&s.playersSkippedRank, 1)
@@ -524,12 +695,17 @@ func (s *Spider) RunContinuous(ctx context.Context) error {
 		atomic.AddInt64(&s.playersSkippedRank, 1)
 		return nil
 	}
+	if s.skipOverQuota(tier) {
+		log.Printf("[Spider] Player %s is %s - %s has its share of matches (skipping)", puuid[:min(16, len(puuid))], tier, riot.TierBracket(tier))
+		return nil
+	}
 
 	// Fetch match history for this player
 	matchIDs, err := s.client.GetMatchHistory(ctx, puuid, s.matchesPerPlayer)
 	if err != nil {
-		if isHTTPError(err, 401) || isHTTPError(err, 403) {
-			return fmt.Errorf("match history failed: %w", WrapHTTPError(getHTTPStatus(err), "API key error"))
+		if abortOnError(err) {
+			s.requeueOnRateLimit(puuid, err)
+			return fmt.Errorf("match history failed: %w", err)
 		}
 		log.Printf("[Spider] Failed to fetch match history for %s: %v", puuid[:min(16, len(puuid))], err)
 		return nil
@@ -537,8 +713,8 @@ func (s *Spider) RunContinuous(ctx context.Context) error {
 
 	elapsed := time.Since(s.startTime)
 	totalMatches := atomic.LoadInt64(&s.totalMatches)
-	fmt.Printf("\n[%d matches] [%s] Processing: %s... (%s %s, %d new)\n",
-		totalMatches, formatDuration(elapsed), puuid[:min(16, len(puuid))], tier, division, len(matchIDs))
+	fmt.Printf("\n[%s] [%d matches] [%s] Processing: %s... (%s %s, %d new)\n",
+		s.client.Platform(), totalMatches, formatDuration(elapsed), puuid[:min(16, len(puuid))], tier, division, len(matchIDs))
 
 	// Process matches synchronously in continuous mode
 	for _, matchID := range matchIDs {
@@ -552,73 +728,44 @@ func (s *Spider) RunContinuous(ctx context.Context) error {
 		if s.hasVisitedMatch(matchID) {
 			continue
 		}
-		s.markMatchVisited(matchID)
 
 		// Fetch and process the match
-		result := s.fetchMatch(ctx, MatchJob{MatchID: matchID, PUUID: puuid})
+		result := s.fetchMatch(ctx, MatchJob{MatchID: matchID, PUUID: puuid, Tier: tier, Division: division})
 		if result.Error != nil {
-			if isHTTPError(result.Error, 401) || isHTTPError(result.Error, 403) {
-				return fmt.Errorf("match fetch failed: %w", WrapHTTPError(getHTTPStatus(result.Error), "API key error"))
+			if abortOnError(result.Error) {
+				// Left unvisited so the requeued player's next batch fetches it again
+				s.requeueOnRateLimit(puuid, result.Error)
+				return fmt.Errorf("match fetch failed: %w", result.Error)
 			}
+			s.markMatchVisited(matchID)
 			log.Printf("  [Spider] Failed to fetch %s: %v", matchID, result.Error)
 			continue
 		}
+		s.markMatchVisited(matchID)
 
-		if result.Match == nil || !result.CurrentPatch {
+		if result.Match == nil || !result.CurrentPatch || !riot.IsStatsQueue(result.Match.Info.QueueID) {
 			continue
 		}
 
-		// Write participants to storage
-		for _, p := range result.Match.Info.Participants {
-			rawMatch := &storage.RawMatch{
-				MatchID:      result.MatchID,
-				GameVersion:  result.Match.Info.GameVersion,
-				GameDuration: result.Match.Info.GameDuration,
-				GameCreation: result.Match.Info.GameCreation,
-				PUUID:        p.PUUID,
-				GameName:     p.RiotIdGameName,
-				TagLine:      p.RiotIdTagline,
-				ChampionID:   p.ChampionID,
-				ChampionName: p.ChampionName,
-				TeamPosition: p.TeamPosition,
-				Win:          p.Win,
-				Item0:        p.Item0,
-				Item1:        p.Item1,
-				Item2:        p.Item2,
-				Item3:        p.Item3,
-				Item4:        p.Item4,
-				Item5:        p.Item5,
-				BuildOrder:   []int{},
-			}
-
-			if result.BuildOrders != nil {
-				if buildOrder, ok := result.BuildOrders[p.ParticipantID]; ok {
-					rawMatch.BuildOrder = buildOrder
-				}
-			}
-
-			if err := s.rotator.WriteLine(rawMatch); err != nil {
-				log.Printf("  [Spider] Failed to write: %v", err)
-			}
-		}
-
-		if err := s.rotator.MatchComplete(); err != nil {
-			log.Printf("  [Spider] Failed to complete match: %v", err)
+		if err := s.writeMatch(result); err != nil {
+			log.Printf("  [Spider] Failed to write %s: %v", result.MatchID, err)
 		}
 
 		atomic.AddInt64(&s.totalMatches, 1)
 
 		// Add new players to queue
 		for _, newPUUID := range result.NewPUUIDs {
-			s.addPlayer(newPUUID)
+			s.addPlayer(newPUUID, result.Priorities[newPUUID])
 		}
 	}
 
+	s.maybeCheckpoint()
 	return nil
 }
 
-// Reset clears all internal state (bloom filters, player queue).
-// Implements SpiderRunner interface.
+// Reset clears the visited sets and counters, so players and matches can be crawled
+// again. Cached ranks expire on their own. The player queue is kept: the crawl carries on
+// from its frontier rather than starting over from Challenger. Implements SpiderRunner interface.
 func (s *Spider) Reset() {
 	log.Println("[Spider] Resetting internal state...")
 
@@ -631,21 +778,42 @@ func (s *Spider) Reset() {
 	s.visitedPUUIDs = bloom.NewWithEstimates(1000000, 0.001)
 	s.puuidsMu.Unlock()
 
-	// Clear player queue
+	// Queued players stay visited, so they aren't queued twice
 	s.playerQueueMu.Lock()
-	s.playerQueue = make([]string, 0, 1000)
+	queue := s.playerQueue.sorted()
 	s.playerQueueMu.Unlock()
+	for _, p := range queue {
+		s.markPUUIDVisited(p.puuid)
+	}
+
+	s.resetCounters()
+
+	if err := s.Checkpoint(); err != nil {
+		log.Printf("[Spider] Failed to checkpoint after reset: %v", err)
+	}
+	log.Printf("[Spider] Reset complete (%d players still queued)", len(queue))
+}
 
-	// Reset counters
+// ResetCounters starts a new session's counters but keeps the visited sets and queue,
+// so matches already collected this patch aren't fetched and written again.
+// Implements SpiderRunner interface.
+func (s *Spider) ResetCounters() {
+	s.resetCounters()
+	if err := s.Checkpoint(); err != nil {
+		log.Printf("[Spider] Failed to checkpoint after counter reset: %v", err)
+	}
+	log.Println("[Spider] Counters reset, visited sets kept")
+}
+
+// resetCounters zeroes the session counters, bracket counts and start time
+func (s *Spider) resetCounters() {
 	atomic.StoreInt64(&s.activePlayerCount, 0)
 	atomic.StoreInt64(&s.totalMatches, 0)
 	atomic.StoreInt64(&s.timelinesCollected, 0)
 	atomic.StoreInt64(&s.playersSkippedRank, 0)
-
-	// Reset start time
+	atomic.StoreInt64(&s.playersSkippedQuota, 0)
+	s.brackets.restore(nil)
 	s.startTime = time.Time{}
-
-	log.Println("[Spider] Reset complete")
 }
 
 // ResetPlayerCount resets just the player count so collection can continue.
@@ -662,48 +830,48 @@ func (s *Spider) SetAPIKey(key string) {
 	s.client.SetAPIKey(key)
 }
 
-// SeedFromChallenger seeds the spider with the top Challenger player.
-// Implements SpiderRunner interface.
-func (s *Spider) SeedFromChallenger(ctx context.Context) error {
-	log.Println("[Spider] Seeding from top Challenger player...")
-
-	puuid, err := s.client.GetTopChallengerPUUID(ctx)
+// SeedFromLadder seeds the spider with random players from the ranked ladder of every
+// bracket with a quota, unless there is a frontier to continue: players still queued, or a
+// checkpoint from this patch with players left. Implements SpiderRunner interface.
+func (s *Spider) SeedFromLadder(ctx context.Context) error {
+	if !s.isQueueEmpty() {
+		log.Println("[Spider] Continuing from the queued frontier (not seeding)")
+		return nil
+	}
+	restored, err := s.restore()
 	if err != nil {
-		if isHTTPError(err, 401) || isHTTPError(err, 403) {
-			return fmt.Errorf("seed from challenger failed: %w", WrapHTTPError(getHTTPStatus(err), "API key error"))
-		}
-		return fmt.Errorf("failed to get top Challenger: %w", err)
+		log.Printf("[Spider] Failed to restore checkpoint: %v (seeding from the ladder)", err)
+	}
+	if restored {
+		return nil
 	}
 
-	s.addPlayer(puuid)
-	log.Printf("[Spider] Seeded with Challenger player: %s...", puuid[:min(16, len(puuid))])
-
+	log.Printf("[Spider] Seeding from the ladder (quotas %s)...", s.brackets.quotas)
+	if err := s.seedFromLadder(ctx); err != nil {
+		return fmt.Errorf("failed to seed from the ladder: %w", err)
+	}
 	return nil
 }
 
-// isHTTPError checks if an error contains a specific HTTP status code
-func isHTTPError(err error, statusCode int) bool {
-	if err == nil {
-		return false
-	}
-	errStr := err.Error()
-	statusStr := fmt.Sprintf("%d", statusCode)
-	return contains(errStr, statusStr)
+// abortOnError reports whether a failed request ends the batch instead of skipping the
+// player or match: the key was rejected (the collector waits for a new one), the context
+// ended, or a rate limit outlasted the client's retries (the next requests would fail too).
+// Not found and server errors only concern the one player or match.
+func abortOnError(err error) bool {
+	return errors.Is(err, riot.ErrKeyExpired) || errors.Is(err, riot.ErrRateLimited) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
 }
 
-// getHTTPStatus extracts HTTP status code from error (best effort)
-func getHTTPStatus(err error) int {
-	if err == nil {
-		return 0
-	}
-	errStr := err.Error()
-	if contains(errStr, "401") {
-		return 401
-	}
-	if contains(errStr, "403") {
-		return 403
+// requeueOnRateLimit puts a player back at the front of the queue when their request was
+// rate limited, so they are crawled once the limit clears instead of being dropped
+func (s *Spider) requeueOnRateLimit(puuid string, err error) {
+	if !errors.Is(err, riot.ErrRateLimited) {
+		return
 	}
-	return 0
+	s.playerQueueMu.Lock()
+	defer s.playerQueueMu.Unlock()
+	heap.Push(&s.playerQueue, queuedPlayer{puuid: puuid, priority: math.Inf(1), seq: -s.queueSeq})
+	s.queueSeq++
 }
 
 // min returns the minimum of two integers
@@ -720,11 +888,13 @@ func (s *Spider) printSummary() {
 	playerCount := atomic.LoadInt64(&s.activePlayerCount)
 	timelinesCollected := atomic.LoadInt64(&s.timelinesCollected)
 	playersSkipped := atomic.LoadInt64(&s.playersSkippedRank)
+	playersSkippedQuota := atomic.LoadInt64(&s.playersSkippedQuota)
 
 	fmt.Printf("\n=== Spider Complete ===\n")
 	fmt.Printf("Total time: %s\n", formatDuration(elapsed))
 	fmt.Printf("Players processed: %d (Emerald 4+)\n", playerCount)
 	fmt.Printf("Players skipped (below Emerald 4 / no rank): %d\n", playersSkipped)
+	fmt.Printf("Players skipped (bracket over quota): %d\n", playersSkippedQuota)
 	fmt.Printf("Matches written: %d\n", totalMatches)
 	fmt.Printf("Total records (participants): %d\n", totalMatches*10)
 
@@ -741,6 +911,25 @@ func (s *Spider) printSummary() {
 		matchesPerMin := float64(totalMatches) / elapsed.Minutes()
 		fmt.Printf("Throughput: %.1f matches/min\n", matchesPerMin)
 	}
+
+	fmt.Printf("Coverage %s\n", s.coverage.Report(s.client.Platform()))
+
+	hits, misses := s.rankCache.Stats()
+	fmt.Printf("Rank cache: %d hits, %d misses (%d players cached)\n", hits, misses, s.rankCache.Len())
+
+	// Matches per rank bracket against the quotas
+	for _, line := range s.brackets.lines() {
+		fmt.Printf("Bracket %s\n", line)
+	}
+
+	// Rate limit budget left, so production key throughput can be tuned
+	for _, b := range s.client.Budget() {
+		fmt.Printf("Rate budget %s %s: %d/%d per %s", b.Host, b.Bucket, b.Remaining, b.Limit, b.Window)
+		if b.Blocked > 0 {
+			fmt.Printf(" (blocked %s)", formatDuration(b.Blocked))
+		}
+		fmt.Println()
+	}
 }
 
 func formatDuration(d time.Duration) string {
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"data-analyzer/internal/riot"
+	"data-analyzer/internal/storage"
+	"ghostdraft/ddragon"
 
 	"github.com/bits-and-blooms/bloom/v3"
 	"github.com/joho/godotenv"
@@ -156,7 +158,7 @@ func TestDataCollection_InvalidPatch(t *testing.T) {
 	ctx := context.Background()
 
 	// Get current patch
-	currentPatch, err := riot.GetCurrentPatch(ctx)
+	currentPatch, err := riot.GetCurrentPatch(ctx, ddragon.New(ddragon.Options{}))
 	if err != nil {
 		t.Fatalf("GetCurrentPatch failed: %v", err)
 	}
@@ -318,3 +320,30 @@ func TestDataCollection_InvalidRateLimit(t *testing.T) {
 		t.Logf("Warning: %d requests completed in <10ms (rate limiter may not be enforcing minimum interval)", instantRequests)
 	}
 }
+
+func TestRawMatches_PlatformFromMatchID(t *testing.T) {
+	match := &riot.MatchResponse{}
+	match.Info.Participants = []riot.MatchParticipant{{ParticipantID: 1, PUUID: "a"}, {ParticipantID: 2, PUUID: "b"}}
+
+	tests := []struct {
+		matchID string
+		want    string
+	}{
+		{"EUW1_7012345678", "euw1"},
+		{"7012345678", "na1"},
+	}
+	for _, tt := range tests {
+		result := &MatchResult{Match: match, MatchID: tt.matchID, SourceTier: "EMERALD", BuildOrders: map[int][]int{2: {3020}}}
+		records := RawMatches(result, "na1")
+		if len(records) != 2 {
+			t.Fatalf("%s: expected 2 records, got %d", tt.matchID, len(records))
+		}
+		first, second := records[0].(*storage.RawMatch), records[1].(*storage.RawMatch)
+		if first.Platform != tt.want || first.SourceTier != "EMERALD" {
+			t.Errorf("%s: got platform %q tier %q, want %q EMERALD", tt.matchID, first.Platform, first.SourceTier, tt.want)
+		}
+		if len(first.BuildOrder) != 0 || len(second.BuildOrder) != 1 {
+			t.Errorf("%s: build orders not matched by participant: %v %v", tt.matchID, first.BuildOrder, second.BuildOrder)
+		}
+	}
+}
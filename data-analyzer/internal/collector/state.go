@@ -11,7 +11,7 @@ import (
 type State int32
 
 const (
-	StateStartup       State = iota // Initial state, seeding from Challenger
+	StateStartup       State = iota // Initial state, seeding from the ladder
 	StateCollecting                 // Actively collecting matches
 	StateReducing                   // Aggregating warm files, archiving to cold
 	StatePushing                    // Pushing aggregated data to Turso
@@ -24,8 +24,9 @@ func (p *TursoDataPusher) PushAggData(ctx context.Context, data *AggData) error
 		return nil
 	}
 
-	log.Printf("[TursoPusher] Starting push: %d champion stats, %d item stats, %d item slot stats, %d matchup stats",
-		len(data.ChampionStats), len(data.ItemStats), len(data.ItemSlotStats), len(data.MatchupStats))
+	log.Printf("[TursoPusher] Starting push: %d champion stats, %d item stats, %d item slot stats, %d matchup stats, %d arena stats",
+		len(data.ChampionStats), len(data.ItemStats), len(data.ItemSlotStats), len(data.MatchupStats),
+		len(data.ArenaStats)+len(data.ArenaAugments)+len(data.ArenaItems))
 
 	// Ensure tables exist
 	if err := p.client.CreateTables(ctx); err != nil {
@@ -37,22 +38,47 @@ func (p *TursoDataPusher) PushAggData(ctx context.Context, data *AggData) error
 		log.Printf("[TursoPusher] Warning: failed to drop indexes: %v", err)
 	}
 
+	if err := InsertAggData(ctx, p.client, data); err != nil {
+		return err
+	}
+
+	// Update data version
+	if data.DetectedPatch != "" {
+		if err := p.client.SetDataVersion(ctx, data.DetectedPatch); err != nil {
+			log.Printf("[TursoPusher] Warning: failed to set data version: %v", err)
+		}
+	}
+
+	// Recreate indexes
+	if err := p.client.CreateIndexes(ctx); err != nil {
+		log.Printf("[TursoPusher] Warning: failed to recreate indexes: %v", err)
+	}
+
+	log.Printf("[TursoPusher] Push complete for patch %s", data.DetectedPatch)
+	return nil
+}
+
+// InsertAggData upserts every aggregate in data into Turso's stats tables
+func InsertAggData(ctx context.Context, client *db.TursoClient, data *AggData) error {
 	// Push champion stats
 	if len(data.ChampionStats) > 0 {
 		stats := make([]db.ChampionStat, 0, len(data.ChampionStats))
 		for k, v := range data.ChampionStats {
 			stats = append(stats, db.ChampionStat{
 				Patch:        k.Patch,
+				QueueID:      k.QueueID,
+				Bracket:      k.Bracket,
+				Region:       k.Region,
 				ChampionID:   k.ChampionID,
 				TeamPosition: k.TeamPosition,
 				Wins:         v.Wins,
 				Matches:      v.Matches,
 			})
 		}
-		if err := p.client.InsertChampionStats(ctx, stats); err != nil {
+		if err := client.InsertChampionStats(ctx, stats); err != nil {
 			return fmt.Errorf("failed to insert champion stats: %w", err)
 		}
-		log.Printf("[TursoPusher] Inserted %d champion stats", len(stats))
+		log.Printf("[Turso] Inserted %d champion stats", len(stats))
 	}
 
 	// Push item stats
@@ -61,6 +87,9 @@ func (p *TursoDataPusher) PushAggData(ctx context.Context, data *AggData) error
 		for k, v := range data.ItemStats {
 			items = append(items, db.ChampionItem{
 				Patch:        k.Patch,
+				QueueID:      k.QueueID,
+				Bracket:      k.Bracket,
+				Region:       k.Region,
 				ChampionID:   k.ChampionID,
 				TeamPosition: k.TeamPosition,
 				ItemID:       k.ItemID,
@@ -68,10 +97,10 @@ func (p *TursoDataPusher) PushAggData(ctx context.Context, data *AggData) error
 				Matches:      v.Matches,
 			})
 		}
-		if err := p.client.InsertChampionItems(ctx, items); err != nil {
+		if err := client.InsertChampionItems(ctx, items); err != nil {
 			return fmt.Errorf("failed to insert champion items: %w", err)
 		}
-		log.Printf("[TursoPusher] Inserted %d item stats", len(items))
+		log.Printf("[Turso] Inserted %d item stats", len(items))
 	}
 
 	// Push item slot stats
@@ -80,6 +109,9 @@ func (p *TursoDataPusher) PushAggData(ctx context.Context, data *AggData) error
 		for k, v := range data.ItemSlotStats {
 			slots = append(slots, db.ChampionItemSlot{
 				Patch:        k.Patch,
+				QueueID:      k.QueueID,
+				Bracket:      k.Bracket,
+				Region:       k.Region,
 				ChampionID:   k.ChampionID,
 				TeamPosition: k.TeamPosition,
 				ItemID:       k.ItemID,
@@ -88,10 +120,10 @@ func (p *TursoDataPusher) PushAggData(ctx context.Context, data *AggData) error
 				Matches:      v.Matches,
 			})
 		}
-		if err := p.client.InsertChampionItemSlots(ctx, slots); err != nil {
+		if err := client.InsertChampionItemSlots(ctx, slots); err != nil {
 			return fmt.Errorf("failed to insert champion item slots: %w", err)
 		}
-		log.Printf("[TursoPusher] Inserted %d item slot stats", len(slots))
+		log.Printf("[Turso] Inserted %d item slot stats", len(slots))
 	}
 
 	// Push matchup stats
@@ -100,6 +132,9 @@ func (p *TursoDataPusher) PushAggData(ctx context.Context, data *AggData) error
 		for k, v := range data.MatchupStats {
 			matchups = append(matchups, db.ChampionMatchup{
 				Patch:           k.Patch,
+				QueueID:         k.QueueID,
+				Bracket:         k.Bracket,
+				Region:          k.Region,
 				ChampionID:      k.ChampionID,
 				TeamPosition:    k.TeamPosition,
 				EnemyChampionID: k.EnemyChampionID,
@@ -107,24 +142,58 @@ func (p *TursoDataPusher) PushAggData(ctx context.Context, data *AggData) error
 				Matches:         v.Matches,
 			})
 		}
-		if err := p.client.InsertChampionMatchups(ctx, matchups); err != nil {
+		if err := client.InsertChampionMatchups(ctx, matchups); err != nil {
 			return fmt.Errorf("failed to insert champion matchups: %w", err)
 		}
-		log.Printf("[TursoPusher] Inserted %d matchup stats", len(matchups))
+		log.Printf("[Turso] Inserted %d matchup stats", len(matchups))
 	}
 
-	// Update data version
-	if data.DetectedPatch != "" {
-		if err := p.client.SetDataVersion(ctx, data.DetectedPatch); err != nil {
-			log.Printf("[TursoPusher] Warning: failed to set data version: %v", err)
+	// Push Arena stats
+	if len(data.ArenaStats) > 0 {
+		stats := make([]db.ArenaStat, 0, len(data.ArenaStats))
+		for k, v := range data.ArenaStats {
+			stats = append(stats, arenaRow(k.Patch, k.ChampionID, 0, v))
+		}
+		if err := client.InsertArenaChampionStats(ctx, stats); err != nil {
+			return fmt.Errorf("failed to insert arena champion stats: %w", err)
 		}
+		log.Printf("[Turso] Inserted %d arena champion stats", len(stats))
 	}
 
-	// Recreate indexes
-	if err := p.client.CreateIndexes(ctx); err != nil {
-		log.Printf("[TursoPusher] Warning: failed to recreate indexes: %v", err)
+	if len(data.ArenaAugments) > 0 {
+		augments := make([]db.ArenaStat, 0, len(data.ArenaAugments))
+		for k, v := range data.ArenaAugments {
+			augments = append(augments, arenaRow(k.Patch, k.ChampionID, k.AugmentID, v))
+		}
+		if err := client.InsertArenaAugments(ctx, augments); err != nil {
+			return fmt.Errorf("failed to insert arena augments: %w", err)
+		}
+		log.Printf("[Turso] Inserted %d arena augment stats", len(augments))
+	}
+
+	if len(data.ArenaItems) > 0 {
+		items := make([]db.ArenaStat, 0, len(data.ArenaItems))
+		for k, v := range data.ArenaItems {
+			items = append(items, arenaRow(k.Patch, k.ChampionID, k.ItemID, v))
+		}
+		if err := client.InsertArenaItems(ctx, items); err != nil {
+			return fmt.Errorf("failed to insert arena items: %w", err)
+		}
+		log.Printf("[Turso] Inserted %d arena item stats", len(items))
 	}
 
-	log.Printf("[TursoPusher] Push complete for patch %s", data.DetectedPatch)
 	return nil
 }
+
+// arenaRow converts an Arena aggregate into a Turso row
+func arenaRow(patch string, championID, id int, v *ArenaStats) db.ArenaStat {
+	return db.ArenaStat{
+		Patch:        patch,
+		ChampionID:   championID,
+		ID:           id,
+		Wins:         v.Wins,
+		Matches:      v.Matches,
+		Firsts:       v.Firsts,
+		PlacementSum: v.PlacementSum,
+	}
+}
@@ -44,55 +44,118 @@ func (c *TursoClient) Close() error {
 	return c.db.Close()
 }
 
-// CreateTables creates the required tables if they don't exist (without indexes for bulk loading)
-func (c *TursoClient) CreateTables(ctx context.Context) error {
-	queries := []string{
-		`CREATE TABLE IF NOT EXISTS data_version (
+// tableDefinitions are the stats tables in creation order. The four Summoner's Rift/ARAM
+// tables are keyed by queue_id, rank bracket (empty when unknown) and region (empty when
+// regions are merged); Arena has its own tables with placements.
+var tableDefinitions = []struct{ name, schema string }{
+	{"data_version", `CREATE TABLE IF NOT EXISTS data_version (
 			id INTEGER PRIMARY KEY CHECK (id = 1),
 			patch TEXT NOT NULL,
 			updated_at TEXT NOT NULL
-		)`,
-		`CREATE TABLE IF NOT EXISTS champion_stats (
+		)`},
+	{"champion_stats", `CREATE TABLE IF NOT EXISTS champion_stats (
 			patch TEXT NOT NULL,
+			queue_id INTEGER NOT NULL DEFAULT 420,
+			bracket TEXT NOT NULL DEFAULT '',
+			region TEXT NOT NULL DEFAULT '',
 			champion_id INTEGER NOT NULL,
 			team_position TEXT NOT NULL,
 			wins INTEGER NOT NULL DEFAULT 0,
 			matches INTEGER NOT NULL DEFAULT 0,
-			PRIMARY KEY (patch, champion_id, team_position)
-		)`,
-		`CREATE TABLE IF NOT EXISTS champion_items (
+			PRIMARY KEY (patch, queue_id, bracket, region, champion_id, team_position)
+		)`},
+	{"champion_items", `CREATE TABLE IF NOT EXISTS champion_items (
 			patch TEXT NOT NULL,
+			queue_id INTEGER NOT NULL DEFAULT 420,
+			bracket TEXT NOT NULL DEFAULT '',
+			region TEXT NOT NULL DEFAULT '',
 			champion_id INTEGER NOT NULL,
 			team_position TEXT NOT NULL,
 			item_id INTEGER NOT NULL,
 			wins INTEGER NOT NULL DEFAULT 0,
 			matches INTEGER NOT NULL DEFAULT 0,
-			PRIMARY KEY (patch, champion_id, team_position, item_id)
-		)`,
-		`CREATE TABLE IF NOT EXISTS champion_item_slots (
+			PRIMARY KEY (patch, queue_id, bracket, region, champion_id, team_position, item_id)
+		)`},
+	{"champion_item_slots", `CREATE TABLE IF NOT EXISTS champion_item_slots (
 			patch TEXT NOT NULL,
+			queue_id INTEGER NOT NULL DEFAULT 420,
+			bracket TEXT NOT NULL DEFAULT '',
+			region TEXT NOT NULL DEFAULT '',
 			champion_id INTEGER NOT NULL,
 			team_position TEXT NOT NULL,
 			item_id INTEGER NOT NULL,
 			build_slot INTEGER NOT NULL,
 			wins INTEGER NOT NULL DEFAULT 0,
 			matches INTEGER NOT NULL DEFAULT 0,
-			PRIMARY KEY (patch, champion_id, team_position, item_id, build_slot)
-		)`,
-		`CREATE TABLE IF NOT EXISTS champion_matchups (
+			PRIMARY KEY (patch, queue_id, bracket, region, champion_id, team_position, item_id, build_slot)
+		)`},
+	{"champion_matchups", `CREATE TABLE IF NOT EXISTS champion_matchups (
 			patch TEXT NOT NULL,
+			queue_id INTEGER NOT NULL DEFAULT 420,
+			bracket TEXT NOT NULL DEFAULT '',
+			region TEXT NOT NULL DEFAULT '',
 			champion_id INTEGER NOT NULL,
 			team_position TEXT NOT NULL,
 			enemy_champion_id INTEGER NOT NULL,
 			wins INTEGER NOT NULL DEFAULT 0,
 			matches INTEGER NOT NULL DEFAULT 0,
-			PRIMARY KEY (patch, champion_id, team_position, enemy_champion_id)
-		)`,
-		// Note: Indexes are created separately via CreateIndexes() for bulk loading optimization
-	}
+			PRIMARY KEY (patch, queue_id, bracket, region, champion_id, team_position, enemy_champion_id)
+		)`},
+	// Arena: wins are top four finishes, placement_sum / matches is the average placement
+	{"arena_champion_stats", `CREATE TABLE IF NOT EXISTS arena_champion_stats (
+			patch TEXT NOT NULL,
+			champion_id INTEGER NOT NULL,
+			wins INTEGER NOT NULL DEFAULT 0,
+			matches INTEGER NOT NULL DEFAULT 0,
+			firsts INTEGER NOT NULL DEFAULT 0,
+			placement_sum INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (patch, champion_id)
+		)`},
+	{"arena_augments", `CREATE TABLE IF NOT EXISTS arena_augments (
+			patch TEXT NOT NULL,
+			champion_id INTEGER NOT NULL,
+			augment_id INTEGER NOT NULL,
+			wins INTEGER NOT NULL DEFAULT 0,
+			matches INTEGER NOT NULL DEFAULT 0,
+			firsts INTEGER NOT NULL DEFAULT 0,
+			placement_sum INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (patch, champion_id, augment_id)
+		)`},
+	{"arena_items", `CREATE TABLE IF NOT EXISTS arena_items (
+			patch TEXT NOT NULL,
+			champion_id INTEGER NOT NULL,
+			item_id INTEGER NOT NULL,
+			wins INTEGER NOT NULL DEFAULT 0,
+			matches INTEGER NOT NULL DEFAULT 0,
+			firsts INTEGER NOT NULL DEFAULT 0,
+			placement_sum INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (patch, champion_id, item_id)
+		)`},
+	// Note: Indexes are created separately via CreateIndexes() for bulk loading optimization
+}
 
-	for _, query := range queries {
-		if _, err := c.db.ExecContext(ctx, query); err != nil {
+// addedKeyColumns are the key columns stats tables gained after they were first created.
+// Rows from before take the columns' defaults: Ranked Solo/Duo, no known bracket, all regions.
+var addedKeyColumns = map[string][]string{
+	"champion_stats":      {"queue_id", "bracket", "region"},
+	"champion_items":      {"queue_id", "bracket", "region"},
+	"champion_item_slots": {"queue_id", "bracket", "region"},
+	"champion_matchups":   {"queue_id", "bracket", "region"},
+}
+
+// statsTables are the tables holding per-patch stats (everything but data_version)
+var statsTables = []string{
+	"champion_stats", "champion_items", "champion_item_slots", "champion_matchups",
+	"arena_champion_stats", "arena_augments", "arena_items",
+}
+
+// CreateTables creates the required tables if they don't exist (without indexes for bulk loading)
+func (c *TursoClient) CreateTables(ctx context.Context) error {
+	for _, table := range tableDefinitions {
+		if err := c.migrateKey(ctx, table.name, table.schema); err != nil {
+			return fmt.Errorf("failed to migrate %s: %w", table.name, err)
+		}
+		if _, err := c.db.ExecContext(ctx, table.schema); err != nil {
 			return fmt.Errorf("failed to execute query: %w", err)
 		}
 	}
@@ -100,6 +163,67 @@ func (c *TursoClient) CreateTables(ctx context.Context) error {
 	return nil
 }
 
+// migrateKey rebuilds a stats table created before some of its key columns existed,
+// copying its rows over. SQLite can't change a primary key in place.
+func (c *TursoClient) migrateKey(ctx context.Context, table, schema string) error {
+	added, ok := addedKeyColumns[table]
+	if !ok {
+		return nil
+	}
+
+	rows, err := c.db.QueryContext(ctx, `SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return err
+	}
+	var columns []string
+	have := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		columns = append(columns, name)
+		have[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	missing := false
+	for _, column := range added {
+		if !have[column] {
+			missing = true
+		}
+	}
+	if len(columns) == 0 || !missing {
+		return nil
+	}
+
+	fmt.Printf("Adding %s to %s...\n", strings.Join(added, ", "), table)
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	old := table + "_before_migration"
+	copied := strings.Join(columns, ", ")
+	queries := []string{
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", table, old),
+		schema,
+		fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s", table, copied, copied, old),
+		fmt.Sprintf("DROP TABLE %s", old),
+	}
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 // CreateTablesWithIndexes creates tables and indexes (for normal operation, not bulk loading)
 func (c *TursoClient) CreateTablesWithIndexes(ctx context.Context) error {
 	if err := c.CreateTables(ctx); err != nil {
@@ -116,7 +240,7 @@ func (c *TursoClient) ClearData(ctx context.Context) error {
 	}
 	defer tx.Rollback()
 
-	tables := []string{"data_version", "champion_stats", "champion_items", "champion_item_slots", "champion_matchups"}
+	tables := append([]string{"data_version"}, statsTables...)
 	for _, table := range tables {
 		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s", table)); err != nil {
 			return fmt.Errorf("failed to clear %s: %w", table, err)
@@ -137,6 +261,9 @@ func (c *TursoClient) SetDataVersion(ctx context.Context, patch string) error {
 // ChampionStat represents a champion stat row
 type ChampionStat struct {
 	Patch        string
+	QueueID      int
+	Bracket      string
+	Region       string
 	ChampionID   int
 	TeamPosition string
 	Wins         int
@@ -146,6 +273,9 @@ type ChampionStat struct {
 // ChampionItem represents a champion item row
 type ChampionItem struct {
 	Patch        string
+	QueueID      int
+	Bracket      string
+	Region       string
 	ChampionID   int
 	TeamPosition string
 	ItemID       int
@@ -156,6 +286,9 @@ type ChampionItem struct {
 // ChampionItemSlot represents a champion item slot row
 type ChampionItemSlot struct {
 	Patch        string
+	QueueID      int
+	Bracket      string
+	Region       string
 	ChampionID   int
 	TeamPosition string
 	ItemID       int
@@ -167,6 +300,9 @@ type ChampionItemSlot struct {
 // ChampionMatchup represents a champion matchup row
 type ChampionMatchup struct {
 	Patch           string
+	QueueID         int
+	Bracket         string
+	Region          string
 	ChampionID      int
 	TeamPosition    string
 	EnemyChampionID int
@@ -197,16 +333,16 @@ func (c *TursoClient) InsertChampionStats(ctx context.Context, stats []ChampionS
 
 		// Build multi-value INSERT: INSERT INTO table VALUES (?,?,?), (?,?,?), ...
 		placeholders := make([]string, len(batch))
-		args := make([]interface{}, 0, len(batch)*5)
+		args := make([]interface{}, 0, len(batch)*8)
 
 		for j, s := range batch {
-			placeholders[j] = "(?, ?, ?, ?, ?)"
-			args = append(args, s.Patch, s.ChampionID, s.TeamPosition, s.Wins, s.Matches)
+			placeholders[j] = "(?, ?, ?, ?, ?, ?, ?, ?)"
+			args = append(args, s.Patch, s.QueueID, s.Bracket, s.Region, s.ChampionID, s.TeamPosition, s.Wins, s.Matches)
 		}
 
 		query := fmt.Sprintf(
-			`INSERT INTO champion_stats (patch, champion_id, team_position, wins, matches) VALUES %s
-			ON CONFLICT(patch, champion_id, team_position) DO UPDATE SET
+			`INSERT INTO champion_stats (patch, queue_id, bracket, region, champion_id, team_position, wins, matches) VALUES %s
+			ON CONFLICT(patch, queue_id, bracket, region, champion_id, team_position) DO UPDATE SET
 				wins = wins + excluded.wins,
 				matches = matches + excluded.matches`,
 			strings.Join(placeholders, ", "))
@@ -239,16 +375,16 @@ func (c *TursoClient) InsertChampionItems(ctx context.Context, items []ChampionI
 		batch := items[i:end]
 
 		placeholders := make([]string, len(batch))
-		args := make([]interface{}, 0, len(batch)*6)
+		args := make([]interface{}, 0, len(batch)*9)
 
 		for j, item := range batch {
-			placeholders[j] = "(?, ?, ?, ?, ?, ?)"
-			args = append(args, item.Patch, item.ChampionID, item.TeamPosition, item.ItemID, item.Wins, item.Matches)
+			placeholders[j] = "(?, ?, ?, ?, ?, ?, ?, ?, ?)"
+			args = append(args, item.Patch, item.QueueID, item.Bracket, item.Region, item.ChampionID, item.TeamPosition, item.ItemID, item.Wins, item.Matches)
 		}
 
 		query := fmt.Sprintf(
-			`INSERT INTO champion_items (patch, champion_id, team_position, item_id, wins, matches) VALUES %s
-			ON CONFLICT(patch, champion_id, team_position, item_id) DO UPDATE SET
+			`INSERT INTO champion_items (patch, queue_id, bracket, region, champion_id, team_position, item_id, wins, matches) VALUES %s
+			ON CONFLICT(patch, queue_id, bracket, region, champion_id, team_position, item_id) DO UPDATE SET
 				wins = wins + excluded.wins,
 				matches = matches + excluded.matches`,
 			strings.Join(placeholders, ", "))
@@ -281,16 +417,16 @@ func (c *TursoClient) InsertChampionItemSlots(ctx context.Context, slots []Champ
 		batch := slots[i:end]
 
 		placeholders := make([]string, len(batch))
-		args := make([]interface{}, 0, len(batch)*7)
+		args := make([]interface{}, 0, len(batch)*10)
 
 		for j, slot := range batch {
-			placeholders[j] = "(?, ?, ?, ?, ?, ?, ?)"
-			args = append(args, slot.Patch, slot.ChampionID, slot.TeamPosition, slot.ItemID, slot.BuildSlot, slot.Wins, slot.Matches)
+			placeholders[j] = "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+			args = append(args, slot.Patch, slot.QueueID, slot.Bracket, slot.Region, slot.ChampionID, slot.TeamPosition, slot.ItemID, slot.BuildSlot, slot.Wins, slot.Matches)
 		}
 
 		query := fmt.Sprintf(
-			`INSERT INTO champion_item_slots (patch, champion_id, team_position, item_id, build_slot, wins, matches) VALUES %s
-			ON CONFLICT(patch, champion_id, team_position, item_id, build_slot) DO UPDATE SET
+			`INSERT INTO champion_item_slots (patch, queue_id, bracket, region, champion_id, team_position, item_id, build_slot, wins, matches) VALUES %s
+			ON CONFLICT(patch, queue_id, bracket, region, champion_id, team_position, item_id, build_slot) DO UPDATE SET
 				wins = wins + excluded.wins,
 				matches = matches + excluded.matches`,
 			strings.Join(placeholders, ", "))
@@ -323,16 +459,16 @@ func (c *TursoClient) InsertChampionMatchups(ctx context.Context, matchups []Cha
 		batch := matchups[i:end]
 
 		placeholders := make([]string, len(batch))
-		args := make([]interface{}, 0, len(batch)*6)
+		args := make([]interface{}, 0, len(batch)*9)
 
 		for j, m := range batch {
-			placeholders[j] = "(?, ?, ?, ?, ?, ?)"
-			args = append(args, m.Patch, m.ChampionID, m.TeamPosition, m.EnemyChampionID, m.Wins, m.Matches)
+			placeholders[j] = "(?, ?, ?, ?, ?, ?, ?, ?, ?)"
+			args = append(args, m.Patch, m.QueueID, m.Bracket, m.Region, m.ChampionID, m.TeamPosition, m.EnemyChampionID, m.Wins, m.Matches)
 		}
 
 		query := fmt.Sprintf(
-			`INSERT INTO champion_matchups (patch, champion_id, team_position, enemy_champion_id, wins, matches) VALUES %s
-			ON CONFLICT(patch, champion_id, team_position, enemy_champion_id) DO UPDATE SET
+			`INSERT INTO champion_matchups (patch, queue_id, bracket, region, champion_id, team_position, enemy_champion_id, wins, matches) VALUES %s
+			ON CONFLICT(patch, queue_id, bracket, region, champion_id, team_position, enemy_champion_id) DO UPDATE SET
 				wins = wins + excluded.wins,
 				matches = matches + excluded.matches`,
 			strings.Join(placeholders, ", "))
@@ -345,6 +481,89 @@ func (c *TursoClient) InsertChampionMatchups(ctx context.Context, matchups []Cha
 	return tx.Commit()
 }
 
+// ArenaStat represents a row of arena_champion_stats (ID unused), arena_augments
+// (ID is the augment) or arena_items (ID is the item)
+type ArenaStat struct {
+	Patch        string
+	ChampionID   int
+	ID           int
+	Wins         int
+	Matches      int
+	Firsts       int
+	PlacementSum int
+}
+
+// InsertArenaChampionStats inserts Arena champion stats using upsert
+func (c *TursoClient) InsertArenaChampionStats(ctx context.Context, stats []ArenaStat) error {
+	return c.insertArenaStats(ctx, "arena_champion_stats", "", stats)
+}
+
+// InsertArenaAugments inserts Arena augment stats using upsert
+func (c *TursoClient) InsertArenaAugments(ctx context.Context, stats []ArenaStat) error {
+	return c.insertArenaStats(ctx, "arena_augments", "augment_id", stats)
+}
+
+// InsertArenaItems inserts Arena item stats using upsert
+func (c *TursoClient) InsertArenaItems(ctx context.Context, stats []ArenaStat) error {
+	return c.insertArenaStats(ctx, "arena_items", "item_id", stats)
+}
+
+// insertArenaStats upserts rows into an Arena table; idColumn is the column holding
+// ArenaStat.ID, or empty for arena_champion_stats
+func (c *TursoClient) insertArenaStats(ctx context.Context, table, idColumn string, stats []ArenaStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+
+	keyColumns := "patch, champion_id"
+	row := "(?, ?, ?, ?, ?, ?)"
+	if idColumn != "" {
+		keyColumns += ", " + idColumn
+		row = "(?, ?, ?, ?, ?, ?, ?)"
+	}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i := 0; i < len(stats); i += batchSize {
+		end := i + batchSize
+		if end > len(stats) {
+			end = len(stats)
+		}
+		batch := stats[i:end]
+
+		placeholders := make([]string, len(batch))
+		args := make([]interface{}, 0, len(batch)*7)
+
+		for j, s := range batch {
+			placeholders[j] = row
+			args = append(args, s.Patch, s.ChampionID)
+			if idColumn != "" {
+				args = append(args, s.ID)
+			}
+			args = append(args, s.Wins, s.Matches, s.Firsts, s.PlacementSum)
+		}
+
+		query := fmt.Sprintf(
+			`INSERT INTO %s (%s, wins, matches, firsts, placement_sum) VALUES %s
+			ON CONFLICT(%s) DO UPDATE SET
+				wins = wins + excluded.wins,
+				matches = matches + excluded.matches,
+				firsts = firsts + excluded.firsts,
+				placement_sum = placement_sum + excluded.placement_sum`,
+			table, keyColumns, strings.Join(placeholders, ", "), keyColumns)
+
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 // GetDataVersion returns the current data version from the database
 func (c *TursoClient) GetDataVersion(ctx context.Context) (string, error) {
 	var version string
@@ -360,13 +579,15 @@ func (c *TursoClient) GetDataVersion(ctx context.Context) (string, error) {
 
 // Index definitions for bulk loading optimization
 var indexDefinitions = []string{
-	`CREATE INDEX IF NOT EXISTS idx_champion_stats_position ON champion_stats(team_position)`,
-	`CREATE INDEX IF NOT EXISTS idx_champion_stats_champ_pos ON champion_stats(champion_id, team_position)`,
-	`CREATE INDEX IF NOT EXISTS idx_champion_items_champ_pos ON champion_items(champion_id, team_position)`,
-	`CREATE INDEX IF NOT EXISTS idx_champion_item_slots_champ_pos ON champion_item_slots(champion_id, team_position)`,
-	`CREATE INDEX IF NOT EXISTS idx_champion_item_slots_champ_pos_slot ON champion_item_slots(champion_id, team_position, build_slot)`,
-	`CREATE INDEX IF NOT EXISTS idx_champion_matchups_champ_pos ON champion_matchups(champion_id, team_position)`,
+	`CREATE INDEX IF NOT EXISTS idx_champion_stats_position ON champion_stats(team_position, queue_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_champion_stats_champ_pos ON champion_stats(champion_id, team_position, queue_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_champion_items_champ_pos ON champion_items(champion_id, team_position, queue_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_champion_item_slots_champ_pos ON champion_item_slots(champion_id, team_position, queue_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_champion_item_slots_champ_pos_slot ON champion_item_slots(champion_id, team_position, build_slot, queue_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_champion_matchups_champ_pos ON champion_matchups(champion_id, team_position, queue_id)`,
 	`CREATE INDEX IF NOT EXISTS idx_champion_matchups_enemy ON champion_matchups(champion_id, team_position, enemy_champion_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_arena_augments_champ ON arena_augments(champion_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_arena_items_champ ON arena_items(champion_id)`,
 }
 
 var indexNames = []string{
@@ -377,6 +598,8 @@ var indexNames = []string{
 	"idx_champion_item_slots_champ_pos_slot",
 	"idx_champion_matchups_champ_pos",
 	"idx_champion_matchups_enemy",
+	"idx_arena_augments_champ",
+	"idx_arena_items_champ",
 }
 
 // DropIndexes drops all indexes for faster bulk inserts
@@ -413,10 +636,9 @@ func (c *TursoClient) DeleteOldPatches(ctx context.Context, minPatch string) (in
 	}
 	defer tx.Rollback()
 
-	tables := []string{"champion_stats", "champion_items", "champion_item_slots", "champion_matchups"}
 	var totalDeleted int64
 
-	for _, table := range tables {
+	for _, table := range statsTables {
 		result, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE patch < ?", table), minPatch)
 		if err != nil {
 			return 0, fmt.Errorf("failed to delete from %s: %w", table, err)
@@ -10,6 +10,8 @@ import (
 	"strconv"
 	"time"
 
+	"data-analyzer/internal/riot"
+
 	json "github.com/goccy/go-json"
 )
 
@@ -25,6 +27,9 @@ const (
 
 	// Number of messages to fetch per poll
 	defaultMessageLimit = 3
+
+	// Endpoint named in API errors
+	endpointMessages = "discord.messages"
 )
 
 // Regex pattern to match Riot API keys
@@ -174,7 +179,7 @@ func (f *KeyFinder) SendMessage(ctx context.Context, content string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("Discord API returned status %d", resp.StatusCode)
+		return riot.NewAPIError(endpointMessages, resp)
 	}
 
 	return nil
@@ -204,7 +209,7 @@ func (f *KeyFinder) SendEmbed(ctx context.Context, payload WebhookPayload) error
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("Discord API returned status %d", resp.StatusCode)
+		return riot.NewAPIError(endpointMessages, resp)
 	}
 
 	return nil
@@ -247,7 +252,7 @@ func (f *KeyFinder) fetchMessages(ctx context.Context) ([]DiscordMessage, error)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Discord API returned status %d", resp.StatusCode)
+		return nil, riot.NewAPIError(endpointMessages, resp)
 	}
 
 	var messages []DiscordMessage
@@ -8,6 +8,8 @@ import (
 	"strconv"
 	"time"
 
+	"data-analyzer/internal/riot"
+
 	json "github.com/goccy/go-json"
 )
 
@@ -19,6 +21,9 @@ const (
 	// Default timeout for webhook requests
 	defaultWebhookTimeout = 10 * time.Second
 
+	// Endpoint named in API errors
+	endpointWebhook = "discord.webhook"
+
 	// Max retries for rate limiting
 	maxRetries = 3
 )
@@ -104,7 +109,7 @@ func NewSessionStartedPayload(apiKey string, seedPlayer string) WebhookPayload {
 					},
 				},
 				Footer: &EmbedFooter{
-					Text: "Fresh crawl beginning from top of ladder",
+					Text: "Crawl resumes from its saved frontier, or the top of the ladder",
 				},
 			},
 		},
@@ -183,10 +188,13 @@ func (c *WebhookClient) sendPayload(ctx context.Context, payload WebhookPayload)
 		}
 
 		// Other error
-		return fmt.Errorf("webhook request failed with status %d", resp.StatusCode)
+		return riot.NewAPIError(endpointWebhook, resp)
 	}
 
-	return fmt.Errorf("webhook request failed after %d retries", maxRetries)
+	return fmt.Errorf("webhook request failed after %d retries: %w", maxRetries, &riot.APIError{
+		StatusCode: http.StatusTooManyRequests,
+		Endpoint:   endpointWebhook,
+	})
 }
 
 // formatNumber formats a number with commas (e.g., 47832 -> "47,832")
@@ -9,6 +9,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"data-analyzer/internal/riot"
 )
 
 // TestKeyExpiredPayload_Format tests that the key expired payload matches expected Discord embed format
@@ -215,6 +217,9 @@ func TestWebhookClient_WebhookError(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error for bad request")
 	}
+	if riot.StatusCode(err) != http.StatusBadRequest {
+		t.Errorf("Expected an API error with status 400, got: %v", err)
+	}
 }
 
 // TestWebhookClient_NetworkError tests handling of network errors
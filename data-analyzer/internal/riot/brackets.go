@@ -0,0 +1,51 @@
+package riot
+
+// Rank brackets stats are split by. Everything collected is Emerald IV or higher.
+const (
+	BracketEmerald    = "emerald"
+	BracketDiamond    = "diamond"
+	BracketMasterPlus = "master_plus" // Master, Grandmaster and Challenger
+)
+
+// Brackets lists the rank brackets from lowest to highest
+var Brackets = []string{BracketEmerald, BracketDiamond, BracketMasterPlus}
+
+// TierBracket returns the stats bracket for a solo queue tier, or "" below Emerald
+// (and for unknown tiers)
+func TierBracket(tier string) string {
+	idx, ok := TierOrder[tier]
+	switch {
+	case !ok || idx < TierOrder["EMERALD"]:
+		return ""
+	case idx == TierOrder["EMERALD"]:
+		return BracketEmerald
+	case idx == TierOrder["DIAMOND"]:
+		return BracketDiamond
+	default:
+		return BracketMasterPlus
+	}
+}
+
+// RankScore returns a rank as the number of divisions above Iron IV, so ranks can be
+// averaged. Master+ tiers count as the tier's first division. ok is false for unknown ranks.
+func RankScore(tier, division string) (score int, ok bool) {
+	idx, ok := TierOrder[tier]
+	if !ok {
+		return 0, false
+	}
+	return idx*4 + DivisionOrder[division], true
+}
+
+// ScoreTier converts a (possibly averaged) rank score back to its tier
+func ScoreTier(score int) string {
+	idx := score / 4
+	for tier, i := range TierOrder {
+		if i == idx {
+			return tier
+		}
+	}
+	if idx > TierOrder["CHALLENGER"] {
+		return "CHALLENGER"
+	}
+	return "IRON"
+}
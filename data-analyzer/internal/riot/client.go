@@ -9,30 +9,43 @@ import (
 	"sync"
 	"time"
 
+	"ghostdraft/ddragon"
 	json "github.com/goccy/go-json"
 )
 
 const (
-	// API base URLs
-	americasBaseURL = "https://americas.api.riotgames.com"
-	na1BaseURL      = "https://na1.api.riotgames.com" // Regional URL for summoner/league endpoints
-
-	// Rate limit settings (conservative: 90 req/2min instead of 100)
-	maxRequestsPer2Min = 90
-	rateLimitWindow    = 2 * time.Minute
-	minRequestInterval = 50 * time.Millisecond // Max ~20 req/sec
+	// Platform the key validator checks against (keys work on every platform)
+	na1BaseURL = "https://na1.api.riotgames.com"
+
+	// Retries of a rate limited (429) request before giving up
+	maxRateLimitRetries = 3
 )
 
-// Client is a Riot API client that handles 429 rate limit responses
+// Client is a Riot API client for one platform that handles 429 rate limit responses.
+// Rate limits are shared with every other client sending to the same platform or cluster.
 type Client struct {
-	apiKey       string
-	httpClient   *http.Client
-	requestTimes []time.Time
-	mu           sync.Mutex
+	apiKey     string
+	platform   string // e.g. "na1", for league-v4
+	region     string // e.g. "americas", for match-v5 and account-v1
+	httpClient *http.Client
+	limiters   *rateLimiters
+	baseURL    string // replaces the API host when set (tests)
+	mu         sync.Mutex
 }
 
-// NewClient creates a new Riot API client
+// NewClient creates a new Riot API client for NA
 func NewClient() (*Client, error) {
+	return NewClientForPlatform(PlatformNA1)
+}
+
+// NewClientForPlatform creates a new Riot API client for a platform (e.g. "euw1")
+func NewClientForPlatform(platform string) (*Client, error) {
+	platform = strings.ToLower(platform)
+	region, ok := RegionForPlatform(platform)
+	if !ok {
+		return nil, fmt.Errorf("unknown platform %q", platform)
+	}
+
 	apiKey := os.Getenv("RIOT_API_KEY")
 	if apiKey == "" {
 		// Also check alternative env var name
@@ -48,14 +61,26 @@ func NewClient() (*Client, error) {
 	}
 
 	return &Client{
-		apiKey: apiKey,
+		apiKey:   apiKey,
+		platform: platform,
+		region:   region,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		requestTimes: make([]time.Time, 0, maxRequestsPer2Min),
+		limiters: sharedLimiters,
 	}, nil
 }
 
+// Platform returns the platform the client reads players and ranks from
+func (c *Client) Platform() string {
+	return c.platform
+}
+
+// Region returns the regional cluster the client reads matches from
+func (c *Client) Region() string {
+	return c.region
+}
+
 // SetAPIKey updates the API key used for requests
 func (c *Client) SetAPIKey(key string) {
 	c.mu.Lock()
@@ -66,155 +91,127 @@ func (c *Client) SetAPIKey(key string) {
 	}
 }
 
-// rateLimit implements a sliding window rate limiter
-func (c *Client) rateLimit() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	now := time.Now()
-	windowStart := now.Add(-rateLimitWindow)
+// Budget returns how much of each rate limit window of the client's platform and cluster
+// is left, as of the last response. Other clients on the same cluster share its windows.
+func (c *Client) Budget() []RateBudget {
+	return c.limiters.budget(c.platform, c.region, accountRegion(c.region))
+}
 
-	// Remove requests outside the 2-minute window
-	validTimes := make([]time.Time, 0, len(c.requestTimes))
-	for _, t := range c.requestTimes {
-		if t.After(windowStart) {
-			validTimes = append(validTimes, t)
-		}
-	}
-	c.requestTimes = validTimes
-
-	// If at limit, wait until oldest request expires
-	if len(c.requestTimes) >= maxRequestsPer2Min {
-		oldestRequest := c.requestTimes[0]
-		waitUntil := oldestRequest.Add(rateLimitWindow)
-		sleepDuration := time.Until(waitUntil)
-		if sleepDuration > 0 {
-			fmt.Printf("      [Rate Limit] At %d/%d requests, waiting %.1fs...\n",
-				len(c.requestTimes), maxRequestsPer2Min, sleepDuration.Seconds())
-			c.mu.Unlock()
-			time.Sleep(sleepDuration + 100*time.Millisecond) // Small buffer
-			c.mu.Lock()
-			// Re-clean after sleeping
-			now = time.Now()
-			windowStart = now.Add(-rateLimitWindow)
-			validTimes = make([]time.Time, 0, len(c.requestTimes))
-			for _, t := range c.requestTimes {
-				if t.After(windowStart) {
-					validTimes = append(validTimes, t)
-				}
-			}
-			c.requestTimes = validTimes
+// doRequest makes a request for a rate limited method. It waits for the method's and the
+// app's budget on the URL's platform or cluster, and on a 429 waits out Retry-After and retries up to maxRateLimitRetries times.
+// Error responses are returned as *APIError.
+func (c *Client) doRequest(ctx context.Context, method, url string, result interface{}) error {
+	limiter := c.limiters.host(routingHost(url))
+	if c.baseURL != "" {
+		if _, path, ok := strings.Cut(url, ".api.riotgames.com"); ok {
+			url = c.baseURL + path
 		}
 	}
 
-	// Enforce minimum interval between requests (20 req/sec max)
-	if len(c.requestTimes) > 0 {
-		lastRequest := c.requestTimes[len(c.requestTimes)-1]
-		elapsed := time.Since(lastRequest)
-		if elapsed < minRequestInterval {
-			c.mu.Unlock()
-			time.Sleep(minRequestInterval - elapsed)
-			c.mu.Lock()
+	for attempt := 0; ; attempt++ {
+		if err := limiter.wait(ctx, method); err != nil {
+			return err
 		}
-	}
-
-	// Record this request
-	c.requestTimes = append(c.requestTimes, time.Now())
-}
 
-// doRequest makes a request and handles 429 rate limit responses
-func (c *Client) doRequest(ctx context.Context, url string, result interface{}) error {
-	// Smart rate limiting
-	c.rateLimit()
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("X-Riot-Token", c.apiKey)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return err
+		}
+		c.mu.Lock()
+		req.Header.Set("X-Riot-Token", c.apiKey)
+		c.mu.Unlock()
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		limiter.update(method, resp.Header)
 
-	if resp.StatusCode == 429 {
-		// Rate limited - wait 30 seconds and retry
-		fmt.Printf("      [429 Rate Limited] Waiting 30 seconds...\n")
-		time.Sleep(30 * time.Second)
-		return c.doRequest(ctx, url, result)
-	}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			resp.Body.Close()
+			apiErr := NewAPIError(method, resp)
+			if attempt >= maxRateLimitRetries {
+				return fmt.Errorf("still rate limited after %d retries: %w", attempt, apiErr)
+			}
+			wait := retryAfter(resp.Header, attempt)
+			fmt.Printf("      [429 Rate Limited] %s (%s limit), waiting %.1fs...\n", method, apiErr.LimitScope, wait.Seconds())
+			limiter.block(method, apiErr.LimitScope, wait)
+			continue
+		}
 
-	if resp.StatusCode == 403 {
-		return fmt.Errorf("API returned 403 Forbidden - check if your API key is valid")
-	}
+		defer resp.Body.Close()
 
-	if resp.StatusCode == 404 {
-		return fmt.Errorf("API returned 404 Not Found - player/match may not exist")
-	}
+		if resp.StatusCode != http.StatusOK {
+			return NewAPIError(method, resp)
+		}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API returned status %d", resp.StatusCode)
+		return json.NewDecoder(resp.Body).Decode(result)
 	}
-
-	return json.NewDecoder(resp.Body).Decode(result)
 }
 
 // GetAccountByRiotID fetches account info by Riot ID (gameName#tagLine)
 func (c *Client) GetAccountByRiotID(ctx context.Context, gameName, tagLine string) (*AccountResponse, error) {
 	url := fmt.Sprintf("%s/riot/account/v1/accounts/by-riot-id/%s/%s",
-		americasBaseURL, gameName, tagLine)
+		regionURL(accountRegion(c.region)), gameName, tagLine)
 
 	var account AccountResponse
-	err := c.doRequest(ctx, url, &account)
+	err := c.doRequest(ctx, MethodAccount, url, &account)
 	return &account, err
 }
 
 // GetAccountByPUUID fetches account info by PUUID
 func (c *Client) GetAccountByPUUID(ctx context.Context, puuid string) (*AccountResponse, error) {
 	url := fmt.Sprintf("%s/riot/account/v1/accounts/by-puuid/%s",
-		americasBaseURL, puuid)
+		regionURL(accountRegion(c.region)), puuid)
 
 	var account AccountResponse
-	err := c.doRequest(ctx, url, &account)
+	err := c.doRequest(ctx, MethodAccount, url, &account)
 	return &account, err
 }
 
-// GetMatchHistory fetches match IDs for a player
+// GetMatchHistory fetches a player's most recent match IDs across all queues
 func (c *Client) GetMatchHistory(ctx context.Context, puuid string, count int) ([]string, error) {
-	url := fmt.Sprintf("%s/lol/match/v5/matches/by-puuid/%s/ids?queue=420&count=%d",
-		americasBaseURL, puuid, count)
+	url := fmt.Sprintf("%s/lol/match/v5/matches/by-puuid/%s/ids?count=%d",
+		regionURL(c.region), puuid, count)
 
 	var matchIDs []string
-	err := c.doRequest(ctx, url, &matchIDs)
+	err := c.doRequest(ctx, MethodMatchIDs, url, &matchIDs)
 	return matchIDs, err
 }
 
 // GetMatch fetches match details
 func (c *Client) GetMatch(ctx context.Context, matchID string) (*MatchResponse, error) {
-	url := fmt.Sprintf("%s/lol/match/v5/matches/%s", americasBaseURL, matchID)
+	url := fmt.Sprintf("%s/lol/match/v5/matches/%s", c.matchURL(matchID), matchID)
 
 	var match MatchResponse
-	err := c.doRequest(ctx, url, &match)
+	err := c.doRequest(ctx, MethodMatch, url, &match)
 	return &match, err
 }
 
+// matchURL returns the host for a match: the cluster of the platform in its ID, which
+// can differ from the client's when a player transferred
+func (c *Client) matchURL(matchID string) string {
+	if region, ok := RegionForPlatform(MatchPlatform(matchID)); ok {
+		return regionURL(region)
+	}
+	return regionURL(c.region)
+}
+
 // GetTimeline fetches match timeline
 func (c *Client) GetTimeline(ctx context.Context, matchID string) (*TimelineResponse, error) {
-	url := fmt.Sprintf("%s/lol/match/v5/matches/%s/timeline", americasBaseURL, matchID)
+	url := fmt.Sprintf("%s/lol/match/v5/matches/%s/timeline", c.matchURL(matchID), matchID)
 
 	var timeline TimelineResponse
-	err := c.doRequest(ctx, url, &timeline)
+	err := c.doRequest(ctx, MethodTimeline, url, &timeline)
 	return &timeline, err
 }
 
 // GetRankedEntriesByPUUID fetches ranked entries directly by PUUID
 func (c *Client) GetRankedEntriesByPUUID(ctx context.Context, puuid string) ([]LeagueEntryResponse, error) {
-	url := fmt.Sprintf("%s/lol/league/v4/entries/by-puuid/%s", na1BaseURL, puuid)
+	url := fmt.Sprintf("%s/lol/league/v4/entries/by-puuid/%s", platformURL(c.platform), puuid)
 
 	var entries []LeagueEntryResponse
-	err := c.doRequest(ctx, url, &entries)
+	err := c.doRequest(ctx, MethodLeague, url, &entries)
 	return entries, err
 }
 
@@ -256,11 +253,39 @@ type ChallengerLeagueEntry struct {
 
 // GetChallengerLeague fetches the challenger league for solo queue
 func (c *Client) GetChallengerLeague(ctx context.Context) (*ChallengerLeagueResponse, error) {
-	url := fmt.Sprintf("%s/lol/league/v4/challengerleagues/by-queue/RANKED_SOLO_5x5", na1BaseURL)
+	return c.GetApexLeague(ctx, "CHALLENGER")
+}
+
+// apexLeagues maps the tiers without divisions to their league-v4 path and rate limited method
+var apexLeagues = map[string]struct{ path, method string }{
+	"CHALLENGER":  {"challengerleagues", MethodChallenger},
+	"GRANDMASTER": {"grandmasterleagues", MethodGrandmaster},
+	"MASTER":      {"masterleagues", MethodMaster},
+}
 
-	var league ChallengerLeagueResponse
-	err := c.doRequest(ctx, url, &league)
-	return &league, err
+// GetApexLeague fetches the whole solo queue league of a tier without divisions
+// (CHALLENGER, GRANDMASTER or MASTER)
+func (c *Client) GetApexLeague(ctx context.Context, tier string) (*ChallengerLeagueResponse, error) {
+	league, ok := apexLeagues[tier]
+	if !ok {
+		return nil, fmt.Errorf("%s is not an apex tier", tier)
+	}
+	url := fmt.Sprintf("%s/lol/league/v4/%s/by-queue/RANKED_SOLO_5x5", platformURL(c.platform), league.path)
+
+	var resp ChallengerLeagueResponse
+	err := c.doRequest(ctx, league.method, url, &resp)
+	return &resp, err
+}
+
+// GetLeagueEntries fetches one page (1-based, about 200 players) of a solo queue
+// division, e.g. DIAMOND II. A page past the end is empty.
+func (c *Client) GetLeagueEntries(ctx context.Context, tier, division string, page int) ([]LeagueEntryResponse, error) {
+	url := fmt.Sprintf("%s/lol/league/v4/entries/RANKED_SOLO_5x5/%s/%s?page=%d",
+		platformURL(c.platform), tier, division, page)
+
+	var entries []LeagueEntryResponse
+	err := c.doRequest(ctx, MethodDivision, url, &entries)
+	return entries, err
 }
 
 // GetTopChallengerPUUID fetches the PUUID of a top challenger player
@@ -290,37 +315,15 @@ func (c *Client) GetTopChallengerPUUID(ctx context.Context) (string, error) {
 	return topPlayer.PUUID, nil
 }
 
-// GetCurrentPatch fetches the current game patch from Data Dragon
-func GetCurrentPatch(ctx context.Context) (string, error) {
-	url := "https://ddragon.leagueoflegends.com/api/versions.json"
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+// GetCurrentPatch returns the current game patch from Data Dragon, read through dd's cache
+func GetCurrentPatch(ctx context.Context, dd *ddragon.Client) (string, error) {
+	latest, err := dd.Latest(ctx)
 	if err != nil {
 		return "", err
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Data Dragon returned status %d", resp.StatusCode)
-	}
-
-	var versions []string
-	if err := json.NewDecoder(resp.Body).Decode(&versions); err != nil {
-		return "", err
-	}
-
-	if len(versions) == 0 {
-		return "", fmt.Errorf("no versions returned from Data Dragon")
-	}
-
 	// Return normalized patch (e.g., "14.24.1" -> "14.24")
-	return NormalizePatch(versions[0]), nil
+	return NormalizePatch(latest), nil
 }
 
 // NormalizePatch converts a full version string to major.minor format
@@ -333,8 +336,17 @@ func NormalizePatch(version string) string {
 	return version
 }
 
+// LoadItemCatalog loads the item catalog for a patch (e.g. "15.1"); an empty patch means the latest
+func LoadItemCatalog(ctx context.Context, dd *ddragon.Client, patch string) (*ddragon.Catalog, error) {
+	version, err := dd.Resolve(ctx, patch)
+	if err != nil {
+		return nil, err
+	}
+	return dd.Catalog(ctx, version, ddragon.DefaultLocale)
+}
+
 // ExtractBuildOrder extracts item purchase order for a participant from timeline
-func ExtractBuildOrder(timeline *TimelineResponse, participantID int) []int {
+func ExtractBuildOrder(timeline *TimelineResponse, participantID int, items *ddragon.Catalog) []int {
 	var buildOrder []int
 	seenItems := make(map[int]bool)
 
@@ -342,7 +354,7 @@ func ExtractBuildOrder(timeline *TimelineResponse, participantID int) []int {
 		for _, event := range frame.Events {
 			if event.Type == "ITEM_PURCHASED" && event.ParticipantID == participantID {
 				// Only include completed items, skip duplicates
-				if IsCompletedItem(event.ItemID) && !seenItems[event.ItemID] {
+				if items.IsCompleted(event.ItemID) && !seenItems[event.ItemID] {
 					buildOrder = append(buildOrder, event.ItemID)
 					seenItems[event.ItemID] = true
 				}
@@ -0,0 +1,78 @@
+package riot
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Error categories an APIError matches with errors.Is
+var (
+	ErrKeyExpired  = errors.New("api key expired or invalid") // 401, 403
+	ErrNotFound    = errors.New("not found")                  // 404
+	ErrRateLimited = errors.New("rate limited")               // 429 after retrying
+	ErrServer      = errors.New("server error")               // 5xx
+)
+
+// APIError is a failed API response. The riot client returns it for every non-OK status;
+// the discord package returns it for Discord's.
+type APIError struct {
+	StatusCode int
+	Endpoint   string        // rate limited method or endpoint, e.g. "match-v5.match"
+	RetryAfter time.Duration // from Retry-After on a 429, 0 if absent
+	LimitScope string        // X-Rate-Limit-Type on a 429: "application", "method" or "service"
+}
+
+// NewAPIError builds an APIError from a response, reading the rate limit headers of a 429
+func NewAPIError(endpoint string, resp *http.Response) *APIError {
+	e := &APIError{StatusCode: resp.StatusCode, Endpoint: endpoint}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		e.RetryAfter, _ = parseRetryAfter(resp.Header)
+		e.LimitScope = resp.Header.Get("X-Rate-Limit-Type")
+	}
+	return e
+}
+
+// Error describes the status; key errors say so, since they stop the collector
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s: API returned %d %s", e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode))
+	switch {
+	case errors.Is(e, ErrKeyExpired):
+		msg += " - check if the API key or token is valid"
+	case errors.Is(e, ErrNotFound):
+		msg += " - player/match may not exist"
+	case e.StatusCode == http.StatusTooManyRequests:
+		if e.LimitScope != "" {
+			msg += " (" + e.LimitScope + " limit)"
+		}
+		if e.RetryAfter > 0 {
+			msg += fmt.Sprintf(", retry after %s", e.RetryAfter)
+		}
+	}
+	return msg
+}
+
+// Is reports whether the error is in a category (ErrKeyExpired, ErrNotFound, ...)
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrKeyExpired:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// StatusCode returns the HTTP status of an APIError anywhere in err's chain, or 0
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+package riot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestAPIError_Categories tests that errors.Is matches each status to its category
+func TestAPIError_Categories(t *testing.T) {
+	categories := []error{ErrKeyExpired, ErrNotFound, ErrRateLimited, ErrServer}
+	tests := []struct {
+		status int
+		want   error // nil for no category
+	}{
+		{http.StatusUnauthorized, ErrKeyExpired},
+		{http.StatusForbidden, ErrKeyExpired},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusInternalServerError, ErrServer},
+		{http.StatusServiceUnavailable, ErrServer},
+		{http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		// Wrapped the way callers wrap it
+		err := fmt.Errorf("failed to get ranked entries: %w", &APIError{StatusCode: tt.status, Endpoint: MethodLeague})
+		for _, category := range categories {
+			if got := errors.Is(err, category); got != (category == tt.want) {
+				t.Errorf("status %d: errors.Is(%v) = %v", tt.status, category, got)
+			}
+		}
+		if got := StatusCode(err); got != tt.status {
+			t.Errorf("StatusCode = %d, want %d", got, tt.status)
+		}
+	}
+
+	if StatusCode(errors.New("connection reset")) != 0 {
+		t.Error("StatusCode of a non-API error should be 0")
+	}
+}
+
+// TestDoRequest_APIError tests that error responses come back as an APIError naming the method
+func TestDoRequest_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).GetMatch(context.Background(), "NA1_1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusForbidden || apiErr.Endpoint != MethodMatch {
+		t.Errorf("APIError = %+v, want 403 from %s", apiErr, MethodMatch)
+	}
+	if !errors.Is(err, ErrKeyExpired) {
+		t.Error("403 should be a key error")
+	}
+}
+
+// TestDoRequest_RateLimitedError tests that an exhausted rate limit keeps its scope and Retry-After
+func TestDoRequest_RateLimitedError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		w.Header().Set("X-Rate-Limit-Type", "service")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).GetTimeline(context.Background(), "NA1_1")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("err = %v, want ErrRateLimited", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.LimitScope != "service" || apiErr.RetryAfter != 0 {
+		t.Errorf("APIError = %+v, want service scope with no wait", apiErr)
+	}
+}
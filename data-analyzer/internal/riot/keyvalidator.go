@@ -90,6 +90,6 @@ func (v *KeyValidator) ValidateKey(ctx context.Context, apiKey string) (bool, er
 
 	default:
 		// Server error or unexpected response - we can't determine if key is valid
-		return false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return false, NewAPIError("lol-status-v4", resp)
 	}
 }
@@ -0,0 +1,81 @@
+package riot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetApexLeague tests that each apex tier is read from its own league endpoint
+func TestGetApexLeague(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tiers := map[string]string{
+			"/lol/league/v4/challengerleagues/by-queue/RANKED_SOLO_5x5":  "CHALLENGER",
+			"/lol/league/v4/grandmasterleagues/by-queue/RANKED_SOLO_5x5": "GRANDMASTER",
+			"/lol/league/v4/masterleagues/by-queue/RANKED_SOLO_5x5":      "MASTER",
+		}
+		tier, ok := tiers[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(ChallengerLeagueResponse{
+			Tier:    tier,
+			Entries: []ChallengerLeagueEntry{{PUUID: "puuid-" + tier, Rank: "I"}},
+		})
+	}))
+	defer server.Close()
+	client := newTestClient(server)
+
+	for _, tier := range []string{"CHALLENGER", "GRANDMASTER", "MASTER"} {
+		league, err := client.GetApexLeague(context.Background(), tier)
+		if err != nil {
+			t.Fatalf("GetApexLeague(%s): %v", tier, err)
+		}
+		if league.Tier != tier || len(league.Entries) != 1 || league.Entries[0].PUUID != "puuid-"+tier {
+			t.Errorf("GetApexLeague(%s) = %+v", tier, league)
+		}
+	}
+
+	if _, err := client.GetApexLeague(context.Background(), "DIAMOND"); err == nil {
+		t.Error("Expected an error for a tier with divisions")
+	}
+}
+
+// TestGetLeagueEntries tests that a division page is requested and decoded with PUUIDs
+func TestGetLeagueEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/lol/league/v4/entries/RANKED_SOLO_5x5/DIAMOND/II" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("page") != "3" {
+			json.NewEncoder(w).Encode([]LeagueEntryResponse{})
+			return
+		}
+		json.NewEncoder(w).Encode([]LeagueEntryResponse{
+			{PUUID: "a", Tier: "DIAMOND", Rank: "II"},
+			{PUUID: "b", Tier: "DIAMOND", Rank: "II"},
+		})
+	}))
+	defer server.Close()
+	client := newTestClient(server)
+
+	entries, err := client.GetLeagueEntries(context.Background(), "DIAMOND", "II", 3)
+	if err != nil {
+		t.Fatalf("GetLeagueEntries: %v", err)
+	}
+	if len(entries) != 2 || entries[0].PUUID != "a" || entries[1].Rank != "II" {
+		t.Errorf("GetLeagueEntries = %+v", entries)
+	}
+
+	entries, err = client.GetLeagueEntries(context.Background(), "DIAMOND", "II", 4)
+	if err != nil {
+		t.Fatalf("GetLeagueEntries past the end: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected an empty page past the end, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,45 @@
+package riot
+
+// Queue IDs (match-v5 info.queueId) the collector keeps stats for
+const (
+	QueueDraft      = 400  // Normal Draft
+	QueueRankedSolo = 420  // Ranked Solo/Duo
+	QueueRankedFlex = 440  // Ranked Flex
+	QueueARAM       = 450  // ARAM
+	QueueSwiftplay  = 480  // Swiftplay
+	QueueArena      = 1700 // Arena
+)
+
+// StatsQueues are the queues whose matches are written and aggregated; other queues
+// (customs, bots, rotating modes) are skipped
+var StatsQueues = []int{QueueDraft, QueueRankedSolo, QueueRankedFlex, QueueARAM, QueueSwiftplay, QueueArena}
+
+// IsStatsQueue reports whether matches from a queue are collected
+func IsStatsQueue(queueID int) bool {
+	for _, id := range StatsQueues {
+		if id == queueID {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPositions reports whether a queue is played on Summoner's Rift with lane positions,
+// so it gets per-position stats and matchups
+func HasPositions(queueID int) bool {
+	switch queueID {
+	case QueueDraft, QueueRankedSolo, QueueRankedFlex, QueueSwiftplay:
+		return true
+	default:
+		return false
+	}
+}
+
+// RecordQueue returns the queue of a stored match record. Records written before the
+// queue was stored are all Ranked Solo/Duo, the only queue collected back then.
+func RecordQueue(queueID int) int {
+	if queueID == 0 {
+		return QueueRankedSolo
+	}
+	return queueID
+}
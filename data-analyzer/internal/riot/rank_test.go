@@ -232,3 +232,38 @@ func TestGetSoloQueueRank_Integration(t *testing.T) {
 		})
 	}
 }
+
+func TestTierBracket(t *testing.T) {
+	tests := map[string]string{
+		"EMERALD":     BracketEmerald,
+		"DIAMOND":     BracketDiamond,
+		"MASTER":      BracketMasterPlus,
+		"GRANDMASTER": BracketMasterPlus,
+		"CHALLENGER":  BracketMasterPlus,
+		"PLATINUM":    "",
+		"":            "",
+	}
+	for tier, want := range tests {
+		if got := TierBracket(tier); got != want {
+			t.Errorf("TierBracket(%q) = %q, want %q", tier, got, want)
+		}
+	}
+}
+
+func TestRankScore_AveragesBackToTier(t *testing.T) {
+	emerald1, _ := RankScore("EMERALD", "I")
+	diamond2, _ := RankScore("DIAMOND", "II")
+	master, _ := RankScore("MASTER", "")
+	if !(emerald1 < diamond2 && diamond2 < master) {
+		t.Errorf("scores out of order: %d, %d, %d", emerald1, diamond2, master)
+	}
+	if got := ScoreTier((emerald1 + diamond2 + master) / 3); got != "DIAMOND" {
+		t.Errorf("average of Emerald I, Diamond II and Master = %s, want DIAMOND", got)
+	}
+	if _, ok := RankScore("UNRANKED", ""); ok {
+		t.Error("expected unknown tiers to have no score")
+	}
+	if got := ScoreTier(1000); got != "CHALLENGER" {
+		t.Errorf("ScoreTier above Challenger = %s", got)
+	}
+}
@@ -0,0 +1,336 @@
+package riot
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rate limited API methods. Riot limits each method separately on top of the app limit.
+const (
+	MethodAccount     = "account-v1"
+	MethodMatchIDs    = "match-v5.ids"
+	MethodMatch       = "match-v5.match"
+	MethodTimeline    = "match-v5.timeline"
+	MethodLeague      = "league-v4.entries"
+	MethodDivision    = "league-v4.division"
+	MethodChallenger  = "league-v4.challenger"
+	MethodGrandmaster = "league-v4.grandmaster"
+	MethodMaster      = "league-v4.master"
+)
+
+// defaultAppLimit is a development key's app limit, used until a response reports the real one
+const defaultAppLimit = "20:1,100:120"
+
+// appBucket names the app-wide bucket in budgets
+const appBucket = "app"
+
+// rateWindow is one "limit:seconds" pair of a rate limit and the requests sent in it
+type rateWindow struct {
+	limit  int
+	window time.Duration
+	sent   []time.Time // oldest first
+}
+
+// prune drops requests that have left the window
+func (w *rateWindow) prune(now time.Time) {
+	cutoff := now.Add(-w.window)
+	i := 0
+	for i < len(w.sent) && !w.sent[i].After(cutoff) {
+		i++
+	}
+	w.sent = w.sent[i:]
+}
+
+// delay returns how long until the window has room for another request
+func (w *rateWindow) delay(now time.Time) time.Duration {
+	w.prune(now)
+	if len(w.sent) < w.limit {
+		return 0
+	}
+	// Wait for enough of the oldest requests to leave the window
+	return w.sent[len(w.sent)-w.limit].Add(w.window).Sub(now)
+}
+
+// rateBucket is the set of windows one limit (the app's or a method's) enforces
+type rateBucket struct {
+	windows      []*rateWindow
+	blockedUntil time.Time // from Retry-After on a 429
+}
+
+// delay returns how long until every window has room and any block has passed
+func (b *rateBucket) delay(now time.Time) time.Duration {
+	wait := b.blockedUntil.Sub(now)
+	for _, w := range b.windows {
+		if d := w.delay(now); d > wait {
+			wait = d
+		}
+	}
+	return max(wait, 0)
+}
+
+// record counts a request sent now in every window
+func (b *rateBucket) record(now time.Time) {
+	for _, w := range b.windows {
+		w.sent = append(w.sent, now)
+	}
+}
+
+// sync applies a limit header ("20:1,100:120") and its count header ("3:1,40:120").
+// Windows keep the requests already logged; when Riot counted more (other processes
+// sharing the key, or requests from before a restart), the difference is logged as sent now.
+func (b *rateBucket) sync(limits, counts string, now time.Time) {
+	parsed := parseRateHeader(limits)
+	if len(parsed) == 0 {
+		return
+	}
+
+	existing := make(map[time.Duration]*rateWindow, len(b.windows))
+	for _, w := range b.windows {
+		existing[w.window] = w
+	}
+	windows := make([]*rateWindow, 0, len(parsed))
+	for window, limit := range parsed {
+		w := existing[window]
+		if w == nil {
+			w = &rateWindow{window: window}
+		}
+		w.limit = limit
+		windows = append(windows, w)
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i].window < windows[j].window })
+	b.windows = windows
+
+	for window, count := range parseRateHeader(counts) {
+		w := existing[window]
+		if w == nil {
+			for _, nw := range windows {
+				if nw.window == window {
+					w = nw
+				}
+			}
+		}
+		if w == nil {
+			continue
+		}
+		w.prune(now)
+		for len(w.sent) < count {
+			w.sent = append(w.sent, now)
+		}
+	}
+}
+
+// parseRateHeader parses "limit:seconds" pairs into limits by window length, skipping
+// malformed pairs
+func parseRateHeader(header string) map[time.Duration]int {
+	result := make(map[time.Duration]int)
+	for _, pair := range strings.Split(header, ",") {
+		value, seconds, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			continue
+		}
+		n, err1 := strconv.Atoi(value)
+		s, err2 := strconv.Atoi(seconds)
+		if err1 != nil || err2 != nil || s <= 0 {
+			continue
+		}
+		result[time.Duration(s)*time.Second] = n
+	}
+	return result
+}
+
+// RateBudget is how much of one rate limit window is left
+type RateBudget struct {
+	Host      string // routing value, e.g. "na1" or "americas"
+	Bucket    string // "app" or a method, e.g. "match-v5.match"
+	Window    time.Duration
+	Limit     int
+	Remaining int
+	Blocked   time.Duration // time left on a Retry-After block, 0 if none
+}
+
+// rateLimiter enforces the app limit and the per-method limits Riot reports in each
+// response's headers
+type rateLimiter struct {
+	mu      sync.Mutex
+	app     *rateBucket
+	methods map[string]*rateBucket
+	now     func() time.Time
+}
+
+// newRateLimiter returns a limiter enforcing a development key's app limit until the
+// first response reports the real limits. Method limits are unknown until then.
+func newRateLimiter() *rateLimiter {
+	l := &rateLimiter{
+		app:     &rateBucket{},
+		methods: make(map[string]*rateBucket),
+		now:     time.Now,
+	}
+	l.app.sync(defaultAppLimit, "", l.now())
+	return l
+}
+
+// method returns a method's bucket, creating it if needed; the caller holds l.mu
+func (l *rateLimiter) method(name string) *rateBucket {
+	b, ok := l.methods[name]
+	if !ok {
+		b = &rateBucket{}
+		l.methods[name] = b
+	}
+	return b
+}
+
+// wait blocks until both the app and the method have room for a request, then counts
+// it. It returns the context's error if ctx ends first.
+func (l *rateLimiter) wait(ctx context.Context, method string) error {
+	for {
+		l.mu.Lock()
+		now := l.now()
+		m := l.method(method)
+		delay := max(l.app.delay(now), m.delay(now))
+		if delay == 0 {
+			l.app.record(now)
+			m.record(now)
+			l.mu.Unlock()
+			return nil
+		}
+		l.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// update applies the rate limit headers of a response to method
+func (l *rateLimiter) update(method string, header http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.app.sync(header.Get("X-App-Rate-Limit"), header.Get("X-App-Rate-Limit-Count"), now)
+	l.method(method).sync(header.Get("X-Method-Rate-Limit"), header.Get("X-Method-Rate-Limit-Count"), now)
+}
+
+// block holds back requests after a 429 for retryAfter. An application limit blocks
+// every method; method and service limits block only the method.
+func (l *rateLimiter) block(method, limitType string, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := l.method(method)
+	if limitType == "application" {
+		b = l.app
+	}
+	if until := l.now().Add(retryAfter); until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+}
+
+// budget returns what's left of every known window, the app's first, then by method
+func (l *rateLimiter) budget() []RateBudget {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+
+	names := make([]string, 0, len(l.methods))
+	for name := range l.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []RateBudget
+	add := func(name string, b *rateBucket) {
+		blocked := max(b.blockedUntil.Sub(now), 0)
+		for _, w := range b.windows {
+			w.prune(now)
+			result = append(result, RateBudget{
+				Bucket:    name,
+				Window:    w.window,
+				Limit:     w.limit,
+				Remaining: max(w.limit-len(w.sent), 0),
+				Blocked:   blocked,
+			})
+		}
+	}
+	add(appBucket, l.app)
+	for _, name := range names {
+		add(name, l.methods[name])
+	}
+	return result
+}
+
+// rateLimiters holds a limiter per routing value. Riot counts a key's requests per
+// platform (league-v4) and per cluster (match-v5, account-v1), so platforms in the same
+// cluster share its limiter.
+type rateLimiters struct {
+	mu    sync.Mutex
+	hosts map[string]*rateLimiter
+}
+
+// sharedLimiters are the limiters every client sends through
+var sharedLimiters = newRateLimiters()
+
+// newRateLimiters returns an empty set of limiters
+func newRateLimiters() *rateLimiters {
+	return &rateLimiters{hosts: make(map[string]*rateLimiter)}
+}
+
+// host returns the limiter of a routing value, creating it if needed
+func (r *rateLimiters) host(name string) *rateLimiter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l, ok := r.hosts[name]
+	if !ok {
+		l = newRateLimiter()
+		r.hosts[name] = l
+	}
+	return l
+}
+
+// budget returns what's left of the windows of each host that has sent requests, in order
+func (r *rateLimiters) budget(hosts ...string) []RateBudget {
+	var result []RateBudget
+	seen := make(map[string]bool, len(hosts))
+	for _, name := range hosts {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		r.mu.Lock()
+		l, ok := r.hosts[name]
+		r.mu.Unlock()
+		if !ok {
+			continue
+		}
+		for _, b := range l.budget() {
+			b.Host = name
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// parseRetryAfter reads a 429's Retry-After header (seconds)
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header.Get("Retry-After")))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// retryAfter returns how long to wait after a 429: Retry-After, or without one (usually
+// a service limit) an exponential backoff from one second
+func retryAfter(header http.Header, attempt int) time.Duration {
+	if d, ok := parseRetryAfter(header); ok {
+		return d
+	}
+	return time.Second << min(attempt, 5)
+}
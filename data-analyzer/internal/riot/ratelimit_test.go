@@ -0,0 +1,266 @@
+package riot
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns an NA client whose requests go to a fake Riot server
+func newTestClient(server *httptest.Server) *Client {
+	return newPlatformTestClient(server, PlatformNA1, newRateLimiters())
+}
+
+// newPlatformTestClient returns a client for a platform that sends through limiters to a
+// fake Riot server
+func newPlatformTestClient(server *httptest.Server, platform string, limiters *rateLimiters) *Client {
+	region, _ := RegionForPlatform(platform)
+	return &Client{
+		apiKey:     "RGAPI-test-key",
+		platform:   platform,
+		region:     region,
+		httpClient: server.Client(),
+		limiters:   limiters,
+		baseURL:    server.URL,
+	}
+}
+
+// findBudget returns the budget of one host's bucket window
+func findBudget(budgets []RateBudget, host, bucket string, window time.Duration) (RateBudget, bool) {
+	for _, b := range budgets {
+		if b.Host == host && b.Bucket == bucket && b.Window == window {
+			return b, true
+		}
+	}
+	return RateBudget{}, false
+}
+
+// TestRateLimiter_HeadersSetBudget tests that the limits and counts Riot reports replace the defaults
+func TestRateLimiter_HeadersSetBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-App-Rate-Limit", "500:10,30000:600")
+		w.Header().Set("X-App-Rate-Limit-Count", "1:10,40:600")
+		w.Header().Set("X-Method-Rate-Limit", "2000:10")
+		w.Header().Set("X-Method-Rate-Limit-Count", "1:10")
+		w.Write([]byte(`{"metadata":{"matchId":"NA1_1"}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	if _, err := client.GetMatch(context.Background(), "NA1_1"); err != nil {
+		t.Fatalf("GetMatch: %v", err)
+	}
+
+	budgets := client.Budget()
+	if _, ok := findBudget(budgets, RegionAmericas, appBucket, time.Second); ok {
+		t.Error("development key window should be replaced by the reported limits")
+	}
+	app, ok := findBudget(budgets, RegionAmericas, appBucket, 600*time.Second)
+	if !ok || app.Limit != 30000 || app.Remaining != 30000-40 {
+		t.Errorf("app 600s budget = %+v, want limit 30000 with 29960 remaining", app)
+	}
+	method, ok := findBudget(budgets, RegionAmericas, MethodMatch, 10*time.Second)
+	if !ok || method.Limit != 2000 || method.Remaining != 1999 {
+		t.Errorf("match budget = %+v, want limit 2000 with 1999 remaining", method)
+	}
+}
+
+// TestRateLimiter_MethodBuckets tests that an exhausted method doesn't hold back other methods
+func TestRateLimiter_MethodBuckets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-App-Rate-Limit", "100:1")
+		if strings.Contains(r.URL.Path, "/timeline") {
+			w.Header().Set("X-Method-Rate-Limit", "1:60")
+			w.Header().Set("X-Method-Rate-Limit-Count", "1:60")
+		} else {
+			w.Header().Set("X-Method-Rate-Limit", "100:60")
+			w.Header().Set("X-Method-Rate-Limit-Count", "1:60")
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	ctx := context.Background()
+	if _, err := client.GetTimeline(ctx, "NA1_1"); err != nil {
+		t.Fatalf("GetTimeline: %v", err)
+	}
+
+	// The timeline method is used up for a minute, matches are not
+	start := time.Now()
+	for range 3 {
+		if _, err := client.GetMatch(ctx, "NA1_1"); err != nil {
+			t.Fatalf("GetMatch: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("matches waited %v behind the timeline limit", elapsed)
+	}
+
+	shortCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetTimeline(shortCtx, "NA1_2"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetTimeline with an exhausted method: err = %v, want deadline exceeded", err)
+	}
+}
+
+// TestRateLimiter_SharedPerHost tests that platforms in one cluster share its limits while
+// keeping their own platform limits
+func TestRateLimiter_SharedPerHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-App-Rate-Limit", "100:10")
+		w.Header().Set("X-App-Rate-Limit-Count", "1:10")
+		if strings.Contains(r.URL.Path, "/lol/match/") {
+			w.Header().Set("X-Method-Rate-Limit", "1:60")
+			w.Header().Set("X-Method-Rate-Limit-Count", "1:60")
+			w.Write([]byte(`{"metadata":{"matchId":"NA1_1"}}`))
+			return
+		}
+		w.Header().Set("X-Method-Rate-Limit", "100:60")
+		w.Write([]byte(`{"entries":[]}`))
+	}))
+	defer server.Close()
+
+	limiters := newRateLimiters()
+	na := newPlatformTestClient(server, PlatformNA1, limiters)
+	br := newPlatformTestClient(server, PlatformBR1, limiters)
+	ctx := context.Background()
+
+	if _, err := na.GetMatch(ctx, "NA1_1"); err != nil {
+		t.Fatalf("GetMatch: %v", err)
+	}
+	if _, err := na.GetChallengerLeague(ctx); err != nil {
+		t.Fatalf("NA GetChallengerLeague: %v", err)
+	}
+
+	// BR has its own platform limits
+	if _, err := br.GetChallengerLeague(ctx); err != nil {
+		t.Fatalf("BR GetChallengerLeague: %v", err)
+	}
+	if _, ok := findBudget(br.Budget(), PlatformNA1, appBucket, 10*time.Second); ok {
+		t.Error("BR budget reports NA's platform limits")
+	}
+	app, ok := findBudget(br.Budget(), PlatformBR1, appBucket, 10*time.Second)
+	if !ok || app.Remaining != 99 {
+		t.Errorf("BR app budget = %+v, want 99 remaining after its one league request", app)
+	}
+
+	// ...but the match method NA used up on americas holds BR back too
+	match, ok := findBudget(br.Budget(), RegionAmericas, MethodMatch, time.Minute)
+	if !ok || match.Remaining != 0 {
+		t.Errorf("BR americas match budget = %+v, want the one NA used up", match)
+	}
+	shortCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := br.GetMatch(shortCtx, "BR1_1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("BR GetMatch with the cluster's method used up: err = %v, want deadline exceeded", err)
+	}
+}
+
+// TestRoutingHost tests reading the routing value from API URLs
+func TestRoutingHost(t *testing.T) {
+	if got := routingHost(platformURL(PlatformEUW1) + "/lol/league/v4/entries"); got != PlatformEUW1 {
+		t.Errorf("routingHost(platform URL) = %q, want %q", got, PlatformEUW1)
+	}
+	if got := routingHost(regionURL(RegionEurope) + "/lol/match/v5/matches/EUW1_1"); got != RegionEurope {
+		t.Errorf("routingHost(region URL) = %q, want %q", got, RegionEurope)
+	}
+}
+
+// TestDoRequest_RetryAfter tests that a 429 waits out Retry-After and then succeeds
+func TestDoRequest_RetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	var first, second time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.Header().Set("X-Rate-Limit-Type", "method")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		second = time.Now()
+		w.Write([]byte(`["NA1_1","NA1_2"]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	ids, err := client.GetMatchHistory(context.Background(), "puuid", 2)
+	if err != nil {
+		t.Fatalf("GetMatchHistory: %v", err)
+	}
+	if len(ids) != 2 {
+		t.Errorf("got %d match IDs, want 2", len(ids))
+	}
+	if calls.Load() != 2 {
+		t.Errorf("server got %d requests, want 2", calls.Load())
+	}
+	if gap := second.Sub(first); gap < time.Second {
+		t.Errorf("retried after %v, want at least the 1s Retry-After", gap)
+	}
+}
+
+// TestDoRequest_RetriesExhausted tests that a request that keeps getting 429 gives up
+func TestDoRequest_RetriesExhausted(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.Header().Set("X-Rate-Limit-Type", "application")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	_, err := client.GetRankedEntriesByPUUID(context.Background(), "puuid")
+	if err == nil || !strings.Contains(err.Error(), "429") {
+		t.Fatalf("err = %v, want a 429 error", err)
+	}
+	if got := calls.Load(); got != maxRateLimitRetries+1 {
+		t.Errorf("server got %d requests, want %d", got, maxRateLimitRetries+1)
+	}
+}
+
+// TestDoRequest_ContextCancelledWhileBlocked tests that a long Retry-After doesn't outlive the context
+func TestDoRequest_ContextCancelledWhileBlocked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "120")
+		w.Header().Set("X-Rate-Limit-Type", "application")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetChallengerLeague(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("returned after %v, want soon after the context ended", elapsed)
+	}
+
+	app, ok := findBudget(client.Budget(), PlatformNA1, appBucket, time.Second)
+	if !ok || app.Blocked <= 0 {
+		t.Errorf("app budget = %+v, want it blocked by the application limit", app)
+	}
+}
+
+// TestParseRateHeader tests parsing limit and count headers
+func TestParseRateHeader(t *testing.T) {
+	got := parseRateHeader("20:1, 100:120,bad,5:x")
+	if len(got) != 2 || got[time.Second] != 20 || got[120*time.Second] != 100 {
+		t.Errorf("parseRateHeader = %v, want 20 per 1s and 100 per 120s", got)
+	}
+	if got := parseRateHeader(""); len(got) != 0 {
+		t.Errorf("parseRateHeader(empty) = %v, want none", got)
+	}
+}
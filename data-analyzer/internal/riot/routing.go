@@ -0,0 +1,123 @@
+package riot
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Platform routing values: the server a player's account and ranked entries live on
+const (
+	PlatformNA1  = "na1"
+	PlatformBR1  = "br1"
+	PlatformLA1  = "la1"
+	PlatformLA2  = "la2"
+	PlatformEUW1 = "euw1"
+	PlatformEUN1 = "eun1"
+	PlatformTR1  = "tr1"
+	PlatformRU   = "ru"
+	PlatformME1  = "me1"
+	PlatformKR   = "kr"
+	PlatformJP1  = "jp1"
+	PlatformOC1  = "oc1"
+	PlatformPH2  = "ph2"
+	PlatformSG2  = "sg2"
+	PlatformTH2  = "th2"
+	PlatformTW2  = "tw2"
+	PlatformVN2  = "vn2"
+)
+
+// Regional routing values: the clusters match-v5 and account-v1 are served from
+const (
+	RegionAmericas = "americas"
+	RegionEurope   = "europe"
+	RegionAsia     = "asia"
+	RegionSEA      = "sea"
+)
+
+// platformRegions maps each platform to the regional cluster holding its matches
+var platformRegions = map[string]string{
+	PlatformNA1:  RegionAmericas,
+	PlatformBR1:  RegionAmericas,
+	PlatformLA1:  RegionAmericas,
+	PlatformLA2:  RegionAmericas,
+	PlatformEUW1: RegionEurope,
+	PlatformEUN1: RegionEurope,
+	PlatformTR1:  RegionEurope,
+	PlatformRU:   RegionEurope,
+	PlatformME1:  RegionEurope,
+	PlatformKR:   RegionAsia,
+	PlatformJP1:  RegionAsia,
+	PlatformOC1:  RegionSEA,
+	PlatformPH2:  RegionSEA,
+	PlatformSG2:  RegionSEA,
+	PlatformTH2:  RegionSEA,
+	PlatformTW2:  RegionSEA,
+	PlatformVN2:  RegionSEA,
+}
+
+// RegionForPlatform returns the regional cluster for a platform (case-insensitive)
+func RegionForPlatform(platform string) (string, bool) {
+	region, ok := platformRegions[strings.ToLower(platform)]
+	return region, ok
+}
+
+// accountRegion returns the cluster account-v1 is read from. Any cluster serves any
+// account, but there is no SEA cluster for it, so SEA uses Asia.
+func accountRegion(region string) string {
+	if region == RegionSEA {
+		return RegionAsia
+	}
+	return region
+}
+
+// ParsePlatforms parses a comma separated platform list (e.g. "na1,euw1,kr")
+func ParsePlatforms(list string) ([]string, error) {
+	var platforms []string
+	seen := make(map[string]bool)
+	for _, p := range strings.Split(list, ",") {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p == "" || seen[p] {
+			continue
+		}
+		if _, ok := platformRegions[p]; !ok {
+			return nil, fmt.Errorf("unknown platform %q", p)
+		}
+		seen[p] = true
+		platforms = append(platforms, p)
+	}
+	if len(platforms) == 0 {
+		return nil, fmt.Errorf("no platforms in %q", list)
+	}
+	return platforms, nil
+}
+
+// MatchPlatform returns the platform a match was played on from its ID prefix
+// (e.g. "EUW1_7012345678" -> "euw1"), or "" if the prefix isn't a known platform
+func MatchPlatform(matchID string) string {
+	prefix, _, ok := strings.Cut(matchID, "_")
+	if !ok {
+		return ""
+	}
+	prefix = strings.ToLower(prefix)
+	if _, ok := platformRegions[prefix]; !ok {
+		return ""
+	}
+	return prefix
+}
+
+// platformURL returns the API host for platform-routed endpoints (league-v4)
+func platformURL(platform string) string {
+	return "https://" + platform + ".api.riotgames.com"
+}
+
+// regionURL returns the API host for region-routed endpoints (match-v5, account-v1)
+func regionURL(region string) string {
+	return "https://" + region + ".api.riotgames.com"
+}
+
+// routingHost returns the routing value an API URL is sent to, e.g. "na1" or "americas".
+// Riot counts rate limits per routing value.
+func routingHost(url string) string {
+	host, _, _ := strings.Cut(strings.TrimPrefix(url, "https://"), ".api.riotgames.com")
+	return host
+}
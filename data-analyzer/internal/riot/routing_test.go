@@ -0,0 +1,63 @@
+package riot
+
+import "testing"
+
+func TestRegionForPlatform(t *testing.T) {
+	tests := []struct {
+		platform string
+		want     string
+		ok       bool
+	}{
+		{"na1", RegionAmericas, true},
+		{"BR1", RegionAmericas, true},
+		{"euw1", RegionEurope, true},
+		{"tr1", RegionEurope, true},
+		{"kr", RegionAsia, true},
+		{"vn2", RegionSEA, true},
+		{"pbe1", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := RegionForPlatform(tt.platform)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("RegionForPlatform(%q) = %q, %v, want %q, %v", tt.platform, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// Account lookups have no SEA cluster
+	if got := accountRegion(RegionSEA); got != RegionAsia {
+		t.Errorf("accountRegion(sea) = %q, want asia", got)
+	}
+}
+
+func TestParsePlatforms(t *testing.T) {
+	platforms, err := ParsePlatforms(" NA1, euw1,,kr,na1 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(platforms) != 3 || platforms[0] != "na1" || platforms[1] != "euw1" || platforms[2] != "kr" {
+		t.Errorf("expected [na1 euw1 kr], got %v", platforms)
+	}
+
+	if _, err := ParsePlatforms("na1,euw"); err == nil {
+		t.Error("expected an unknown platform to fail")
+	}
+	if _, err := ParsePlatforms(" , "); err == nil {
+		t.Error("expected an empty list to fail")
+	}
+}
+
+func TestMatchPlatform(t *testing.T) {
+	tests := map[string]string{
+		"NA1_5012345678":  "na1",
+		"EUW1_7012345678": "euw1",
+		"KR_7312345678":   "kr",
+		"XX1_123":         "",
+		"5012345678":      "",
+	}
+	for matchID, want := range tests {
+		if got := MatchPlatform(matchID); got != want {
+			t.Errorf("MatchPlatform(%q) = %q, want %q", matchID, got, want)
+		}
+	}
+}
@@ -23,6 +23,7 @@ type MatchInfo struct {
 	GameDuration  int                `json:"gameDuration"`
 	GameVersion   string             `json:"gameVersion"`
 	QueueID       int                `json:"queueId"`
+	GameMode      string             `json:"gameMode"` // CLASSIC, ARAM, CHERRY (Arena)...
 	Participants  []MatchParticipant `json:"participants"`
 }
 
@@ -42,6 +43,26 @@ type MatchParticipant struct {
 	Item4          int    `json:"item4"`
 	Item5          int    `json:"item5"`
 	Item6          int    `json:"item6"` // Trinket
+
+	// Arena only
+	Placement      int `json:"placement"` // 1-8
+	PlayerAugment1 int `json:"playerAugment1"`
+	PlayerAugment2 int `json:"playerAugment2"`
+	PlayerAugment3 int `json:"playerAugment3"`
+	PlayerAugment4 int `json:"playerAugment4"`
+	PlayerAugment5 int `json:"playerAugment5"`
+	PlayerAugment6 int `json:"playerAugment6"`
+}
+
+// Augments returns the Arena augments a participant picked, in order
+func (p MatchParticipant) Augments() []int {
+	var augments []int
+	for _, id := range []int{p.PlayerAugment1, p.PlayerAugment2, p.PlayerAugment3, p.PlayerAugment4, p.PlayerAugment5, p.PlayerAugment6} {
+		if id > 0 {
+			augments = append(augments, id)
+		}
+	}
+	return augments
 }
 
 // TimelineResponse represents the response from /lol/match/v5/matches/{matchId}/timeline
@@ -76,6 +97,7 @@ type TimelineEvent struct {
 type LeagueEntryResponse struct {
 	LeagueID     string `json:"leagueId"`
 	SummonerID   string `json:"summonerId"`
+	PUUID        string `json:"puuid"`
 	QueueType    string `json:"queueType"` // RANKED_SOLO_5x5, RANKED_FLEX_SR
 	Tier         string `json:"tier"`      // IRON, BRONZE, SILVER, GOLD, PLATINUM, EMERALD, DIAMOND, MASTER, GRANDMASTER, CHALLENGER
 	Rank         string `json:"rank"`      // I, II, III, IV
@@ -134,62 +156,3 @@ func IsEmerald4OrHigher(tier, division string) bool {
 	_, divExists := DivisionOrder[division]
 	return divExists
 }
-
-// Items that should be excluded from build order (consumables, components, etc.)
-var ExcludedItems = map[int]bool{
-	// Potions and consumables
-	2003: true, // Health Potion
-	2031: true, // Refillable Potion
-	2033: true, // Corrupting Potion
-	2055: true, // Control Ward
-	2138: true, // Elixir of Iron
-	2139: true, // Elixir of Sorcery
-	2140: true, // Elixir of Wrath
-
-	// Trinkets
-	3340: true, // Stealth Ward
-	3341: true, // Sweeping Lens
-	3363: true, // Farsight Alteration
-	3364: true, // Oracle Lens
-
-	// Boots components
-	1001: true, // Boots
-
-	// Common early components (optional - can remove if you want to track all purchases)
-	1036: true, // Long Sword
-	1037: true, // Pickaxe
-	1038: true, // BF Sword
-	1052: true, // Amplifying Tome
-	1058: true, // Needlessly Large Rod
-	1026: true, // Blasting Wand
-	1027: true, // Sapphire Crystal
-	1028: true, // Ruby Crystal
-	1029: true, // Cloth Armor
-	1031: true, // Chain Vest
-	1033: true, // Null-Magic Mantle
-	1057: true, // Negatron Cloak
-	1042: true, // Dagger
-	1043: true, // Recurve Bow
-	1018: true, // Cloak of Agility
-	1053: true, // Vampiric Scepter
-	1054: true, // Doran's Shield
-	1055: true, // Doran's Blade
-	1056: true, // Doran's Ring
-	1082: true, // Dark Seal
-	1083: true, // Cull
-}
-
-// IsCompletedItem returns true if the item is a completed item worth tracking
-func IsCompletedItem(itemID int) bool {
-	// Item ID 0 means empty slot
-	if itemID == 0 {
-		return false
-	}
-	// Exclude known consumables/components
-	if ExcludedItems[itemID] {
-		return false
-	}
-	// Generally, completed items have IDs >= 3000 (with some exceptions)
-	// This is a heuristic - adjust as needed
-	return itemID >= 2000
-}
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bits-and-blooms/bloom/v3"
+)
+
+// FrontierSnapshot is a spider's crawl state: the players still to crawl, what it has
+// already seen, and its counters. One snapshot is kept per platform.
+type FrontierSnapshot struct {
+	Platform string
+	Patch    string // patch the crawl was collecting; a snapshot from an older patch is stale
+	SavedAt  time.Time
+
+	Queue          []string  // players still to crawl, next first
+	QueuePriority  []float64 // crawl priority of each queued player (empty in older snapshots)
+	VisitedMatches *bloom.BloomFilter
+	VisitedPUUIDs  *bloom.BloomFilter
+
+	// Samples collected per champion and position, which drive crawl priorities
+	Coverage []CoverageCount
+
+	// Matches collected per rank bracket, which the bracket quotas are enforced against
+	BracketMatches map[string]int64
+
+	// Counters
+	Players             int64
+	Matches             int64
+	Timelines           int64
+	PlayersSkippedRank  int64
+	PlayersSkippedQuota int64
+}
+
+// CoverageCount is the samples collected for one champion in one position of a queue
+type CoverageCount struct {
+	QueueID      int
+	ChampionID   int
+	ChampionName string
+	Position     string
+	Matches      int
+}
+
+// FrontierStore keeps crawl snapshots as one file per platform in a directory.
+// Snapshots are written to a temporary file and renamed over the old one, so a crash
+// mid-save leaves the previous snapshot intact.
+type FrontierStore struct {
+	dir string
+}
+
+// NewFrontierStore creates a store in dir, creating the directory if needed
+func NewFrontierStore(dir string) (*FrontierStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create frontier directory %s: %w", dir, err)
+	}
+	return &FrontierStore{dir: dir}, nil
+}
+
+// path returns the snapshot file for a platform
+func (s *FrontierStore) path(platform string) string {
+	return filepath.Join(s.dir, platform+".gob")
+}
+
+// Save atomically replaces a platform's snapshot
+func (s *FrontierStore) Save(snap *FrontierSnapshot) error {
+	return writeGobFile(s.path(snap.Platform), snap)
+}
+
+// RanksPath returns the file a platform's rank cache is kept in, beside its snapshot
+func (s *FrontierStore) RanksPath(platform string) string {
+	return filepath.Join(s.dir, platform+".ranks.gob")
+}
+
+// writeGobFile gob-encodes v to a temporary file in path's directory and renames it over
+// path, so a crash mid-save leaves the previous file intact
+func writeGobFile(path string, v any) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	// Removes the temp file if anything below fails; a no-op after the rename
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(v); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load reads a platform's snapshot, or returns nil if none was saved
+func (s *FrontierStore) Load(platform string) (*FrontierSnapshot, error) {
+	f, err := os.Open(s.path(platform))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	var snap FrontierSnapshot
+	if err := gob.NewDecoder(f).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot %s: %w", f.Name(), err)
+	}
+	return &snap, nil
+}
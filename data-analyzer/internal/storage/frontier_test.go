@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bits-and-blooms/bloom/v3"
+)
+
+// TestFrontierStore_SaveLoad tests that a snapshot round trips and replaces the previous one
+func TestFrontierStore_SaveLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "frontier")
+	store, err := NewFrontierStore(dir)
+	if err != nil {
+		t.Fatalf("NewFrontierStore: %v", err)
+	}
+
+	if snap, err := store.Load("na1"); err != nil || snap != nil {
+		t.Fatalf("Load with no snapshot = %v, %v; want nil, nil", snap, err)
+	}
+
+	matches := bloom.NewWithEstimates(1000, 0.001)
+	matches.AddString("NA1_1")
+	snap := &FrontierSnapshot{
+		Platform:       "na1",
+		Patch:          "15.1",
+		Queue:          []string{"puuid-a", "puuid-b"},
+		VisitedMatches: matches,
+		VisitedPUUIDs:  bloom.NewWithEstimates(1000, 0.001),
+		Matches:        7,
+	}
+	if err := store.Save(snap); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	snap.Queue = []string{"puuid-c"}
+	if err := store.Save(snap); err != nil {
+		t.Fatalf("second Save: %v", err)
+	}
+
+	got, err := store.Load("na1")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.Patch != "15.1" || len(got.Queue) != 1 || got.Queue[0] != "puuid-c" || got.Matches != 7 {
+		t.Errorf("loaded %+v, want the second snapshot", got)
+	}
+	if !got.VisitedMatches.TestString("NA1_1") || got.VisitedMatches.TestString("NA1_2") {
+		t.Error("visited matches filter didn't round trip")
+	}
+
+	// Only the snapshot file is left, no temporary files
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "na1.gob" {
+		t.Errorf("frontier directory has %v, want only na1.gob", entries)
+	}
+}
+
+// TestFrontierStore_Corrupt tests that a corrupt snapshot is an error, not an empty frontier
+func TestFrontierStore_Corrupt(t *testing.T) {
+	store, err := NewFrontierStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFrontierStore: %v", err)
+	}
+	if err := os.WriteFile(store.path("euw1"), []byte("not a snapshot"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load("euw1"); err == nil {
+		t.Error("expected an error for a corrupt snapshot")
+	}
+}
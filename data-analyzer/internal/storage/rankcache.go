@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultRankTTL is how long a looked up rank is trusted before it is checked again
+const DefaultRankTTL = 24 * time.Hour
+
+// RankEntry is a player's solo queue rank as of CheckedAt. An empty Tier means the
+// player had no solo queue rank.
+type RankEntry struct {
+	Tier      string
+	Division  string
+	CheckedAt time.Time
+}
+
+// RankCache keeps solo queue ranks by PUUID for a TTL, so players seen again (or found
+// on the ladder) don't cost a rank lookup. It is saved to a file when it has a path.
+type RankCache struct {
+	mu      sync.Mutex
+	path    string // empty: kept in memory only
+	ttl     time.Duration
+	entries map[string]RankEntry
+	now     func() time.Time
+
+	hits   int64
+	misses int64
+}
+
+// NewRankCache creates a cache of ranks younger than ttl (default DefaultRankTTL), loading
+// the ranks saved at path if there are any. An empty path keeps the cache in memory.
+func NewRankCache(path string, ttl time.Duration) (*RankCache, error) {
+	if ttl <= 0 {
+		ttl = DefaultRankTTL
+	}
+	c := &RankCache{
+		path:    path,
+		ttl:     ttl,
+		entries: make(map[string]RankEntry),
+		now:     time.Now,
+	}
+	if path == "" {
+		return c, nil
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, fmt.Errorf("failed to open rank cache: %w", err)
+	}
+	defer f.Close()
+
+	var entries map[string]RankEntry
+	if err := gob.NewDecoder(f).Decode(&entries); err != nil {
+		return c, fmt.Errorf("failed to decode rank cache %s: %w", path, err)
+	}
+	now := c.now()
+	for puuid, e := range entries {
+		if now.Sub(e.CheckedAt) < ttl {
+			c.entries[puuid] = e
+		}
+	}
+	return c, nil
+}
+
+// Get returns a player's rank if it was checked within the TTL, counting a hit or a miss
+func (c *RankCache) Get(puuid string) (RankEntry, bool) {
+	e, ok := c.Peek(puuid)
+	if ok {
+		atomic.AddInt64(&c.hits, 1)
+	} else {
+		atomic.AddInt64(&c.misses, 1)
+	}
+	return e, ok
+}
+
+// Peek returns a player's rank if it was checked within the TTL without counting a hit
+// or a miss, for lookups that would never have gone to the API
+func (c *RankCache) Peek(puuid string) (RankEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[puuid]
+	if ok && c.now().Sub(e.CheckedAt) >= c.ttl {
+		delete(c.entries, puuid)
+		return RankEntry{}, false
+	}
+	return e, ok
+}
+
+// Put stores a player's rank as checked now (an empty tier: no solo queue rank)
+func (c *RankCache) Put(puuid, tier, division string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[puuid] = RankEntry{Tier: tier, Division: division, CheckedAt: c.now()}
+}
+
+// Len returns the number of cached ranks, expired ones included until they are dropped
+func (c *RankCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Stats returns the lookups answered from the cache and those that weren't
+func (c *RankCache) Stats() (hits, misses int64) {
+	return atomic.LoadInt64(&c.hits), atomic.LoadInt64(&c.misses)
+}
+
+// Save atomically writes the ranks still within the TTL to the cache file, dropping the
+// expired ones. It does nothing for an in-memory cache.
+func (c *RankCache) Save() error {
+	if c.path == "" {
+		return nil
+	}
+	c.mu.Lock()
+	now := c.now()
+	for puuid, e := range c.entries {
+		if now.Sub(e.CheckedAt) >= c.ttl {
+			delete(c.entries, puuid)
+		}
+	}
+	entries := make(map[string]RankEntry, len(c.entries))
+	for puuid, e := range c.entries {
+		entries[puuid] = e
+	}
+	c.mu.Unlock()
+
+	return writeGobFile(c.path, entries)
+}
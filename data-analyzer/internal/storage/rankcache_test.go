@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestRankCache_TTL tests hits and misses, that peeking counts neither, and that ranks
+// expire after the TTL
+func TestRankCache_TTL(t *testing.T) {
+	cache, err := NewRankCache("", time.Hour)
+	if err != nil {
+		t.Fatalf("NewRankCache: %v", err)
+	}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	if _, ok := cache.Get("puuid-a"); ok {
+		t.Error("Expected a miss on an empty cache")
+	}
+	cache.Put("puuid-a", "DIAMOND", "II")
+	cache.Put("puuid-b", "", "")
+
+	if e, ok := cache.Get("puuid-a"); !ok || e.Tier != "DIAMOND" || e.Division != "II" {
+		t.Errorf("Get(puuid-a) = %+v, %v", e, ok)
+	}
+	if e, ok := cache.Get("puuid-b"); !ok || e.Tier != "" {
+		t.Errorf("Expected puuid-b cached as unranked, got %+v, %v", e, ok)
+	}
+
+	if e, ok := cache.Peek("puuid-a"); !ok || e.Tier != "DIAMOND" {
+		t.Errorf("Peek(puuid-a) = %+v, %v", e, ok)
+	}
+
+	now = now.Add(time.Hour)
+	if _, ok := cache.Get("puuid-a"); ok {
+		t.Error("Expected puuid-a to expire after the TTL")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Len = %d, want the expired rank dropped", cache.Len())
+	}
+
+	if hits, misses := cache.Stats(); hits != 2 || misses != 2 {
+		t.Errorf("Stats = %d hits, %d misses; want 2, 2", hits, misses)
+	}
+}
+
+// TestRankCache_SaveLoad tests that saved ranks are loaded back and expired ones are dropped
+func TestRankCache_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "na1.ranks.gob")
+	cache, err := NewRankCache(path, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("NewRankCache: %v", err)
+	}
+	now := time.Now()
+	cache.now = func() time.Time { return now.Add(-30 * time.Hour) }
+	cache.Put("stale", "EMERALD", "IV")
+	cache.now = func() time.Time { return now }
+	cache.Put("fresh", "MASTER", "I")
+
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := NewRankCache(path, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("NewRankCache (load): %v", err)
+	}
+	if loaded.Len() != 1 {
+		t.Errorf("Len = %d, want only the fresh rank", loaded.Len())
+	}
+	if e, ok := loaded.Get("fresh"); !ok || e.Tier != "MASTER" {
+		t.Errorf("Get(fresh) = %+v, %v", e, ok)
+	}
+}
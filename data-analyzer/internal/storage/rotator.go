@@ -88,7 +88,11 @@ func (r *FileRotator) SetOnRotateCallback(callback func()) {
 func (r *FileRotator) WriteLine(record interface{}) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	return r.writeLine(record)
+}
 
+// writeLine writes a record; the caller holds r.mu
+func (r *FileRotator) writeLine(record interface{}) error {
 	// Marshal record to JSON
 	data, err := json.Marshal(record)
 	if err != nil {
@@ -111,7 +115,25 @@ func (r *FileRotator) WriteLine(record interface{}) error {
 func (r *FileRotator) MatchComplete() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	return r.matchComplete()
+}
+
+// WriteMatch writes a match's participant records and completes the match in one step,
+// so collectors writing concurrently never interleave or split a match across files
+func (r *FileRotator) WriteMatch(records []interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, record := range records {
+		if err := r.writeLine(record); err != nil {
+			return err
+		}
+	}
+	return r.matchComplete()
+}
 
+// matchComplete counts a finished match and rotates if needed; the caller holds r.mu
+func (r *FileRotator) matchComplete() error {
 	r.matchCount++
 
 	// Flush after each match
@@ -3,6 +3,7 @@ package storage
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -252,3 +253,57 @@ func TestFlushAndRotate_ReturnsMatchCount(t *testing.T) {
 		t.Error("expected rotated=true for file with matches")
 	}
 }
+
+// WriteMatch keeps concurrent writers' matches whole
+func TestWriteMatch_ConcurrentWriters(t *testing.T) {
+	tmpDir := t.TempDir()
+	r, err := NewFileRotator(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to create rotator: %v", err)
+	}
+	defer r.Close()
+
+	var wg sync.WaitGroup
+	for _, platform := range []string{"na1", "euw1", "kr"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				records := make([]interface{}, 10)
+				for j := range records {
+					records[j] = &RawMatch{MatchID: platform, Platform: platform, ChampionID: j}
+				}
+				if err := r.WriteMatch(records); err != nil {
+					t.Errorf("WriteMatch failed: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := r.FlushAndRotate(); err != nil {
+		t.Fatalf("FlushAndRotate failed: %v", err)
+	}
+	files, _ := filepath.Glob(filepath.Join(tmpDir, "warm", "*.jsonl"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 warm file, got %d", len(files))
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every run of 10 lines is one match from one platform
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 600 {
+		t.Fatalf("expected 600 records, got %d", len(lines))
+	}
+	for i := 0; i < len(lines); i += 10 {
+		matchID := lines[i][:strings.Index(lines[i], ",")] // {"matchId":"..."
+		for _, line := range lines[i+1 : i+10] {
+			if !strings.HasPrefix(line, matchID+",") {
+				t.Fatalf("match starting at line %d was interleaved: %s", i, line)
+			}
+		}
+	}
+}
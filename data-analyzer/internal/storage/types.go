@@ -8,6 +8,16 @@ type RawMatch struct {
 	GameVersion  string `json:"gameVersion"`
 	GameDuration int    `json:"gameDuration"`
 	GameCreation int64  `json:"gameCreation"`
+	QueueID      int    `json:"queueId,omitempty"`  // 420 ranked solo, 450 ARAM, 1700 Arena... (0 in files written before it was stored)
+	GameMode     string `json:"gameMode,omitempty"` // CLASSIC, ARAM, CHERRY (Arena)
+	Platform     string `json:"platform,omitempty"` // na1, euw1, kr... (empty in files written before it was stored; the match ID prefix has it too)
+
+	// Rank the match was found through: the solo queue rank of the player whose history
+	// it came from, and the lobby's average tier estimated from every participant whose
+	// rank the collector knew. Empty in files written before ranks were stored.
+	SourceTier     string `json:"sourceTier,omitempty"`
+	SourceDivision string `json:"sourceDivision,omitempty"`
+	LobbyTier      string `json:"lobbyTier,omitempty"`
 
 	// Participant data
 	PUUID        string `json:"puuid"`
@@ -15,7 +25,7 @@ type RawMatch struct {
 	TagLine      string `json:"tagLine,omitempty"`
 	ChampionID   int    `json:"championId"`
 	ChampionName string `json:"championName"`
-	TeamPosition string `json:"teamPosition"` // TOP, JUNGLE, MIDDLE, BOTTOM, UTILITY
+	TeamPosition string `json:"teamPosition"` // TOP, JUNGLE, MIDDLE, BOTTOM, UTILITY (empty in ARAM and Arena)
 	Win          bool   `json:"win"`
 
 	// Final items (used for item stats and build inference)
@@ -29,6 +39,19 @@ type RawMatch struct {
 	// BuildOrder contains the order items were purchased (from timeline, ~20% of matches)
 	// Used for champion_item_slots table (1st item, 2nd item, etc.)
 	BuildOrder []int `json:"buildOrder,omitempty"`
+
+	// Arena only: final placement (1-8) and augments in pick order
+	Placement int   `json:"placement,omitempty"`
+	Augments  []int `json:"augments,omitempty"`
+}
+
+// Tier returns the tier the record's stats are bracketed by: the lobby estimate, or the
+// source player's tier if there is none
+func (r *RawMatch) Tier() string {
+	if r.LobbyTier != "" {
+		return r.LobbyTier
+	}
+	return r.SourceTier
 }
 
 // GetFinalItems returns the final inventory items as a slice (excluding empty slots)
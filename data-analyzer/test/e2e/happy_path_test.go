@@ -23,6 +23,7 @@ type mockSpiderRunner struct {
 	runsCompleted   atomic.Int64
 	seeded          bool
 	reset           bool
+	countersReset   bool
 	onRunContinuous func() error
 	rotator         *storage.FileRotator
 	onRotation      func() // Called when a file rotation occurs
@@ -92,7 +93,14 @@ func (m *mockSpiderRunner) Reset() {
 	m.runsCompleted.Store(0)
 }
 
-func (m *mockSpiderRunner) SeedFromChallenger(ctx context.Context) error {
+func (m *mockSpiderRunner) ResetCounters() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.countersReset = true
+	m.runsCompleted.Store(0)
+}
+
+func (m *mockSpiderRunner) SeedFromLadder(ctx context.Context) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.seeded = true
@@ -111,6 +119,12 @@ func (m *mockSpiderRunner) WasReset() bool {
 	return m.reset
 }
 
+func (m *mockSpiderRunner) WasCountersReset() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.countersReset
+}
+
 // mockDataPusher captures push calls for verification
 type mockDataPusher struct {
 	mu        sync.Mutex
@@ -271,7 +285,7 @@ func TestHappyPath_FullCollectionCycle(t *testing.T) {
 
 	// Verify spider was seeded
 	if !spider.WasSeeded() {
-		t.Error("Spider was not seeded from Challenger")
+		t.Error("Spider was not seeded from the ladder")
 	}
 
 	// Verify data was aggregated
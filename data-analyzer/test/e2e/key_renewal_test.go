@@ -114,6 +114,11 @@ func (s *expireAfterNSpider) Reset() {
 	s.runCount.Store(0)
 }
 
+func (s *expireAfterNSpider) ResetCounters() {
+	s.mockSpiderRunner.ResetCounters()
+	s.runCount.Store(0)
+}
+
 // TestKeyRenewal_FullCycle tests the key expiration and renewal flow:
 // - Start collector with mock API
 // - After some runs, mock returns 401
@@ -300,9 +305,12 @@ func TestKeyRenewal_FullCycle(t *testing.T) {
 	}
 	notifyMu.Unlock()
 
-	// Verify spider was reset (fresh start)
-	if !spider.WasReset() {
-		t.Error("Spider was not reset on fresh restart")
+	// Verify the spider's counters were reset (fresh start) without clearing its bloom filters
+	if !spider.WasCountersReset() {
+		t.Error("Spider counters were not reset on fresh restart")
+	}
+	if spider.WasReset() {
+		t.Error("Spider bloom filters were cleared on fresh restart")
 	}
 
 	// Shutdown
@@ -0,0 +1,136 @@
+package ddragon
+
+import (
+	"context"
+	"strconv"
+)
+
+const (
+	// riftMap is Summoner's Rift's map ID in item.json
+	riftMap = "11"
+	// completedMinGold separates finished items from cheap leftovers that don't build further
+	completedMinGold = 1000
+)
+
+// Catalog answers questions about one patch's items: build trees, stats, and what kind
+// of item something is. Classifications are read from item.json rather than kept as ID
+// lists, so they follow the game when items are added, removed or reworked.
+// A nil Catalog knows no items.
+type Catalog struct {
+	version string
+	items   map[int]Item
+}
+
+// NewCatalog creates a catalog from item.json data
+func NewCatalog(version string, items map[int]Item) *Catalog {
+	return &Catalog{version: version, items: items}
+}
+
+// Catalog loads the item catalog for a version and locale.
+// An empty locale means DefaultLocale.
+func (c *Client) Catalog(ctx context.Context, version, locale string) (*Catalog, error) {
+	items, err := c.Items(ctx, version, locale)
+	if err != nil {
+		return nil, err
+	}
+	return NewCatalog(version, items), nil
+}
+
+// Version returns the Data Dragon version the catalog was built from
+func (c *Catalog) Version() string {
+	if c == nil {
+		return ""
+	}
+	return c.version
+}
+
+// Len returns the number of items in the catalog
+func (c *Catalog) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.items)
+}
+
+// Item returns an item by ID
+func (c *Catalog) Item(id int) (Item, bool) {
+	if c == nil {
+		return Item{}, false
+	}
+	item, ok := c.items[id]
+	return item, ok
+}
+
+// onRift returns a Summoner's Rift item that can be bought. Items without map data count as available.
+func (c *Catalog) onRift(id int) (Item, bool) {
+	item, ok := c.Item(id)
+	if !ok || !item.Gold.Purchasable {
+		return Item{}, false
+	}
+	if available, listed := item.Maps[riftMap]; listed && !available {
+		return Item{}, false
+	}
+	return item, true
+}
+
+// IsBoots reports whether an item is boots, including the basic Boots component
+func (c *Catalog) IsBoots(id int) bool {
+	item, ok := c.Item(id)
+	return ok && item.HasTag("Boots")
+}
+
+// IsCompleted reports whether an item is a finished build item worth tracking: upgraded
+// boots, or a Summoner's Rift item that doesn't build any further and isn't a starter or consumable
+func (c *Catalog) IsCompleted(id int) bool {
+	item, ok := c.onRift(id)
+	if !ok || item.Consumed || item.HasTag("Consumable") {
+		return false
+	}
+	if item.HasTag("Boots") {
+		return len(item.From) > 0
+	}
+	return len(c.upgrades(item)) == 0 && item.Gold.Total >= completedMinGold
+}
+
+// upgrades returns what an item builds into, leaving out upgrades only one champion can
+// make (Ornn's masterworks), which don't make the base item a component
+func (c *Catalog) upgrades(item Item) []int {
+	var ids []int
+	for _, id := range parseIDs(item.Into) {
+		if into, ok := c.Item(id); ok && into.RequiredAlly != "" {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ComponentsOf returns the items an item is built from (one level of the tree).
+// Duplicates are kept, e.g. two Long Swords.
+func (c *Catalog) ComponentsOf(id int) []int {
+	item, ok := c.Item(id)
+	if !ok {
+		return nil
+	}
+	return parseIDs(item.From)
+}
+
+// BuildsInto returns the items an item is a component of
+func (c *Catalog) BuildsInto(id int) []int {
+	item, ok := c.Item(id)
+	if !ok {
+		return nil
+	}
+	return c.upgrades(item)
+}
+
+// parseIDs converts item.json's string item IDs, skipping any that aren't numbers
+func parseIDs(strs []string) []int {
+	ids := make([]int, 0, len(strs))
+	for _, s := range strs {
+		if id, err := strconv.Atoi(s); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
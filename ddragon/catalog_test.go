@@ -0,0 +1,96 @@
+package ddragon
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// catalogJSON is a trimmed item.json covering each kind of item
+const catalogJSON = `{
+	"1001": {"name": "Boots", "into": ["3020"], "gold": {"total": 300, "purchasable": true}, "tags": ["Boots"], "maps": {"11": true}, "depth": 1},
+	"3020": {"name": "Sorcerer's Shoes", "from": ["1001"], "gold": {"total": 1100, "purchasable": true}, "tags": ["Boots", "MagicPenetration"], "maps": {"11": true}, "stats": {"FlatMovementSpeedMod": 45}, "depth": 2},
+	"1052": {"name": "Amplifying Tome", "into": ["6655"], "gold": {"total": 400, "purchasable": true}, "tags": ["SpellDamage"], "maps": {"11": true}, "stats": {"FlatMagicDamageMod": 20}, "depth": 1},
+	"6655": {"name": "Luden's Companion", "from": ["1052", "1052", "3802"], "into": ["7002"], "gold": {"total": 2900, "purchasable": true}, "tags": ["SpellDamage", "Mana"], "maps": {"11": true}, "stats": {"FlatMagicDamageMod": 100}, "depth": 3},
+	"7002": {"name": "Luden's Masterwork", "from": ["6655"], "gold": {"total": 2900}, "requiredAlly": "Ornn", "maps": {"11": true}},
+	"1056": {"name": "Doran's Ring", "gold": {"total": 400, "purchasable": true}, "tags": ["Lane", "Mana"], "maps": {"11": true}},
+	"1101": {"name": "Scorchclaw Pup", "gold": {"total": 450, "purchasable": true}, "tags": ["Jungle"], "maps": {"11": true}},
+	"3865": {"name": "World Atlas", "into": ["3866"], "gold": {"total": 400, "purchasable": true}, "tags": ["GoldPer", "Lane"], "maps": {"11": true}},
+	"3866": {"name": "Runic Compass", "from": ["3865"], "gold": {"total": 400}, "tags": ["GoldPer"], "maps": {"11": true}},
+	"3070": {"name": "Tear of the Goddess", "into": ["3003"], "gold": {"total": 400, "purchasable": true}, "tags": ["Mana"], "maps": {"11": true}},
+	"2003": {"name": "Health Potion", "gold": {"total": 50, "purchasable": true}, "tags": ["Consumable"], "consumed": true, "maps": {"11": true}},
+	"2055": {"name": "Control Ward", "gold": {"total": 75, "purchasable": true}, "tags": ["Consumable", "Vision"], "consumed": true, "maps": {"11": true}},
+	"3340": {"name": "Stealth Ward", "gold": {"total": 0, "purchasable": true}, "tags": ["Trinket", "Vision"], "maps": {"11": true}},
+	"3177": {"name": "Guardian's Blade", "gold": {"total": 950, "purchasable": true}, "maps": {"11": false, "12": true}},
+	"3084": {"name": "Heartsteel", "from": ["1011", "3801"], "gold": {"total": 3000, "purchasable": true}, "tags": ["Health"], "maps": {"11": false, "12": false}}
+}`
+
+// testCatalog builds a catalog from catalogJSON
+func testCatalog(t *testing.T) *Catalog {
+	var raw map[string]Item
+	if err := json.Unmarshal([]byte(catalogJSON), &raw); err != nil {
+		t.Fatal(err)
+	}
+	items := make(map[int]Item, len(raw))
+	for idStr, item := range raw {
+		id, _ := strconv.Atoi(idStr)
+		items[id] = item
+	}
+	return NewCatalog("15.1.1", items)
+}
+
+func TestCatalog_Classifications(t *testing.T) {
+	catalog := testCatalog(t)
+
+	tests := []struct {
+		id               int
+		boots, completed bool
+	}{
+		{1001, true, false},  // Boots (component)
+		{3020, true, true},   // Sorcerer's Shoes
+		{1052, false, false}, // Amplifying Tome
+		{6655, false, true},  // Luden's: Ornn's upgrade doesn't make it a component
+		{7002, false, false}, // Masterwork: can't be bought
+		{1056, false, false}, // Doran's Ring (starter)
+		{1101, false, false}, // Jungle pet (starter)
+		{3865, false, false}, // Support item upgrades in place (starter)
+		{3070, false, false}, // Tear is a component
+		{2003, false, false}, // Health Potion
+		{2055, false, false}, // Control Ward
+		{3340, false, false}, // Trinket
+		{3177, false, false}, // ARAM only
+		{3084, false, false}, // Not on the Rift
+		{9999, false, false}, // Unknown
+	}
+	for _, tt := range tests {
+		if got := catalog.IsBoots(tt.id); got != tt.boots {
+			t.Errorf("IsBoots(%d) = %v", tt.id, got)
+		}
+		if got := catalog.IsCompleted(tt.id); got != tt.completed {
+			t.Errorf("IsCompleted(%d) = %v", tt.id, got)
+		}
+	}
+}
+
+func TestCatalog_TreesAndStats(t *testing.T) {
+	catalog := testCatalog(t)
+
+	if got := catalog.ComponentsOf(6655); !slices.Equal(got, []int{1052, 1052, 3802}) {
+		t.Errorf("ComponentsOf(6655) = %v", got)
+	}
+	if got := catalog.BuildsInto(1052); !slices.Equal(got, []int{6655}) {
+		t.Errorf("BuildsInto(1052) = %v", got)
+	}
+	if got := catalog.BuildsInto(6655); len(got) != 0 {
+		t.Errorf("expected masterworks to be left out, got %v", got)
+	}
+	if luden, _ := catalog.Item(6655); luden.Stats["FlatMagicDamageMod"] != 100 || luden.Depth != 3 {
+		t.Errorf("unexpected stats: %+v", luden)
+	}
+
+	var empty *Catalog
+	if empty.IsCompleted(3020) || empty.ComponentsOf(6655) != nil || empty.Len() != 0 {
+		t.Error("expected a nil catalog to know no items")
+	}
+}
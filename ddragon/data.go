@@ -0,0 +1,112 @@
+package ddragon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Image is a Data Dragon sprite reference; Full is the icon's file name
+type Image struct {
+	Full string `json:"full"`
+}
+
+// Champion is one entry of champion.json
+type Champion struct {
+	ID    string   `json:"id"`  // Icon and file ID, e.g. "MonkeyKing"
+	Key   string   `json:"key"` // Numeric champion ID as a string, e.g. "62"
+	Name  string   `json:"name"`
+	Title string   `json:"title"`
+	Tags  []string `json:"tags"`
+	Image Image    `json:"image"`
+}
+
+// NumericID returns the champion's numeric ID, or 0 if Key isn't a number
+func (c Champion) NumericID() int {
+	id, _ := strconv.Atoi(c.Key)
+	return id
+}
+
+// Gold is an item's cost
+type Gold struct {
+	Base        int  `json:"base"`
+	Total       int  `json:"total"`
+	Sell        int  `json:"sell"`
+	Purchasable bool `json:"purchasable"`
+}
+
+// Item is one entry of item.json
+type Item struct {
+	Name         string             `json:"name"`
+	Plaintext    string             `json:"plaintext"`
+	Into         []string           `json:"into,omitempty"` // Items this builds into (as string IDs)
+	From         []string           `json:"from,omitempty"` // Items this is built from (as string IDs)
+	Gold         Gold               `json:"gold"`
+	Tags         []string           `json:"tags"`
+	Maps         map[string]bool    `json:"maps"`  // Map availability, keyed by map ID ("11" is Summoner's Rift)
+	Stats        map[string]float64 `json:"stats"` // e.g. "FlatMagicDamageMod": 90
+	Depth        int                `json:"depth"` // Build tree tier: 1 for basic items, 3 for most legendaries
+	Consumed     bool               `json:"consumed"`
+	RequiredAlly string             `json:"requiredAlly,omitempty"` // e.g. "Ornn" for masterwork upgrades
+	Image        Image              `json:"image"`
+}
+
+// HasTag reports whether the item carries a tag, e.g. "Boots"
+func (i Item) HasTag(tag string) bool {
+	return slices.Contains(i.Tags, tag)
+}
+
+// Champions returns champion.json for a version and locale, keyed by champion ID (e.g. "Ahri").
+// An empty locale means DefaultLocale.
+func (c *Client) Champions(ctx context.Context, version, locale string) (map[string]Champion, error) {
+	var file struct {
+		Data map[string]Champion `json:"data"`
+	}
+	if err := c.getJSON(ctx, dataPath(version, locale, "champion.json"), &file); err != nil {
+		return nil, fmt.Errorf("failed to load champions: %w", err)
+	}
+	return file.Data, nil
+}
+
+// Items returns item.json for a version and locale, keyed by item ID.
+// An empty locale means DefaultLocale.
+func (c *Client) Items(ctx context.Context, version, locale string) (map[int]Item, error) {
+	var file struct {
+		Data map[string]Item `json:"data"`
+	}
+	if err := c.getJSON(ctx, dataPath(version, locale, "item.json"), &file); err != nil {
+		return nil, fmt.Errorf("failed to load items: %w", err)
+	}
+
+	items := make(map[int]Item, len(file.Data))
+	for idStr, item := range file.Data {
+		if id, err := strconv.Atoi(idStr); err == nil {
+			items[id] = item
+		}
+	}
+	return items, nil
+}
+
+// dataPath returns the path of a localized data file
+func dataPath(version, locale, name string) string {
+	if locale == "" {
+		locale = DefaultLocale
+	}
+	return fmt.Sprintf("cdn/%s/data/%s/%s", version, locale, name)
+}
+
+// ChampionIcon returns the path of a champion's square icon
+func ChampionIcon(version, championID string) string {
+	return fmt.Sprintf("cdn/%s/img/champion/%s.png", version, championID)
+}
+
+// ChampionSplash returns the path of a champion's default splash art (not versioned)
+func ChampionSplash(championID string) string {
+	return fmt.Sprintf("cdn/img/champion/splash/%s_0.jpg", championID)
+}
+
+// ItemIcon returns the path of an item's icon
+func ItemIcon(version string, itemID int) string {
+	return fmt.Sprintf("cdn/%s/img/item/%d.png", version, itemID)
+}
@@ -0,0 +1,265 @@
+// Package ddragon is a disk-cached client for Riot's Data Dragon, shared by the desktop
+// app and the data analyzer. Versioned files never change, so once champion or item data
+// or an icon is on disk it's served from there; only versions.json is refreshed, and the
+// last copy is used when Data Dragon can't be reached.
+package ddragon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBaseURL     = "https://ddragon.leagueoflegends.com"
+	DefaultLocale      = "en_US"
+	DefaultVersionsTTL = 6 * time.Hour
+	// versionsRetry is how long cached versions are used before retrying after a failed refresh
+	versionsRetry = time.Minute
+)
+
+// ErrNotFound means Data Dragon has no such file, e.g. an unknown version or locale
+var ErrNotFound = errors.New("not found on Data Dragon")
+
+// errInvalidPath rejects paths that would leave the cache directory
+var errInvalidPath = errors.New("invalid Data Dragon path")
+
+// Options configures a Client. Zero values use the defaults.
+type Options struct {
+	CacheDir    string        // Where files are kept; empty disables the disk cache
+	BaseURL     string        // Data Dragon root (tests point this at a local server)
+	HTTPClient  *http.Client  // Defaults to a client with a 10s timeout
+	VersionsTTL time.Duration // How long versions.json is trusted before re-fetching
+}
+
+// Client fetches Data Dragon files through an on-disk cache
+type Client struct {
+	baseURL     string
+	cacheDir    string
+	http        *http.Client
+	versionsTTL time.Duration
+	now         func() time.Time
+
+	mu        sync.Mutex // Guards versions and serializes refreshes
+	versions  []string
+	fetchedAt time.Time
+	failedAt  time.Time
+}
+
+// New creates a Data Dragon client
+func New(opts Options) *Client {
+	if opts.BaseURL == "" {
+		opts.BaseURL = DefaultBaseURL
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if opts.VersionsTTL <= 0 {
+		opts.VersionsTTL = DefaultVersionsTTL
+	}
+	return &Client{
+		baseURL:     strings.TrimRight(opts.BaseURL, "/"),
+		cacheDir:    opts.CacheDir,
+		http:        opts.HTTPClient,
+		versionsTTL: opts.VersionsTTL,
+		now:         time.Now,
+	}
+}
+
+// Versions returns every Data Dragon version, newest first. The list is re-fetched once
+// it's older than the TTL; if that fails, the cached list is used.
+func (c *Client) Versions(ctx context.Context) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.versions == nil {
+		c.versions, c.fetchedAt = c.readCachedVersions()
+	}
+	if c.versions != nil && (c.now().Sub(c.fetchedAt) < c.versionsTTL || c.now().Sub(c.failedAt) < versionsRetry) {
+		return c.versions, nil
+	}
+
+	body, err := c.download(ctx, "api/versions.json")
+	if err == nil {
+		var versions []string
+		if err = json.Unmarshal(body, &versions); err == nil && len(versions) == 0 {
+			err = fmt.Errorf("no versions available")
+		}
+		if err == nil {
+			c.versions, c.fetchedAt = versions, c.now()
+			c.writeCache("api/versions.json", body)
+			return c.versions, nil
+		}
+	}
+
+	c.failedAt = c.now()
+	if c.versions != nil {
+		fmt.Printf("[DDragon] Using cached versions (%v)\n", err)
+		return c.versions, nil
+	}
+	return nil, fmt.Errorf("failed to fetch versions: %w", err)
+}
+
+// Latest returns the newest Data Dragon version
+func (c *Client) Latest(ctx context.Context) (string, error) {
+	versions, err := c.Versions(ctx)
+	if err != nil {
+		return "", err
+	}
+	return versions[0], nil
+}
+
+// Resolve returns the newest Data Dragon version for a patch, e.g. "15.1" -> "15.1.1",
+// so names and icons match the stats in use. An empty or unknown patch resolves to the
+// latest version.
+func (c *Client) Resolve(ctx context.Context, patch string) (string, error) {
+	versions, err := c.Versions(ctx)
+	if err != nil {
+		return "", err
+	}
+	if patch == "" {
+		return versions[0], nil
+	}
+	for _, v := range versions {
+		if v == patch || strings.HasPrefix(v, patch+".") {
+			return v, nil
+		}
+	}
+	fmt.Printf("[DDragon] No version for patch %s, using %s\n", patch, versions[0])
+	return versions[0], nil
+}
+
+// URL returns the Data Dragon URL of a file, e.g. "cdn/15.1.1/img/item/3020.png"
+func (c *Client) URL(file string) string {
+	return c.baseURL + "/" + file
+}
+
+// Asset returns the contents of a Data Dragon file, from disk when it's been fetched before
+func (c *Client) Asset(ctx context.Context, file string) ([]byte, error) {
+	file, err := cleanPath(file)
+	if err != nil {
+		return nil, err
+	}
+	if body, err := c.readCache(file); err == nil {
+		return body, nil
+	}
+
+	body, err := c.download(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	c.writeCache(file, body)
+	return body, nil
+}
+
+// getJSON decodes a versioned JSON file through the cache
+func (c *Client) getJSON(ctx context.Context, file string, v any) error {
+	body, err := c.Asset(ctx, file)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	return nil
+}
+
+// download fetches a file from Data Dragon
+func (c *Client) download(ctx context.Context, file string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.URL(file), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden:
+		// Data Dragon answers 403 for files that don't exist
+		return nil, fmt.Errorf("%s: %w", file, ErrNotFound)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("Data Dragon returned status %d for %s", resp.StatusCode, file)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// readCachedVersions loads versions.json from disk along with when it was saved
+func (c *Client) readCachedVersions() ([]string, time.Time) {
+	body, err := c.readCache("api/versions.json")
+	if err != nil {
+		return nil, time.Time{}
+	}
+	var versions []string
+	if json.Unmarshal(body, &versions) != nil || len(versions) == 0 {
+		return nil, time.Time{}
+	}
+	info, err := os.Stat(c.cachePath("api/versions.json"))
+	if err != nil {
+		return nil, time.Time{}
+	}
+	return versions, info.ModTime()
+}
+
+// readCache reads a file from the disk cache
+func (c *Client) readCache(file string) ([]byte, error) {
+	if c.cacheDir == "" {
+		return nil, os.ErrNotExist
+	}
+	return os.ReadFile(c.cachePath(file))
+}
+
+// writeCache saves a file to the disk cache. Failures only cost a re-download later.
+func (c *Client) writeCache(file string, body []byte) {
+	if c.cacheDir == "" {
+		return
+	}
+	target := c.cachePath(file)
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		fmt.Printf("[DDragon] Failed to cache %s: %v\n", file, err)
+		return
+	}
+
+	// Write then rename, so a crash never leaves a truncated file behind
+	tmp, err := os.CreateTemp(filepath.Dir(target), filepath.Base(target)+".*.tmp")
+	if err != nil {
+		fmt.Printf("[DDragon] Failed to cache %s: %v\n", file, err)
+		return
+	}
+	_, err = tmp.Write(body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), target)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		fmt.Printf("[DDragon] Failed to cache %s: %v\n", file, err)
+	}
+}
+
+// cachePath maps a Data Dragon path onto the cache directory
+func (c *Client) cachePath(file string) string {
+	return filepath.Join(c.cacheDir, filepath.FromSlash(file))
+}
+
+// cleanPath rejects paths that could escape the cache directory
+func cleanPath(file string) (string, error) {
+	cleaned := path.Clean(strings.TrimPrefix(file, "/"))
+	if cleaned == "." || cleaned != strings.TrimPrefix(file, "/") || strings.HasPrefix(cleaned, "..") || strings.Contains(cleaned, "\\") {
+		return "", fmt.Errorf("%w %q", errInvalidPath, file)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,162 @@
+package ddragon
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeDDragon serves a tiny Data Dragon and counts requests per path
+type fakeDDragon struct {
+	*httptest.Server
+	hits map[string]*atomic.Int32
+}
+
+// newFakeDDragon starts a local Data Dragon with two patches and two locales
+func newFakeDDragon(t *testing.T) *fakeDDragon {
+	files := map[string]string{
+		"/api/versions.json":                        `["15.2.1", "15.1.1", "14.24.1"]`,
+		"/cdn/15.1.1/data/en_US/champion.json":      `{"data": {"Ahri": {"id": "Ahri", "key": "103", "name": "Ahri"}, "MonkeyKing": {"id": "MonkeyKing", "key": "62", "name": "Wukong"}}}`,
+		"/cdn/15.1.1/data/ko_KR/champion.json":      `{"data": {"Ahri": {"id": "Ahri", "key": "103", "name": "아리"}}}`,
+		"/cdn/15.1.1/data/en_US/item.json":          `{"data": {"3020": {"name": "Sorcerer's Shoes", "gold": {"total": 1100, "purchasable": true}, "maps": {"11": true}}, "1001": {"name": "Boots", "into": ["3020"]}}}`,
+		"/cdn/15.1.1/img/champion/Ahri.png":         "png-bytes",
+		"/cdn/img/champion/splash/MonkeyKing_0.jpg": "jpg-bytes",
+	}
+	f := &fakeDDragon{hits: make(map[string]*atomic.Int32)}
+	for path := range files {
+		f.hits[path] = &atomic.Int32{}
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[r.URL.Path]
+		if !ok {
+			// Data Dragon answers 403 for missing files
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		f.hits[r.URL.Path].Add(1)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func TestClient_ResolvePinsToPatch(t *testing.T) {
+	server := newFakeDDragon(t)
+	client := New(Options{BaseURL: server.URL, CacheDir: t.TempDir()})
+	ctx := context.Background()
+
+	for patch, want := range map[string]string{"15.1": "15.1.1", "14.24": "14.24.1", "": "15.2.1", "13.1": "15.2.1"} {
+		if got, err := client.Resolve(ctx, patch); err != nil || got != want {
+			t.Errorf("Resolve(%q) = %q, %v; want %q", patch, got, err, want)
+		}
+	}
+	if n := server.hits["/api/versions.json"].Load(); n != 1 {
+		t.Errorf("expected versions.json to be fetched once within the TTL, got %d", n)
+	}
+}
+
+func TestClient_LocalesAndData(t *testing.T) {
+	server := newFakeDDragon(t)
+	client := New(Options{BaseURL: server.URL})
+	ctx := context.Background()
+
+	champions, err := client.Champions(ctx, "15.1.1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wukong := champions["MonkeyKing"]; wukong.Name != "Wukong" || wukong.NumericID() != 62 {
+		t.Errorf("expected Wukong (62), got %+v", wukong)
+	}
+
+	korean, err := client.Champions(ctx, "15.1.1", "ko_KR")
+	if err != nil || korean["Ahri"].Name != "아리" {
+		t.Errorf("expected the Korean name, got %+v err=%v", korean["Ahri"], err)
+	}
+	if _, err := client.Champions(ctx, "15.1.1", "xx_XX"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown locale, got %v", err)
+	}
+
+	items, err := client.Items(ctx, "15.1.1", "en_US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if boots := items[3020]; boots.Gold.Total != 1100 || !boots.Maps["11"] || items[1001].Into[0] != "3020" {
+		t.Errorf("unexpected items: %+v", items)
+	}
+}
+
+func TestClient_WorksOfflineFromCache(t *testing.T) {
+	server := newFakeDDragon(t)
+	cacheDir := t.TempDir()
+	ctx := context.Background()
+
+	online := New(Options{BaseURL: server.URL, CacheDir: cacheDir})
+	version, _ := online.Resolve(ctx, "15.1")
+	if _, err := online.Champions(ctx, version, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := online.Asset(ctx, ChampionIcon(version, "Ahri")); err != nil {
+		t.Fatal(err)
+	}
+	online.Champions(ctx, version, "") // second read comes from disk
+	if n := server.hits["/cdn/15.1.1/data/en_US/champion.json"].Load(); n != 1 {
+		t.Errorf("expected champion.json to be downloaded once, got %d", n)
+	}
+	server.Close()
+
+	// A new session with a stale versions list and no network
+	offline := New(Options{BaseURL: server.URL, CacheDir: cacheDir, VersionsTTL: time.Nanosecond})
+	if got, err := offline.Resolve(ctx, "15.1"); err != nil || got != "15.1.1" {
+		t.Fatalf("expected cached versions offline, got %q err=%v", got, err)
+	}
+	champions, err := offline.Champions(ctx, "15.1.1", "")
+	if err != nil || champions["Ahri"].Key != "103" {
+		t.Errorf("expected champions from disk, got %v err=%v", champions, err)
+	}
+
+	rec := httptest.NewRecorder()
+	offline.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cdn/15.1.1/img/champion/Ahri.png", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "png-bytes" || rec.Header().Get("Content-Type") != "image/png" {
+		t.Errorf("expected the cached icon, got %d %q", rec.Code, rec.Body.String())
+	}
+	if _, err := offline.Asset(ctx, ChampionSplash("MonkeyKing")); err == nil {
+		t.Error("expected a never-fetched file to fail offline")
+	}
+}
+
+func TestHandler_OnlyServesImages(t *testing.T) {
+	server := newFakeDDragon(t)
+	handler := New(Options{BaseURL: server.URL, CacheDir: t.TempDir()}).Handler()
+
+	for path, want := range map[string]int{
+		"/cdn/img/champion/splash/MonkeyKing_0.jpg": http.StatusOK,
+		"/cdn/15.1.1/img/champion/Missing.png":      http.StatusNotFound,
+		"/cdn/15.1.1/data/en_US/champion.json":      http.StatusNotFound,
+		"/api/versions.json":                        http.StatusNotFound,
+		"/cdn/15.1.1/img/../../../../secret.png":    http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.URL.Path = path // keep ".." segments the router would otherwise clean
+		handler.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("%s: expected %d, got %d", path, want, rec.Code)
+		}
+	}
+}
+
+func TestCleanPath(t *testing.T) {
+	for _, bad := range []string{"", "..", "cdn/../../etc/passwd", `cdn\..\x`, "cdn//img/x.png"} {
+		if _, err := cleanPath(bad); err == nil || !strings.Contains(err.Error(), "invalid") {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+	if got, err := cleanPath("/cdn/15.1.1/img/item/3020.png"); err != nil || got != "cdn/15.1.1/img/item/3020.png" {
+		t.Errorf("expected a clean path, got %q err=%v", got, err)
+	}
+}
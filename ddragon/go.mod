@@ -0,0 +1,3 @@
+module ghostdraft/ddragon
+
+go 1.24.0
@@ -0,0 +1,46 @@
+package ddragon
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Handler serves Data Dragon images through the disk cache, so icons keep working offline.
+// Mount it with http.StripPrefix; requests are Data Dragon paths such as
+// "cdn/15.1.1/img/champion/Ahri.png". Only images under cdn/ are served.
+func (c *Client) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file := strings.TrimPrefix(r.URL.Path, "/")
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !strings.HasPrefix(file, "cdn/") || !strings.Contains(file, "/img/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		body, err := c.Asset(r.Context(), file)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			http.NotFound(w, r)
+			return
+		case errors.Is(err, errInvalidPath):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		if contentType := mime.TypeByExtension(path.Ext(file)); contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		}
+		// Versioned files never change; splash art rarely does
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		w.Write(body)
+	})
+}
@@ -0,0 +1,331 @@
+// Code generated by go generate ./internal/events; DO NOT EDIT.
+// Payloads for every event the backend emits, keyed by event name in EventMap.
+
+export interface LCUStatus {
+	connected: boolean;
+	message: string;
+	port?: string;
+}
+
+export interface GameflowUpdate {
+	phase: string;
+}
+
+export interface ChampSelectUpdate {
+	inChampSelect: boolean;
+	phase?: string;
+	championName?: string;
+	championID?: number;
+	isLocked?: boolean;
+	localPosition?: string;
+	actionType?: string;
+	timeLeft?: number;
+	banPhaseComplete?: boolean;
+}
+
+export interface AlliesUpdate {
+	hasData: boolean;
+	allies: AllyProfile[] | null;
+}
+
+export interface BuildUpdate {
+	hasBuild: boolean;
+	championName?: string;
+	role?: string;
+	winRate?: string;
+	winRateLabel?: string;
+	enemyName?: string;
+	matchupStatus?: string;
+	patch?: string;
+	bracket?: string;
+	error?: string;
+}
+
+export interface BansUpdate {
+	hasBans: boolean;
+	championName?: string;
+	role?: string;
+	bans: ChampionPick[] | null;
+	noData?: boolean;
+}
+
+export interface CounterPicksUpdate {
+	hasData: boolean;
+	enemyName?: string;
+	enemyIcon?: string;
+	picks: ChampionPick[] | null;
+}
+
+export interface ItemsUpdate {
+	hasItems: boolean;
+	championName?: string;
+	role?: string;
+	builds: ItemBuild[] | null;
+}
+
+export interface TeamCompUpdate {
+	show: boolean;
+	recommendation?: string;
+	severity?: string;
+	apCount?: number;
+	adCount?: number;
+}
+
+export interface FullCompUpdate {
+	ready: boolean;
+	allyArchetype?: string;
+	allyTags?: string[];
+	allyAP?: number;
+	allyAD?: number;
+	enemyArchetype?: string;
+	enemyTags?: string[];
+	enemyAP?: number;
+	enemyAD?: number;
+}
+
+export interface InGameBuild {
+	hasBuild: boolean;
+	championName?: string;
+	championID?: number;
+	championIcon?: string;
+	role?: string;
+	builds: ItemBuild[] | null;
+	error?: string;
+}
+
+export interface ScoutingUpdate {
+	hasData: boolean;
+	complete?: boolean;
+	myTeam: ScoutedPlayer[] | null;
+	enemyTeam: ScoutedPlayer[] | null;
+	error?: string;
+}
+
+export interface GoldUpdate {
+	hasData: boolean;
+	error?: string;
+	myTeam: GoldPlayer[] | null;
+	enemyTeam: GoldPlayer[] | null;
+	myTeamGold: number;
+	enemyTeamGold: number;
+	goldDiff: number;
+	matchups: GoldMatchup[] | null;
+	nextPurchase?: NextPurchase;
+}
+
+export type GoldBoxShow = boolean;
+
+export interface SettingsChanged {
+	version: number;
+	window: WindowSettings;
+	hotkeys: HotkeySettings;
+	matchups: MatchupSettings;
+	teamComp: TeamCompSettings;
+}
+
+export interface StatsStatus {
+	state: string;
+	message: string;
+	patch?: string;
+	error?: string;
+	failures: number;
+	retryIn?: number;
+}
+
+export interface HistorySynced {
+	added: number;
+}
+
+export interface AllyProfile {
+	cellId: number;
+	puuid: string;
+	gameName: string;
+	tagLine: string;
+	isMe: boolean;
+	position: string;
+	championId: number;
+	championName: string;
+	championIcon: string;
+	isLocked: boolean;
+	status: string;
+	error: string;
+	rankTier: string;
+	rankDivision: string;
+	rankLabel: string;
+	recentGames: number;
+	recentWinRate: number;
+	championGames: number;
+	championWinRate: number;
+	firstTime: boolean;
+	autofilled: boolean;
+	mainRoles: string[] | null;
+}
+
+export interface ChampionPick {
+	championID: number;
+	championName: string;
+	iconURL: string;
+	damageType?: string;
+	winRate: number;
+	games: number;
+}
+
+export interface ItemBuild {
+	name?: string;
+	winRate?: number;
+	games?: number;
+	startingItems?: Item[];
+	coreItems: Item[] | null;
+	fourthItems: ItemOption[] | null;
+	fifthItems: ItemOption[] | null;
+	sixthItems: ItemOption[] | null;
+}
+
+export interface ScoutedPlayer {
+	puuid: string;
+	gameName: string;
+	tagLine: string;
+	championId: number;
+	championName: string;
+	championIcon: string;
+	isMe: boolean;
+	position: string;
+	status: string;
+	error: string;
+	summonerLevel: number;
+	soloRank: Rank;
+	flexRank: Rank;
+	masteryLevel: number;
+	masteryPoints: number;
+	championGames: number;
+	championWinRate: number;
+	isSmurf: boolean;
+	isOneTrick: boolean;
+	isOffRole: boolean;
+	games: number;
+	wins: number;
+	winRate: number;
+	avgKills: number;
+	avgDeaths: number;
+	avgAssists: number;
+	kda: number;
+	avgCS: number;
+	tiltLevel: string;
+	funFact: string;
+	insights: Insight[] | null;
+}
+
+export interface GoldPlayer {
+	summonerName: string;
+	championName: string;
+	championIcon: string;
+	position: string;
+	team: string;
+	isMe: boolean;
+	level: number;
+	kills: number;
+	deaths: number;
+	assists: number;
+	cs: number;
+	itemGold: number;
+	items: GoldItem[] | null;
+}
+
+export interface GoldMatchup {
+	position: string;
+	myPlayer: GoldPlayer;
+	enemyPlayer: GoldPlayer;
+	goldDiff: number;
+}
+
+export interface NextPurchase {
+	target: GoldItem;
+	item?: GoldItem;
+	cost: number;
+	currentGold: number;
+	goldMissing: number;
+}
+
+export interface WindowSettings {
+	width: number;
+	height: number;
+	customPosition: boolean;
+	x: number;
+	y: number;
+}
+
+export interface HotkeySettings {
+	toggle: string;
+	tabHudEnable: boolean;
+}
+
+export interface MatchupSettings {
+	winningThreshold: number;
+	losingThreshold: number;
+}
+
+export interface TeamCompSettings {
+	warningRatio: number;
+	criticalRatio: number;
+}
+
+export interface Item {
+	id: number;
+	name: string;
+	iconURL: string;
+}
+
+export interface ItemOption {
+	id: number;
+	name: string;
+	iconURL: string;
+	winRate: number;
+	games: number;
+}
+
+export interface Rank {
+	tier: string;
+	division: string;
+	lp: number;
+	wins: number;
+	losses: number;
+	label: string;
+	isRanked: boolean;
+}
+
+export interface Insight {
+	ruleId: string;
+	message: string;
+	tags: string[] | null;
+	score: number;
+	priority: number;
+}
+
+export interface GoldItem {
+	id: number;
+	name: string;
+	gold: number;
+	iconURL: string;
+}
+
+export interface EventMap {
+	"lcu:status": LCUStatus;
+	"gameflow:update": GameflowUpdate;
+	"champselect:update": ChampSelectUpdate;
+	"champselect:allies": AlliesUpdate;
+	"build:update": BuildUpdate;
+	"bans:update": BansUpdate;
+	"counterpicks:update": CounterPicksUpdate;
+	"items:update": ItemsUpdate;
+	"teamcomp:update": TeamCompUpdate;
+	"fullcomp:update": FullCompUpdate;
+	"ingame:build": InGameBuild;
+	"ingame:scouting": ScoutingUpdate;
+	"gold:update": GoldUpdate;
+	"goldbox:show": GoldBoxShow;
+	"settings:changed": SettingsChanged;
+	"stats:status": StatsStatus;
+	"history:synced": HistorySynced;
+}
+
+export type EventName = keyof EventMap;
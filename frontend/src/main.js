@@ -1,5 +1,5 @@
 import './style.css';
-import { GetConnectionStatus, GetMetaChampions, GetPersonalStats, GetChampionDetails, GetChampionBuild, GetGameflowPhase } from '../wailsjs/go/main/App';
+import { GetConnectionStatus, GetMetaChampions, GetPersonalStats, GetChampionDetails, GetChampionBuild, GetGameflowPhase, GetSettings, GetStatsStatus, SaveWindowPosition } from '../wailsjs/go/main/App';
 import { EventsOn } from '../wailsjs/runtime/runtime';
 
 // Initial HTML structure
@@ -11,6 +11,7 @@ document.querySelector('#app').innerHTML = `
             <span class="gold-team enemy" id="gold-enemy-team">0g</span>
         </div>
         <span class="gold-diff" id="gold-diff"></span>
+        <div class="next-purchase hidden" id="next-purchase"></div>
     </div>
     <div class="build-box hidden" id="build-box">
         <div class="build-box-content" id="build-box-content">
@@ -27,6 +28,7 @@ document.querySelector('#app').innerHTML = `
                 <div class="status-dot waiting" id="status-dot"></div>
                 <span class="status-message" id="status-message">Initializing...</span>
             </div>
+            <div class="stats-status hidden" id="stats-status"></div>
         </div>
 
         <div class="ingame-overlay hidden" id="ingame-overlay">
@@ -86,6 +88,11 @@ document.querySelector('#app').innerHTML = `
                     <div class="counterpicks-list" id="counterpicks-list"></div>
                 </div>
 
+                <div class="allies-card hidden" id="allies-card">
+                    <div class="allies-header">Your Team</div>
+                    <div class="allies-list" id="allies-list"></div>
+                </div>
+
                 <div class="build-card hidden" id="build-card">
                     <div class="build-role" id="build-role"></div>
                     <div class="build-matchup">
@@ -134,6 +141,7 @@ const buildBox = document.getElementById('build-box');
 const goldMyTeam = document.getElementById('gold-my-team');
 const goldEnemyTeam = document.getElementById('gold-enemy-team');
 const goldDiff = document.getElementById('gold-diff');
+const nextPurchase = document.getElementById('next-purchase');
 const buildBoxContent = document.getElementById('build-box-content');
 
 // DOM elements - Main overlay
@@ -141,6 +149,7 @@ const overlayBox = document.getElementById('overlay-box');
 const statusDot = document.getElementById('status-dot');
 const statusMessage = document.getElementById('status-message');
 const statusCard = document.getElementById('status-card');
+const statsStatus = document.getElementById('stats-status');
 const tabsContainer = document.getElementById('tabs-container');
 const ingameOverlay = document.getElementById('ingame-overlay');
 const ingameChampIcon = document.getElementById('ingame-champ-icon');
@@ -156,6 +165,8 @@ const bansList = document.getElementById('bans-list');
 const counterpicksCard = document.getElementById('counterpicks-card');
 const counterpicksSubheader = document.getElementById('counterpicks-subheader');
 const counterpicksList = document.getElementById('counterpicks-list');
+const alliesCard = document.getElementById('allies-card');
+const alliesList = document.getElementById('allies-list');
 const buildCard = document.getElementById('build-card');
 const buildRole = document.getElementById('build-role');
 const buildWinrate = document.getElementById('build-winrate');
@@ -362,12 +373,30 @@ function renderBasicItems(items) {
     return '<div class="items-empty">No data</div>';
 }
 
+// Matchup win rate cutoffs - kept in sync with settings
+let matchupThresholds = { winningThreshold: 51, losingThreshold: 49 };
+
+// Classify a win rate as winning/losing/even using the user's thresholds
+function winRateClass(winRate) {
+    if (winRate >= matchupThresholds.winningThreshold) return 'winning';
+    if (winRate <= matchupThresholds.losingThreshold) return 'losing';
+    return 'even';
+}
+
+// Apply settings that affect rendering
+/** @param {import('./events').SettingsChanged} settings */
+function applySettings(settings) {
+    if (settings && settings.matchups) {
+        matchupThresholds = settings.matchups;
+    }
+}
+
 // Helper to render items with win rate - shared between Build tab and Meta details
 function renderItemsWithWR(items) {
     if (items && items.length > 0) {
         return items.map(item => {
             const wr = item.winRate ? item.winRate.toFixed(1) : '?';
-            const wrClass = item.winRate >= 51 ? 'winning' : item.winRate <= 49 ? 'losing' : 'even';
+            const wrClass = winRateClass(item.winRate);
             return `
                 <div class="item-slot-wr" data-tooltip="${item.name}">
                     <img class="item-icon" src="${item.iconURL}" alt="${item.name}" />
@@ -493,7 +522,7 @@ function loadMetaData() {
                 return;
             }
 
-            metaHeader.textContent = `Top Champions - Patch ${data.patch}`;
+            metaHeader.textContent = `Top Champions - Patch ${data.patch} · ${data.bracket}`;
             metaDataLoaded = true;
             currentMetaData = data;
 
@@ -643,12 +672,31 @@ function formatRole(role) {
 }
 
 // Update connection status
+/** @param {import('./events').LCUStatus} status */
 function updateStatus(status) {
     statusMessage.textContent = status.message;
     statusDot.className = status.connected ? 'status-dot connected' : 'status-dot waiting';
 }
 
+// Reload personal stats when a background sync stores new games, if they're on screen
+/** @param {import('./events').HistorySynced} data */
+function onHistorySynced(data) {
+    console.log(`Match history synced: ${data.added} new games`);
+    if (document.getElementById('tab-stats').classList.contains('active')) {
+        loadPersonalStats();
+    }
+}
+
+// Show the stats database connection only while it's unhealthy
+/** @param {import('./events').StatsStatus} status */
+function updateStatsStatus(status) {
+    statsStatus.textContent = status.message;
+    statsStatus.className = `stats-status ${status.state}`;
+    statsStatus.classList.toggle('hidden', status.state === 'connected');
+}
+
 // Update gameflow state
+/** @param {import('./events').GameflowUpdate} data */
 function updateGameflow(data) {
     const phase = data.phase;
     console.log('Gameflow phase:', phase);
@@ -763,7 +811,7 @@ function renderBuildBoxItemsWithWR(items) {
     if (!items || items.length === 0) return '<span class="build-box-empty">-</span>';
     return items.slice(0, 4).map(item => {
         const wr = item.winRate ? item.winRate.toFixed(1) : '?';
-        const wrClass = item.winRate >= 51 ? 'winning' : item.winRate <= 49 ? 'losing' : 'even';
+        const wrClass = winRateClass(item.winRate);
         return `
             <div class="build-box-item-wr" data-tooltip="${item.name}">
                 <img class="build-box-item-icon" src="${item.iconURL}" alt="${item.name}" />
@@ -774,6 +822,7 @@ function renderBuildBoxItemsWithWR(items) {
 }
 
 // Update build box from items:update event (champ select)
+/** @param {import('./events').ItemsUpdate} data */
 function updateBuildBoxFromItems(data) {
     if (!data || !data.hasItems || !data.builds || data.builds.length === 0) {
         return;
@@ -782,6 +831,7 @@ function updateBuildBoxFromItems(data) {
 }
 
 // Update build box from ingame:build event (in-game)
+/** @param {import('./events').InGameBuild} data */
 function updateBuildBoxFromInGame(data) {
     if (!data || !data.hasBuild || !data.builds || data.builds.length === 0) {
         return;
@@ -790,6 +840,7 @@ function updateBuildBoxFromInGame(data) {
 }
 
 // Update in-game build
+/** @param {import('./events').InGameBuild} data */
 function updateInGameBuild(data) {
     console.log('In-game build data:', data);
 
@@ -856,7 +907,7 @@ function renderInGameItemsWithWR(items) {
     if (!items || items.length === 0) return '<span class="ingame-no-items">-</span>';
     return items.slice(0, 5).map(item => {
         const wr = item.winRate ? item.winRate.toFixed(1) : '?';
-        const wrClass = item.winRate >= 51 ? 'winning' : item.winRate <= 49 ? 'losing' : 'even';
+        const wrClass = winRateClass(item.winRate);
         return `
             <div class="ingame-item-wr" data-tooltip="${item.name}">
                 <img class="ingame-item-icon" src="${item.iconURL}" alt="${item.name}" />
@@ -867,6 +918,7 @@ function renderInGameItemsWithWR(items) {
 }
 
 // Update scouting data
+/** @param {import('./events').ScoutingUpdate} data */
 function updateScouting(data) {
     console.log('Scouting data:', data);
 
@@ -913,29 +965,73 @@ function renderPlayerCard(player) {
     const tiltIcon = player.tiltLevel === 'tilted' ? '🔥'
         : player.tiltLevel === 'on_fire' ? '⭐'
         : player.tiltLevel === 'warming_up' ? '😰'
+        : player.tiltLevel === 'smurf' ? '🎭'
         : '';
 
     const meTag = player.isMe ? '<span class="player-me-tag">YOU</span>' : '';
+    const riotId = player.gameName ? `${player.gameName}${player.tagLine ? ' #' + player.tagLine : ''}` : 'Unknown';
+
+    if (player.status === 'loading') {
+        return `
+            <div class="player-card loading">
+                <div class="player-header">
+                    <img class="player-champ-icon" src="${player.championIcon}" alt="${player.championName}" />
+                    <div class="player-info">
+                        <div class="player-name">${riotId}${meTag}</div>
+                        <div class="player-champ">${player.championName}</div>
+                    </div>
+                </div>
+                <div class="player-funfact">Loading history...</div>
+            </div>
+        `;
+    }
 
     return `
         <div class="player-card ${tiltClass}">
             <div class="player-header">
                 <img class="player-champ-icon" src="${player.championIcon}" alt="${player.championName}" />
                 <div class="player-info">
-                    <div class="player-name">${player.gameName || 'Unknown'}${meTag} ${tiltIcon}</div>
+                    <div class="player-name">${riotId}${meTag} ${tiltIcon}</div>
                     <div class="player-champ">${player.championName}</div>
                 </div>
                 <div class="player-wr ${wrClass}">${wrDisplay}</div>
             </div>
+            <div class="player-stats">
+                <span class="player-stat"><strong>Solo:</strong> ${formatRank(player.soloRank)}</span>
+                <span class="player-stat"><strong>Flex:</strong> ${formatRank(player.flexRank)}</span>
+            </div>
             <div class="player-stats">
                 <span class="player-stat"><strong>KDA:</strong> ${kdaDisplay} (${kdaAvg})</span>
                 <span class="player-stat"><strong>Games:</strong> ${player.games}</span>
             </div>
+            <div class="player-stats">
+                <span class="player-stat"><strong>Mastery:</strong> ${player.masteryPoints > 0 ? Math.round(player.masteryPoints / 1000) + 'k' : '-'}</span>
+                <span class="player-stat"><strong>On champ:</strong> ${player.championGames > 0 ? `${player.championGames} (${player.championWinRate.toFixed(0)}%)` : '-'}</span>
+            </div>
+            ${renderPlayerFlags(player)}
             ${player.funFact ? `<div class="player-funfact">${player.funFact}</div>` : ''}
+            ${(player.insights || []).slice(1, 3).map(i => `<div class="player-funfact secondary">${i.message}</div>`).join('')}
         </div>
     `;
 }
 
+// Format a ranked queue (e.g., "Gold II 45 LP · 18W 4L")
+function formatRank(rank) {
+    if (!rank || !rank.isRanked) {
+        return 'Unranked';
+    }
+    return `${rank.label} ${rank.lp} LP · ${rank.wins}W ${rank.losses}L`;
+}
+
+// Render smurf/one-trick/off-role badges
+function renderPlayerFlags(player) {
+    const flags = [];
+    if (player.isSmurf) flags.push('<span class="ally-badge">Smurf</span>');
+    if (player.isOneTrick) flags.push('<span class="ally-badge">One-trick</span>');
+    if (player.isOffRole) flags.push('<span class="ally-badge">Off-role</span>');
+    return flags.length > 0 ? `<div class="player-flags">${flags.join('')}</div>` : '';
+}
+
 // Format gold value (e.g., 12500 -> "12.5k")
 function formatGold(gold) {
     if (gold >= 1000) {
@@ -945,12 +1041,14 @@ function formatGold(gold) {
 }
 
 // Update gold box display
+/** @param {import('./events').GoldUpdate} data */
 function updateGoldBox(data) {
     if (!data.hasData) {
         goldMyTeam.textContent = '---';
         goldEnemyTeam.textContent = '---';
         goldDiff.textContent = '';
         goldDiff.className = 'gold-diff';
+        updateNextPurchase(null);
         return;
     }
 
@@ -966,9 +1064,36 @@ function updateGoldBox(data) {
 
     goldDiff.textContent = `${sign}${formatGold(Math.abs(diff))}`;
     goldDiff.className = `gold-diff ${diffClass}`;
+    updateNextPurchase(data.nextPurchase);
+}
+
+// Show what to buy on the next recall toward the next build item
+/** @param {import('./events').NextPurchase | null | undefined} next */
+function updateNextPurchase(next) {
+    if (!next) {
+        nextPurchase.classList.add('hidden');
+        return;
+    }
+
+    const buy = next.item
+        ? `<img class="next-purchase-icon" src="${next.item.iconURL}" alt="">
+           <span class="next-purchase-name">${next.item.name}</span>
+           <span class="next-purchase-cost">${next.cost}g</span>`
+        : `<span class="next-purchase-name muted">Nothing affordable</span>`;
+    const toward = next.item && next.item.id === next.target.id
+        ? ''
+        : `<span class="next-purchase-toward">→</span>
+           <img class="next-purchase-icon target" src="${next.target.iconURL}" alt="" title="${next.target.name}">`;
+    const missing = next.goldMissing > 0
+        ? `<span class="next-purchase-missing">${next.goldMissing}g missing</span>`
+        : `<span class="next-purchase-missing ready">Ready</span>`;
+
+    nextPurchase.innerHTML = `<span class="next-purchase-label">Next</span>${buy}${toward}${missing}`;
+    nextPurchase.classList.remove('hidden');
 }
 
 // Toggle tab HUD mode
+/** @param {import('./events').GoldBoxShow} active */
 function onGoldBoxShow(active) {
     isGoldBoxMode = active;
     if (active) {
@@ -983,6 +1108,7 @@ function onGoldBoxShow(active) {
 }
 
 // Update champ select state
+/** @param {import('./events').ChampSelectUpdate} data */
 function updateChampSelect(data) {
     // Don't update UI if we're in game
     if (isInGame) {
@@ -1023,6 +1149,7 @@ function updateChampSelect(data) {
 }
 
 // Update team comp warning
+/** @param {import('./events').TeamCompUpdate} data */
 function updateTeamComp(data) {
     if (!data || !data.show) {
         teamcompCard.classList.add('hidden');
@@ -1035,6 +1162,7 @@ function updateTeamComp(data) {
 }
 
 // Update recommended bans
+/** @param {import('./events').BansUpdate} data */
 function updateBans(data) {
     if (!data || !data.hasBans) {
         bansSubheader.textContent = '';
@@ -1072,6 +1200,7 @@ function updateBans(data) {
 }
 
 // Update build/matchup data
+/** @param {import('./events').BuildUpdate} data */
 function updateBuild(data) {
     if (!data.hasBuild) {
         buildCard.classList.add('hidden');
@@ -1080,7 +1209,7 @@ function updateBuild(data) {
 
     buildCard.classList.remove('hidden');
 
-    buildRole.textContent = formatRole(data.role);
+    buildRole.textContent = data.bracket ? `${formatRole(data.role)} · ${data.bracket}` : formatRole(data.role);
     winrateLabel.textContent = data.winRateLabel || 'Win Rate';
     buildWinrate.textContent = data.winRate;
 
@@ -1091,6 +1220,7 @@ function updateBuild(data) {
 }
 
 // Update full team comp analysis (when all locked in)
+/** @param {import('./events').FullCompUpdate} data */
 function updateFullComp(data) {
     if (!data || !data.ready) {
         compWaiting.classList.remove('hidden');
@@ -1168,6 +1298,7 @@ function renderBuildsToContainer(subtabsEl, contentEl, builds) {
 }
 
 // Update item build with multiple build paths (Build tab during champ select)
+/** @param {import('./events').ItemsUpdate} data */
 function updateItems(data) {
     console.log('updateItems called with:', data);
 
@@ -1189,6 +1320,7 @@ function updateItems(data) {
 }
 
 // Update counter picks (shown after ban phase)
+/** @param {import('./events').CounterPicksUpdate} data */
 function updateCounterPicks(data) {
     if (!data || !data.hasData) {
         counterpicksSubheader.textContent = 'Waiting for enemy...';
@@ -1218,6 +1350,54 @@ function updateCounterPicks(data) {
     counterpicksList.innerHTML = html;
 }
 
+/** @param {import('./events').AlliesUpdate} data */
+function updateAllies(data) {
+    if (!data || !data.hasData || !data.allies || data.allies.length === 0) {
+        alliesCard.classList.add('hidden');
+        alliesList.innerHTML = '';
+        return;
+    }
+
+    alliesCard.classList.remove('hidden');
+
+    let html = '';
+    for (const ally of data.allies) {
+        const name = ally.gameName || 'Teammate';
+        const icon = ally.championIcon
+            ? `<img class="counterpick-icon" src="${ally.championIcon}" alt="${ally.championName}" />`
+            : '<div class="counterpick-icon ally-icon-empty"></div>';
+
+        let detail;
+        if (ally.status === 'loading') {
+            detail = 'Loading...';
+        } else if (ally.status === 'error') {
+            detail = 'No history';
+        } else {
+            const parts = [`${ally.rankLabel}`, `${ally.recentWinRate.toFixed(0)}% WR (${ally.recentGames})`];
+            if (ally.championId > 0 && ally.championGames > 0) {
+                parts.push(`${ally.championWinRate.toFixed(0)}% on ${ally.championName} (${ally.championGames})`);
+            }
+            detail = parts.join(' · ');
+        }
+
+        const badges = [];
+        if (ally.firstTime) badges.push('<span class="ally-badge">First time</span>');
+        if (ally.autofilled) badges.push('<span class="ally-badge">Autofill</span>');
+
+        html += `
+            <div class="counterpick-row${ally.isMe ? ' ally-me' : ''}">
+                ${icon}
+                <div class="ally-info">
+                    <span class="counterpick-name">${name}</span>
+                    <span class="counterpick-games">${detail}</span>
+                </div>
+                ${badges.join('')}
+            </div>
+        `;
+    }
+    alliesList.innerHTML = html;
+}
+
 // Event listeners
 EventsOn('lcu:status', updateStatus);
 EventsOn('champselect:update', updateChampSelect);
@@ -1227,12 +1407,24 @@ EventsOn('teamcomp:update', updateTeamComp);
 EventsOn('fullcomp:update', updateFullComp);
 EventsOn('items:update', updateItems);
 EventsOn('counterpicks:update', updateCounterPicks);
+EventsOn('champselect:allies', updateAllies);
+EventsOn('settings:changed', applySettings);
+EventsOn('stats:status', updateStatsStatus);
+EventsOn('history:synced', onHistorySynced);
 EventsOn('gameflow:update', updateGameflow);
 EventsOn('ingame:build', updateInGameBuild);
 EventsOn('ingame:scouting', updateScouting);
 EventsOn('gold:update', updateGoldBox);
 EventsOn('goldbox:show', onGoldBoxShow);
 
+// Load settings, and remember where the overlay was dragged to
+GetSettings().then(applySettings).catch(err => console.log('Failed to get settings:', err));
+document.querySelector('.drag-region').addEventListener('mouseup', () => {
+    SaveWindowPosition();
+});
+
+GetStatsStatus().then(updateStatsStatus).catch(err => console.log('Failed to get stats status:', err));
+
 // Get initial status
 GetConnectionStatus()
     .then(status => {
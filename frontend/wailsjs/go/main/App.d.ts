@@ -2,27 +2,49 @@
 // This file is automatically generated. DO NOT EDIT
 import {main} from '../models';
 import {lcu} from '../models';
+import {history} from '../models';
+import {settings} from '../models';
+import {events} from '../models';
+import {data} from '../models';
 
 export function ForceStatsUpdate():Promise<string>;
 
+export function GetCacheStats():Promise<data.CacheStats>;
+
 export function GetChampionBuild(arg1:number,arg2:string):Promise<main.ChampionBuildData>;
 
 export function GetChampionDetails(arg1:number,arg2:string):Promise<main.ChampionDetails>;
 
+export function GetChampionPool(arg1:history.Filter):Promise<Array<lcu.ChampionPersonalStats>>;
+
 export function GetConnectionStatus():Promise<Record<string, any>>;
 
 export function GetGameflowPhase():Promise<Record<string, any>>;
 
-export function GetGoldDiff():Promise<Record<string, any>>;
+export function GetGoldDiff():Promise<events.GoldUpdate>;
+
+export function GetHistoryPatches():Promise<Array<string>>;
+
+export function GetMatchupHistory(arg1:history.Filter):Promise<Array<history.MatchupStats>>;
 
 export function GetMetaChampions():Promise<main.MetaData>;
 
 export function GetPersonalStats():Promise<lcu.PersonalStats>;
 
+export function GetPersonalStatsFiltered(arg1:history.Filter):Promise<lcu.PersonalStats>;
+
+export function GetSettings():Promise<settings.Settings>;
+
+export function GetStatsStatus():Promise<data.ConnStatus>;
+
 export function HideForGame():Promise<void>;
 
 export function RegisterToggleHotkey():Promise<void>;
 
+export function SaveWindowPosition():Promise<void>;
+
 export function ShowAfterGame():Promise<void>;
 
 export function ToggleWindow():Promise<void>;
+
+export function UpdateSettings(arg1:settings.Settings):Promise<settings.Settings>;
@@ -6,6 +6,10 @@ export function ForceStatsUpdate() {
   return window['go']['main']['App']['ForceStatsUpdate']();
 }
 
+export function GetCacheStats() {
+  return window['go']['main']['App']['GetCacheStats']();
+}
+
 export function GetChampionBuild(arg1, arg2) {
   return window['go']['main']['App']['GetChampionBuild'](arg1, arg2);
 }
@@ -14,6 +18,10 @@ export function GetChampionDetails(arg1, arg2) {
   return window['go']['main']['App']['GetChampionDetails'](arg1, arg2);
 }
 
+export function GetChampionPool(arg1) {
+  return window['go']['main']['App']['GetChampionPool'](arg1);
+}
+
 export function GetConnectionStatus() {
   return window['go']['main']['App']['GetConnectionStatus']();
 }
@@ -26,6 +34,14 @@ export function GetGoldDiff() {
   return window['go']['main']['App']['GetGoldDiff']();
 }
 
+export function GetHistoryPatches() {
+  return window['go']['main']['App']['GetHistoryPatches']();
+}
+
+export function GetMatchupHistory(arg1) {
+  return window['go']['main']['App']['GetMatchupHistory'](arg1);
+}
+
 export function GetMetaChampions() {
   return window['go']['main']['App']['GetMetaChampions']();
 }
@@ -34,6 +50,18 @@ export function GetPersonalStats() {
   return window['go']['main']['App']['GetPersonalStats']();
 }
 
+export function GetPersonalStatsFiltered(arg1) {
+  return window['go']['main']['App']['GetPersonalStatsFiltered'](arg1);
+}
+
+export function GetSettings() {
+  return window['go']['main']['App']['GetSettings']();
+}
+
+export function GetStatsStatus() {
+  return window['go']['main']['App']['GetStatsStatus']();
+}
+
 export function HideForGame() {
   return window['go']['main']['App']['HideForGame']();
 }
@@ -42,6 +70,10 @@ export function RegisterToggleHotkey() {
   return window['go']['main']['App']['RegisterToggleHotkey']();
 }
 
+export function SaveWindowPosition() {
+  return window['go']['main']['App']['SaveWindowPosition']();
+}
+
 export function ShowAfterGame() {
   return window['go']['main']['App']['ShowAfterGame']();
 }
@@ -49,3 +81,7 @@ export function ShowAfterGame() {
 export function ToggleWindow() {
   return window['go']['main']['App']['ToggleWindow']();
 }
+
+export function UpdateSettings(arg1) {
+  return window['go']['main']['App']['UpdateSettings'](arg1);
+}
@@ -1,3 +1,310 @@
+export namespace data {
+	
+	export class CacheStats {
+	    version: string;
+	    entries: number;
+	    maxEntries: number;
+	    persistent: boolean;
+	    hits: number;
+	    diskHits: number;
+	    misses: number;
+	    evictions: number;
+	    expired: number;
+	    invalidations: number;
+	    hitRate: number;
+	    coalesced: number;
+	    staleServed: number;
+	    partialBuilds: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CacheStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.entries = source["entries"];
+	        this.maxEntries = source["maxEntries"];
+	        this.persistent = source["persistent"];
+	        this.hits = source["hits"];
+	        this.diskHits = source["diskHits"];
+	        this.misses = source["misses"];
+	        this.evictions = source["evictions"];
+	        this.expired = source["expired"];
+	        this.invalidations = source["invalidations"];
+	        this.hitRate = source["hitRate"];
+	        this.coalesced = source["coalesced"];
+	        this.staleServed = source["staleServed"];
+	        this.partialBuilds = source["partialBuilds"];
+	    }
+	}
+	export class ConnStatus {
+	    state: string;
+	    message: string;
+	    patch?: string;
+	    error?: string;
+	    failures: number;
+	    retryIn?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConnStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.state = source["state"];
+	        this.message = source["message"];
+	        this.patch = source["patch"];
+	        this.error = source["error"];
+	        this.failures = source["failures"];
+	        this.retryIn = source["retryIn"];
+	    }
+	}
+
+}
+
+export namespace events {
+	
+	export class GoldItem {
+	    id: number;
+	    name: string;
+	    gold: number;
+	    iconURL: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GoldItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.gold = source["gold"];
+	        this.iconURL = source["iconURL"];
+	    }
+	}
+	export class GoldMatchup {
+	    position: string;
+	    myPlayer: GoldPlayer;
+	    enemyPlayer: GoldPlayer;
+	    goldDiff: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GoldMatchup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.position = source["position"];
+	        this.myPlayer = this.convertValues(source["myPlayer"], GoldPlayer);
+	        this.enemyPlayer = this.convertValues(source["enemyPlayer"], GoldPlayer);
+	        this.goldDiff = source["goldDiff"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GoldPlayer {
+	    summonerName: string;
+	    championName: string;
+	    championIcon: string;
+	    position: string;
+	    team: string;
+	    isMe: boolean;
+	    level: number;
+	    kills: number;
+	    deaths: number;
+	    assists: number;
+	    cs: number;
+	    itemGold: number;
+	    items: GoldItem[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GoldPlayer(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.summonerName = source["summonerName"];
+	        this.championName = source["championName"];
+	        this.championIcon = source["championIcon"];
+	        this.position = source["position"];
+	        this.team = source["team"];
+	        this.isMe = source["isMe"];
+	        this.level = source["level"];
+	        this.kills = source["kills"];
+	        this.deaths = source["deaths"];
+	        this.assists = source["assists"];
+	        this.cs = source["cs"];
+	        this.itemGold = source["itemGold"];
+	        this.items = this.convertValues(source["items"], GoldItem);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GoldUpdate {
+	    hasData: boolean;
+	    error?: string;
+	    myTeam: GoldPlayer[];
+	    enemyTeam: GoldPlayer[];
+	    myTeamGold: number;
+	    enemyTeamGold: number;
+	    goldDiff: number;
+	    matchups: GoldMatchup[];
+	    nextPurchase?: NextPurchase;
+	
+	    static createFrom(source: any = {}) {
+	        return new GoldUpdate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hasData = source["hasData"];
+	        this.error = source["error"];
+	        this.myTeam = this.convertValues(source["myTeam"], GoldPlayer);
+	        this.enemyTeam = this.convertValues(source["enemyTeam"], GoldPlayer);
+	        this.myTeamGold = source["myTeamGold"];
+	        this.enemyTeamGold = source["enemyTeamGold"];
+	        this.goldDiff = source["goldDiff"];
+	        this.matchups = this.convertValues(source["matchups"], GoldMatchup);
+	        this.nextPurchase = this.convertValues(source["nextPurchase"], NextPurchase);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NextPurchase {
+	    target: GoldItem;
+	    item?: GoldItem;
+	    cost: number;
+	    currentGold: number;
+	    goldMissing: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new NextPurchase(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.target = this.convertValues(source["target"], GoldItem);
+	        this.item = this.convertValues(source["item"], GoldItem);
+	        this.cost = source["cost"];
+	        this.currentGold = source["currentGold"];
+	        this.goldMissing = source["goldMissing"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace history {
+	
+	export class Filter {
+	    queueIds: number[];
+	    patch: string;
+	    championId: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Filter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.queueIds = source["queueIds"];
+	        this.patch = source["patch"];
+	        this.championId = source["championId"];
+	    }
+	}
+	export class MatchupStats {
+	    championId: number;
+	    championName: string;
+	    iconURL: string;
+	    games: number;
+	    wins: number;
+	    winRate: number;
+	    avgKDA: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MatchupStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.championId = source["championId"];
+	        this.championName = source["championName"];
+	        this.iconURL = source["iconURL"];
+	        this.games = source["games"];
+	        this.wins = source["wins"];
+	        this.winRate = source["winRate"];
+	        this.avgKDA = source["avgKDA"];
+	    }
+	}
+
+}
+
 export namespace lcu {
 	
 	export class ChampionPersonalStats {
@@ -315,6 +622,7 @@ export namespace main {
 	}
 	export class MetaData {
 	    patch: string;
+	    bracket: string;
 	    hasData: boolean;
 	    roles: Record<string, Array<MetaChampion>>;
 	
@@ -325,6 +633,7 @@ export namespace main {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.patch = source["patch"];
+	        this.bracket = source["bracket"];
 	        this.hasData = source["hasData"];
 	        this.roles = this.convertValues(source["roles"], Array<MetaChampion>, true);
 	    }
@@ -350,3 +659,108 @@ export namespace main {
 
 }
 
+export namespace settings {
+	
+	export class HotkeySettings {
+	    toggle: string;
+	    tabHudEnable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new HotkeySettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.toggle = source["toggle"];
+	        this.tabHudEnable = source["tabHudEnable"];
+	    }
+	}
+	export class MatchupSettings {
+	    winningThreshold: number;
+	    losingThreshold: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MatchupSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.winningThreshold = source["winningThreshold"];
+	        this.losingThreshold = source["losingThreshold"];
+	    }
+	}
+	export class Settings {
+	    version: number;
+	    window: WindowSettings;
+	    hotkeys: HotkeySettings;
+	    matchups: MatchupSettings;
+	    teamComp: TeamCompSettings;
+	
+	    static createFrom(source: any = {}) {
+	        return new Settings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.window = this.convertValues(source["window"], WindowSettings);
+	        this.hotkeys = this.convertValues(source["hotkeys"], HotkeySettings);
+	        this.matchups = this.convertValues(source["matchups"], MatchupSettings);
+	        this.teamComp = this.convertValues(source["teamComp"], TeamCompSettings);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TeamCompSettings {
+	    warningRatio: number;
+	    criticalRatio: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TeamCompSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.warningRatio = source["warningRatio"];
+	        this.criticalRatio = source["criticalRatio"];
+	    }
+	}
+	export class WindowSettings {
+	    width: number;
+	    height: number;
+	    customPosition: boolean;
+	    x: number;
+	    y: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WindowSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.width = source["width"];
+	        this.height = source["height"];
+	        this.customPosition = source["customPosition"];
+	        this.x = source["x"];
+	        this.y = source["y"];
+	    }
+	}
+
+}
+
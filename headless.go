@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"ghostdraft/internal/server"
+)
+
+// defaultListenAddr keeps the companion server on this PC unless another address is chosen
+const defaultListenAddr = "127.0.0.1:7421"
+
+// runHeadless runs the app without a window. Events stream to the companion server's
+// viewer and the bound getters are served over REST. Stops on Ctrl+C.
+func runHeadless(app *App, addr string, resetToken bool) error {
+	tokenPath := server.UserTokenPath()
+	loadToken := server.LoadToken
+	if resetToken {
+		loadToken = server.ResetToken
+	}
+	token, err := loadToken(tokenPath)
+	if err != nil {
+		return err
+	}
+
+	srv := server.New(token)
+	srv.Bind(app)
+	srv.Handle(assetRoute+"/", http.StripPrefix(assetRoute, app.ddragon.Handler()))
+	app.headless = true
+	app.emitter = srv
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	app.startup(ctx)
+	defer app.shutdown(ctx)
+
+	fmt.Printf("[Server] Listening on %s\n", addr)
+	fmt.Printf("[Server] Viewer: http://%s/?token=%s\n", viewerHost(addr), token)
+	if host, _, _ := net.SplitHostPort(addr); !isLoopback(host) {
+		fmt.Println("[Server] Reachable from your network - share the viewer link only with your own devices")
+	}
+
+	return srv.ListenAndServe(ctx, addr)
+}
+
+// viewerHost turns a listen address into one a browser can open
+func viewerHost(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// isLoopback reports whether host only accepts connections from this PC
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
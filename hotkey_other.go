@@ -0,0 +1,45 @@
+//go:build !windows
+
+package main
+
+import (
+	"fmt"
+
+	"ghostdraft/internal/settings"
+
+	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// applyHotkeySettings does nothing - global hotkeys need the Windows keyboard hook
+func (a *App) applyHotkeySettings(h settings.HotkeySettings) {}
+
+// RegisterToggleHotkey reports that global hotkeys aren't available on this platform
+func (a *App) RegisterToggleHotkey() {
+	fmt.Println("[Hotkey] Global hotkeys and the Tab HUD are only supported on Windows")
+}
+
+// ToggleWindow toggles the window visibility
+func (a *App) ToggleWindow() {
+	a.windowVisible = !a.windowVisible
+	if a.windowVisible {
+		a.ShowAfterGame()
+	} else {
+		a.HideForGame()
+	}
+}
+
+// HideForGame hides the overlay when entering a game
+func (a *App) HideForGame() {
+	if a.ctx == nil || a.headless {
+		return
+	}
+	wailsRuntime.WindowHide(a.ctx)
+}
+
+// ShowAfterGame shows the overlay when leaving a game
+func (a *App) ShowAfterGame() {
+	if a.ctx == nil || a.headless {
+		return
+	}
+	wailsRuntime.WindowShow(a.ctx)
+}
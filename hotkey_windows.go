@@ -2,10 +2,14 @@ package main
 
 import (
 	"fmt"
+	"sync/atomic"
 	"syscall"
 	"time"
 	"unsafe"
 
+	"ghostdraft/internal/events"
+	"ghostdraft/internal/settings"
+
 	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
@@ -25,8 +29,9 @@ const (
 	WH_KEYBOARD_LL = 13
 	WM_KEYDOWN     = 0x0100
 	WM_KEYUP       = 0x0101
-	VK_O           = 0x4F
 	VK_CONTROL     = 0x11
+	VK_SHIFT       = 0x10
+	VK_MENU        = 0x12 // Alt
 	VK_TAB         = 0x09
 
 	// Window style constants for click-through
@@ -57,6 +62,10 @@ var keyboardHook uintptr
 var tabPressed bool
 var stopGoldPoll chan struct{}
 
+// Current hotkey settings - swapped when settings change, read by the hook
+var toggleHotkey atomic.Value // settings.Hotkey
+var tabHUDEnabled atomic.Bool
+
 // Track if gold box is shown during in-game Tab hold
 var isGoldBoxVisible bool
 
@@ -105,8 +114,8 @@ func keyboardProc(nCode int, wParam uintptr, lParam uintptr) uintptr {
 		kbStruct := (*KBDLLHOOKSTRUCT)(unsafe.Pointer(lParam))
 
 		if wParam == WM_KEYDOWN {
-			// Check for Ctrl+O
-			if kbStruct.VkCode == VK_O && isKeyPressed(VK_CONTROL) {
+			// Check for the toggle hotkey (Ctrl+O by default)
+			if isToggleHotkey(kbStruct.VkCode) {
 				if appInstance != nil {
 					appInstance.ToggleWindow()
 				}
@@ -132,9 +141,33 @@ func keyboardProc(nCode int, wParam uintptr, lParam uintptr) uintptr {
 	return ret
 }
 
-// RegisterToggleHotkey registers Ctrl+O as a global hotkey using low-level keyboard hook
+// isToggleHotkey reports whether a key press with the current modifiers matches the toggle hotkey
+func isToggleHotkey(vkCode uint32) bool {
+	hk, ok := toggleHotkey.Load().(settings.Hotkey)
+	if !ok || vkCode != hk.VirtualKey() {
+		return false
+	}
+	return isKeyPressed(VK_CONTROL) == hk.Ctrl &&
+		isKeyPressed(VK_MENU) == hk.Alt &&
+		isKeyPressed(VK_SHIFT) == hk.Shift
+}
+
+// applyHotkeySettings swaps in new hotkeys; the keyboard hook picks them up on the next key press
+func (a *App) applyHotkeySettings(h settings.HotkeySettings) {
+	hk, err := settings.ParseHotkey(h.Toggle)
+	if err != nil {
+		fmt.Printf("Invalid toggle hotkey %q: %v\n", h.Toggle, err)
+		return
+	}
+	toggleHotkey.Store(hk)
+	tabHUDEnabled.Store(h.TabHUDEnable)
+	fmt.Printf("Hotkeys: toggle=%s, Tab HUD=%v\n", hk, h.TabHUDEnable)
+}
+
+// RegisterToggleHotkey registers the toggle hotkey (Ctrl+O by default) using a low-level keyboard hook
 func (a *App) RegisterToggleHotkey() {
 	appInstance = a
+	a.applyHotkeySettings(a.currentSettings().Hotkeys)
 
 	go func() {
 		// Create callback
@@ -152,7 +185,7 @@ func (a *App) RegisterToggleHotkey() {
 			return
 		}
 		keyboardHook = ret
-		fmt.Println("Installed low-level keyboard hook for Ctrl+O")
+		fmt.Println("Installed low-level keyboard hook")
 
 		// Message loop to keep the hook alive
 		var msg MSG
@@ -172,10 +205,10 @@ func (a *App) RegisterToggleHotkey() {
 func (a *App) ToggleWindow() {
 	a.windowVisible = !a.windowVisible
 	if a.windowVisible {
-		fmt.Println("Showing overlay (Ctrl+O)")
+		fmt.Println("Showing overlay (hotkey)")
 		a.showWindow()
 	} else {
-		fmt.Println("Hiding overlay (Ctrl+O)")
+		fmt.Println("Hiding overlay (hotkey)")
 		a.hideWindow()
 	}
 }
@@ -204,8 +237,8 @@ func (a *App) hideWindow() {
 
 // onTabPressed shows gold box overlay when Tab is held in-game
 func (a *App) onTabPressed() {
-	// Only activate if in game
-	if !a.liveClient.IsGameRunning() {
+	// Only activate if enabled and in game
+	if !tabHUDEnabled.Load() || !a.liveClient.IsGameRunning() {
 		return
 	}
 
@@ -216,7 +249,7 @@ func (a *App) onTabPressed() {
 	savedWindowW, savedWindowH = wailsRuntime.WindowGetSize(a.ctx)
 
 	// Tell frontend to show gold box mode FIRST (hides overlay-box)
-	wailsRuntime.EventsEmit(a.ctx, "goldbox:show", true)
+	a.emitter.Emit(events.GoldBoxShow(true))
 
 	// Small delay to let frontend hide the overlay-box before resize
 	time.Sleep(10 * time.Millisecond)
@@ -278,7 +311,7 @@ func (a *App) onTabReleased() {
 		isGoldBoxVisible = false
 
 		// Tell frontend to hide gold box mode
-		wailsRuntime.EventsEmit(a.ctx, "goldbox:show", false)
+		a.emitter.Emit(events.GoldBoxShow(false))
 
 		// Restore mouse events (no longer click-through)
 		hwnd := findGhostDraftWindow()
@@ -299,13 +332,12 @@ func (a *App) emitGoldUpdate() {
 	if a.ctx == nil {
 		return
 	}
-	data := a.GetGoldDiff()
-	wailsRuntime.EventsEmit(a.ctx, "gold:update", data)
+	a.emitter.Emit(a.GetGoldDiff())
 }
 
 // HideForGame hides the overlay when entering a game
 func (a *App) HideForGame() {
-	if a.ctx == nil {
+	if a.ctx == nil || a.headless {
 		return
 	}
 	a.hideWindow()
@@ -313,7 +345,7 @@ func (a *App) HideForGame() {
 
 // ShowAfterGame shows the overlay when leaving a game
 func (a *App) ShowAfterGame() {
-	if a.ctx == nil {
+	if a.ctx == nil || a.headless {
 		return
 	}
 	a.showWindow()
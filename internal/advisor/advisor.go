@@ -0,0 +1,109 @@
+// Package advisor suggests what to buy on the next recall, walking the item build tree
+// toward the next item of a recommended build.
+package advisor
+
+import "ghostdraft/ddragon"
+
+// Purchase is the suggestion for the next recall
+type Purchase struct {
+	Target      int // Next build item the player doesn't have yet
+	Item        int // What to buy now: Target itself or a piece of it; 0 when nothing is affordable
+	Cost        int // What Item costs, given the components already owned
+	GoldMissing int // Gold still needed to finish Target with the current gold
+}
+
+// piece is an item of the target's build tree the player still has to buy
+type piece struct {
+	id   int
+	cost int // Gold to buy it, counting owned components
+}
+
+// Next picks the next purchase toward build, an ordered list of item IDs. Inventory is
+// what the player holds (one entry per item, duplicates allowed). It returns false once
+// every build item is owned, or when the catalog doesn't know the next one.
+func Next(catalog *ddragon.Catalog, build []int, inventory []int, gold int) (Purchase, bool) {
+	target := 0
+	for _, id := range build {
+		if !owns(catalog, inventory, id) {
+			target = id
+			break
+		}
+	}
+	if _, ok := catalog.Item(target); !ok {
+		return Purchase{}, false
+	}
+
+	owned := make(map[int]int, len(inventory))
+	for _, id := range inventory {
+		owned[id]++
+	}
+	var pieces []piece
+	total := remaining(catalog, target, owned, &pieces)
+
+	purchase := Purchase{Target: target}
+	if total > gold {
+		purchase.GoldMissing = total - gold
+	}
+
+	// The target when it's affordable, otherwise its most expensive affordable piece
+	// (pieces lists the target last)
+	best := -1
+	for i, p := range pieces {
+		if p.cost > gold {
+			continue
+		}
+		if best < 0 || p.cost >= pieces[best].cost {
+			best = i
+		}
+	}
+	if best >= 0 {
+		purchase.Item = pieces[best].id
+		purchase.Cost = pieces[best].cost
+	}
+	return purchase, true
+}
+
+// remaining returns the gold needed to complete an item, using up owned components as it
+// goes, and appends every piece still to buy (components before the items they build)
+func remaining(catalog *ddragon.Catalog, id int, owned map[int]int, pieces *[]piece) int {
+	if owned[id] > 0 {
+		owned[id]--
+		return 0
+	}
+	item, ok := catalog.Item(id)
+	if !ok {
+		return 0
+	}
+
+	cost := item.Gold.Base // What combining the components costs on top of them
+	for _, component := range catalog.ComponentsOf(id) {
+		cost += remaining(catalog, component, owned, pieces)
+	}
+	*pieces = append(*pieces, piece{id: id, cost: cost})
+	return cost
+}
+
+// owns reports whether the player has an item or something built from it. Any finished
+// boots count for recommended boots, since players pick boots for the game they're in.
+func owns(catalog *ddragon.Catalog, inventory []int, id int) bool {
+	bootsTarget := catalog.IsBoots(id) && catalog.IsCompleted(id)
+	for _, held := range inventory {
+		if held == id || buildsFrom(catalog, held, id) {
+			return true
+		}
+		if bootsTarget && catalog.IsBoots(held) && catalog.IsCompleted(held) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildsFrom reports whether item is built (at any depth) from component
+func buildsFrom(catalog *ddragon.Catalog, item, component int) bool {
+	for _, id := range catalog.ComponentsOf(item) {
+		if id == component || buildsFrom(catalog, id, component) {
+			return true
+		}
+	}
+	return false
+}
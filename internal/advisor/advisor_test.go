@@ -0,0 +1,63 @@
+package advisor
+
+import (
+	"testing"
+
+	"ghostdraft/ddragon"
+)
+
+// testCatalog is a small build tree: Luden's from Lost Chapter and Blasting Wand,
+// Shadowflame from two Wands, and two boots
+func testCatalog() *ddragon.Catalog {
+	rift := map[string]bool{"11": true}
+	item := func(base, total int, from []string, tags ...string) ddragon.Item {
+		return ddragon.Item{From: from, Gold: ddragon.Gold{Base: base, Total: total, Purchasable: true}, Tags: tags, Maps: rift}
+	}
+	return ddragon.NewCatalog("15.1.1", map[int]ddragon.Item{
+		1052: item(400, 400, nil),
+		1027: item(300, 300, nil),
+		1026: item(850, 850, nil),
+		3802: item(500, 1200, []string{"1052", "1027"}),
+		6655: item(850, 2900, []string{"3802", "1026"}),
+		4645: item(1300, 3000, []string{"1026", "1026"}),
+		1001: item(300, 300, nil, "Boots"),
+		3020: item(800, 1100, []string{"1001"}, "Boots"),
+		3047: item(900, 1200, []string{"1001"}, "Boots"),
+	})
+}
+
+func TestNext(t *testing.T) {
+	catalog := testCatalog()
+	build := []int{6655, 3020, 4645}
+
+	tests := []struct {
+		name      string
+		inventory []int
+		gold      int
+		want      Purchase
+	}{
+		{"fresh start buys the priciest affordable component", nil, 500,
+			Purchase{Target: 6655, Item: 1052, Cost: 400, GoldMissing: 2400}},
+		{"owned components are used up", []int{1052}, 900,
+			Purchase{Target: 6655, Item: 1026, Cost: 850, GoldMissing: 1600}},
+		{"the target itself once affordable", []int{3802, 1026}, 900,
+			Purchase{Target: 6655, Item: 6655, Cost: 850}},
+		{"other finished boots count, nothing affordable", []int{6655, 3047}, 100,
+			Purchase{Target: 4645, GoldMissing: 2900}},
+		{"a component counts toward the next item", []int{6655, 1001}, 800,
+			Purchase{Target: 3020, Item: 3020, Cost: 800}},
+	}
+	for _, tt := range tests {
+		got, ok := Next(catalog, build, tt.inventory, tt.gold)
+		if !ok || got != tt.want {
+			t.Errorf("%s: got %+v (ok=%v), want %+v", tt.name, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := Next(catalog, build, []int{6655, 3020, 4645}, 5000); ok {
+		t.Error("expected no suggestion once the build is complete")
+	}
+	if _, ok := Next(nil, build, nil, 5000); ok {
+		t.Error("expected no suggestion without item data")
+	}
+}
@@ -0,0 +1,121 @@
+package champselect
+
+import (
+	"context"
+	"sync"
+)
+
+// Pipeline runs at most one fetch per slot (e.g. "bans" or "items"). Starting a slot
+// with a new key cancels the fetch that was running for the old key, and results from
+// cancelled fetches are dropped by Commit.
+type Pipeline struct {
+	parent context.Context
+
+	mu    sync.Mutex
+	seq   uint64
+	slots map[string]*slot
+	wg    sync.WaitGroup
+}
+
+// slot is the latest fetch for one slot
+type slot struct {
+	key    string
+	seq    uint64
+	cancel context.CancelFunc
+}
+
+// Job is one fetch started by a Pipeline
+type Job struct {
+	ctx      context.Context
+	pipeline *Pipeline
+	slot     string
+	seq      uint64
+}
+
+// NewPipeline creates a pipeline whose fetches are cancelled along with parent
+func NewPipeline(parent context.Context) *Pipeline {
+	return &Pipeline{parent: parent, slots: make(map[string]*slot)}
+}
+
+// Start runs fn in a new goroutine unless the slot already started for key.
+// It reports whether a fetch was started.
+func (p *Pipeline) Start(name, key string, fn func(job *Job)) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if s, ok := p.slots[name]; ok {
+		if s.key == key {
+			return false
+		}
+		s.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(p.parent)
+	p.seq++
+	p.slots[name] = &slot{key: key, seq: p.seq, cancel: cancel}
+
+	job := &Job{ctx: ctx, pipeline: p, slot: name, seq: p.seq}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer cancel()
+		fn(job)
+	}()
+	return true
+}
+
+// Cancel stops the slot's fetch and forgets its key so the next Start always runs
+func (p *Pipeline) Cancel(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if s, ok := p.slots[name]; ok {
+		s.cancel()
+		delete(p.slots, name)
+	}
+}
+
+// CancelAll stops every fetch, e.g. when champ select ends
+func (p *Pipeline) CancelAll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for name, s := range p.slots {
+		s.cancel()
+		delete(p.slots, name)
+	}
+}
+
+// Wait blocks until every started fetch has returned
+func (p *Pipeline) Wait() {
+	p.wg.Wait()
+}
+
+// Context is cancelled once a newer fetch replaces this one
+func (j *Job) Context() context.Context {
+	return j.ctx
+}
+
+// Current reports whether this is still the latest fetch for its slot
+func (j *Job) Current() bool {
+	j.pipeline.mu.Lock()
+	defer j.pipeline.mu.Unlock()
+	return j.currentLocked()
+}
+
+// Commit runs fn only if this is still the latest fetch for its slot, and reports
+// whether it ran. No newer fetch can start while fn runs, so a stale result can never
+// land after a fresh one.
+func (j *Job) Commit(fn func()) bool {
+	j.pipeline.mu.Lock()
+	defer j.pipeline.mu.Unlock()
+	if !j.currentLocked() {
+		return false
+	}
+	fn()
+	return true
+}
+
+// currentLocked is Current for callers holding the pipeline lock
+func (j *Job) currentLocked() bool {
+	s, ok := j.pipeline.slots[j.slot]
+	return ok && s.seq == j.seq && j.ctx.Err() == nil
+}
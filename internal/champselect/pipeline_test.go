@@ -0,0 +1,146 @@
+package champselect
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPipeline_DropsStaleResults(t *testing.T) {
+	p := NewPipeline(context.Background())
+
+	var mu sync.Mutex
+	var emitted []string
+	emit := func(job *Job, result string) {
+		job.Commit(func() {
+			mu.Lock()
+			emitted = append(emitted, result)
+			mu.Unlock()
+		})
+	}
+
+	// A slow fetch for an old hover finishes after the fetch for the new one
+	release := make(chan struct{})
+	p.Start("bans", "103-middle", func(job *Job) {
+		<-release
+		emit(job, "ahri")
+	})
+	p.Start("bans", "238-middle", func(job *Job) {
+		emit(job, "zed")
+	})
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	p.Wait()
+
+	if len(emitted) != 1 || emitted[0] != "zed" {
+		t.Errorf("expected only the newest result, got %v", emitted)
+	}
+}
+
+func TestPipeline_CancelsReplacedFetch(t *testing.T) {
+	p := NewPipeline(context.Background())
+
+	cancelled := make(chan bool, 1)
+	p.Start("items", "103-middle", func(job *Job) {
+		select {
+		case <-job.Context().Done():
+			cancelled <- !job.Current()
+		case <-time.After(5 * time.Second):
+			cancelled <- false
+		}
+	})
+	p.Start("items", "238-middle", func(job *Job) {})
+	p.Wait()
+
+	if !<-cancelled {
+		t.Error("expected the replaced fetch's context to be cancelled")
+	}
+}
+
+func TestPipeline_SkipsSameKey(t *testing.T) {
+	p := NewPipeline(context.Background())
+	var runs atomic.Int32
+
+	for i := 0; i < 3; i++ {
+		p.Start("build", "103-middle-[238]", func(job *Job) { runs.Add(1) })
+	}
+	p.Wait()
+	if runs.Load() != 1 {
+		t.Errorf("expected one fetch for a repeated key, got %d", runs.Load())
+	}
+
+	// After Cancel the same key runs again (e.g. re-entering champ select)
+	p.Cancel("build")
+	if !p.Start("build", "103-middle-[238]", func(job *Job) { runs.Add(1) }) {
+		t.Error("expected Start to run after Cancel")
+	}
+	p.Wait()
+	if runs.Load() != 2 {
+		t.Errorf("expected a second fetch after Cancel, got %d", runs.Load())
+	}
+}
+
+func TestPipeline_CancelAllDropsEverything(t *testing.T) {
+	parent, stop := context.WithCancel(context.Background())
+	defer stop()
+	p := NewPipeline(parent)
+
+	release := make(chan struct{})
+	var committed atomic.Int32
+	for _, slot := range []string{"bans", "items", "counters", "build"} {
+		p.Start(slot, "key", func(job *Job) {
+			<-release
+			if job.Commit(func() {}) {
+				committed.Add(1)
+			}
+		})
+	}
+	p.CancelAll()
+	close(release)
+	p.Wait()
+
+	if committed.Load() != 0 {
+		t.Errorf("expected no commits after CancelAll, got %d", committed.Load())
+	}
+}
+
+func TestPipeline_ConcurrentStartsKeepNewest(t *testing.T) {
+	p := NewPipeline(context.Background())
+
+	var mu sync.Mutex
+	last := ""
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("hover-%d", i)
+			p.Start("bans", key, func(job *Job) {
+				time.Sleep(time.Millisecond)
+				job.Commit(func() {
+					mu.Lock()
+					last = key
+					mu.Unlock()
+				})
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	// One final start from the session handler wins regardless of earlier timing
+	p.Start("bans", "final", func(job *Job) {
+		job.Commit(func() {
+			mu.Lock()
+			last = "final"
+			mu.Unlock()
+		})
+	})
+	p.Wait()
+
+	if last != "final" {
+		t.Errorf("expected the newest fetch to commit last, got %q", last)
+	}
+}
@@ -0,0 +1,125 @@
+// Package champselect turns champ select sessions into immutable snapshots and runs
+// the fetches that depend on them, dropping results that a newer session has replaced.
+package champselect
+
+import (
+	"sort"
+
+	"ghostdraft/internal/lcu"
+)
+
+// positions are the champ select roles in the order missing ones are inferred
+var positions = []string{"top", "jungle", "middle", "bottom", "utility"}
+
+// Snapshot is everything derived from one champ select session. Snapshots are never
+// modified after Derive returns, so they can be shared between goroutines.
+type Snapshot struct {
+	Seq           uint64 // increases with every applied session
+	InChampSelect bool
+
+	Phase    string // timer phase, e.g. "BAN_PICK" or "FINALIZATION"
+	TimeLeft int    // milliseconds left in the phase
+
+	LocalCellID      int
+	LocalChampionID  int    // our locked (or server-assigned) champion
+	Position         string // our role, inferred from the missing one if the client didn't assign it
+	PositionInferred bool
+
+	ActionType string // "pick" or "ban" when it's our turn with a champion selected
+	ChampionID int    // hovered pick, else locked champion
+	IsLocked   bool   // ChampionID is locked rather than hovered
+
+	BanPhaseComplete bool
+	EnemyChampionIDs []int // sorted
+	EnemyLanerID     int   // enemy in our role, once visible
+
+	Session *lcu.ChampSelectSession // the source session; read only
+}
+
+// Derive computes a snapshot from a champ select session
+func Derive(session *lcu.ChampSelectSession) Snapshot {
+	snap := Snapshot{
+		InChampSelect: true,
+		Phase:         session.Timer.Phase,
+		TimeLeft:      session.Timer.TimeLeftInPhase,
+		LocalCellID:   session.LocalPlayerCellID,
+		Session:       session,
+	}
+
+	foundPlayer := false
+	for i := range session.MyTeam {
+		player := &session.MyTeam[i]
+		if player.CellID == session.LocalPlayerCellID {
+			snap.LocalChampionID = player.ChampionID
+			snap.Position = player.GetPosition()
+			foundPlayer = true
+			break
+		}
+	}
+	if foundPlayer && snap.Position == "" {
+		snap.Position = missingPosition(session.MyTeam)
+		snap.PositionInferred = snap.Position != ""
+	}
+
+	// Our in-progress action - only pick hovers count as our champion, not ban hovers
+	if action := currentAction(session); action != nil {
+		snap.ActionType = action.Type
+		if action.Type == "pick" {
+			snap.ChampionID = action.ChampionID
+		}
+	}
+	if snap.ChampionID == 0 && snap.LocalChampionID > 0 {
+		snap.ChampionID = snap.LocalChampionID
+		snap.IsLocked = true
+	}
+
+	snap.BanPhaseComplete = true
+	for _, group := range session.Actions {
+		for _, action := range group {
+			if action.Type == "ban" && !action.Completed {
+				snap.BanPhaseComplete = false
+			}
+		}
+	}
+
+	for i := range session.TheirTeam {
+		enemy := &session.TheirTeam[i]
+		if enemy.ChampionID == 0 {
+			continue
+		}
+		snap.EnemyChampionIDs = append(snap.EnemyChampionIDs, enemy.ChampionID)
+		if snap.EnemyLanerID == 0 && snap.Position != "" && enemy.GetPosition() == snap.Position {
+			snap.EnemyLanerID = enemy.ChampionID
+		}
+	}
+	sort.Ints(snap.EnemyChampionIDs)
+
+	return snap
+}
+
+// missingPosition returns the first role no teammate has, or "" if all are taken
+func missingPosition(team []lcu.ChampSelectPlayer) string {
+	taken := make(map[string]bool)
+	for i := range team {
+		taken[team[i].GetPosition()] = true
+	}
+	for _, pos := range positions {
+		if !taken[pos] {
+			return pos
+		}
+	}
+	return ""
+}
+
+// currentAction finds the local player's first unfinished action with a champion selected
+func currentAction(session *lcu.ChampSelectSession) *lcu.ChampSelectAction {
+	for _, group := range session.Actions {
+		for i := range group {
+			action := &group[i]
+			if action.ActorCellID == session.LocalPlayerCellID && !action.Completed && action.ChampionID > 0 {
+				return action
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package champselect
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"ghostdraft/internal/lcu"
+)
+
+// draftSession is a mid-draft session: we're cell 2 (middle) hovering Ahri,
+// bans are done and the enemy mid is Zed
+func draftSession() *lcu.ChampSelectSession {
+	return &lcu.ChampSelectSession{
+		Timer:             lcu.ChampSelectTimer{Phase: "BAN_PICK", TimeLeftInPhase: 25000},
+		LocalPlayerCellID: 2,
+		MyTeam: []lcu.ChampSelectPlayer{
+			{CellID: 0, AssignedPosition: "top", ChampionID: 86},
+			{CellID: 1, AssignedPosition: "jungle"},
+			{CellID: 2, AssignedPosition: "middle"},
+			{CellID: 3, AssignedPosition: "bottom"},
+			{CellID: 4, AssignedPosition: "utility"},
+		},
+		TheirTeam: []lcu.ChampSelectPlayer{
+			{CellID: 5, AssignedPosition: "top", ChampionID: 122},
+			{CellID: 6, AssignedPosition: "middle", ChampionID: 238},
+			{CellID: 7, AssignedPosition: "jungle"},
+		},
+		Actions: [][]lcu.ChampSelectAction{
+			{{ActorCellID: 2, Type: "ban", ChampionID: 157, Completed: true}},
+			{{ActorCellID: 2, Type: "pick", ChampionID: 103, IsInProgress: true}},
+		},
+	}
+}
+
+func TestDerive_HoveredPick(t *testing.T) {
+	snap := Derive(draftSession())
+
+	if snap.ChampionID != 103 || snap.IsLocked || snap.ActionType != "pick" {
+		t.Errorf("expected hovered Ahri pick, got champion=%d locked=%v action=%q", snap.ChampionID, snap.IsLocked, snap.ActionType)
+	}
+	if snap.Position != "middle" || snap.PositionInferred {
+		t.Errorf("expected assigned middle, got %q inferred=%v", snap.Position, snap.PositionInferred)
+	}
+	if !snap.BanPhaseComplete {
+		t.Error("expected ban phase complete")
+	}
+	if snap.EnemyLanerID != 238 {
+		t.Errorf("expected enemy laner Zed (238), got %d", snap.EnemyLanerID)
+	}
+	if !reflect.DeepEqual(snap.EnemyChampionIDs, []int{122, 238}) {
+		t.Errorf("expected sorted enemy IDs [122 238], got %v", snap.EnemyChampionIDs)
+	}
+	if snap.Phase != "BAN_PICK" || snap.TimeLeft != 25000 {
+		t.Errorf("expected timer copied, got %q %d", snap.Phase, snap.TimeLeft)
+	}
+}
+
+func TestDerive_BanHoverIsNotOurChampion(t *testing.T) {
+	session := draftSession()
+	session.Actions = [][]lcu.ChampSelectAction{
+		{{ActorCellID: 2, Type: "ban", ChampionID: 157, IsInProgress: true}},
+	}
+
+	snap := Derive(session)
+	if snap.ChampionID != 0 || snap.ActionType != "ban" {
+		t.Errorf("expected ban action without a champion, got champion=%d action=%q", snap.ChampionID, snap.ActionType)
+	}
+	if snap.BanPhaseComplete {
+		t.Error("expected ban phase incomplete")
+	}
+}
+
+func TestDerive_LockedChampion(t *testing.T) {
+	session := draftSession()
+	session.MyTeam[2].ChampionID = 103
+	session.Actions[1][0].Completed = true
+
+	snap := Derive(session)
+	if snap.ChampionID != 103 || !snap.IsLocked || snap.ActionType != "" {
+		t.Errorf("expected locked Ahri, got champion=%d locked=%v action=%q", snap.ChampionID, snap.IsLocked, snap.ActionType)
+	}
+}
+
+func TestDerive_InfersMissingPosition(t *testing.T) {
+	session := draftSession()
+	session.MyTeam[2].AssignedPosition = ""
+
+	snap := Derive(session)
+	if snap.Position != "middle" || !snap.PositionInferred {
+		t.Errorf("expected inferred middle, got %q inferred=%v", snap.Position, snap.PositionInferred)
+	}
+
+	// Blind pick: nobody has a role, so there's no lane opponent to find
+	for i := range session.MyTeam {
+		session.MyTeam[i].AssignedPosition = ""
+	}
+	for i := range session.TheirTeam {
+		session.TheirTeam[i].AssignedPosition = ""
+	}
+	snap = Derive(session)
+	if snap.Position != "top" || snap.EnemyLanerID != 0 {
+		t.Errorf("expected first missing role and no laner, got %q laner=%d", snap.Position, snap.EnemyLanerID)
+	}
+}
+
+func TestStore_ApplyTracksSequenceAndLockIn(t *testing.T) {
+	store := NewStore()
+
+	_, first := store.Apply(draftSession(), true)
+	locked := draftSession()
+	locked.MyTeam[2].ChampionID = 103
+	locked.Actions[1][0].Completed = true
+	prev, second := store.Apply(locked, true)
+
+	if second.Seq <= first.Seq || prev.Seq != first.Seq {
+		t.Errorf("expected increasing sequence, got first=%d prev=%d second=%d", first.Seq, prev.Seq, second.Seq)
+	}
+	if got := store.Locked(); got != (LockedPick{ChampionID: 103, Position: "middle"}) {
+		t.Errorf("expected Ahri middle locked, got %+v", got)
+	}
+
+	// Leaving champ select keeps the lock-in for the game
+	_, ended := store.Apply(nil, false)
+	if ended.InChampSelect || store.Current().Seq != ended.Seq {
+		t.Errorf("expected an empty current snapshot, got %+v", store.Current())
+	}
+	if store.Locked().ChampionID != 103 {
+		t.Error("expected lock-in to survive the end of champ select")
+	}
+
+	store.ClearLocked()
+	if store.Locked() != (LockedPick{}) {
+		t.Error("expected ClearLocked to forget the pick")
+	}
+}
+
+func TestStore_ConcurrentApplyAndRead(t *testing.T) {
+	store := NewStore()
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				store.Apply(draftSession(), true)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			var last uint64
+			for j := 0; j < 100; j++ {
+				snap := store.Current()
+				if snap.Seq < last {
+					t.Errorf("sequence went backwards: %d after %d", snap.Seq, last)
+				}
+				last = snap.Seq
+				_ = store.Locked()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := store.Current().Seq; got != 800 {
+		t.Errorf("expected 800 applied sessions, got %d", got)
+	}
+}
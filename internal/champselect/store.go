@@ -0,0 +1,69 @@
+package champselect
+
+import (
+	"sync"
+
+	"ghostdraft/internal/lcu"
+)
+
+// LockedPick is the champion and role we locked in, kept for the in-game build
+type LockedPick struct {
+	ChampionID int
+	Position   string
+}
+
+// Store holds the latest champ select snapshot. It is safe for concurrent use.
+type Store struct {
+	mu      sync.RWMutex
+	seq     uint64
+	current Snapshot
+	locked  LockedPick
+}
+
+// NewStore creates an empty store (not in champ select)
+func NewStore() *Store {
+	return &Store{}
+}
+
+// Apply replaces the current snapshot with one derived from session and returns the
+// previous and new snapshots. A nil session or inChampSelect=false means champ select ended.
+func (s *Store) Apply(session *lcu.ChampSelectSession, inChampSelect bool) (prev, next Snapshot) {
+	if inChampSelect && session != nil {
+		next = Derive(session)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	next.Seq = s.seq
+	prev = s.current
+	s.current = next
+
+	// Remember the lock-in for the in-game build - it outlives champ select
+	if next.IsLocked && next.Position != "" {
+		s.locked = LockedPick{ChampionID: next.ChampionID, Position: next.Position}
+	}
+	return prev, next
+}
+
+// Current returns the latest snapshot
+func (s *Store) Current() Snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// Locked returns the last locked pick, or a zero value if there is none
+func (s *Store) Locked() LockedPick {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.locked
+}
+
+// ClearLocked forgets the locked pick once the game is over
+func (s *Store) ClearLocked() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.locked = LockedPick{}
+}
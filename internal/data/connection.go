@@ -0,0 +1,284 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ConnState is the health of the stats database connection
+type ConnState string
+
+const (
+	StatsConnected ConnState = "connected" // Health checks pass
+	StatsDegraded  ConnState = "degraded"  // Recent health checks failed; cached results are still served
+	StatsOffline   ConnState = "offline"   // No connection; reconnecting with backoff
+)
+
+// Connection defaults
+const (
+	DefaultMinBackoff    = 2 * time.Second
+	DefaultMaxBackoff    = 2 * time.Minute
+	DefaultCheckInterval = 30 * time.Second
+	DefaultMaxFailures   = 3
+	connectTimeout       = 10 * time.Second
+)
+
+// ConnStatus is a snapshot of the stats connection
+type ConnStatus struct {
+	State    ConnState `json:"state"`
+	Message  string    `json:"message"`
+	Patch    string    `json:"patch,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	Failures int       `json:"failures"`          // Consecutive failed connects or health checks
+	RetryIn  int       `json:"retryIn,omitempty"` // Seconds until the next attempt
+}
+
+// ConnOptions tunes reconnects and health checks. Zero values use the defaults.
+type ConnOptions struct {
+	MinBackoff    time.Duration // First retry delay, doubled after each failure
+	MaxBackoff    time.Duration // Cap on the retry delay
+	CheckInterval time.Duration // Health check period while connected
+	MaxFailures   int           // Failed health checks before the connection is dropped
+}
+
+// Opener opens and pings a connection to the stats database
+type Opener func(ctx context.Context) (*sql.DB, error)
+
+// StatsConn keeps a StatsProvider connected to Turso. It retries with backoff until the
+// first connection succeeds, health checks it, and after repeated failures drops it and
+// reconnects. Callers read the provider with Provider(), which is nil while offline.
+type StatsConn struct {
+	open      Opener
+	cache     *QueryCache
+	opts      ConnOptions
+	onConnect func(*StatsProvider)
+	onStatus  func(ConnStatus)
+
+	provider atomic.Pointer[StatsProvider]
+	client   *TursoClient // Only touched by the run loop and Close
+
+	mu     sync.Mutex // Guards status
+	status ConnStatus
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewStatsConn creates a connection manager. Every provider it creates shares cache.
+func NewStatsConn(open Opener, cache *QueryCache, opts ConnOptions) *StatsConn {
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = DefaultMinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(DefaultMaxBackoff, opts.MinBackoff)
+	}
+	if opts.CheckInterval <= 0 {
+		opts.CheckInterval = DefaultCheckInterval
+	}
+	if opts.MaxFailures <= 0 {
+		opts.MaxFailures = DefaultMaxFailures
+	}
+	if cache == nil {
+		cache = NewQueryCache()
+	}
+	return &StatsConn{
+		open:   open,
+		cache:  cache,
+		opts:   opts,
+		status: ConnStatus{State: StatsOffline, Message: "Connecting to stats database..."},
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// OnConnect sets a hook run for each new provider before it is swapped in. Call it before Start.
+func (c *StatsConn) OnConnect(fn func(*StatsProvider)) {
+	c.onConnect = fn
+}
+
+// OnStatus sets a callback for every status change. Call it before Start.
+func (c *StatsConn) OnStatus(fn func(ConnStatus)) {
+	c.onStatus = fn
+}
+
+// Start runs the connection loop in the background until Close
+func (c *StatsConn) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	go func() {
+		defer close(c.done)
+		c.run(ctx)
+	}()
+}
+
+// Close stops the connection loop and closes the current connection
+func (c *StatsConn) Close() {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+	c.disconnect()
+}
+
+// Provider returns the current stats provider, or nil while offline. A provider that
+// gets swapped out stays safe to use; its queries fail or fall back to the cache.
+func (c *StatsConn) Provider() *StatsProvider {
+	return c.provider.Load()
+}
+
+// Status returns the latest connection status
+func (c *StatsConn) Status() ConnStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status
+}
+
+// Reconnect skips the current backoff or check interval and tries right away
+func (c *StatsConn) Reconnect() {
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run connects, health checks and reconnects until ctx is cancelled
+func (c *StatsConn) run(ctx context.Context) {
+	backoff := c.opts.MinBackoff
+	failures := 0
+
+	for {
+		var wait time.Duration
+		if c.provider.Load() == nil {
+			err := c.connect(ctx)
+			switch {
+			case err == nil:
+				failures = 0
+				backoff = c.opts.MinBackoff
+				wait = c.opts.CheckInterval
+			case errors.Is(err, ErrTursoNotConfigured):
+				c.setStatus(ConnStatus{State: StatsOffline, Message: "Stats database not configured", Error: err.Error()})
+				return
+			case ctx.Err() != nil:
+				return
+			default:
+				failures++
+				wait = backoff
+				backoff = min(backoff*2, c.opts.MaxBackoff)
+				fmt.Printf("[Stats] Connection failed (attempt %d): %v\n", failures, err)
+				c.setStatus(ConnStatus{
+					State:    StatsOffline,
+					Message:  fmt.Sprintf("Stats offline, retrying in %s", wait.Round(time.Second)),
+					Error:    err.Error(),
+					Failures: failures,
+					RetryIn:  int(wait.Round(time.Second) / time.Second),
+				})
+			}
+		} else if err := c.check(ctx); err == nil {
+			failures = 0
+			wait = c.opts.CheckInterval
+			c.setConnected()
+		} else if ctx.Err() != nil {
+			return
+		} else {
+			failures++
+			fmt.Printf("[Stats] Health check failed (%d/%d): %v\n", failures, c.opts.MaxFailures, err)
+			if failures >= c.opts.MaxFailures {
+				// Give up on this connection; the next pass reconnects
+				c.disconnect()
+				failures = 0
+				c.setStatus(ConnStatus{State: StatsOffline, Message: "Stats connection lost, reconnecting...", Error: err.Error()})
+				continue
+			}
+			wait = c.opts.MinBackoff
+			c.setStatus(ConnStatus{
+				State:    StatsDegraded,
+				Message:  "Stats connection unstable, using cached data",
+				Patch:    c.Provider().GetPatch(),
+				Error:    err.Error(),
+				Failures: failures,
+				RetryIn:  int(wait.Round(time.Second) / time.Second),
+			})
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-c.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// connect opens a connection, reads the current patch and swaps in a new provider
+func (c *StatsConn) connect(ctx context.Context) error {
+	openCtx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	db, err := c.open(openCtx)
+	if err != nil {
+		return err
+	}
+	client := &TursoClient{db: db}
+
+	provider, err := NewStatsProvider(client, c.cache)
+	if err != nil {
+		client.Close()
+		return err
+	}
+	if err := provider.FetchPatch(openCtx); err != nil {
+		client.Close()
+		return fmt.Errorf("no stats patch available: %w", err)
+	}
+	if c.onConnect != nil {
+		c.onConnect(provider)
+	}
+
+	c.client = client
+	c.provider.Store(provider)
+	fmt.Printf("[Stats] Connected (patch %s)\n", provider.GetPatch())
+	c.setConnected()
+	return nil
+}
+
+// check pings the current connection
+func (c *StatsConn) check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+	return ping(ctx, c.client.db)
+}
+
+// disconnect swaps the provider out and closes its connection
+func (c *StatsConn) disconnect() {
+	c.provider.Store(nil)
+	if c.client != nil {
+		c.client.Close()
+		c.client = nil
+	}
+}
+
+// setConnected reports a healthy connection
+func (c *StatsConn) setConnected() {
+	patch := c.Provider().GetPatch()
+	c.setStatus(ConnStatus{State: StatsConnected, Message: fmt.Sprintf("Stats connected (patch %s)", patch), Patch: patch})
+}
+
+// setStatus records status and notifies the callback if anything changed
+func (c *StatsConn) setStatus(status ConnStatus) {
+	c.mu.Lock()
+	changed := status != c.status
+	c.status = status
+	c.mu.Unlock()
+
+	if changed && c.onStatus != nil {
+		c.onStatus(status)
+	}
+}
@@ -0,0 +1,178 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// standIn is a local SQLite "server" that can be killed and restarted
+type standIn struct {
+	path  string
+	up    atomic.Bool
+	mu    sync.Mutex
+	conns []*sql.DB
+}
+
+// newStandIn seeds a stats database and starts it up or down
+func newStandIn(t *testing.T, up bool) *standIn {
+	s := &standIn{path: newTestStatsFile(t)}
+	s.up.Store(up)
+	t.Cleanup(s.kill)
+	return s
+}
+
+// open is the Opener; it fails while the stand-in is down
+func (s *standIn) open(ctx context.Context) (*sql.DB, error) {
+	if !s.up.Load() {
+		return nil, errors.New("connection refused")
+	}
+	db, err := sql.Open("sqlite", s.path)
+	if err != nil {
+		return nil, err
+	}
+	if err := ping(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	s.mu.Lock()
+	s.conns = append(s.conns, db)
+	s.mu.Unlock()
+	return db, nil
+}
+
+// kill drops every open connection, as a network loss would
+func (s *standIn) kill() {
+	s.up.Store(false)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, db := range s.conns {
+		db.Close()
+	}
+	s.conns = nil
+}
+
+// watchStatus collects status changes and returns a wait function for a given state
+func watchStatus(t *testing.T, conn *StatsConn) func(ConnState) ConnStatus {
+	updates := make(chan ConnStatus, 100)
+	conn.OnStatus(func(status ConnStatus) { updates <- status })
+
+	return func(state ConnState) ConnStatus {
+		t.Helper()
+		timeout := time.After(2 * time.Second)
+		for {
+			select {
+			case status := <-updates:
+				if status.State == state {
+					return status
+				}
+			case <-timeout:
+				t.Fatalf("timed out waiting for %s (now %+v)", state, conn.Status())
+			}
+		}
+	}
+}
+
+func TestStatsConn_ReconnectsAfterLoss(t *testing.T) {
+	server := newStandIn(t, false)
+	conn := NewStatsConn(server.open, nil, ConnOptions{
+		MinBackoff:    5 * time.Millisecond,
+		MaxBackoff:    20 * time.Millisecond,
+		CheckInterval: 5 * time.Millisecond,
+		MaxFailures:   2,
+	})
+	var connects atomic.Int32
+	conn.OnConnect(func(provider *StatsProvider) {
+		provider.SetItemCatalog(testItemCatalog)
+		connects.Add(1)
+	})
+	waitFor := watchStatus(t, conn)
+	conn.Start()
+	defer conn.Close()
+
+	if status := waitFor(StatsOffline); status.Failures < 1 || status.Error == "" || conn.Provider() != nil {
+		t.Errorf("expected a failed attempt while the server is down, got %+v", status)
+	}
+
+	server.up.Store(true)
+	if status := waitFor(StatsConnected); status.Patch != "15.1" {
+		t.Errorf("expected patch 15.1, got %+v", status)
+	}
+	if _, err := conn.Provider().FetchChampionData(context.Background(), 103, "Ahri", "middle"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Readers keep going while the provider is swapped out and back in
+	stop := make(chan struct{})
+	var readers sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if provider := conn.Provider(); provider != nil {
+					provider.FetchAllMatchups(context.Background(), 103, "middle")
+				}
+			}
+		}()
+	}
+
+	server.kill()
+	waitFor(StatsDegraded)
+	waitFor(StatsOffline)
+	if conn.Provider() != nil {
+		t.Error("expected the provider to be swapped out while offline")
+	}
+
+	server.up.Store(true)
+	waitFor(StatsConnected)
+	close(stop)
+	readers.Wait()
+
+	if conn.Provider() == nil || connects.Load() != 2 {
+		t.Errorf("expected a second provider after reconnecting, got %d connects", connects.Load())
+	}
+}
+
+func TestStatsConn_ReconnectSkipsBackoff(t *testing.T) {
+	server := newStandIn(t, false)
+	conn := NewStatsConn(server.open, nil, ConnOptions{MinBackoff: time.Hour})
+	waitFor := watchStatus(t, conn)
+	conn.Start()
+	defer conn.Close()
+
+	if status := waitFor(StatsOffline); status.RetryIn != 3600 {
+		t.Errorf("expected a one hour retry, got %+v", status)
+	}
+	server.up.Store(true)
+	conn.Reconnect()
+	waitFor(StatsConnected)
+}
+
+func TestStatsConn_StopsWhenNotConfigured(t *testing.T) {
+	var opens atomic.Int32
+	conn := NewStatsConn(func(ctx context.Context) (*sql.DB, error) {
+		opens.Add(1)
+		return nil, ErrTursoNotConfigured
+	}, nil, ConnOptions{MinBackoff: time.Millisecond})
+	conn.Start()
+
+	// Close only returns once the loop has exited
+	time.Sleep(20 * time.Millisecond)
+	conn.Close()
+	if opens.Load() != 1 {
+		t.Errorf("expected a single attempt, got %d", opens.Load())
+	}
+	if status := conn.Status(); status.State != StatsOffline || status.Error == "" {
+		t.Errorf("expected offline with an error, got %+v", status)
+	}
+}
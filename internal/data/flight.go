@@ -0,0 +1,74 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// flightGroup coalesces concurrent identical queries so each runs once
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+// flight is one in-progress query and the callers waiting on it
+type flight struct {
+	done    chan struct{}
+	value   interface{}
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// do runs fn once for concurrent callers with the same key and reports whether the
+// call joined one already in flight.
+//
+// fn runs detached from any one caller, bounded by timeout. A caller whose deadline
+// passes stops waiting but the query keeps going, so its result still reaches the cache
+// for the next call. Once every waiting caller has been cancelled outright (e.g. champ
+// select moved on), nobody wants the result and fn's context is cancelled too.
+func (g *flightGroup) do(ctx context.Context, key string, timeout time.Duration, fn func(ctx context.Context) (interface{}, error)) (interface{}, error, bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flight)
+	}
+	f, shared := g.calls[key]
+	if !shared {
+		queryCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = f
+
+		go func() {
+			f.value, f.err = fn(queryCtx)
+			cancel()
+			g.mu.Lock()
+			// A cancelled flight may already have been replaced by a newer one
+			if g.calls[key] == f {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.value, f.err, shared
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 && errors.Is(ctx.Err(), context.Canceled) {
+			f.cancel()
+			// New callers start a fresh flight instead of joining the cancelled one
+			if g.calls[key] == f {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err(), shared
+	}
+}
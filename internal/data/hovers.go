@@ -0,0 +1,150 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxHoverEntries caps how many champion/role pairs the hover log remembers
+const maxHoverEntries = 100
+
+// HoverTarget is a champion/role pair the player has hovered in champ select
+type HoverTarget struct {
+	ChampionID   int       `json:"championId"`
+	ChampionName string    `json:"championName"`
+	Role         string    `json:"role"`
+	Count        int       `json:"count"`
+	LastHovered  time.Time `json:"lastHovered"`
+}
+
+// HoverLog counts champ select hovers so the stats cache can be warmed for the
+// champions a player is most likely to pick. It persists to a JSON file.
+type HoverLog struct {
+	path string
+
+	mu      sync.Mutex
+	targets map[string]*HoverTarget
+}
+
+// NewHoverLog loads hovers.json from the GhostDraft config directory
+func NewHoverLog() (*HoverLog, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = "."
+	}
+
+	dir := filepath.Join(configDir, "GhostDraft")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create hover log directory: %w", err)
+	}
+
+	return OpenHoverLog(filepath.Join(dir, "hovers.json"))
+}
+
+// OpenHoverLog loads a hover log from path. A missing or unreadable file starts empty.
+func OpenHoverLog(path string) (*HoverLog, error) {
+	h := &HoverLog{path: path, targets: make(map[string]*HoverTarget)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hover log: %w", err)
+	}
+
+	var targets []HoverTarget
+	if err := json.Unmarshal(data, &targets); err != nil {
+		fmt.Printf("[Stats] Ignoring unreadable hover log: %v\n", err)
+		return h, nil
+	}
+	for i := range targets {
+		t := targets[i]
+		h.targets[hoverKey(t.ChampionID, t.Role)] = &t
+	}
+	return h, nil
+}
+
+// Record counts a hover and saves the log
+func (h *HoverLog) Record(championID int, championName, role string) error {
+	if championID <= 0 || role == "" {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := hoverKey(championID, role)
+	t, ok := h.targets[key]
+	if !ok {
+		t = &HoverTarget{ChampionID: championID, Role: role}
+		h.targets[key] = t
+	}
+	t.ChampionName = championName
+	t.Count++
+	t.LastHovered = time.Now()
+
+	// Forget the least-hovered pairs once the log gets long
+	ranked := h.rankedLocked()
+	for _, stale := range ranked[min(len(ranked), maxHoverEntries):] {
+		delete(h.targets, hoverKey(stale.ChampionID, stale.Role))
+	}
+	if len(ranked) > maxHoverEntries {
+		ranked = ranked[:maxHoverEntries]
+	}
+	return h.save(ranked)
+}
+
+// Top returns the n most hovered champion/role pairs, most recent first on ties
+func (h *HoverLog) Top(n int) []HoverTarget {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ranked := h.rankedLocked()
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
+
+// rankedLocked returns copies of every target sorted by hover count. Callers hold h.mu.
+func (h *HoverLog) rankedLocked() []HoverTarget {
+	ranked := make([]HoverTarget, 0, len(h.targets))
+	for _, t := range h.targets {
+		ranked = append(ranked, *t)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Count != ranked[j].Count {
+			return ranked[i].Count > ranked[j].Count
+		}
+		return ranked[i].LastHovered.After(ranked[j].LastHovered)
+	})
+	return ranked
+}
+
+// save writes the log atomically so a crash can't leave a half-written file. Callers hold h.mu.
+func (h *HoverLog) save(targets []HoverTarget) error {
+	data, err := json.MarshalIndent(targets, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write hover log: %w", err)
+	}
+	if err := os.Rename(tmp, h.path); err != nil {
+		return fmt.Errorf("failed to save hover log: %w", err)
+	}
+	return nil
+}
+
+// hoverKey identifies a champion/role pair
+func hoverKey(championID int, role string) string {
+	return fmt.Sprintf("%d:%s", championID, role)
+}
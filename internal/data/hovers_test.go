@@ -0,0 +1,54 @@
+package data
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestHoverLog_RanksAndPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hovers.json")
+	log, err := OpenHoverLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log.Record(103, "Ahri", "middle")
+	log.Record(238, "Zed", "middle")
+	log.Record(103, "Ahri", "middle")
+	log.Record(157, "Yasuo", "middle")
+	log.Record(0, "", "middle") // nothing hovered - ignored
+
+	top := log.Top(2)
+	if len(top) != 2 || top[0].ChampionID != 103 || top[0].Count != 2 {
+		t.Fatalf("expected Ahri first with 2 hovers, got %+v", top)
+	}
+	// Ties go to the most recent hover
+	if top[1].ChampionID != 157 {
+		t.Errorf("expected Yasuo second (latest tie), got %+v", top[1])
+	}
+
+	reloaded, err := OpenHoverLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Top(10); len(got) != 3 || got[0].ChampionName != "Ahri" || got[0].Role != "middle" {
+		t.Errorf("expected 3 hovers after reload, got %+v", got)
+	}
+}
+
+func TestHoverLog_CapsEntries(t *testing.T) {
+	log, _ := OpenHoverLog(filepath.Join(t.TempDir(), "hovers.json"))
+	log.Record(1, "Annie", "middle")
+	log.Record(1, "Annie", "middle")
+	for id := 2; id <= maxHoverEntries+10; id++ {
+		log.Record(id, "", "top")
+	}
+
+	top := log.Top(1000)
+	if len(top) != maxHoverEntries {
+		t.Errorf("expected %d entries, got %d", maxHoverEntries, len(top))
+	}
+	if top[0].ChampionID != 1 {
+		t.Errorf("expected the most hovered champion to be kept, got %+v", top[0])
+	}
+}
@@ -0,0 +1,441 @@
+package data
+
+import (
+	"container/list"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	// DefaultCacheEntries is how many query results are kept, in memory and on disk
+	DefaultCacheEntries = 2000
+	// DefaultCacheTTL is how long a result is kept when the caller doesn't give a TTL.
+	// New data is picked up sooner through SetVersion, so this is only a backstop.
+	DefaultCacheTTL = 6 * time.Hour
+	// StaleGrace is how long an expired result is kept as a fallback for queries that
+	// time out. Prune drops entries once it has passed.
+	StaleGrace = 24 * time.Hour
+)
+
+// CacheOptions configures a QueryCache. Zero values fall back to the defaults.
+type CacheOptions struct {
+	MaxEntries int
+	TTL        time.Duration
+	Path       string // SQLite file for the on-disk tier; empty keeps the cache in memory only
+}
+
+// CacheStats are hit/miss counters for diagnostics
+type CacheStats struct {
+	Version       string  `json:"version"`
+	Entries       int     `json:"entries"`
+	MaxEntries    int     `json:"maxEntries"`
+	Persistent    bool    `json:"persistent"`
+	Hits          int64   `json:"hits"`     // served from memory
+	DiskHits      int64   `json:"diskHits"` // served from the on-disk tier
+	Misses        int64   `json:"misses"`
+	Evictions     int64   `json:"evictions"` // dropped to stay under MaxEntries (either tier)
+	Expired       int64   `json:"expired"`   // lookups that found only an expired entry
+	Invalidations int64   `json:"invalidations"`
+	HitRate       float64 `json:"hitRate"` // percent of lookups served from either tier
+
+	// Filled in by StatsProvider.CacheStats
+	Coalesced     int64 `json:"coalesced"`     // calls that joined an identical query already in flight
+	StaleServed   int64 `json:"staleServed"`   // timed out or failed calls answered with expired data
+	PartialBuilds int64 `json:"partialBuilds"` // builds returned with some item slots missing
+}
+
+// cacheEntry is one cached query result
+type cacheEntry struct {
+	key       string
+	value     interface{}
+	expiresAt time.Time
+}
+
+// QueryCache is a thread-safe LRU cache of query results with per-key TTLs. Entries
+// belong to a data version (patch + Turso data_version); changing the version drops
+// everything cached for the old one. Expired entries are misses but stay around for
+// StaleGrace as a fallback (cacheGetStale). With a Path, results are also written to
+// SQLite so they survive restarts; the disk tier holds at most MaxEntries too, dropping
+// the least recently used.
+type QueryCache struct {
+	maxEntries int
+	ttl        time.Duration
+	disk       *sql.DB
+	now        func() time.Time
+
+	mu      sync.Mutex
+	version string
+	entries map[string]*list.Element
+	order   *list.List // front is most recently used
+	stats   CacheStats
+}
+
+// NewQueryCache creates an in-memory cache with the default size and TTL
+func NewQueryCache() *QueryCache {
+	cache, _ := OpenQueryCache(CacheOptions{})
+	return cache
+}
+
+// UserCachePath returns the on-disk cache location in the GhostDraft config directory
+func UserCachePath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = "."
+	}
+
+	dir := filepath.Join(configDir, "GhostDraft")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return filepath.Join(dir, "stats_cache.db"), nil
+}
+
+// OpenQueryCache creates a cache, opening (or creating) the on-disk tier if opts.Path is set
+func OpenQueryCache(opts CacheOptions) (*QueryCache, error) {
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = DefaultCacheEntries
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultCacheTTL
+	}
+
+	c := &QueryCache{
+		maxEntries: opts.MaxEntries,
+		ttl:        opts.TTL,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+	if opts.Path == "" {
+		return c, nil
+	}
+
+	db, err := sql.Open("sqlite", opts.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache database: %w", err)
+	}
+	// SQLite only supports one writer; serialize through a single connection
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS query_cache (
+			key TEXT PRIMARY KEY,
+			version TEXT NOT NULL,
+			value BLOB NOT NULL,
+			expires_at INTEGER NOT NULL,
+			accessed_at INTEGER NOT NULL DEFAULT 0
+		)
+	`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create cache table: %w", err)
+	}
+	// Caches written before the disk tier was bounded have no access times
+	var hasAccessed int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('query_cache') WHERE name = 'accessed_at'`).Scan(&hasAccessed); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to read cache table: %w", err)
+	}
+	if hasAccessed == 0 {
+		if _, err := db.Exec(`ALTER TABLE query_cache ADD COLUMN accessed_at INTEGER NOT NULL DEFAULT 0`); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to migrate cache table: %w", err)
+		}
+	}
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_query_cache_accessed ON query_cache (accessed_at)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create cache index: %w", err)
+	}
+
+	c.disk = db
+	return c, nil
+}
+
+// Close closes the on-disk tier
+func (c *QueryCache) Close() error {
+	if c.disk == nil {
+		return nil
+	}
+	return c.disk.Close()
+}
+
+// Get retrieves a value from memory. Use cacheGet to also read the on-disk tier.
+func (c *QueryCache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, ok := c.lookupLocked(key)
+	if ok {
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+	return value, ok
+}
+
+// Set stores a value with the default TTL
+func (c *QueryCache) Set(key string, value interface{}) {
+	c.SetTTL(key, value, c.ttl)
+}
+
+// SetTTL stores a value that expires after ttl
+func (c *QueryCache) SetTTL(key string, value interface{}, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
+
+	c.mu.Lock()
+	now := c.now()
+	expiresAt := now.Add(ttl)
+	c.putLocked(key, value, expiresAt)
+	version := c.version
+	c.mu.Unlock()
+
+	if c.disk == nil {
+		return
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	if _, err := c.disk.Exec(`
+		INSERT OR REPLACE INTO query_cache (key, version, value, expires_at, accessed_at) VALUES (?, ?, ?, ?, ?)
+	`, key, version, encoded, expiresAt.UnixMilli(), now.UnixMilli()); err != nil {
+		fmt.Printf("[Cache] Failed to persist %s: %v\n", key, err)
+		return
+	}
+	c.trimDisk()
+}
+
+// trimDisk drops the least recently used disk entries over maxEntries
+func (c *QueryCache) trimDisk() {
+	res, err := c.disk.Exec(`
+		DELETE FROM query_cache WHERE key IN (
+			SELECT key FROM query_cache ORDER BY accessed_at DESC LIMIT -1 OFFSET ?
+		)
+	`, c.maxEntries)
+	if err != nil {
+		fmt.Printf("[Cache] Failed to trim the disk cache: %v\n", err)
+		return
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		c.mu.Lock()
+		c.stats.Evictions += n
+		c.mu.Unlock()
+	}
+}
+
+// Version returns the data version entries currently belong to
+func (c *QueryCache) Version() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.version
+}
+
+// SetVersion switches the cache to a new data version, dropping every entry (in memory
+// and on disk) cached for another one. It reports whether the version changed.
+func (c *QueryCache) SetVersion(version string) bool {
+	c.mu.Lock()
+	changed := version != c.version
+	if changed {
+		c.stats.Invalidations += int64(c.order.Len())
+		c.resetLocked()
+		c.version = version
+	}
+	c.mu.Unlock()
+
+	// Runs on every call - entries left by a previous run with other data go too
+	if c.disk != nil {
+		if res, err := c.disk.Exec(`DELETE FROM query_cache WHERE version != ?`, version); err == nil {
+			if n, _ := res.RowsAffected(); n > 0 {
+				c.mu.Lock()
+				c.stats.Invalidations += n
+				c.mu.Unlock()
+			}
+		}
+	}
+	return changed
+}
+
+// Invalidate drops every entry whose key starts with prefix and returns how many were in memory
+func (c *QueryCache) Invalidate(prefix string) int {
+	c.mu.Lock()
+	removed := 0
+	for key, elem := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.removeLocked(elem)
+			removed++
+		}
+	}
+	c.stats.Invalidations += int64(removed)
+	c.mu.Unlock()
+
+	if c.disk != nil {
+		// Escape LIKE wildcards so keys like "build:1_" only match literally
+		escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+		c.disk.Exec(`DELETE FROM query_cache WHERE key LIKE ? ESCAPE '\'`, escaped+"%")
+	}
+	return removed
+}
+
+// Clear removes all cached values, in memory and on disk
+func (c *QueryCache) Clear() {
+	c.mu.Lock()
+	c.stats.Invalidations += int64(c.order.Len())
+	c.resetLocked()
+	c.mu.Unlock()
+
+	if c.disk != nil {
+		c.disk.Exec(`DELETE FROM query_cache`)
+	}
+}
+
+// Prune drops entries that expired more than StaleGrace ago from both tiers
+func (c *QueryCache) Prune() {
+	c.mu.Lock()
+	cutoff := c.now().Add(-StaleGrace)
+	for _, elem := range c.entries {
+		if !cutoff.Before(elem.Value.(*cacheEntry).expiresAt) {
+			c.removeLocked(elem)
+		}
+	}
+	c.mu.Unlock()
+
+	if c.disk != nil {
+		c.disk.Exec(`DELETE FROM query_cache WHERE expires_at <= ?`, cutoff.UnixMilli())
+	}
+}
+
+// Stats returns the cache counters
+func (c *QueryCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Version = c.version
+	stats.Entries = c.order.Len()
+	stats.MaxEntries = c.maxEntries
+	stats.Persistent = c.disk != nil
+	if lookups := stats.Hits + stats.DiskHits + stats.Misses; lookups > 0 {
+		stats.HitRate = float64(stats.Hits+stats.DiskHits) / float64(lookups) * 100
+	}
+	return stats
+}
+
+// cacheGet returns a cached value of type T, checking memory first and then the on-disk
+// tier. Disk hits are promoted back into memory.
+func cacheGet[T any](c *QueryCache, key string) (T, bool) {
+	var value T
+
+	c.mu.Lock()
+	if cached, ok := c.lookupLocked(key); ok {
+		if typed, ok := cached.(T); ok {
+			c.stats.Hits++
+			c.mu.Unlock()
+			return typed, true
+		}
+	}
+	version := c.version
+	c.mu.Unlock()
+
+	if c.disk != nil {
+		var encoded []byte
+		var expiresAt int64
+		err := c.disk.QueryRow(`
+			SELECT value, expires_at FROM query_cache WHERE key = ? AND version = ?
+		`, key, version).Scan(&encoded, &expiresAt)
+		if err == nil && c.now().UnixMilli() < expiresAt && json.Unmarshal(encoded, &value) == nil {
+			c.disk.Exec(`UPDATE query_cache SET accessed_at = ? WHERE key = ?`, c.now().UnixMilli(), key)
+			c.mu.Lock()
+			// Skip the promotion if the version moved on while we were reading
+			if c.version == version {
+				c.putLocked(key, value, time.UnixMilli(expiresAt))
+			}
+			c.stats.DiskHits++
+			c.mu.Unlock()
+			return value, true
+		}
+	}
+
+	c.mu.Lock()
+	c.stats.Misses++
+	c.mu.Unlock()
+	return value, false
+}
+
+// cacheGetStale returns a cached value of type T even if it has expired, for callers
+// that would otherwise have nothing to show. It doesn't touch the hit/miss counters.
+func cacheGetStale[T any](c *QueryCache, key string) (T, bool) {
+	var value T
+
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		if typed, ok := elem.Value.(*cacheEntry).value.(T); ok {
+			c.mu.Unlock()
+			return typed, true
+		}
+	}
+	version := c.version
+	c.mu.Unlock()
+
+	if c.disk == nil {
+		return value, false
+	}
+	var encoded []byte
+	err := c.disk.QueryRow(`
+		SELECT value FROM query_cache WHERE key = ? AND version = ?
+	`, key, version).Scan(&encoded)
+	return value, err == nil && json.Unmarshal(encoded, &value) == nil
+}
+
+// lookupLocked returns a fresh entry and marks it recently used. Expired entries are
+// left in place for cacheGetStale. Callers hold c.mu.
+func (c *QueryCache) lookupLocked(key string) (interface{}, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.stats.Expired++
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// putLocked stores an entry and evicts the least recently used ones over the limit. Callers hold c.mu.
+func (c *QueryCache) putLocked(key string, value interface{}, expiresAt time.Time) {
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.maxEntries {
+		c.removeLocked(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+// removeLocked drops one entry from memory. Callers hold c.mu.
+func (c *QueryCache) removeLocked(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+}
+
+// resetLocked empties memory. Callers hold c.mu.
+func (c *QueryCache) resetLocked() {
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
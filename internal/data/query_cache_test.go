@@ -0,0 +1,230 @@
+package data
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQueryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache, _ := OpenQueryCache(CacheOptions{MaxEntries: 2})
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Get("a") // a is now more recent than b
+	cache.Set("c", 3)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+	if stats := cache.Stats(); stats.Evictions != 1 || stats.Entries != 2 {
+		t.Errorf("expected 1 eviction and 2 entries, got %+v", stats)
+	}
+}
+
+func TestQueryCache_ExpiresPerKey(t *testing.T) {
+	cache := NewQueryCache()
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	cache.SetTTL("short", "x", time.Minute)
+	cache.SetTTL("long", "y", time.Hour)
+	now = now.Add(2 * time.Minute)
+
+	if _, ok := cache.Get("short"); ok {
+		t.Error("expected short-lived entry to expire")
+	}
+	if _, ok := cache.Get("long"); !ok {
+		t.Error("expected long-lived entry to survive")
+	}
+	if stats := cache.Stats(); stats.Expired != 1 {
+		t.Errorf("expected 1 expired entry, got %d", stats.Expired)
+	}
+}
+
+func TestQueryCache_VersionChangeInvalidates(t *testing.T) {
+	cache := NewQueryCache()
+	cache.SetVersion("15.1@2025-01-01")
+	cache.Set("build:103:middle", "old")
+
+	if cache.SetVersion("15.1@2025-01-01") {
+		t.Error("expected same version to be a no-op")
+	}
+	if _, ok := cache.Get("build:103:middle"); !ok {
+		t.Error("expected entry to survive the same version")
+	}
+
+	if !cache.SetVersion("15.2@2025-01-08") {
+		t.Error("expected new version to report a change")
+	}
+	if _, ok := cache.Get("build:103:middle"); ok {
+		t.Error("expected entry from the old version to be dropped")
+	}
+}
+
+func TestQueryCache_InvalidatePrefix(t *testing.T) {
+	cache, err := OpenQueryCache(CacheOptions{Path: filepath.Join(t.TempDir(), "cache.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cache.Close()
+
+	cache.Set("counters:103:middle", []MatchupStat{{EnemyChampionID: 238}})
+	cache.Set("counters:1_:middle", []MatchupStat{})
+	cache.Set("build:103:middle", &BuildData{ChampionID: 103})
+
+	if n := cache.Invalidate("counters:103"); n != 1 {
+		t.Errorf("expected 1 entry invalidated, got %d", n)
+	}
+	if _, ok := cacheGet[[]MatchupStat](cache, "counters:103:middle"); ok {
+		t.Error("expected counters to be gone from both tiers")
+	}
+	if _, ok := cacheGet[[]MatchupStat](cache, "counters:1_:middle"); !ok {
+		t.Error("expected LIKE wildcards in the prefix to match literally")
+	}
+	if _, ok := cacheGet[*BuildData](cache, "build:103:middle"); !ok {
+		t.Error("expected other keys to survive")
+	}
+}
+
+func TestQueryCache_PersistsAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+
+	cache, err := OpenQueryCache(CacheOptions{Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.SetVersion("15.1@a")
+	cache.Set("build:103:middle", &BuildData{
+		ChampionID: 103,
+		Builds:     []BuildPath{{CoreItems: []int{6655, 3020}}},
+	})
+	cache.Set("matchups:103:middle", []MatchupStat{{EnemyChampionID: 238, Wins: 52, Matches: 100, WinRate: 52}})
+	cache.Close()
+
+	// Same data version - served from disk, then from memory
+	cache, err = OpenQueryCache(CacheOptions{Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.SetVersion("15.1@a")
+
+	build, ok := cacheGet[*BuildData](cache, "build:103:middle")
+	if !ok || build.ChampionID != 103 || len(build.Builds) != 1 || build.Builds[0].CoreItems[1] != 3020 {
+		t.Fatalf("expected build from disk, got %+v ok=%v", build, ok)
+	}
+	cacheGet[*BuildData](cache, "build:103:middle")
+	if stats := cache.Stats(); stats.DiskHits != 1 || stats.Hits != 1 || !stats.Persistent {
+		t.Errorf("expected 1 disk hit then 1 memory hit, got %+v", stats)
+	}
+	cache.Close()
+
+	// New data uploaded while the app was closed - nothing from disk is reused
+	cache, err = OpenQueryCache(CacheOptions{Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cache.Close()
+	cache.SetVersion("15.1@b")
+
+	if _, ok := cacheGet[[]MatchupStat](cache, "matchups:103:middle"); ok {
+		t.Error("expected entries from the old data version to be dropped")
+	}
+	if stats := cache.Stats(); stats.Invalidations != 2 {
+		t.Errorf("expected 2 invalidated disk entries, got %d", stats.Invalidations)
+	}
+}
+
+func TestQueryCache_BoundsDiskTier(t *testing.T) {
+	cache, err := OpenQueryCache(CacheOptions{MaxEntries: 3, Path: filepath.Join(t.TempDir(), "cache.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cache.Close()
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	for _, key := range []string{"a", "b", "c"} {
+		cache.Set(key, key)
+		now = now.Add(time.Second)
+	}
+	// Read "a" from disk so "b" is the least recently used there
+	cache.resetLocked()
+	if _, ok := cacheGet[string](cache, "a"); !ok {
+		t.Fatal("expected a disk hit for a")
+	}
+	now = now.Add(time.Second)
+	cache.Set("d", "d")
+	cache.Set("e", "e")
+
+	var rows int
+	if err := cache.disk.QueryRow(`SELECT COUNT(*) FROM query_cache`).Scan(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if rows != 3 {
+		t.Errorf("expected the disk tier capped at 3 entries, got %d", rows)
+	}
+	cache.resetLocked()
+	for key, want := range map[string]bool{"a": true, "b": false, "c": false, "d": true, "e": true} {
+		if _, ok := cacheGet[string](cache, key); ok != want {
+			t.Errorf("disk has %s = %v, want %v", key, ok, want)
+		}
+	}
+	if stats := cache.Stats(); stats.Evictions < 2 {
+		t.Errorf("expected disk evictions to be counted, got %+v", stats)
+	}
+}
+
+func TestQueryCache_SkipsExpiredDiskEntries(t *testing.T) {
+	cache, err := OpenQueryCache(CacheOptions{Path: filepath.Join(t.TempDir(), "cache.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cache.Close()
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	cache.SetTTL("meta:top:5", []ChampionWinRate{{ChampionID: 86}}, time.Hour)
+	cache.resetLocked() // drop memory only, as after a restart
+	now = now.Add(2 * time.Hour)
+
+	if _, ok := cacheGet[[]ChampionWinRate](cache, "meta:top:5"); ok {
+		t.Error("expected expired disk entry to miss")
+	}
+	if stats := cache.Stats(); stats.Misses != 1 || stats.HitRate != 0 {
+		t.Errorf("expected a single miss, got %+v", stats)
+	}
+}
+
+func TestQueryCache_ConcurrentAccess(t *testing.T) {
+	cache, _ := OpenQueryCache(CacheOptions{MaxEntries: 50})
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				key := fmt.Sprintf("k%d", j%80)
+				if _, ok := cacheGet[int](cache, key); !ok {
+					cache.Set(key, j)
+				}
+				if j%50 == 0 {
+					cache.SetVersion(fmt.Sprintf("v%d", j/50))
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if stats := cache.Stats(); stats.Entries > 50 {
+		t.Errorf("expected at most 50 entries, got %d", stats.Entries)
+	}
+}
@@ -0,0 +1,161 @@
+package data
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Queue IDs the analyzer keeps separate stats for (match-v5 / LCU queue IDs)
+const (
+	QueueDraft      = 400
+	QueueRankedSolo = 420
+	QueueRankedFlex = 440
+	QueueARAM       = 450
+	QueueSwiftplay  = 480
+	QueueArena      = 1700
+)
+
+// Rank brackets the analyzer splits stats by. BracketAll reads every bracket together.
+const (
+	BracketAll        = ""
+	BracketEmerald    = "emerald"
+	BracketDiamond    = "diamond"
+	BracketMasterPlus = "master_plus"
+)
+
+// Dataset is a group of queues whose stats are read together, optionally narrowed to
+// one rank bracket and region
+type Dataset struct {
+	Name    string
+	Queues  []int
+	Bracket string
+	Region  string
+}
+
+// Datasets the provider can read from. Solo/Duo and Flex share ranked stats.
+var (
+	DatasetRanked    = Dataset{Name: "ranked", Queues: []int{QueueRankedSolo, QueueRankedFlex}}
+	DatasetDraft     = Dataset{Name: "draft", Queues: []int{QueueDraft}}
+	DatasetSwiftplay = Dataset{Name: "swiftplay", Queues: []int{QueueSwiftplay}}
+	DatasetARAM      = Dataset{Name: "aram", Queues: []int{QueueARAM}}
+	DatasetArena     = Dataset{Name: "arena", Queues: []int{QueueArena}}
+)
+
+// DatasetForQueue returns the dataset for an LCU queue ID. Queues without stats of their
+// own (customs, bots, rotating modes, or no queue at all) read ranked stats.
+func DatasetForQueue(queueID int) Dataset {
+	switch queueID {
+	case QueueDraft:
+		return DatasetDraft
+	case QueueSwiftplay:
+		return DatasetSwiftplay
+	case QueueARAM:
+		return DatasetARAM
+	case QueueArena:
+		return DatasetArena
+	default:
+		return DatasetRanked
+	}
+}
+
+// BracketForTier returns the stats bracket for a solo queue tier. Stats are only
+// collected from Emerald up, so lower tiers read the closest bracket, Emerald; unranked
+// players (and unknown tiers) read all brackets.
+func BracketForTier(tier string) string {
+	switch strings.ToUpper(tier) {
+	case "IRON", "BRONZE", "SILVER", "GOLD", "PLATINUM", "EMERALD":
+		return BracketEmerald
+	case "DIAMOND":
+		return BracketDiamond
+	case "MASTER", "GRANDMASTER", "CHALLENGER":
+		return BracketMasterPlus
+	default:
+		return BracketAll
+	}
+}
+
+// BracketLabel returns the bracket's name for display
+func BracketLabel(bracket string) string {
+	switch bracket {
+	case BracketEmerald:
+		return "Emerald"
+	case BracketDiamond:
+		return "Diamond"
+	case BracketMasterPlus:
+		return "Master+"
+	default:
+		return "Emerald+"
+	}
+}
+
+// WithBracket returns the dataset narrowed to a rank bracket. Arena's tables aren't split
+// by bracket, so it always reads all of them.
+func (d Dataset) WithBracket(bracket string) Dataset {
+	if d.IsArena() {
+		bracket = BracketAll
+	}
+	d.Bracket = bracket
+	return d
+}
+
+// WithRegion returns the dataset narrowed to a region. Like brackets, Arena isn't split
+// by region.
+func (d Dataset) WithRegion(region string) Dataset {
+	if d.IsArena() {
+		region = RegionAll
+	}
+	d.Region = region
+	return d
+}
+
+// HasRoles reports whether the dataset is split by lane position, with matchups
+func (d Dataset) HasRoles() bool {
+	return d.Name != DatasetARAM.Name && d.Name != DatasetArena.Name
+}
+
+// IsArena reports whether the dataset is Arena, which has its own tables
+func (d Dataset) IsArena() bool {
+	return d.Name == DatasetArena.Name
+}
+
+// position returns the team_position stored for a role in this dataset; ARAM has none
+func (d Dataset) position(role string) string {
+	if !d.HasRoles() {
+		return ""
+	}
+	return roleToPosition(role)
+}
+
+// key identifies the dataset, bracket and region in cache keys
+func (d Dataset) key() string {
+	key := d.Name
+	if d.Bracket != BracketAll {
+		key += "/" + d.Bracket
+	}
+	if d.Region != RegionAll {
+		key += "@" + d.Region
+	}
+	return key
+}
+
+// queueFilter returns the SQL condition matching this dataset's queues, bracket and
+// region in table. Reading all brackets also counts records collected before tiers were
+// recorded. A region reads its own rows, or the rows without one (stats the reducer
+// merged across regions, or recorded before regions were) for patches it has none of.
+func (d Dataset) queueFilter(table string) string {
+	ids := make([]string, len(d.Queues))
+	for i, id := range d.Queues {
+		ids[i] = strconv.Itoa(id)
+	}
+	queues := "queue_id IN (" + strings.Join(ids, ", ") + ")"
+	filter := queues
+	if d.Bracket != BracketAll {
+		filter += " AND bracket = '" + d.Bracket + "'"
+	}
+	if d.Region != RegionAll {
+		filter += " AND region = CASE WHEN EXISTS (SELECT 1 FROM champion_stats regional" +
+			" WHERE regional.patch = " + table + ".patch AND regional.region = '" + d.Region + "'" +
+			" AND regional." + queues + ") THEN '" + d.Region + "' ELSE '' END"
+	}
+	return filter
+}
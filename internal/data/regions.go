@@ -0,0 +1,55 @@
+package data
+
+import "strings"
+
+// Regional clusters the analyzer can split stats by. RegionAll reads every region.
+const (
+	RegionAll      = ""
+	RegionAmericas = "americas"
+	RegionEurope   = "europe"
+	RegionAsia     = "asia"
+	RegionSEA      = "sea"
+)
+
+// lcuRegions maps the client's region (from /riotclient/region-locale) to its cluster.
+// Both the short names and the platform IDs some regions report are listed.
+var lcuRegions = map[string]string{
+	"NA":   RegionAmericas,
+	"NA1":  RegionAmericas,
+	"BR":   RegionAmericas,
+	"BR1":  RegionAmericas,
+	"LAN":  RegionAmericas,
+	"LA1":  RegionAmericas,
+	"LAS":  RegionAmericas,
+	"LA2":  RegionAmericas,
+	"EUW":  RegionEurope,
+	"EUW1": RegionEurope,
+	"EUNE": RegionEurope,
+	"EUN1": RegionEurope,
+	"TR":   RegionEurope,
+	"TR1":  RegionEurope,
+	"RU":   RegionEurope,
+	"ME":   RegionEurope,
+	"ME1":  RegionEurope,
+	"KR":   RegionAsia,
+	"JP":   RegionAsia,
+	"JP1":  RegionAsia,
+	"OCE":  RegionSEA,
+	"OC1":  RegionSEA,
+	"PH":   RegionSEA,
+	"PH2":  RegionSEA,
+	"SG":   RegionSEA,
+	"SG2":  RegionSEA,
+	"TH":   RegionSEA,
+	"TH2":  RegionSEA,
+	"TW":   RegionSEA,
+	"TW2":  RegionSEA,
+	"VN":   RegionSEA,
+	"VN2":  RegionSEA,
+}
+
+// RegionForLCU returns the stats region for the client's region, or RegionAll for
+// regions without one (PBE, or anything unknown)
+func RegionForLCU(region string) string {
+	return lcuRegions[strings.ToUpper(strings.TrimSpace(region))]
+}
@@ -1,14 +1,38 @@
 package data
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ghostdraft/ddragon"
 )
 
 // Minimum games threshold for using current patch only
 // If current patch has fewer games, fallback to aggregated data
 const minGamesForCurrentPatch = 1000
 
+// How long each kind of query stays cached. New Turso data invalidates everything
+// through the data version, so these only bound how stale a result can get otherwise.
+const (
+	statsTTL = DefaultCacheTTL // builds, matchups, counters
+	metaTTL  = time.Hour       // tier list - its patch choice depends on game counts
+	roleTTL  = 24 * time.Hour  // most played role barely moves
+)
+
+const (
+	// DefaultCallTimeout is how long a provider call waits for Turso before falling back
+	// to cached (or partial) data
+	DefaultCallTimeout = 5 * time.Second
+	// queryTimeout bounds a single Turso query. Queries outlive callers that time out,
+	// so a slow result still reaches the cache for the next call.
+	queryTimeout = 30 * time.Second
+)
+
 // ItemOption holds item ID with win rate
 type ItemOption struct {
 	ItemID   int
@@ -35,12 +59,39 @@ type BuildData struct {
 	ChampionName string
 	Role         string
 	Builds       []BuildPath
+	Partial      bool // some item slots timed out; fetching again fills them in
 }
 
-// StatsProvider fetches build data from Turso with caching
+// StatsProvider fetches build data from Turso with caching. Every query method takes
+// a context and is bounded by the call timeout; identical concurrent queries share
+// one round trip.
 type StatsProvider struct {
-	client       *TursoClient
+	client      *TursoClient
+	cache       *QueryCache
+	flights     flightGroup
+	callTimeout time.Duration
+
+	patchMu      sync.RWMutex
 	currentPatch string
+
+	// Matchup win rate cutoffs (percent) for counters and counter picks
+	thresholdMu      sync.RWMutex
+	winningThreshold float64
+	losingThreshold  float64
+
+	// Queues stats are read from, following the queue the client is in, the rank
+	// bracket, following the player's solo queue tier, and the client's region
+	datasetMu sync.RWMutex
+	ds        Dataset
+	bracket   string
+	region    string
+
+	// Item catalog for the pinned patch, used to tell boots and finished items apart
+	itemCatalog func() *ddragon.Catalog
+
+	coalesced     atomic.Int64
+	staleServed   atomic.Int64
+	partialBuilds atomic.Int64
 }
 
 // ItemStat represents aggregated item statistics
@@ -69,13 +120,112 @@ type ChampionWinRate struct {
 	PickRate   float64
 }
 
-// NewStatsProvider creates a new stats provider from a TursoClient
-func NewStatsProvider(client *TursoClient) (*StatsProvider, error) {
+// NewStatsProvider creates a new stats provider from a TursoClient. A nil cache
+// uses an in-memory one.
+func NewStatsProvider(client *TursoClient, cache *QueryCache) (*StatsProvider, error) {
+	if cache == nil {
+		cache = NewQueryCache()
+	}
 	return &StatsProvider{
-		client: client,
+		client:           client,
+		cache:            cache,
+		callTimeout:      DefaultCallTimeout,
+		winningThreshold: 51,
+		losingThreshold:  49,
+		ds:               DatasetRanked,
 	}, nil
 }
 
+// SetCallTimeout changes how long calls wait for Turso. Call it before the provider is shared.
+func (p *StatsProvider) SetCallTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		p.callTimeout = timeout
+	}
+}
+
+// SetItemCatalog sets where builds read item data from. The source is called once per
+// build, so a reloaded catalog is picked up. Call it before the provider is shared.
+func (p *StatsProvider) SetItemCatalog(source func() *ddragon.Catalog) {
+	p.itemCatalog = source
+}
+
+// catalog returns the current item catalog, or nil if none is loaded
+func (p *StatsProvider) catalog() *ddragon.Catalog {
+	if p.itemCatalog == nil {
+		return nil
+	}
+	return p.itemCatalog()
+}
+
+// SetMatchupThresholds sets the win rates (percent) a counter pick must beat and a counter must stay under
+func (p *StatsProvider) SetMatchupThresholds(winning, losing float64) {
+	p.thresholdMu.Lock()
+	defer p.thresholdMu.Unlock()
+	p.winningThreshold = winning
+	p.losingThreshold = losing
+}
+
+// matchupThresholds returns the current winning and losing cutoffs as ratios
+func (p *StatsProvider) matchupThresholds() (winning, losing float64) {
+	p.thresholdMu.RLock()
+	defer p.thresholdMu.RUnlock()
+	return p.winningThreshold / 100, p.losingThreshold / 100
+}
+
+// SetDataset switches which queues' stats the provider reads. Results are cached per
+// dataset, so switching back and forth doesn't refetch.
+func (p *StatsProvider) SetDataset(ds Dataset) {
+	p.datasetMu.Lock()
+	defer p.datasetMu.Unlock()
+	p.ds = ds
+}
+
+// SetBracket switches which rank bracket's stats the provider reads; BracketAll reads
+// every bracket. Like datasets, results are cached per bracket.
+func (p *StatsProvider) SetBracket(bracket string) {
+	p.datasetMu.Lock()
+	defer p.datasetMu.Unlock()
+	p.bracket = bracket
+}
+
+// Bracket returns the rank bracket queries read from
+func (p *StatsProvider) Bracket() string {
+	p.datasetMu.RLock()
+	defer p.datasetMu.RUnlock()
+	return p.bracket
+}
+
+// SetRegion switches which region's stats the provider reads; RegionAll reads every
+// region. Results are cached per region.
+func (p *StatsProvider) SetRegion(region string) {
+	p.datasetMu.Lock()
+	defer p.datasetMu.Unlock()
+	p.region = region
+}
+
+// Region returns the region queries read from
+func (p *StatsProvider) Region() string {
+	p.datasetMu.RLock()
+	defer p.datasetMu.RUnlock()
+	return p.region
+}
+
+// Dataset returns the dataset queries read from, narrowed to the current bracket and region
+func (p *StatsProvider) Dataset() Dataset {
+	p.datasetMu.RLock()
+	defer p.datasetMu.RUnlock()
+	return p.ds.WithBracket(p.bracket).WithRegion(p.region)
+}
+
+// roleDataset returns the dataset for role-based queries (most played role, the meta
+// tier list): the current one, or ranked in modes without roles
+func (p *StatsProvider) roleDataset() Dataset {
+	if ds := p.Dataset(); ds.HasRoles() {
+		return ds
+	}
+	return DatasetRanked.WithBracket(p.Bracket()).WithRegion(p.Region())
+}
+
 // Close is a no-op since the TursoClient owns the connection
 func (p *StatsProvider) Close() {
 	// Connection owned by TursoClient
@@ -83,7 +233,18 @@ func (p *StatsProvider) Close() {
 
 // ClearCache clears the query cache
 func (p *StatsProvider) ClearCache() {
-	p.client.ClearCache()
+	p.cache.Clear()
+	fmt.Println("[Stats] Cache cleared")
+}
+
+// CacheStats returns the query cache's hit/miss counters along with the provider's
+// coalescing and fallback counters
+func (p *StatsProvider) CacheStats() CacheStats {
+	stats := p.cache.Stats()
+	stats.Coalesced = p.coalesced.Load()
+	stats.StaleServed = p.staleServed.Load()
+	stats.PartialBuilds = p.partialBuilds.Load()
+	return stats
 }
 
 // db returns the underlying database connection
@@ -91,21 +252,57 @@ func (p *StatsProvider) db() *sql.DB {
 	return p.client.GetDB()
 }
 
-// cache returns the query cache
-func (p *StatsProvider) cache() *QueryCache {
-	return p.client.GetCache()
+// withDeadline bounds a call by the provider's call timeout (or ctx's own deadline, if sooner)
+func (p *StatsProvider) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, p.callTimeout)
 }
 
-// FetchPatch gets the latest patch from our database
-func (p *StatsProvider) FetchPatch() error {
-	// Check cache first
-	if cached, ok := p.cache().Get("current_patch"); ok {
-		p.currentPatch = cached.(string)
-		return nil
+// query returns the cached value for key, or runs fn - once across concurrent callers -
+// and caches its result for ttl. If the call times out or fails, an expired cached value
+// is returned instead when there is one. Cancelled calls just return ctx's error.
+func query[T any](ctx context.Context, p *StatsProvider, key string, ttl time.Duration, fn func(ctx context.Context) (T, error)) (T, error) {
+	if cached, ok := cacheGet[T](p.cache, key); ok {
+		return cached, nil
+	}
+
+	ctx, cancel := p.withDeadline(ctx)
+	defer cancel()
+
+	value, err, shared := p.flights.do(ctx, key, queryTimeout, func(ctx context.Context) (interface{}, error) {
+		result, err := fn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p.cache.SetTTL(key, result, ttl)
+		return result, nil
+	})
+	if shared {
+		p.coalesced.Add(1)
+	}
+	if err == nil {
+		return value.(T), nil
 	}
 
+	if !errors.Is(err, context.Canceled) {
+		if stale, ok := cacheGetStale[T](p.cache, key); ok {
+			p.staleServed.Add(1)
+			fmt.Printf("[Stats] Serving stale %s: %v\n", key, err)
+			return stale, nil
+		}
+	}
+	var zero T
+	return zero, err
+}
+
+// FetchPatch gets the latest patch and data version from our database. If either
+// changed since the last call (or since the on-disk cache was written), cached
+// results for the old data are dropped.
+func (p *StatsProvider) FetchPatch(ctx context.Context) error {
+	ctx, cancel := p.withDeadline(ctx)
+	defer cancel()
+
 	var patch string
-	err := p.db().QueryRow(`
+	err := p.db().QueryRowContext(ctx, `
 		SELECT patch FROM champion_stats
 		ORDER BY patch DESC
 		LIMIT 1
@@ -115,57 +312,62 @@ func (p *StatsProvider) FetchPatch() error {
 		return fmt.Errorf("failed to get patch: %w", err)
 	}
 
+	// The analyzer stamps data_version on every upload; older databases don't have it
+	var updatedAt string
+	p.db().QueryRowContext(ctx, `SELECT updated_at FROM data_version WHERE id = 1`).Scan(&updatedAt)
+
+	p.patchMu.Lock()
+	previous := p.currentPatch
 	p.currentPatch = patch
-	p.cache().Set("current_patch", patch)
-	fmt.Printf("[Stats] Using patch: %s\n", patch)
+	p.patchMu.Unlock()
+
+	version := patch
+	if updatedAt != "" {
+		version = patch + "@" + updatedAt
+	}
+	oldVersion := p.cache.Version()
+	if p.cache.SetVersion(version) && oldVersion != "" {
+		fmt.Printf("[Stats] Data version changed (%s -> %s), cache invalidated\n", oldVersion, version)
+	}
+	if patch != previous {
+		fmt.Printf("[Stats] Using patch: %s\n", patch)
+	}
 	return nil
 }
 
 // GetPatch returns the current patch
 func (p *StatsProvider) GetPatch() string {
+	p.patchMu.RLock()
+	defer p.patchMu.RUnlock()
 	return p.currentPatch
 }
 
 // GetMostPlayedRole returns the most common role for a champion based on game count
-func (p *StatsProvider) GetMostPlayedRole(championID int) string {
-	cacheKey := fmt.Sprintf("most_played_role:%d", championID)
-	if cached, ok := p.cache().Get(cacheKey); ok {
-		return cached.(string)
-	}
-
-	var position string
-	err := p.db().QueryRow(`
-		SELECT team_position FROM champion_stats
-		WHERE champion_id = ?
-		GROUP BY team_position
-		ORDER BY SUM(matches) DESC
-		LIMIT 1
-	`, championID).Scan(&position)
+func (p *StatsProvider) GetMostPlayedRole(ctx context.Context, championID int) string {
+	ds := p.roleDataset()
+	cacheKey := fmt.Sprintf("most_played_role:%s:%d", ds.key(), championID)
+	role, err := query(ctx, p, cacheKey, roleTTL, func(ctx context.Context) (string, error) {
+		var position string
+		err := p.db().QueryRowContext(ctx, fmt.Sprintf(`
+			SELECT team_position FROM champion_stats
+			WHERE %s AND champion_id = ?
+			GROUP BY team_position
+			ORDER BY SUM(matches) DESC
+			LIMIT 1
+		`, ds.queueFilter("champion_stats")), championID).Scan(&position)
+		if err != nil {
+			return "", err
+		}
 
+		role := positionToRole(position)
+		if role == "" {
+			return "", fmt.Errorf("unknown position %q", position)
+		}
+		return role, nil
+	})
 	if err != nil {
 		return ""
 	}
-
-	// Convert database position back to role
-	var role string
-	switch position {
-	case "TOP":
-		role = "top"
-	case "JUNGLE":
-		role = "jungle"
-	case "MIDDLE":
-		role = "middle"
-	case "BOTTOM":
-		role = "bottom"
-	case "UTILITY":
-		role = "utility"
-	default:
-		role = ""
-	}
-
-	if role != "" {
-		p.cache().Set(cacheKey, role)
-	}
 	return role
 }
 
@@ -187,69 +389,133 @@ func roleToPosition(role string) string {
 	}
 }
 
-// FetchChampionData gets build data for a champion from Turso with caching
-func (p *StatsProvider) FetchChampionData(championID int, championName string, role string) (*BuildData, error) {
-	cacheKey := fmt.Sprintf("build:%d:%s", championID, role)
-	if cached, ok := p.cache().Get(cacheKey); ok {
-		return cached.(*BuildData), nil
+// positionToRole converts database team_position values back to role names
+func positionToRole(position string) string {
+	switch position {
+	case "TOP":
+		return "top"
+	case "JUNGLE":
+		return "jungle"
+	case "MIDDLE":
+		return "middle"
+	case "BOTTOM":
+		return "bottom"
+	case "UTILITY":
+		return "utility"
+	default:
+		return ""
+	}
+}
+
+// FetchChampionData gets build data for a champion from Turso with caching.
+// The game count, boots and six item slot queries run in parallel. If some slots
+// time out, the build is returned without them and marked Partial; it isn't cached,
+// but the slots that finished are, so fetching again only waits on the rest.
+func (p *StatsProvider) FetchChampionData(ctx context.Context, championID int, championName string, role string) (*BuildData, error) {
+	ds := p.Dataset()
+	if ds.IsArena() {
+		return p.fetchArenaBuild(ctx, championID, championName, role)
+	}
+
+	cacheKey := fmt.Sprintf("build:%s:%d:%s", ds.key(), championID, role)
+	if cached, ok := cacheGet[*BuildData](p.cache, cacheKey); ok {
+		return cached, nil
 	}
 
-	position := roleToPosition(role)
+	ctx, cancel := p.withDeadline(ctx)
+	defer cancel()
+
+	position := ds.position(role)
 
-	// Get total games for this champion/position (aggregate across all patches)
+	var wg sync.WaitGroup
 	var totalGames int
-	err := p.db().QueryRow(`
-		SELECT COALESCE(SUM(matches), 0) FROM champion_stats
-		WHERE champion_id = ? AND team_position = ?
-	`, championID, position).Scan(&totalGames)
+	var gamesErr error
+	slots := make([][]ItemOption, 7)
+	slotErrs := make([]error, 7)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		totalGames, gamesErr = p.championGames(ctx, ds, championID, position)
+	}()
+	for slot := 1; slot <= 6; slot++ {
+		wg.Add(1)
+		go func(slot int) {
+			defer wg.Done()
+			slots[slot], slotErrs[slot] = p.slotItems(ctx, ds, championID, position, slot)
+		}(slot)
+	}
+	wg.Wait()
 
-	if err != nil || totalGames == 0 {
-		return nil, fmt.Errorf("no data for champion %d in position %s", championID, position)
+	if gamesErr != nil || totalGames == 0 {
+		return nil, fmt.Errorf("no %s data for champion %d in position %q", ds.Name, championID, position)
 	}
 
-	// Build the response using slot-based data
-	build, err := p.constructBuildPathFromSlots(championID, position, totalGames)
-	if err != nil {
-		return nil, err
+	// The slot queries are cached by now, so the build completes as soon as items load
+	catalog := p.catalog()
+	if catalog.Len() == 0 {
+		return nil, fmt.Errorf("item data not loaded yet")
+	}
+
+	partial := false
+	for _, err := range slotErrs[1:] {
+		if err != nil {
+			partial = true
+		}
 	}
 
 	result := &BuildData{
 		ChampionID:   championID,
 		ChampionName: championName,
 		Role:         role,
-		Builds:       []BuildPath{build},
+		Builds:       []BuildPath{assembleBuild(totalGames, slots, catalog)},
+		Partial:      partial,
 	}
 
-	p.cache().Set(cacheKey, result)
+	if partial {
+		p.partialBuilds.Add(1)
+		fmt.Printf("[Stats] Partial build for %d %s (some slots timed out)\n", championID, role)
+		return result, nil
+	}
+	p.cache.SetTTL(cacheKey, result, statsTTL)
 	return result, nil
 }
 
-// constructBuildPathFromSlots creates a build path using item slot data
-func (p *StatsProvider) constructBuildPathFromSlots(championID int, position string, totalGames int) (BuildPath, error) {
-	// Track excluded items (already used in build)
-	excluded := make(map[int]bool)
+// championGames returns total games for a champion/position (aggregate across all patches)
+func (p *StatsProvider) championGames(ctx context.Context, ds Dataset, championID int, position string) (int, error) {
+	cacheKey := fmt.Sprintf("games:%s:%d:%s", ds.key(), championID, position)
+	return query(ctx, p, cacheKey, statsTTL, func(ctx context.Context) (int, error) {
+		var totalGames int
+		err := p.db().QueryRowContext(ctx, fmt.Sprintf(`
+			SELECT COALESCE(SUM(matches), 0) FROM champion_stats
+			WHERE %s AND champion_id = ? AND team_position = ?
+		`, ds.queueFilter("champion_stats")), championID, position).Scan(&totalGames)
+		return totalGames, err
+	})
+}
 
-	// Query items for each slot, ordered by matches (popularity)
-	// Uses window function to calculate pick_rate from sampled data (avoids denominator trap)
-	// Excludes boots and any items in the excluded map
-	getSlotItems := func(slot int, limit int, excludeBoots bool) ([]ItemOption, error) {
-		rows, err := p.db().Query(`
+// slotItems returns every item bought in a build slot, ordered by matches (popularity).
+// Uses a window function to calculate pick_rate from sampled data (avoids denominator trap).
+func (p *StatsProvider) slotItems(ctx context.Context, ds Dataset, championID int, position string, slot int) ([]ItemOption, error) {
+	cacheKey := fmt.Sprintf("slot:%s:%d:%s:%d", ds.key(), championID, position, slot)
+	return query(ctx, p, cacheKey, statsTTL, func(ctx context.Context) ([]ItemOption, error) {
+		rows, err := p.db().QueryContext(ctx, fmt.Sprintf(`
 			SELECT
 				item_id,
 				SUM(wins) as wins,
 				SUM(matches) as matches,
 				CAST(SUM(matches) AS REAL) / SUM(SUM(matches)) OVER () * 100 as pick_rate
 			FROM champion_item_slots
-			WHERE champion_id = ? AND team_position = ? AND build_slot = ?
+			WHERE %s AND champion_id = ? AND team_position = ? AND build_slot = ?
 			GROUP BY item_id
 			ORDER BY SUM(matches) DESC
-		`, championID, position, slot)
+		`, ds.queueFilter("champion_item_slots")), championID, position, slot)
 		if err != nil {
 			return nil, err
 		}
 		defer rows.Close()
 
-		var items []ItemOption
+		items := []ItemOption{}
 		for rows.Next() {
 			var itemID, wins, matches int
 			var pickRate float64
@@ -257,44 +523,113 @@ func (p *StatsProvider) constructBuildPathFromSlots(championID int, position str
 				continue
 			}
 			if matches > 0 {
-				// Skip excluded items (duplicates)
-				if excluded[itemID] {
-					continue
-				}
-				// Skip boots if requested
-				if excludeBoots && isBootsItem(itemID) {
-					continue
-				}
-				// Skip starting items
-				if isStartingItem(itemID) {
-					continue
-				}
 				items = append(items, ItemOption{
 					ItemID:   itemID,
 					WinRate:  float64(wins) / float64(matches) * 100,
 					PickRate: pickRate,
 					Games:    matches,
 				})
-				if len(items) >= limit {
-					break
-				}
 			}
 		}
-		return items, nil
-	}
+		// A cancelled query ends the loop early - don't cache a truncated list
+		return items, rows.Err()
+	})
+}
 
-	// Get best boots across all slots
-	var bestBoots int
-	bootsRow := p.db().QueryRow(`
-		SELECT item_id
-		FROM champion_item_slots
-		WHERE champion_id = ? AND team_position = ?
-		AND item_id IN (3006, 3009, 3020, 3047, 3111, 3117, 3158)
-		GROUP BY item_id
-		ORDER BY SUM(matches) DESC
-		LIMIT 1
-	`, championID, position)
-	bootsRow.Scan(&bestBoots)
+// fetchArenaBuild builds from Arena's item stats. Arena has no build order, so the most
+// played items are core and the next ones fill the 4th-6th options; win rates are top
+// four rates.
+func (p *StatsProvider) fetchArenaBuild(ctx context.Context, championID int, championName string, role string) (*BuildData, error) {
+	cacheKey := fmt.Sprintf("build:arena:%d:%s", championID, role)
+	return query(ctx, p, cacheKey, statsTTL, func(ctx context.Context) (*BuildData, error) {
+		var top4, games int
+		err := p.db().QueryRowContext(ctx, `
+			SELECT COALESCE(SUM(wins), 0), COALESCE(SUM(matches), 0) FROM arena_champion_stats
+			WHERE champion_id = ?
+		`, championID).Scan(&top4, &games)
+		if err != nil || games == 0 {
+			return nil, fmt.Errorf("no arena data for champion %d", championID)
+		}
+
+		rows, err := p.db().QueryContext(ctx, `
+			SELECT item_id, SUM(wins), SUM(matches)
+			FROM arena_items
+			WHERE champion_id = ?
+			GROUP BY item_id
+			ORDER BY SUM(matches) DESC
+			LIMIT 12
+		`, championID)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		var items []ItemOption
+		for rows.Next() {
+			var itemID, wins, matches int
+			if err := rows.Scan(&itemID, &wins, &matches); err != nil || matches == 0 {
+				continue
+			}
+			items = append(items, ItemOption{
+				ItemID:   itemID,
+				WinRate:  float64(wins) / float64(matches) * 100,
+				PickRate: float64(matches) / float64(games) * 100,
+				Games:    matches,
+			})
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		next := func(n int) []ItemOption {
+			if n > len(items) {
+				n = len(items)
+			}
+			picked := items[:n]
+			items = items[n:]
+			return picked
+		}
+		build := BuildPath{
+			Name:    "Arena Build",
+			WinRate: float64(top4) / float64(games) * 100,
+			Games:   games,
+		}
+		for _, item := range next(3) {
+			build.CoreItems = append(build.CoreItems, item.ItemID)
+		}
+		build.FourthItemOptions = next(3)
+		build.FifthItemOptions = next(3)
+		build.SixthItemOptions = next(3)
+
+		return &BuildData{
+			ChampionID:   championID,
+			ChampionName: championName,
+			Role:         role,
+			Builds:       []BuildPath{build},
+		}, nil
+	})
+}
+
+// assembleBuild creates a build path from each slot's items (most played first).
+// Slots that are missing (nil) are left out.
+func assembleBuild(totalGames int, slots [][]ItemOption, catalog *ddragon.Catalog) BuildPath {
+	// Track excluded items (already used in build)
+	excluded := make(map[int]bool)
+
+	// Pick the most played items in a slot, skipping boots, anything unfinished and duplicates
+	pick := func(slot int, limit int) []ItemOption {
+		var items []ItemOption
+		for _, item := range slots[slot] {
+			if excluded[item.ItemID] || catalog.IsBoots(item.ItemID) || !catalog.IsCompleted(item.ItemID) {
+				continue
+			}
+			items = append(items, item)
+			if len(items) >= limit {
+				break
+			}
+		}
+		return items
+	}
 
 	// Get 2 core items (slots 1, 2, 3 - excluding boots and duplicates)
 	var coreItemIDs []int
@@ -303,11 +638,7 @@ func (p *StatsProvider) constructBuildPathFromSlots(championID int, position str
 		if len(coreItemIDs) >= 2 {
 			break
 		}
-		items, err := getSlotItems(slot, 1, true) // exclude boots
-		if err != nil {
-			continue
-		}
-		if len(items) > 0 {
+		if items := pick(slot, 1); len(items) > 0 {
 			coreItemIDs = append(coreItemIDs, items[0].ItemID)
 			excluded[items[0].ItemID] = true
 			if len(coreItemIDs) == 1 {
@@ -317,31 +648,22 @@ func (p *StatsProvider) constructBuildPathFromSlots(championID int, position str
 	}
 
 	// Add best boots to core items
-	if bestBoots > 0 {
+	if bestBoots := mostPlayedBoots(slots, catalog); bestBoots > 0 {
 		coreItemIDs = append(coreItemIDs, bestBoots)
 		excluded[bestBoots] = true
 	}
 
-	// Mark all boots as excluded for later slots
-	for _, bootsID := range []int{3006, 3009, 3020, 3047, 3111, 3117, 3158} {
-		excluded[bootsID] = true
-	}
-
 	// Get 4th, 5th, 6th item options (3 choices each, excluding core and boots)
-	fourthItems, _ := getSlotItems(4, 3, true)
-	fifthItems, _ := getSlotItems(5, 3, true)
-	sixthItems, _ := getSlotItems(6, 3, true)
-
 	return BuildPath{
 		Name:              "Recommended Build",
 		WinRate:           winRate,
 		Games:             totalGames,
 		StartingItems:     nil,
 		CoreItems:         coreItemIDs,
-		FourthItemOptions: fourthItems,
-		FifthItemOptions:  fifthItems,
-		SixthItemOptions:  sixthItems,
-	}, nil
+		FourthItemOptions: pick(4, 3),
+		FifthItemOptions:  pick(5, 3),
+		SixthItemOptions:  pick(6, 3),
+	}
 }
 
 // toItemOptions converts ItemStats to ItemOptions
@@ -362,335 +684,357 @@ func (p *StatsProvider) toItemOptions(items []ItemStat, start, count int) []Item
 	return options
 }
 
-// isBootsItem checks if an item is boots
-func isBootsItem(itemID int) bool {
-	boots := map[int]bool{
-		3006: true, // Berserker's Greaves
-		3009: true, // Boots of Swiftness
-		3020: true, // Sorcerer's Shoes
-		3047: true, // Plated Steelcaps
-		3111: true, // Mercury's Treads
-		3117: true, // Mobility Boots
-		3158: true, // Ionian Boots of Lucidity
-	}
-	return boots[itemID]
-}
-
-// isStartingItem checks if an item is a starting item
-func isStartingItem(itemID int) bool {
-	starters := map[int]bool{
-		1054: true, // Doran's Shield
-		1055: true, // Doran's Blade
-		1056: true, // Doran's Ring
-		1082: true, // Dark Seal
-		1083: true, // Cull
-		1101: true, // Scorchclaw Pup
-		1102: true, // Gustwalker Hatchling
-		1103: true, // Mosstomper Seedling
-		2003: true, // Health Potion
-		2031: true, // Refillable Potion
-		2033: true, // Corrupting Potion
-		3070: true, // Tear of the Goddess
-		3850: true, // Spellthief's Edge
-		3851: true, // Frostfang
-		3854: true, // Steel Shoulderguards
-		3855: true, // Runesteel Spaulders
-		3858: true, // Relic Shield
-		3859: true, // Targon's Buckler
-		3862: true, // Spectral Sickle
-		3863: true, // Harrowing Crescent
-		1036: true, // Long Sword
-		1052: true, // Amplifying Tome
-		1058: true, // Needlessly Large Rod
-	}
-	return starters[itemID]
+// mostPlayedBoots returns the finished boots bought most across all slots, or 0 if there are none
+func mostPlayedBoots(slots [][]ItemOption, catalog *ddragon.Catalog) int {
+	games := make(map[int]int)
+	for _, items := range slots {
+		for _, item := range items {
+			if catalog.IsBoots(item.ItemID) && catalog.IsCompleted(item.ItemID) {
+				games[item.ItemID] += item.Games
+			}
+		}
+	}
+
+	best := 0
+	for id, n := range games {
+		if best == 0 || n > games[best] || (n == games[best] && id < best) {
+			best = id
+		}
+	}
+	return best
 }
 
 // HasData checks if we have data for a champion
-func (p *StatsProvider) HasData(championID int, role string) bool {
-	position := roleToPosition(role)
+func (p *StatsProvider) HasData(ctx context.Context, championID int, role string) bool {
+	ctx, cancel := p.withDeadline(ctx)
+	defer cancel()
+
+	ds := p.Dataset()
+	if ds.IsArena() {
+		var count int
+		err := p.db().QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM arena_items WHERE champion_id = ?
+		`, championID).Scan(&count)
+		return err == nil && count > 0
+	}
 
 	var count int
-	err := p.db().QueryRow(`
+	err := p.db().QueryRowContext(ctx, fmt.Sprintf(`
 		SELECT COUNT(*) FROM champion_items
-		WHERE champion_id = ? AND team_position = ?
-	`, championID, position).Scan(&count)
+		WHERE %s AND champion_id = ? AND team_position = ?
+	`, ds.queueFilter("champion_items")), championID, ds.position(role)).Scan(&count)
 
 	return err == nil && count > 0
 }
 
 // FetchMatchup returns the win rate for a specific champion vs enemy matchup
-func (p *StatsProvider) FetchMatchup(championID int, enemyChampionID int, role string) (*MatchupStat, error) {
-	cacheKey := fmt.Sprintf("matchup:%d:%d:%s", championID, enemyChampionID, role)
-	if cached, ok := p.cache().Get(cacheKey); ok {
-		return cached.(*MatchupStat), nil
-	}
+func (p *StatsProvider) FetchMatchup(ctx context.Context, championID int, enemyChampionID int, role string) (*MatchupStat, error) {
+	ds := p.Dataset()
+	cacheKey := fmt.Sprintf("matchup:%s:%d:%d:%s", ds.key(), championID, enemyChampionID, role)
+	return query(ctx, p, cacheKey, statsTTL, func(ctx context.Context) (*MatchupStat, error) {
+		position := ds.position(role)
 
-	position := roleToPosition(role)
-
-	var m MatchupStat
-	m.EnemyChampionID = enemyChampionID
+		var m MatchupStat
+		m.EnemyChampionID = enemyChampionID
 
-	// Aggregate across all patches
-	err := p.db().QueryRow(`
-		SELECT COALESCE(SUM(wins), 0), COALESCE(SUM(matches), 0)
-		FROM champion_matchups
-		WHERE champion_id = ? AND team_position = ? AND enemy_champion_id = ?
-	`, championID, position, enemyChampionID).Scan(&m.Wins, &m.Matches)
+		// Aggregate across all patches
+		err := p.db().QueryRowContext(ctx, fmt.Sprintf(`
+			SELECT COALESCE(SUM(wins), 0), COALESCE(SUM(matches), 0)
+			FROM champion_matchups
+			WHERE %s AND champion_id = ? AND team_position = ? AND enemy_champion_id = ?
+		`, ds.queueFilter("champion_matchups")), championID, position, enemyChampionID).Scan(&m.Wins, &m.Matches)
 
-	if err != nil || m.Matches == 0 {
-		return nil, fmt.Errorf("no matchup data for %d vs %d", championID, enemyChampionID)
-	}
+		if err != nil || m.Matches == 0 {
+			return nil, fmt.Errorf("no matchup data for %d vs %d", championID, enemyChampionID)
+		}
 
-	m.WinRate = float64(m.Wins) / float64(m.Matches) * 100
-	p.cache().Set(cacheKey, &m)
-	return &m, nil
+		m.WinRate = float64(m.Wins) / float64(m.Matches) * 100
+		return &m, nil
+	})
 }
 
 // FetchAllMatchups returns all matchup data for a champion in a role
-func (p *StatsProvider) FetchAllMatchups(championID int, role string) ([]MatchupStat, error) {
-	position := roleToPosition(role)
-
-	// Aggregate across all patches
-	rows, err := p.db().Query(`
-		SELECT enemy_champion_id, SUM(wins) as wins, SUM(matches) as matches
-		FROM champion_matchups
-		WHERE champion_id = ? AND team_position = ?
-		GROUP BY enemy_champion_id
-		ORDER BY SUM(matches) DESC
-	`, championID, position)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to query matchups: %w", err)
-	}
-	defer rows.Close()
+func (p *StatsProvider) FetchAllMatchups(ctx context.Context, championID int, role string) ([]MatchupStat, error) {
+	ds := p.Dataset()
+	cacheKey := fmt.Sprintf("matchups:%s:%d:%s", ds.key(), championID, role)
+	return query(ctx, p, cacheKey, statsTTL, func(ctx context.Context) ([]MatchupStat, error) {
+		position := ds.position(role)
+
+		// Aggregate across all patches
+		rows, err := p.db().QueryContext(ctx, fmt.Sprintf(`
+			SELECT enemy_champion_id, SUM(wins) as wins, SUM(matches) as matches
+			FROM champion_matchups
+			WHERE %s AND champion_id = ? AND team_position = ?
+			GROUP BY enemy_champion_id
+			ORDER BY SUM(matches) DESC
+		`, ds.queueFilter("champion_matchups")), championID, position)
 
-	var matchups []MatchupStat
-	for rows.Next() {
-		var m MatchupStat
-		if err := rows.Scan(&m.EnemyChampionID, &m.Wins, &m.Matches); err != nil {
-			continue
+		if err != nil {
+			return nil, fmt.Errorf("failed to query matchups: %w", err)
 		}
-		if m.Matches > 0 {
-			m.WinRate = float64(m.Wins) / float64(m.Matches) * 100
+		defer rows.Close()
+
+		var matchups []MatchupStat
+		for rows.Next() {
+			var m MatchupStat
+			if err := rows.Scan(&m.EnemyChampionID, &m.Wins, &m.Matches); err != nil {
+				continue
+			}
+			if m.Matches > 0 {
+				m.WinRate = float64(m.Wins) / float64(m.Matches) * 100
+			}
+			matchups = append(matchups, m)
 		}
-		matchups = append(matchups, m)
-	}
 
-	return matchups, nil
+		return matchups, rows.Err()
+	})
 }
 
 // FetchCounterMatchups returns the champions that counter the specified champion
 // (i.e., matchups where the specified champion has the lowest win rate)
-func (p *StatsProvider) FetchCounterMatchups(championID int, role string, limit int) ([]MatchupStat, error) {
-	cacheKey := fmt.Sprintf("counters:%d:%s:%d", championID, role, limit)
-	if cached, ok := p.cache().Get(cacheKey); ok {
-		return cached.([]MatchupStat), nil
-	}
-
-	position := roleToPosition(role)
-
-	if limit <= 0 {
-		limit = 10
-	}
-
-	// Query matchups ordered by lowest win rate (hardest counters first)
-	// Only include matchups where win rate < 49% (true counters)
-	rows, err := p.db().Query(`
-		SELECT enemy_champion_id, SUM(wins) as wins, SUM(matches) as matches
-		FROM champion_matchups
-		WHERE champion_id = ? AND team_position = ?
-		GROUP BY enemy_champion_id
-		HAVING SUM(matches) >= 10
-		   AND (CAST(SUM(wins) AS REAL) / CAST(SUM(matches) AS REAL)) < 0.49
-		ORDER BY (CAST(SUM(wins) AS REAL) / CAST(SUM(matches) AS REAL)) ASC
-		LIMIT ?
-	`, championID, position, limit)
+func (p *StatsProvider) FetchCounterMatchups(ctx context.Context, championID int, role string, limit int) ([]MatchupStat, error) {
+	_, losing := p.matchupThresholds()
+	ds := p.Dataset()
+	cacheKey := fmt.Sprintf("counters:%s:%d:%s:%d:%.3f", ds.key(), championID, role, limit, losing)
+	return query(ctx, p, cacheKey, statsTTL, func(ctx context.Context) ([]MatchupStat, error) {
+		position := ds.position(role)
+
+		if limit <= 0 {
+			limit = 10
+		}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to query matchups: %w", err)
-	}
-	defer rows.Close()
+		// Query matchups ordered by lowest win rate (hardest counters first)
+		// Only include matchups under the losing threshold, 49% by default (true counters)
+		rows, err := p.db().QueryContext(ctx, fmt.Sprintf(`
+			SELECT enemy_champion_id, SUM(wins) as wins, SUM(matches) as matches
+			FROM champion_matchups
+			WHERE %s AND champion_id = ? AND team_position = ?
+			GROUP BY enemy_champion_id
+			HAVING SUM(matches) >= 10
+			   AND (CAST(SUM(wins) AS REAL) / CAST(SUM(matches) AS REAL)) < ?
+			ORDER BY (CAST(SUM(wins) AS REAL) / CAST(SUM(matches) AS REAL)) ASC
+			LIMIT ?
+		`, ds.queueFilter("champion_matchups")), championID, position, losing, limit)
 
-	var matchups []MatchupStat
-	for rows.Next() {
-		var m MatchupStat
-		if err := rows.Scan(&m.EnemyChampionID, &m.Wins, &m.Matches); err != nil {
-			continue
+		if err != nil {
+			return nil, fmt.Errorf("failed to query matchups: %w", err)
 		}
-		if m.Matches > 0 {
-			m.WinRate = float64(m.Wins) / float64(m.Matches) * 100
+		defer rows.Close()
+
+		var matchups []MatchupStat
+		for rows.Next() {
+			var m MatchupStat
+			if err := rows.Scan(&m.EnemyChampionID, &m.Wins, &m.Matches); err != nil {
+				continue
+			}
+			if m.Matches > 0 {
+				m.WinRate = float64(m.Wins) / float64(m.Matches) * 100
+			}
+			matchups = append(matchups, m)
 		}
-		matchups = append(matchups, m)
-	}
 
-	p.cache().Set(cacheKey, matchups)
-	return matchups, nil
+		return matchups, rows.Err()
+	})
 }
 
 // FetchCounterPicks returns champions that counter a specific enemy champion in a role
 // (i.e., champions with high win rate against the enemy)
-func (p *StatsProvider) FetchCounterPicks(enemyChampionID int, role string, limit int) ([]MatchupStat, error) {
-	cacheKey := fmt.Sprintf("counterpicks:%d:%s:%d", enemyChampionID, role, limit)
-	if cached, ok := p.cache().Get(cacheKey); ok {
-		return cached.([]MatchupStat), nil
-	}
-
-	position := roleToPosition(role)
-
-	if limit <= 0 {
-		limit = 5
-	}
-
-	// Query champions that have high win rate against this enemy
-	// We flip the query - find champions where they beat the enemy
-	rows, err := p.db().Query(`
-		SELECT champion_id, SUM(wins) as wins, SUM(matches) as matches
-		FROM champion_matchups
-		WHERE enemy_champion_id = ? AND team_position = ?
-		GROUP BY champion_id
-		HAVING SUM(matches) >= 10
-		   AND (CAST(SUM(wins) AS REAL) / CAST(SUM(matches) AS REAL)) > 0.51
-		ORDER BY (CAST(SUM(wins) AS REAL) / CAST(SUM(matches) AS REAL)) DESC
-		LIMIT ?
-	`, enemyChampionID, position, limit)
+func (p *StatsProvider) FetchCounterPicks(ctx context.Context, enemyChampionID int, role string, limit int) ([]MatchupStat, error) {
+	winning, _ := p.matchupThresholds()
+	ds := p.Dataset()
+	cacheKey := fmt.Sprintf("counterpicks:%s:%d:%s:%d:%.3f", ds.key(), enemyChampionID, role, limit, winning)
+	return query(ctx, p, cacheKey, statsTTL, func(ctx context.Context) ([]MatchupStat, error) {
+		position := ds.position(role)
+
+		if limit <= 0 {
+			limit = 5
+		}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to query counter picks: %w", err)
-	}
-	defer rows.Close()
+		// Query champions that beat this enemy above the winning threshold, 51% by default
+		// We flip the query - find champions where they beat the enemy
+		rows, err := p.db().QueryContext(ctx, fmt.Sprintf(`
+			SELECT champion_id, SUM(wins) as wins, SUM(matches) as matches
+			FROM champion_matchups
+			WHERE %s AND enemy_champion_id = ? AND team_position = ?
+			GROUP BY champion_id
+			HAVING SUM(matches) >= 10
+			   AND (CAST(SUM(wins) AS REAL) / CAST(SUM(matches) AS REAL)) > ?
+			ORDER BY (CAST(SUM(wins) AS REAL) / CAST(SUM(matches) AS REAL)) DESC
+			LIMIT ?
+		`, ds.queueFilter("champion_matchups")), enemyChampionID, position, winning, limit)
 
-	var matchups []MatchupStat
-	for rows.Next() {
-		var m MatchupStat
-		m.EnemyChampionID = enemyChampionID
-		var champID int
-		if err := rows.Scan(&champID, &m.Wins, &m.Matches); err != nil {
-			continue
+		if err != nil {
+			return nil, fmt.Errorf("failed to query counter picks: %w", err)
 		}
-		if m.Matches > 0 {
-			m.WinRate = float64(m.Wins) / float64(m.Matches) * 100
+		defer rows.Close()
+
+		var matchups []MatchupStat
+		for rows.Next() {
+			var m MatchupStat
+			var champID int
+			if err := rows.Scan(&champID, &m.Wins, &m.Matches); err != nil {
+				continue
+			}
+			if m.Matches > 0 {
+				m.WinRate = float64(m.Wins) / float64(m.Matches) * 100
+			}
+			// Store the counter pick champion ID in EnemyChampionID field (repurposed)
+			m.EnemyChampionID = champID
+			matchups = append(matchups, m)
 		}
-		// Store the counter pick champion ID in EnemyChampionID field (repurposed)
-		m.EnemyChampionID = champID
-		matchups = append(matchups, m)
-	}
 
-	p.cache().Set(cacheKey, matchups)
-	return matchups, nil
+		return matchups, rows.Err()
+	})
 }
 
 // FetchTopChampionsByRole returns the top N champions by win rate for a given role
-// Uses tiered logic: prefer current patch, fallback to aggregated if not enough data
-func (p *StatsProvider) FetchTopChampionsByRole(role string, limit int) ([]ChampionWinRate, error) {
-	cacheKey := fmt.Sprintf("meta:%s:%d", role, limit)
-	if cached, ok := p.cache().Get(cacheKey); ok {
-		return cached.([]ChampionWinRate), nil
-	}
+// Uses tiered logic: prefer current patch, fallback to aggregated if not enough data.
+// ARAM and Arena have no roles, so their tier list is ranked's.
+func (p *StatsProvider) FetchTopChampionsByRole(ctx context.Context, role string, limit int) ([]ChampionWinRate, error) {
+	ds := p.roleDataset()
+	cacheKey := fmt.Sprintf("meta:%s:%s:%d", ds.key(), role, limit)
+	return query(ctx, p, cacheKey, metaTTL, func(ctx context.Context) ([]ChampionWinRate, error) {
+		position := roleToPosition(role)
+		queues := ds.queueFilter("champion_stats")
+
+		if limit <= 0 {
+			limit = 5
+		}
 
-	position := roleToPosition(role)
+		// Check if current patch has enough games
+		currentPatch := p.GetPatch()
+		var currentPatchGames int
+		if currentPatch != "" {
+			p.db().QueryRowContext(ctx, fmt.Sprintf(`
+				SELECT COALESCE(SUM(matches), 0) FROM champion_stats
+				WHERE %s AND team_position = ? AND patch = ?
+			`, queues), position, currentPatch).Scan(&currentPatchGames)
+		}
 
-	if limit <= 0 {
-		limit = 5
-	}
+		// Decide whether to use current patch only or aggregate
+		useCurrentPatchOnly := currentPatchGames >= minGamesForCurrentPatch
 
-	// Check if current patch has enough games
-	var currentPatchGames int
-	if p.currentPatch != "" {
-		p.db().QueryRow(`
-			SELECT COALESCE(SUM(matches), 0) FROM champion_stats
-			WHERE team_position = ? AND patch = ?
-		`, position, p.currentPatch).Scan(&currentPatchGames)
-	}
+		var totalGames int
+		var rows *sql.Rows
+		var err error
 
-	// Decide whether to use current patch only or aggregate
-	useCurrentPatchOnly := currentPatchGames >= minGamesForCurrentPatch
+		if useCurrentPatchOnly {
+			// Current patch has enough data - use it exclusively
+			fmt.Printf("[Stats] Using current patch %s only for %s (%d games)\n", currentPatch, role, currentPatchGames)
 
-	var totalGames int
-	var rows *sql.Rows
-	var err error
+			err = p.db().QueryRowContext(ctx, fmt.Sprintf(`
+				SELECT COALESCE(SUM(matches), 0) FROM champion_stats
+				WHERE %s AND team_position = ? AND patch = ?
+			`, queues), position, currentPatch).Scan(&totalGames)
+			if err != nil {
+				totalGames = 0
+			}
 
-	if useCurrentPatchOnly {
-		// Current patch has enough data - use it exclusively
-		fmt.Printf("[Stats] Using current patch %s only for %s (%d games)\n", p.currentPatch, role, currentPatchGames)
+			rows, err = p.db().QueryContext(ctx, fmt.Sprintf(`
+				SELECT champion_id, SUM(wins) as wins, SUM(matches) as matches
+				FROM champion_stats
+				WHERE %s AND team_position = ? AND patch = ?
+				GROUP BY champion_id
+				HAVING SUM(matches) >= 100
+				ORDER BY (CAST(SUM(wins) AS REAL) / CAST(SUM(matches) AS REAL)) DESC
+				LIMIT ?
+			`, queues), position, currentPatch, limit)
+		} else {
+			// Not enough data in current patch - aggregate all patches
+			fmt.Printf("[Stats] Aggregating all patches for %s (current patch %s has only %d games)\n", role, currentPatch, currentPatchGames)
+
+			err = p.db().QueryRowContext(ctx, fmt.Sprintf(`
+				SELECT COALESCE(SUM(matches), 0) FROM champion_stats
+				WHERE %s AND team_position = ?
+			`, queues), position).Scan(&totalGames)
+			if err != nil {
+				totalGames = 0
+			}
 
-		err = p.db().QueryRow(`
-			SELECT COALESCE(SUM(matches), 0) FROM champion_stats
-			WHERE team_position = ? AND patch = ?
-		`, position, p.currentPatch).Scan(&totalGames)
-		if err != nil {
-			totalGames = 0
+			rows, err = p.db().QueryContext(ctx, fmt.Sprintf(`
+				SELECT champion_id, SUM(wins) as wins, SUM(matches) as matches
+				FROM champion_stats
+				WHERE %s AND team_position = ?
+				GROUP BY champion_id
+				HAVING SUM(matches) >= 100
+				ORDER BY (CAST(SUM(wins) AS REAL) / CAST(SUM(matches) AS REAL)) DESC
+				LIMIT ?
+			`, queues), position, limit)
 		}
 
-		rows, err = p.db().Query(`
-			SELECT champion_id, SUM(wins) as wins, SUM(matches) as matches
-			FROM champion_stats
-			WHERE team_position = ? AND patch = ?
-			GROUP BY champion_id
-			HAVING SUM(matches) >= 100
-			ORDER BY (CAST(SUM(wins) AS REAL) / CAST(SUM(matches) AS REAL)) DESC
-			LIMIT ?
-		`, position, p.currentPatch, limit)
-	} else {
-		// Not enough data in current patch - aggregate all patches
-		fmt.Printf("[Stats] Aggregating all patches for %s (current patch %s has only %d games)\n", role, p.currentPatch, currentPatchGames)
-
-		err = p.db().QueryRow(`
-			SELECT COALESCE(SUM(matches), 0) FROM champion_stats
-			WHERE team_position = ?
-		`, position).Scan(&totalGames)
 		if err != nil {
-			totalGames = 0
+			return nil, fmt.Errorf("failed to query top champions: %w", err)
 		}
+		defer rows.Close()
 
-		rows, err = p.db().Query(`
-			SELECT champion_id, SUM(wins) as wins, SUM(matches) as matches
-			FROM champion_stats
-			WHERE team_position = ?
-			GROUP BY champion_id
-			HAVING SUM(matches) >= 100
-			ORDER BY (CAST(SUM(wins) AS REAL) / CAST(SUM(matches) AS REAL)) DESC
-			LIMIT ?
-		`, position, limit)
-	}
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to query top champions: %w", err)
-	}
-	defer rows.Close()
-
-	var champions []ChampionWinRate
-	for rows.Next() {
-		var c ChampionWinRate
-		if err := rows.Scan(&c.ChampionID, &c.Wins, &c.Matches); err != nil {
-			continue
-		}
-		if c.Matches > 0 {
-			c.WinRate = float64(c.Wins) / float64(c.Matches) * 100
-			if totalGames > 0 {
-				c.PickRate = float64(c.Matches) / float64(totalGames) * 100
+		var champions []ChampionWinRate
+		for rows.Next() {
+			var c ChampionWinRate
+			if err := rows.Scan(&c.ChampionID, &c.Wins, &c.Matches); err != nil {
+				continue
+			}
+			if c.Matches > 0 {
+				c.WinRate = float64(c.Wins) / float64(c.Matches) * 100
+				if totalGames > 0 {
+					c.PickRate = float64(c.Matches) / float64(totalGames) * 100
+				}
 			}
+			champions = append(champions, c)
 		}
-		champions = append(champions, c)
-	}
 
-	p.cache().Set(cacheKey, champions)
-	return champions, nil
+		return champions, rows.Err()
+	})
 }
 
-// FetchAllRolesTopChampions returns top N champions for all 5 roles
-func (p *StatsProvider) FetchAllRolesTopChampions(limit int) (map[string][]ChampionWinRate, error) {
+// FetchAllRolesTopChampions returns top N champions for all 5 roles, querying roles in parallel
+func (p *StatsProvider) FetchAllRolesTopChampions(ctx context.Context, limit int) (map[string][]ChampionWinRate, error) {
 	roles := []string{"top", "jungle", "middle", "bottom", "utility"}
 	result := make(map[string][]ChampionWinRate)
 
+	var mu sync.Mutex
+	var wg sync.WaitGroup
 	for _, role := range roles {
-		champs, err := p.FetchTopChampionsByRole(role, limit)
-		if err != nil {
-			result[role] = []ChampionWinRate{}
-			continue
-		}
-		result[role] = champs
+		wg.Add(1)
+		go func(role string) {
+			defer wg.Done()
+			champs, err := p.FetchTopChampionsByRole(ctx, role, limit)
+			if err != nil {
+				champs = []ChampionWinRate{}
+			}
+			mu.Lock()
+			result[role] = champs
+			mu.Unlock()
+		}(role)
 	}
+	wg.Wait()
 
 	return result, nil
 }
+
+// RecommendedBans is how many bans champ select recommends. Prefetch uses the same
+// limit so prefetched counters land on the key champ select reads.
+const RecommendedBans = 5
+
+// Prefetch warms the cache with builds, matchups and counters for each target.
+// Targets run one at a time so a prefetch never competes with champ select for Turso.
+func (p *StatsProvider) Prefetch(ctx context.Context, targets []HoverTarget) {
+	if len(targets) == 0 {
+		return
+	}
+
+	before := p.cache.Stats()
+	start := time.Now()
+	for _, t := range targets {
+		if ctx.Err() != nil {
+			return
+		}
+		p.FetchChampionData(ctx, t.ChampionID, t.ChampionName, t.Role)
+		p.FetchAllMatchups(ctx, t.ChampionID, t.Role)
+		p.FetchCounterMatchups(ctx, t.ChampionID, t.Role, RecommendedBans)
+	}
+	after := p.cache.Stats()
+
+	fmt.Printf("[Stats] Prefetched %d champions in %v (%d from cache, %d from Turso)\n",
+		len(targets), time.Since(start).Round(time.Millisecond),
+		(after.Hits+after.DiskHits)-(before.Hits+before.DiskHits), after.Misses-before.Misses)
+}
@@ -0,0 +1,484 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ghostdraft/ddragon"
+)
+
+// statsSchema is the subset of the analyzer's Turso schema the provider reads
+var statsSchema = []string{
+	`CREATE TABLE data_version (id INTEGER PRIMARY KEY CHECK (id = 1), patch TEXT NOT NULL, updated_at TEXT NOT NULL)`,
+	`CREATE TABLE champion_stats (patch TEXT, queue_id INTEGER, bracket TEXT, region TEXT, champion_id INTEGER, team_position TEXT, wins INTEGER, matches INTEGER)`,
+	`CREATE TABLE champion_items (patch TEXT, queue_id INTEGER, bracket TEXT, region TEXT, champion_id INTEGER, team_position TEXT, item_id INTEGER, wins INTEGER, matches INTEGER)`,
+	`CREATE TABLE champion_item_slots (patch TEXT, queue_id INTEGER, bracket TEXT, region TEXT, champion_id INTEGER, team_position TEXT, item_id INTEGER, build_slot INTEGER, wins INTEGER, matches INTEGER)`,
+	`CREATE TABLE champion_matchups (patch TEXT, queue_id INTEGER, bracket TEXT, region TEXT, champion_id INTEGER, team_position TEXT, enemy_champion_id INTEGER, wins INTEGER, matches INTEGER)`,
+	`CREATE TABLE arena_champion_stats (patch TEXT, champion_id INTEGER, wins INTEGER, matches INTEGER, firsts INTEGER, placement_sum INTEGER)`,
+	`CREATE TABLE arena_items (patch TEXT, champion_id INTEGER, item_id INTEGER, wins INTEGER, matches INTEGER, firsts INTEGER, placement_sum INTEGER)`,
+}
+
+// testItemCatalog returns the items the Ahri build data refers to
+func testItemCatalog() *ddragon.Catalog {
+	rift := map[string]bool{"11": true}
+	finished := func(name string) ddragon.Item {
+		return ddragon.Item{Name: name, Gold: ddragon.Gold{Total: 3000, Purchasable: true}, Maps: rift, Depth: 3}
+	}
+	return ddragon.NewCatalog("15.1.1", map[int]ddragon.Item{
+		1056: {Name: "Doran's Ring", Gold: ddragon.Gold{Total: 400, Purchasable: true}, Tags: []string{"Lane"}, Maps: rift},
+		3020: {Name: "Sorcerer's Shoes", From: []string{"1001"}, Gold: ddragon.Gold{Total: 1100, Purchasable: true}, Tags: []string{"Boots"}, Maps: rift},
+		6655: finished("Luden's Companion"),
+		4645: finished("Shadowflame"),
+		3089: finished("Rabadon's Deathcap"),
+		3157: finished("Zhonya's Hourglass"),
+		3135: finished("Void Staff"),
+		3165: finished("Morellonomicon"),
+	})
+}
+
+// openTestStatsDB creates a local SQLite stand-in for Turso with Ahri mid data
+func openTestStatsDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", newTestStatsFile(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// newTestStatsFile seeds a SQLite stats database and returns its path
+func newTestStatsFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stats.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	statements := append([]string{}, statsSchema...)
+	statements = append(statements,
+		`INSERT INTO data_version VALUES (1, '15.1', '2025-01-01T00:00:00Z')`,
+		`INSERT INTO champion_stats VALUES ('15.1', 420, 'diamond', '', 103, 'MIDDLE', 520, 1000)`,
+		// Slot 1: starting item and boots are skipped, Luden's is core
+		`INSERT INTO champion_item_slots VALUES ('15.1', 420, 'diamond', '', 103, 'MIDDLE', 1056, 1, 300, 600)`,
+		`INSERT INTO champion_item_slots VALUES ('15.1', 420, 'diamond', '', 103, 'MIDDLE', 3020, 1, 250, 500)`,
+		`INSERT INTO champion_item_slots VALUES ('15.1', 420, 'diamond', '', 103, 'MIDDLE', 6655, 1, 200, 400)`,
+		// Slot 2: Luden's again (duplicate) then Shadowflame
+		`INSERT INTO champion_item_slots VALUES ('15.1', 420, 'diamond', '', 103, 'MIDDLE', 6655, 2, 100, 200)`,
+		`INSERT INTO champion_item_slots VALUES ('15.1', 420, 'diamond', '', 103, 'MIDDLE', 4645, 2, 90, 150)`,
+		`INSERT INTO champion_item_slots VALUES ('15.1', 420, 'diamond', '', 103, 'MIDDLE', 3089, 4, 60, 100)`,
+		`INSERT INTO champion_item_slots VALUES ('15.1', 420, 'diamond', '', 103, 'MIDDLE', 3157, 4, 50, 90)`,
+		`INSERT INTO champion_item_slots VALUES ('15.1', 420, 'diamond', '', 103, 'MIDDLE', 3135, 5, 40, 80)`,
+		`INSERT INTO champion_item_slots VALUES ('15.1', 420, 'diamond', '', 103, 'MIDDLE', 3165, 6, 30, 60)`,
+		`INSERT INTO champion_matchups VALUES ('15.1', 420, 'diamond', '', 103, 'MIDDLE', 238, 40, 100)`,
+		`INSERT INTO champion_matchups VALUES ('15.1', 420, 'diamond', '', 103, 'MIDDLE', 157, 55, 100)`,
+	)
+	for _, stmt := range statements {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	return path
+}
+
+// newTestProvider returns a provider over the SQLite stand-in
+func newTestProvider(t *testing.T) (*StatsProvider, *sql.DB) {
+	db := openTestStatsDB(t)
+	provider, _ := NewStatsProvider(&TursoClient{db: db}, nil)
+	provider.SetItemCatalog(testItemCatalog)
+	if err := provider.FetchPatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return provider, db
+}
+
+func TestStatsProvider_FetchChampionData(t *testing.T) {
+	provider, _ := newTestProvider(t)
+
+	build, err := provider.FetchChampionData(context.Background(), 103, "Ahri", "middle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if build.Partial || len(build.Builds) != 1 {
+		t.Fatalf("expected one complete build, got %+v", build)
+	}
+
+	path := build.Builds[0]
+	if want := []int{6655, 4645, 3020}; len(path.CoreItems) != 3 || path.CoreItems[0] != want[0] || path.CoreItems[1] != want[1] || path.CoreItems[2] != want[2] {
+		t.Errorf("expected core %v, got %v", want, path.CoreItems)
+	}
+	if path.Games != 1000 || path.WinRate != 50 {
+		t.Errorf("expected 1000 games at 50%%, got %d at %.1f", path.Games, path.WinRate)
+	}
+	if len(path.FourthItemOptions) != 2 || len(path.FifthItemOptions) != 1 || len(path.SixthItemOptions) != 1 {
+		t.Errorf("unexpected item options: %+v", path)
+	}
+
+	if _, err := provider.FetchChampionData(context.Background(), 1, "Annie", "middle"); err == nil {
+		t.Error("expected an error for a champion without data")
+	}
+}
+
+func TestStatsProvider_BuildWaitsForItemData(t *testing.T) {
+	provider, _ := newTestProvider(t)
+	var catalog atomic.Pointer[ddragon.Catalog]
+	provider.SetItemCatalog(catalog.Load)
+
+	if _, err := provider.FetchChampionData(context.Background(), 103, "Ahri", "middle"); err == nil {
+		t.Fatal("expected an error before items are loaded")
+	}
+	catalog.Store(testItemCatalog())
+	build, err := provider.FetchChampionData(context.Background(), 103, "Ahri", "middle")
+	if err != nil || len(build.Builds[0].CoreItems) != 3 {
+		t.Errorf("expected the build once items load, got %+v err=%v", build, err)
+	}
+}
+
+func TestStatsProvider_PartialBuildOnDeadline(t *testing.T) {
+	provider, _ := newTestProvider(t)
+	provider.SetCallTimeout(50 * time.Millisecond)
+
+	// Hold slot 6 in flight, as if Turso were slow to answer it
+	release := make(chan struct{})
+	held := make(chan struct{})
+	go func() {
+		defer close(held)
+		provider.flights.do(context.Background(), "slot:ranked:103:MIDDLE:6", time.Minute, func(ctx context.Context) (interface{}, error) {
+			<-release
+			return nil, errors.New("slow query abandoned")
+		})
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	build, err := provider.FetchChampionData(context.Background(), 103, "Ahri", "middle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !build.Partial || len(build.Builds[0].SixthItemOptions) != 0 || len(build.Builds[0].CoreItems) != 3 {
+		t.Errorf("expected a partial build without slot 6, got %+v", build)
+	}
+	close(release)
+	<-held
+
+	// The other slots were cached, so the next call completes the build
+	build, err = provider.FetchChampionData(context.Background(), 103, "Ahri", "middle")
+	if err != nil || build.Partial || len(build.Builds[0].SixthItemOptions) != 1 {
+		t.Errorf("expected the full build on retry, got %+v err=%v", build, err)
+	}
+	if stats := provider.CacheStats(); stats.PartialBuilds != 1 || stats.Coalesced < 1 {
+		t.Errorf("expected 1 partial build and a coalesced slot query, got %+v", stats)
+	}
+}
+
+func TestQuery_CoalescesIdenticalCalls(t *testing.T) {
+	provider, _ := NewStatsProvider(&TursoClient{}, nil)
+
+	var runs atomic.Int32
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = query(context.Background(), provider, "games:103:MIDDLE", time.Minute, func(ctx context.Context) (int, error) {
+				runs.Add(1)
+				<-release
+				return 1000, nil
+			})
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if runs.Load() != 1 {
+		t.Errorf("expected one query, got %d", runs.Load())
+	}
+	for _, r := range results {
+		if r != 1000 {
+			t.Errorf("expected every caller to get 1000, got %v", results)
+			break
+		}
+	}
+	if got := provider.CacheStats().Coalesced; got != 9 {
+		t.Errorf("expected 9 coalesced calls, got %d", got)
+	}
+}
+
+func TestQuery_TimedOutResultStillCached(t *testing.T) {
+	provider, _ := NewStatsProvider(&TursoClient{}, nil)
+	provider.SetCallTimeout(20 * time.Millisecond)
+
+	done := make(chan struct{})
+	_, err := query(context.Background(), provider, "matchups:103:middle", time.Minute, func(ctx context.Context) ([]MatchupStat, error) {
+		defer close(done)
+		time.Sleep(60 * time.Millisecond)
+		return []MatchupStat{{EnemyChampionID: 238}}, ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline error with nothing cached, got %v", err)
+	}
+
+	<-done
+	for i := 0; i < 100; i++ {
+		if cached, ok := cacheGet[[]MatchupStat](provider.cache, "matchups:103:middle"); ok && cached[0].EnemyChampionID == 238 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Error("expected the slow result to be cached after the caller gave up")
+}
+
+func TestQuery_ServesStaleOnDeadline(t *testing.T) {
+	provider, _ := NewStatsProvider(&TursoClient{}, nil)
+	provider.SetCallTimeout(20 * time.Millisecond)
+	now := time.Now()
+	provider.cache.now = func() time.Time { return now }
+
+	provider.cache.SetTTL("counters:103:middle:5:0.490", []MatchupStat{{EnemyChampionID: 238}}, time.Minute)
+	now = now.Add(time.Hour)
+
+	got, err := query(context.Background(), provider, "counters:103:middle:5:0.490", time.Minute, func(ctx context.Context) ([]MatchupStat, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err != nil || len(got) != 1 || got[0].EnemyChampionID != 238 {
+		t.Errorf("expected stale counters, got %v err=%v", got, err)
+	}
+	if provider.CacheStats().StaleServed != 1 {
+		t.Error("expected the stale fallback to be counted")
+	}
+}
+
+func TestFlightGroup_CancelsWhenEveryCallerCancels(t *testing.T) {
+	var group flightGroup
+	ctx, cancel := context.WithCancel(context.Background())
+
+	queryCancelled := make(chan bool, 1)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err, _ := group.do(ctx, "bans", time.Minute, func(ctx context.Context) (interface{}, error) {
+		select {
+		case <-ctx.Done():
+			queryCancelled <- true
+		case <-time.After(5 * time.Second):
+			queryCancelled <- false
+		}
+		return nil, ctx.Err()
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the caller to see its cancellation, got %v", err)
+	}
+	if !<-queryCancelled {
+		t.Error("expected the query to be cancelled once nobody was waiting")
+	}
+}
+
+func TestFlightGroup_NewCallerSkipsCancelledFlight(t *testing.T) {
+	var group flightGroup
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// The first query is slow to notice its cancellation
+	release := make(chan struct{})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	group.do(ctx, "bans", time.Minute, func(ctx context.Context) (interface{}, error) {
+		<-release
+		return nil, ctx.Err()
+	})
+	defer close(release)
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+	defer waitCancel()
+	value, err, shared := group.do(waitCtx, "bans", time.Minute, func(ctx context.Context) (interface{}, error) {
+		return "fresh", nil
+	})
+	if err != nil || value != "fresh" || shared {
+		t.Errorf("expected a fresh flight, got %v err=%v shared=%v", value, err, shared)
+	}
+}
+
+func TestStatsProvider_ContextThroughQueries(t *testing.T) {
+	provider, db := newTestProvider(t)
+
+	matchups, err := provider.FetchAllMatchups(context.Background(), 103, "middle")
+	if err != nil || len(matchups) != 2 {
+		t.Fatalf("expected 2 matchups, got %v err=%v", matchups, err)
+	}
+	counters, err := provider.FetchCounterMatchups(context.Background(), 103, "middle", RecommendedBans)
+	if err != nil || len(counters) != 1 || counters[0].EnemyChampionID != 238 {
+		t.Errorf("expected Zed as the only counter, got %v err=%v", counters, err)
+	}
+	if role := provider.GetMostPlayedRole(context.Background(), 103); role != "middle" {
+		t.Errorf("expected middle, got %q", role)
+	}
+
+	// A new upload changes the data version and drops the cached matchups
+	db.Exec(`UPDATE data_version SET updated_at = '2025-01-02T00:00:00Z'`)
+	db.Exec(`INSERT INTO champion_matchups VALUES ('15.1', 420, 'diamond', '', 103, 'MIDDLE', 7, 45, 100)`)
+	if err := provider.FetchPatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	matchups, _ = provider.FetchAllMatchups(context.Background(), 103, "middle")
+	if len(matchups) != 3 {
+		t.Errorf("expected fresh matchups after the version change, got %d", len(matchups))
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := provider.FetchCounterPicks(cancelled, 238, "middle", 6); err == nil {
+		t.Error("expected a cancelled context to fail the query")
+	}
+}
+
+func TestStatsProvider_Datasets(t *testing.T) {
+	provider, db := newTestProvider(t)
+	for _, stmt := range []string{
+		// Draft and ARAM games, plus an Arena item list
+		`INSERT INTO champion_stats VALUES ('15.1', 400, 'diamond', '', 103, 'MIDDLE', 30, 50)`,
+		`INSERT INTO champion_matchups VALUES ('15.1', 400, 'diamond', '', 103, 'MIDDLE', 238, 30, 50)`,
+		`INSERT INTO champion_stats VALUES ('15.1', 450, 'diamond', '', 103, '', 60, 100)`,
+		`INSERT INTO champion_item_slots VALUES ('15.1', 450, 'diamond', '', 103, '', 3089, 1, 60, 100)`,
+		`INSERT INTO arena_champion_stats VALUES ('15.1', 103, 30, 50, 10, 200)`,
+		`INSERT INTO arena_items VALUES ('15.1', 103, 3089, 20, 30, 8, 100)`,
+		`INSERT INTO arena_items VALUES ('15.1', 103, 3157, 5, 10, 1, 50)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	provider.SetDataset(DatasetForQueue(QueueDraft))
+	matchups, err := provider.FetchAllMatchups(context.Background(), 103, "middle")
+	if err != nil || len(matchups) != 1 || matchups[0].WinRate != 60 {
+		t.Errorf("expected only the draft matchup, got %v err=%v", matchups, err)
+	}
+
+	// ARAM has no roles, whatever role is asked for
+	provider.SetDataset(DatasetForQueue(QueueARAM))
+	build, err := provider.FetchChampionData(context.Background(), 103, "Ahri", "top")
+	if err != nil || build.Builds[0].Games != 100 || build.Builds[0].CoreItems[0] != 3089 {
+		t.Errorf("expected the ARAM build, got %+v err=%v", build, err)
+	}
+	if role := provider.GetMostPlayedRole(context.Background(), 103); role != "middle" {
+		t.Errorf("expected ranked's most played role in ARAM, got %q", role)
+	}
+
+	provider.SetDataset(DatasetForQueue(QueueArena))
+	build, err = provider.FetchChampionData(context.Background(), 103, "Ahri", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path := build.Builds[0]; path.Games != 50 || path.WinRate != 60 || len(path.CoreItems) != 2 || path.CoreItems[0] != 3089 {
+		t.Errorf("expected the Arena build, got %+v", path)
+	}
+	if !provider.HasData(context.Background(), 103, "") {
+		t.Error("expected Arena data for Ahri")
+	}
+
+	// Switching back reads ranked stats again
+	provider.SetDataset(DatasetForQueue(0))
+	build, err = provider.FetchChampionData(context.Background(), 103, "Ahri", "middle")
+	if err != nil || build.Builds[0].Games != 1000 {
+		t.Errorf("expected the ranked build, got %+v err=%v", build, err)
+	}
+}
+
+func TestStatsProvider_Brackets(t *testing.T) {
+	provider, db := newTestProvider(t)
+	for _, stmt := range []string{
+		// Emerald games, and one recorded before tiers were
+		`INSERT INTO champion_stats VALUES ('15.1', 420, 'emerald', '', 103, 'MIDDLE', 90, 200)`,
+		`INSERT INTO champion_stats VALUES ('15.1', 420, '', '', 103, 'MIDDLE', 5, 10)`,
+		`INSERT INTO champion_matchups VALUES ('15.1', 420, 'emerald', '', 103, 'MIDDLE', 238, 60, 100)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	ctx := context.Background()
+	if games, _ := provider.championGames(ctx, provider.Dataset(), 103, "MIDDLE"); games != 1210 {
+		t.Errorf("expected every bracket by default, got %d games", games)
+	}
+
+	provider.SetBracket(BracketForTier("PLATINUM"))
+	if games, _ := provider.championGames(ctx, provider.Dataset(), 103, "MIDDLE"); games != 200 {
+		t.Errorf("expected Emerald's games below Emerald, got %d", games)
+	}
+	matchups, err := provider.FetchAllMatchups(ctx, 103, "middle")
+	if err != nil || len(matchups) != 1 || matchups[0].WinRate != 60 {
+		t.Errorf("expected only the Emerald matchup, got %v err=%v", matchups, err)
+	}
+
+	provider.SetBracket(BracketForTier("DIAMOND"))
+	matchups, _ = provider.FetchAllMatchups(ctx, 103, "middle")
+	if len(matchups) != 2 {
+		t.Errorf("expected the Diamond matchups, got %v", matchups)
+	}
+
+	// Arena isn't split by bracket
+	provider.SetDataset(DatasetArena)
+	if ds := provider.Dataset(); ds.Bracket != BracketAll {
+		t.Errorf("expected Arena to read every bracket, got %q", ds.Bracket)
+	}
+}
+
+func TestStatsProvider_Regions(t *testing.T) {
+	provider, db := newTestProvider(t)
+	for _, stmt := range []string{
+		// Games from Europe and Korea, on top of the seed data without a region (merged,
+		// or recorded before regions were)
+		`INSERT INTO champion_stats VALUES ('15.1', 420, 'diamond', 'europe', 103, 'MIDDLE', 30, 50)`,
+		`INSERT INTO champion_stats VALUES ('15.1', 420, 'diamond', 'asia', 103, 'MIDDLE', 20, 50)`,
+		`INSERT INTO champion_matchups VALUES ('15.1', 420, 'diamond', 'europe', 103, 'MIDDLE', 7, 30, 50)`,
+		`INSERT INTO champion_matchups VALUES ('15.1', 420, 'diamond', 'asia', 103, 'MIDDLE', 4, 20, 50)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	ctx := context.Background()
+	if matchups, _ := provider.FetchAllMatchups(ctx, 103, "middle"); len(matchups) != 4 {
+		t.Errorf("expected every region by default, got %v", matchups)
+	}
+
+	// A region with its own rows reads only those, so merged games aren't counted twice
+	provider.SetRegion(RegionForLCU("EUW"))
+	matchups, err := provider.FetchAllMatchups(ctx, 103, "middle")
+	if err != nil || len(matchups) != 1 || matchups[0].EnemyChampionID != 7 {
+		t.Fatalf("expected only the European matchup, got %v err=%v", matchups, err)
+	}
+	if games, _ := provider.championGames(ctx, provider.Dataset(), 103, "MIDDLE"); games != 50 {
+		t.Errorf("expected Europe's 50 games, got %d", games)
+	}
+
+	// A region without rows for the patch falls back to the rows without a region
+	provider.SetRegion(RegionForLCU("OC1"))
+	matchups, err = provider.FetchAllMatchups(ctx, 103, "middle")
+	if err != nil || len(matchups) != 2 {
+		t.Fatalf("expected the matchups without a region, got %v err=%v", matchups, err)
+	}
+	if games, _ := provider.championGames(ctx, provider.Dataset(), 103, "MIDDLE"); games != 1000 {
+		t.Errorf("expected the 1000 games without a region, got %d", games)
+	}
+
+	if RegionForLCU("PBE") != RegionAll || RegionForLCU("oc1") != RegionSEA {
+		t.Error("expected PBE to read every region and OC1 to read SEA")
+	}
+}
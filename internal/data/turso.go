@@ -3,10 +3,9 @@ package data
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
-	"sync"
-	"time"
 
 	_ "github.com/tursodatabase/libsql-client-go/libsql"
 )
@@ -18,49 +17,16 @@ var (
 	TursoAuthToken string // Turso auth token (read-only)
 )
 
-// TursoClient wraps a connection to Turso with caching
+// TursoClient wraps a connection to Turso
 type TursoClient struct {
-	db    *sql.DB
-	cache *QueryCache
+	db *sql.DB
 }
 
-// QueryCache provides thread-safe in-memory caching
-type QueryCache struct {
-	mu   sync.RWMutex
-	data map[string]interface{}
-}
+// ErrTursoNotConfigured means no Turso URL was built in or set in the environment
+var ErrTursoNotConfigured = errors.New("Turso URL not configured (set TURSO_DATABASE_URL or build with -ldflags)")
 
-// NewQueryCache creates a new query cache
-func NewQueryCache() *QueryCache {
-	return &QueryCache{
-		data: make(map[string]interface{}),
-	}
-}
-
-// Get retrieves a value from the cache
-func (c *QueryCache) Get(key string) (interface{}, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	val, ok := c.data[key]
-	return val, ok
-}
-
-// Set stores a value in the cache
-func (c *QueryCache) Set(key string, value interface{}) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.data[key] = value
-}
-
-// Clear removes all cached values
-func (c *QueryCache) Clear() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.data = make(map[string]interface{})
-}
-
-// NewTursoClient creates a new Turso client with caching
-func NewTursoClient() (*TursoClient, error) {
+// OpenTurso opens and pings a connection to the configured Turso database
+func OpenTurso(ctx context.Context) (*sql.DB, error) {
 	url := TursoURL
 	token := TursoAuthToken
 
@@ -73,7 +39,7 @@ func NewTursoClient() (*TursoClient, error) {
 	}
 
 	if url == "" {
-		return nil, fmt.Errorf("Turso URL not configured (set TURSO_DATABASE_URL or build with -ldflags)")
+		return nil, ErrTursoNotConfigured
 	}
 
 	connStr := url
@@ -86,21 +52,17 @@ func NewTursoClient() (*TursoClient, error) {
 		return nil, fmt.Errorf("failed to connect to Turso: %w", err)
 	}
 
-	// Test connection with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	if err := db.PingContext(ctx); err != nil {
+	if err := ping(ctx, db); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("failed to ping Turso: %w", err)
 	}
+	return db, nil
+}
 
-	fmt.Println("[Turso] Connected successfully")
-
-	return &TursoClient{
-		db:    db,
-		cache: NewQueryCache(),
-	}, nil
+// ping runs a trivial query, since a remote driver may connect lazily and pass a plain Ping
+func ping(ctx context.Context, db *sql.DB) error {
+	var one int
+	return db.QueryRowContext(ctx, "SELECT 1").Scan(&one)
 }
 
 // Close closes the Turso connection
@@ -113,17 +75,6 @@ func (c *TursoClient) GetDB() *sql.DB {
 	return c.db
 }
 
-// GetCache returns the query cache
-func (c *TursoClient) GetCache() *QueryCache {
-	return c.cache
-}
-
-// ClearCache clears all cached query results
-func (c *TursoClient) ClearCache() {
-	c.cache.Clear()
-	fmt.Println("[Turso] Cache cleared")
-}
-
 // getEnv gets an environment variable with a default fallback
 func getEnv(key, defaultVal string) string {
 	if val := os.Getenv(key); val != "" {
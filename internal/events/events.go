@@ -0,0 +1,93 @@
+// Package events defines every event the backend sends to the frontend as a typed
+// payload, and the emitters that deliver them.
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+//go:generate go run ./gen -out ../../frontend/src/events.d.ts
+
+// Event is a payload that knows which frontend event it belongs to
+type Event interface {
+	EventName() string
+}
+
+// Emitter delivers events to the frontend
+type Emitter interface {
+	Emit(e Event)
+}
+
+// WailsEmitter emits events through the Wails runtime
+type WailsEmitter struct {
+	ctx context.Context
+}
+
+// NewWailsEmitter creates an emitter for the window bound to ctx
+func NewWailsEmitter(ctx context.Context) *WailsEmitter {
+	return &WailsEmitter{ctx: ctx}
+}
+
+// Emit sends the event to the frontend
+func (w *WailsEmitter) Emit(e Event) {
+	runtime.EventsEmit(w.ctx, e.EventName(), e)
+}
+
+// Discard drops every event. It stands in before the frontend is ready.
+var Discard Emitter = discard{}
+
+// discard is the Emitter behind Discard
+type discard struct{}
+
+// Emit does nothing
+func (discard) Emit(Event) {}
+
+// Recorder keeps every emitted event in order so tests can assert on them
+type Recorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// Emit records the event
+func (r *Recorder) Emit(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+// Events returns everything recorded so far
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+// Named returns the recorded events with the given name
+func (r *Recorder) Named(name string) []Event {
+	var matched []Event
+	for _, e := range r.Events() {
+		if e.EventName() == name {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+// Last returns the most recent event with the given name, or nil if there was none
+func (r *Recorder) Last(name string) Event {
+	matched := r.Named(name)
+	if len(matched) == 0 {
+		return nil
+	}
+	return matched[len(matched)-1]
+}
+
+// Reset forgets everything recorded so far
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
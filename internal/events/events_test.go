@@ -0,0 +1,89 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_KeepsOrderAndFiltersByName(t *testing.T) {
+	var rec Recorder
+	var emitter Emitter = &rec
+
+	emitter.Emit(BuildUpdate{HasBuild: false, Error: "no stats"})
+	emitter.Emit(GameflowUpdate{Phase: "ChampSelect"})
+	emitter.Emit(BuildUpdate{HasBuild: true, ChampionName: "Ahri"})
+
+	if got := len(rec.Events()); got != 3 {
+		t.Fatalf("expected 3 events, got %d", got)
+	}
+	if got := len(rec.Named("build:update")); got != 2 {
+		t.Errorf("expected 2 build updates, got %d", got)
+	}
+	last, ok := rec.Last("build:update").(BuildUpdate)
+	if !ok || last.ChampionName != "Ahri" {
+		t.Errorf("expected last build update for Ahri, got %+v", rec.Last("build:update"))
+	}
+	if rec.Last("items:update") != nil {
+		t.Error("expected nil for an event that was never emitted")
+	}
+
+	rec.Reset()
+	if len(rec.Events()) != 0 {
+		t.Error("expected Reset to clear events")
+	}
+}
+
+func TestEventNames_Unique(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, e := range All {
+		if seen[e.EventName()] {
+			t.Errorf("event %s registered twice", e.EventName())
+		}
+		seen[e.EventName()] = true
+	}
+}
+
+func TestPayloads_OmitFieldsOutsideTheirState(t *testing.T) {
+	data, _ := json.Marshal(ChampSelectUpdate{InChampSelect: false})
+	if string(data) != `{"inChampSelect":false}` {
+		t.Errorf("expected only inChampSelect when leaving champ select, got %s", data)
+	}
+
+	data, _ = json.Marshal(GoldBoxShow(true))
+	if string(data) != `true` {
+		t.Errorf("expected goldbox:show to encode as a bare boolean, got %s", data)
+	}
+}
+
+func TestTypeScript_DeclaresEveryEvent(t *testing.T) {
+	ts := TypeScript()
+	for _, e := range All {
+		if !strings.Contains(ts, `"`+e.EventName()+`"`) {
+			t.Errorf("EventMap is missing %s", e.EventName())
+		}
+	}
+	for _, want := range []string{
+		"export interface BuildUpdate {",
+		"\thasBuild: boolean;",
+		"\twinRate?: string;",
+		"\tbans: ChampionPick[] | null;",
+		"export interface Insight {", // declared from a nested field in another package
+		"export type GoldBoxShow = boolean;",
+	} {
+		if !strings.Contains(ts, want) {
+			t.Errorf("expected generated definitions to contain %q", want)
+		}
+	}
+}
+
+func TestTypeScript_CheckedInFileIsCurrent(t *testing.T) {
+	data, err := os.ReadFile("../../frontend/src/events.d.ts")
+	if err != nil {
+		t.Fatalf("failed to read generated definitions: %v", err)
+	}
+	if string(data) != TypeScript() {
+		t.Error("frontend/src/events.d.ts is out of date - run go generate ./internal/events")
+	}
+}
@@ -0,0 +1,20 @@
+// Command gen writes the frontend's TypeScript definitions for backend events.
+// Run it with go generate ./internal/events.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"ghostdraft/internal/events"
+)
+
+func main() {
+	out := flag.String("out", "events.d.ts", "Output file")
+	flag.Parse()
+
+	if err := os.WriteFile(*out, []byte(events.TypeScript()), 0644); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+}
@@ -0,0 +1,373 @@
+package events
+
+import (
+	"ghostdraft/internal/data"
+	"ghostdraft/internal/insights"
+	"ghostdraft/internal/settings"
+)
+
+// All lists one value of every event type. The TypeScript definitions are generated from it.
+var All = []Event{
+	LCUStatus{},
+	GameflowUpdate{},
+	ChampSelectUpdate{},
+	AlliesUpdate{},
+	BuildUpdate{},
+	BansUpdate{},
+	CounterPicksUpdate{},
+	ItemsUpdate{},
+	TeamCompUpdate{},
+	FullCompUpdate{},
+	InGameBuild{},
+	ScoutingUpdate{},
+	GoldUpdate{},
+	GoldBoxShow(false),
+	SettingsChanged{},
+	StatsStatus{},
+	HistorySynced{},
+}
+
+// LCUStatus reports the League client connection
+type LCUStatus struct {
+	Connected bool   `json:"connected"`
+	Message   string `json:"message"`
+	Port      string `json:"port,omitempty"`
+}
+
+// EventName implements Event
+func (LCUStatus) EventName() string { return "lcu:status" }
+
+// GameflowUpdate reports a gameflow phase change, e.g. "ChampSelect" or "InProgress"
+type GameflowUpdate struct {
+	Phase string `json:"phase"`
+}
+
+// EventName implements Event
+func (GameflowUpdate) EventName() string { return "gameflow:update" }
+
+// ChampSelectUpdate is the local player's champ select state. Only InChampSelect is
+// set once champ select ends.
+type ChampSelectUpdate struct {
+	InChampSelect    bool   `json:"inChampSelect"`
+	Phase            string `json:"phase,omitempty"`
+	ChampionName     string `json:"championName,omitempty"`
+	ChampionID       int    `json:"championID,omitempty"`
+	IsLocked         bool   `json:"isLocked,omitempty"`
+	LocalPosition    string `json:"localPosition,omitempty"`
+	ActionType       string `json:"actionType,omitempty"` // "pick", "ban" or "" when it isn't our turn
+	TimeLeft         int    `json:"timeLeft,omitempty"`
+	BanPhaseComplete bool   `json:"banPhaseComplete,omitempty"`
+}
+
+// EventName implements Event
+func (ChampSelectUpdate) EventName() string { return "champselect:update" }
+
+// AlliesUpdate is the teammate panel during champ select
+type AlliesUpdate struct {
+	HasData bool          `json:"hasData"`
+	Allies  []AllyProfile `json:"allies"`
+}
+
+// EventName implements Event
+func (AlliesUpdate) EventName() string { return "champselect:allies" }
+
+// AllyProfile is a teammate's scouting card during champ select
+type AllyProfile struct {
+	CellID          int      `json:"cellId"`
+	PUUID           string   `json:"puuid"`
+	GameName        string   `json:"gameName"`
+	TagLine         string   `json:"tagLine"`
+	IsMe            bool     `json:"isMe"`
+	Position        string   `json:"position"`
+	ChampionID      int      `json:"championId"`
+	ChampionName    string   `json:"championName"`
+	ChampionIcon    string   `json:"championIcon"`
+	IsLocked        bool     `json:"isLocked"`
+	Status          string   `json:"status"` // "loading", "ready", "error"
+	Error           string   `json:"error"`
+	RankTier        string   `json:"rankTier"`
+	RankDivision    string   `json:"rankDivision"`
+	RankLabel       string   `json:"rankLabel"` // e.g. "Gold II", "Unranked"
+	RecentGames     int      `json:"recentGames"`
+	RecentWinRate   float64  `json:"recentWinRate"`
+	ChampionGames   int      `json:"championGames"`
+	ChampionWinRate float64  `json:"championWinRate"`
+	FirstTime       bool     `json:"firstTime"`
+	Autofilled      bool     `json:"autofilled"`
+	MainRoles       []string `json:"mainRoles"`
+}
+
+// BuildUpdate is the lane matchup header during champ select
+type BuildUpdate struct {
+	HasBuild      bool   `json:"hasBuild"`
+	ChampionName  string `json:"championName,omitempty"`
+	Role          string `json:"role,omitempty"`
+	WinRate       string `json:"winRate,omitempty"` // formatted, e.g. "52.3%", or "-" without a lane opponent
+	WinRateLabel  string `json:"winRateLabel,omitempty"`
+	EnemyName     string `json:"enemyName,omitempty"`
+	MatchupStatus string `json:"matchupStatus,omitempty"` // "winning", "losing" or "even"
+	Patch         string `json:"patch,omitempty"`
+	Bracket       string `json:"bracket,omitempty"` // rank bracket the stats come from, e.g. "Diamond"
+	Error         string `json:"error,omitempty"`
+}
+
+// EventName implements Event
+func (BuildUpdate) EventName() string { return "build:update" }
+
+// BansUpdate is the recommended bans for our champion and role
+type BansUpdate struct {
+	HasBans      bool           `json:"hasBans"`
+	ChampionName string         `json:"championName,omitempty"`
+	Role         string         `json:"role,omitempty"`
+	Bans         []ChampionPick `json:"bans"`
+	NoData       bool           `json:"noData,omitempty"`
+}
+
+// EventName implements Event
+func (BansUpdate) EventName() string { return "bans:update" }
+
+// CounterPicksUpdate is the champions that beat the enemy laner
+type CounterPicksUpdate struct {
+	HasData   bool           `json:"hasData"`
+	EnemyName string         `json:"enemyName,omitempty"`
+	EnemyIcon string         `json:"enemyIcon,omitempty"`
+	Picks     []ChampionPick `json:"picks"`
+}
+
+// EventName implements Event
+func (CounterPicksUpdate) EventName() string { return "counterpicks:update" }
+
+// ChampionPick is a champion suggested as a ban or counter pick
+type ChampionPick struct {
+	ChampionID   int     `json:"championID"`
+	ChampionName string  `json:"championName"`
+	IconURL      string  `json:"iconURL"`
+	DamageType   string  `json:"damageType,omitempty"` // bans only
+	WinRate      float64 `json:"winRate"`
+	Games        int     `json:"games"`
+}
+
+// ItemsUpdate is the champ select build paths for our champion and role
+type ItemsUpdate struct {
+	HasItems     bool        `json:"hasItems"`
+	ChampionName string      `json:"championName,omitempty"`
+	Role         string      `json:"role,omitempty"`
+	Builds       []ItemBuild `json:"builds"`
+}
+
+// EventName implements Event
+func (ItemsUpdate) EventName() string { return "items:update" }
+
+// InGameBuild is the build shown while the game is running
+type InGameBuild struct {
+	HasBuild     bool        `json:"hasBuild"`
+	ChampionName string      `json:"championName,omitempty"`
+	ChampionID   int         `json:"championID,omitempty"`
+	ChampionIcon string      `json:"championIcon,omitempty"`
+	Role         string      `json:"role,omitempty"`
+	Builds       []ItemBuild `json:"builds"`
+	Error        string      `json:"error,omitempty"`
+}
+
+// EventName implements Event
+func (InGameBuild) EventName() string { return "ingame:build" }
+
+// ItemBuild is one build path. The in-game build leaves out the name, record and starting items.
+type ItemBuild struct {
+	Name          string       `json:"name,omitempty"` // named after the first core item
+	WinRate       float64      `json:"winRate,omitempty"`
+	Games         int          `json:"games,omitempty"`
+	StartingItems []Item       `json:"startingItems,omitempty"`
+	CoreItems     []Item       `json:"coreItems"`
+	FourthItems   []ItemOption `json:"fourthItems"`
+	FifthItems    []ItemOption `json:"fifthItems"`
+	SixthItems    []ItemOption `json:"sixthItems"`
+}
+
+// Item is an item with its display name and icon
+type Item struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	IconURL string `json:"iconURL"`
+}
+
+// ItemOption is a late-game item choice with how it performed in that slot
+type ItemOption struct {
+	ID      int     `json:"id"`
+	Name    string  `json:"name"`
+	IconURL string  `json:"iconURL"`
+	WinRate float64 `json:"winRate"`
+	Games   int     `json:"games"`
+}
+
+// TeamCompUpdate warns when our team's damage leans too far AP or AD
+type TeamCompUpdate struct {
+	Show           bool   `json:"show"`
+	Recommendation string `json:"recommendation,omitempty"`
+	Severity       string `json:"severity,omitempty"` // "warning" or "critical"
+	APCount        int    `json:"apCount,omitempty"`
+	ADCount        int    `json:"adCount,omitempty"`
+}
+
+// EventName implements Event
+func (TeamCompUpdate) EventName() string { return "teamcomp:update" }
+
+// FullCompUpdate compares both team compositions once everyone has locked in
+type FullCompUpdate struct {
+	Ready          bool     `json:"ready"`
+	AllyArchetype  string   `json:"allyArchetype,omitempty"`
+	AllyTags       []string `json:"allyTags,omitempty"`
+	AllyAP         int      `json:"allyAP,omitempty"` // percent of damage dealers
+	AllyAD         int      `json:"allyAD,omitempty"`
+	EnemyArchetype string   `json:"enemyArchetype,omitempty"`
+	EnemyTags      []string `json:"enemyTags,omitempty"`
+	EnemyAP        int      `json:"enemyAP,omitempty"`
+	EnemyAD        int      `json:"enemyAD,omitempty"`
+}
+
+// EventName implements Event
+func (FullCompUpdate) EventName() string { return "fullcomp:update" }
+
+// ScoutingUpdate is the in-game scouting panel. It is re-sent as each player finishes
+// loading; Complete is set on the last one.
+type ScoutingUpdate struct {
+	HasData   bool            `json:"hasData"`
+	Complete  bool            `json:"complete,omitempty"`
+	MyTeam    []ScoutedPlayer `json:"myTeam"`
+	EnemyTeam []ScoutedPlayer `json:"enemyTeam"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// EventName implements Event
+func (ScoutingUpdate) EventName() string { return "ingame:scouting" }
+
+// ScoutedPlayer is one player's scouting card
+type ScoutedPlayer struct {
+	PUUID           string             `json:"puuid"`
+	GameName        string             `json:"gameName"`
+	TagLine         string             `json:"tagLine"`
+	ChampionID      int                `json:"championId"`
+	ChampionName    string             `json:"championName"`
+	ChampionIcon    string             `json:"championIcon"`
+	IsMe            bool               `json:"isMe"`
+	Position        string             `json:"position"`
+	Status          string             `json:"status"` // "loading", "ready", "error"
+	Error           string             `json:"error"`
+	SummonerLevel   int                `json:"summonerLevel"`
+	SoloRank        Rank               `json:"soloRank"`
+	FlexRank        Rank               `json:"flexRank"`
+	MasteryLevel    int                `json:"masteryLevel"`
+	MasteryPoints   int                `json:"masteryPoints"`
+	ChampionGames   int                `json:"championGames"`
+	ChampionWinRate float64            `json:"championWinRate"`
+	IsSmurf         bool               `json:"isSmurf"`
+	IsOneTrick      bool               `json:"isOneTrick"`
+	IsOffRole       bool               `json:"isOffRole"`
+	Games           int                `json:"games"`
+	Wins            int                `json:"wins"`
+	WinRate         float64            `json:"winRate"`
+	AvgKills        float64            `json:"avgKills"`
+	AvgDeaths       float64            `json:"avgDeaths"`
+	AvgAssists      float64            `json:"avgAssists"`
+	KDA             float64            `json:"kda"`
+	AvgCS           float64            `json:"avgCS"`
+	TiltLevel       string             `json:"tiltLevel"` // "tilted", "warming_up", "on_fire", "smurf", ""
+	FunFact         string             `json:"funFact"`
+	Insights        []insights.Insight `json:"insights"`
+}
+
+// Rank is a player's standing in one ranked queue
+type Rank struct {
+	Tier     string `json:"tier"`
+	Division string `json:"division"`
+	LP       int    `json:"lp"`
+	Wins     int    `json:"wins"`
+	Losses   int    `json:"losses"`
+	Label    string `json:"label"` // e.g. "Gold II", "Unranked"
+	IsRanked bool   `json:"isRanked"`
+}
+
+// GoldUpdate is the Tab HUD's item gold comparison, polled while Tab is held
+type GoldUpdate struct {
+	HasData       bool          `json:"hasData"`
+	Error         string        `json:"error,omitempty"`
+	MyTeam        []GoldPlayer  `json:"myTeam"`
+	EnemyTeam     []GoldPlayer  `json:"enemyTeam"`
+	MyTeamGold    int           `json:"myTeamGold"`
+	EnemyTeamGold int           `json:"enemyTeamGold"`
+	GoldDiff      int           `json:"goldDiff"`
+	Matchups      []GoldMatchup `json:"matchups"`
+	NextPurchase  *NextPurchase `json:"nextPurchase,omitempty"` // Absent without a recommended build
+}
+
+// EventName implements Event
+func (GoldUpdate) EventName() string { return "gold:update" }
+
+// GoldPlayer is one player's items and score from the live client
+type GoldPlayer struct {
+	SummonerName string     `json:"summonerName"`
+	ChampionName string     `json:"championName"`
+	ChampionIcon string     `json:"championIcon"`
+	Position     string     `json:"position"` // TOP, JUNGLE, MIDDLE, BOTTOM, UTILITY
+	Team         string     `json:"team"`     // ORDER or CHAOS
+	IsMe         bool       `json:"isMe"`
+	Level        int        `json:"level"`
+	Kills        int        `json:"kills"`
+	Deaths       int        `json:"deaths"`
+	Assists      int        `json:"assists"`
+	CS           int        `json:"cs"`
+	ItemGold     int        `json:"itemGold"`
+	Items        []GoldItem `json:"items"`
+}
+
+// GoldItem is an item in a player's inventory with its gold value
+type GoldItem struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Gold    int    `json:"gold"`
+	IconURL string `json:"iconURL"`
+}
+
+// GoldMatchup compares the two players in the same position
+type GoldMatchup struct {
+	Position    string     `json:"position"`
+	MyPlayer    GoldPlayer `json:"myPlayer"`
+	EnemyPlayer GoldPlayer `json:"enemyPlayer"`
+	GoldDiff    int        `json:"goldDiff"`
+}
+
+// NextPurchase is what to buy on the next recall, toward the next item of the recommended build
+type NextPurchase struct {
+	Target      GoldItem  `json:"target"`         // Next build item the player doesn't have yet
+	Item        *GoldItem `json:"item,omitempty"` // What to buy now: Target or a piece of it; absent when nothing is affordable
+	Cost        int       `json:"cost"`           // What Item costs, counting owned components
+	CurrentGold int       `json:"currentGold"`
+	GoldMissing int       `json:"goldMissing"` // Gold still needed to finish Target
+}
+
+// GoldBoxShow switches the frontend into or out of the Tab HUD layout
+type GoldBoxShow bool
+
+// EventName implements Event
+func (GoldBoxShow) EventName() string { return "goldbox:show" }
+
+// SettingsChanged carries the settings after a successful update
+type SettingsChanged settings.Settings
+
+// EventName implements Event
+func (SettingsChanged) EventName() string { return "settings:changed" }
+
+// StatsStatus reports the health of the stats database connection
+type StatsStatus data.ConnStatus
+
+// EventName implements Event
+func (StatsStatus) EventName() string { return "stats:status" }
+
+// HistorySynced reports that a background match history sync stored new games, so
+// personal stats shown from the local store are out of date
+type HistorySynced struct {
+	Added int `json:"added"` // Games added to the local store
+}
+
+// EventName implements Event
+func (HistorySynced) EventName() string { return "history:synced" }
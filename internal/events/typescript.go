@@ -0,0 +1,124 @@
+package events
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TypeScript renders a declaration file with an interface for every event payload and
+// an EventMap from event name to payload type
+func TypeScript() string {
+	g := &tsGenerator{seen: make(map[string]reflect.Type)}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by go generate ./internal/events; DO NOT EDIT.\n")
+	b.WriteString("// Payloads for every event the backend emits, keyed by event name in EventMap.\n")
+
+	for _, e := range All {
+		g.declare(reflect.TypeOf(e))
+	}
+	for i := 0; i < len(g.queue); i++ {
+		b.WriteString("\n")
+		b.WriteString(g.render(g.queue[i]))
+	}
+
+	b.WriteString("\nexport interface EventMap {\n")
+	for _, e := range All {
+		fmt.Fprintf(&b, "\t%q: %s;\n", e.EventName(), reflect.TypeOf(e).Name())
+	}
+	b.WriteString("}\n\nexport type EventName = keyof EventMap;\n")
+
+	return b.String()
+}
+
+// tsGenerator tracks which named Go types still need a declaration
+type tsGenerator struct {
+	seen  map[string]reflect.Type
+	queue []reflect.Type
+}
+
+// declare queues a named type for rendering. Two Go types can't share a TypeScript name.
+func (g *tsGenerator) declare(t reflect.Type) {
+	if prev, ok := g.seen[t.Name()]; ok {
+		if prev != t {
+			panic(fmt.Sprintf("events: %s and %s both render as %s", prev.PkgPath(), t.PkgPath(), t.Name()))
+		}
+		return
+	}
+	g.seen[t.Name()] = t
+	g.queue = append(g.queue, t)
+}
+
+// render declares a named type as an interface, or a type alias for non-structs
+func (g *tsGenerator) render(t reflect.Type) string {
+	if t.Kind() != reflect.Struct {
+		return fmt.Sprintf("export type %s = %s;\n", t.Name(), g.tsType(t))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "export interface %s {\n", t.Name())
+	g.writeFields(&b, t)
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// writeFields writes a struct's JSON fields, flattening embedded structs the way encoding/json does
+func (g *tsGenerator) writeFields(b *strings.Builder, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			g.writeFields(b, f.Type)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		optional := strings.Contains(opts, "omitempty")
+		typ := g.tsType(f.Type)
+		if !optional && (f.Type.Kind() == reflect.Slice || f.Type.Kind() == reflect.Map || f.Type.Kind() == reflect.Pointer) {
+			typ += " | null" // nil encodes as null
+		}
+
+		if optional {
+			fmt.Fprintf(b, "\t%s?: %s;\n", name, typ)
+		} else {
+			fmt.Fprintf(b, "\t%s: %s;\n", name, typ)
+		}
+	}
+}
+
+// tsType maps a Go type to its TypeScript equivalent, declaring named structs as it goes
+func (g *tsGenerator) tsType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return g.tsType(t.Elem()) + "[]"
+	case reflect.Map:
+		return fmt.Sprintf("Record<%s, %s>", g.tsType(t.Key()), g.tsType(t.Elem()))
+	case reflect.Pointer:
+		return g.tsType(t.Elem())
+	case reflect.Struct:
+		g.declare(t)
+		return t.Name()
+	case reflect.Interface:
+		return "any"
+	}
+	panic(fmt.Sprintf("events: no TypeScript type for %s", t))
+}
@@ -0,0 +1,117 @@
+package history
+
+import (
+	"sort"
+
+	"ghostdraft/internal/lcu"
+)
+
+// RankedQueues are the queue IDs used for personal stats by default (solo/duo and flex)
+var RankedQueues = []int{420, 440}
+
+// MatchupStats holds the account's record against one lane opponent
+type MatchupStats struct {
+	ChampionID   int     `json:"championId"`
+	ChampionName string  `json:"championName"`
+	IconURL      string  `json:"iconURL"`
+	Games        int     `json:"games"`
+	Wins         int     `json:"wins"`
+	WinRate      float64 `json:"winRate"`
+	AvgKDA       float64 `json:"avgKDA"`
+}
+
+// personalGames converts records into the aggregation input used by the lcu package
+func personalGames(records []MatchRecord) []lcu.PersonalGame {
+	games := make([]lcu.PersonalGame, 0, len(records))
+	for _, r := range records {
+		games = append(games, lcu.PersonalGame{
+			ChampionID: r.ChampionID,
+			Role:       r.Role,
+			Win:        r.Win,
+			Kills:      r.Kills,
+			Deaths:     r.Deaths,
+			Assists:    r.Assists,
+			CS:         r.CS,
+			Duration:   r.GameDuration,
+		})
+	}
+	return games
+}
+
+// PersonalStats aggregates all stored games for an account matching the filter
+func (s *Store) PersonalStats(puuid string, f Filter, champs *lcu.ChampionRegistry) (*lcu.PersonalStats, error) {
+	records, err := s.Query(puuid, f)
+	if err != nil {
+		return nil, err
+	}
+	return lcu.AggregatePersonalStats(personalGames(records), champs), nil
+}
+
+// ChampionPool returns per-champion stats for every champion played, most played first
+func (s *Store) ChampionPool(puuid string, f Filter, champs *lcu.ChampionRegistry) ([]lcu.ChampionPersonalStats, error) {
+	stats, err := s.PersonalStats(puuid, f, champs)
+	if err != nil {
+		return nil, err
+	}
+	return stats.ChampionStats, nil
+}
+
+// Matchups returns the account's record against each lane opponent, most faced first.
+// Set f.ChampionID to restrict to games on one champion.
+func (s *Store) Matchups(puuid string, f Filter, champs *lcu.ChampionRegistry) ([]MatchupStats, error) {
+	records, err := s.Query(puuid, f)
+	if err != nil {
+		return nil, err
+	}
+
+	type totals struct {
+		games, wins, kills, deaths, assists int
+	}
+	byEnemy := make(map[int]*totals)
+	for _, r := range records {
+		if r.OpponentChampionID == 0 {
+			continue
+		}
+		t, ok := byEnemy[r.OpponentChampionID]
+		if !ok {
+			t = &totals{}
+			byEnemy[r.OpponentChampionID] = t
+		}
+		t.games++
+		if r.Win {
+			t.wins++
+		}
+		t.kills += r.Kills
+		t.deaths += r.Deaths
+		t.assists += r.Assists
+	}
+
+	matchups := make([]MatchupStats, 0, len(byEnemy))
+	for enemyID, t := range byEnemy {
+		m := MatchupStats{
+			ChampionID: enemyID,
+			Games:      t.games,
+			Wins:       t.wins,
+			WinRate:    float64(t.wins) / float64(t.games) * 100,
+		}
+		if t.deaths > 0 {
+			m.AvgKDA = float64(t.kills+t.assists) / float64(t.deaths)
+		} else {
+			m.AvgKDA = float64(t.kills + t.assists)
+		}
+		if champs != nil {
+			m.ChampionName = champs.GetName(enemyID)
+			m.IconURL = champs.GetIconURL(enemyID)
+		}
+		matchups = append(matchups, m)
+	}
+
+	sort.Slice(matchups, func(i, j int) bool {
+		if matchups[i].Games != matchups[j].Games {
+			return matchups[i].Games > matchups[j].Games
+		}
+		return matchups[i].ChampionID < matchups[j].ChampionID
+	})
+
+	return matchups, nil
+}
@@ -0,0 +1,297 @@
+package history
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ghostdraft/internal/lcu"
+
+	_ "modernc.org/sqlite"
+)
+
+// MatchRecord is a single stored game from one account's perspective
+type MatchRecord struct {
+	PUUID              string
+	GameID             int64
+	GameCreation       int64 // unix millis
+	GameDuration       int   // seconds
+	QueueID            int
+	GameMode           string
+	Patch              string // major.minor, e.g. "14.24"
+	ChampionID         int
+	Role               string // TOP, JUNGLE, MID, ADC, SUPPORT
+	Win                bool
+	Kills              int
+	Deaths             int
+	Assists            int
+	CS                 int
+	Items              []int
+	OpponentChampionID int // lane opponent, 0 if unknown
+}
+
+// Filter narrows queries against the store. Zero values match everything.
+type Filter struct {
+	QueueIDs   []int  `json:"queueIds"`
+	Patch      string `json:"patch"`
+	ChampionID int    `json:"championId"`
+}
+
+// Store is the local SQLite database of every game seen per account, and of the match
+// histories last fetched for scouted players
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore opens (or creates) the match history database in the GhostDraft config directory
+func NewStore() (*Store, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = "."
+	}
+
+	dbDir := filepath.Join(configDir, "GhostDraft")
+	if err := os.MkdirAll(dbDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create db directory: %w", err)
+	}
+
+	return OpenStore(filepath.Join(dbDir, "match_history.db"))
+}
+
+// OpenStore opens a match history database at the given path
+func OpenStore(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// SQLite only supports one writer; serialize through a single connection
+	db.SetMaxOpenConns(1)
+
+	s := &Store{db: db}
+	if err := s.init(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// init creates the schema
+func (s *Store) init() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS matches (
+			puuid TEXT NOT NULL,
+			game_id INTEGER NOT NULL,
+			game_creation INTEGER NOT NULL,
+			game_duration INTEGER NOT NULL,
+			queue_id INTEGER NOT NULL,
+			game_mode TEXT NOT NULL,
+			patch TEXT NOT NULL,
+			champion_id INTEGER NOT NULL,
+			role TEXT NOT NULL,
+			win INTEGER NOT NULL,
+			kills INTEGER NOT NULL,
+			deaths INTEGER NOT NULL,
+			assists INTEGER NOT NULL,
+			cs INTEGER NOT NULL,
+			items TEXT NOT NULL,
+			opponent_champion_id INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (puuid, game_id)
+		);
+		CREATE INDEX IF NOT EXISTS idx_matches_champion ON matches (puuid, champion_id);
+		CREATE INDEX IF NOT EXISTS idx_matches_queue_patch ON matches (puuid, queue_id, patch);
+		CREATE TABLE IF NOT EXISTS sync_state (
+			puuid TEXT PRIMARY KEY,
+			backfill_complete INTEGER NOT NULL DEFAULT 0,
+			last_sync INTEGER NOT NULL DEFAULT 0
+		);
+		CREATE TABLE IF NOT EXISTS scouted_history (
+			puuid TEXT PRIMARY KEY,
+			fetched_at INTEGER NOT NULL,
+			history TEXT NOT NULL
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create tables: %w", err)
+	}
+	return nil
+}
+
+// Close closes the database connection
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// HasGame reports whether a game is already stored for an account
+func (s *Store) HasGame(puuid string, gameID int64) (bool, error) {
+	var n int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM matches WHERE puuid = ? AND game_id = ?`, puuid, gameID).Scan(&n)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Insert stores a game, ignoring it if it already exists. Returns true if the game was new.
+func (s *Store) Insert(r MatchRecord) (bool, error) {
+	items, err := json.Marshal(r.Items)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := s.db.Exec(`
+		INSERT OR IGNORE INTO matches (
+			puuid, game_id, game_creation, game_duration, queue_id, game_mode, patch,
+			champion_id, role, win, kills, deaths, assists, cs, items, opponent_champion_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, r.PUUID, r.GameID, r.GameCreation, r.GameDuration, r.QueueID, r.GameMode, r.Patch,
+		r.ChampionID, r.Role, r.Win, r.Kills, r.Deaths, r.Assists, r.CS, string(items), r.OpponentChampionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to insert game %d: %w", r.GameID, err)
+	}
+
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// Count returns the number of stored games for an account
+func (s *Store) Count(puuid string) (int, error) {
+	var n int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM matches WHERE puuid = ?`, puuid).Scan(&n)
+	return n, err
+}
+
+// Query returns stored games for an account matching the filter, newest first
+func (s *Store) Query(puuid string, f Filter) ([]MatchRecord, error) {
+	where := []string{"puuid = ?"}
+	args := []interface{}{puuid}
+
+	if len(f.QueueIDs) > 0 {
+		placeholders := make([]string, len(f.QueueIDs))
+		for i, q := range f.QueueIDs {
+			placeholders[i] = "?"
+			args = append(args, q)
+		}
+		where = append(where, fmt.Sprintf("queue_id IN (%s)", strings.Join(placeholders, ", ")))
+	}
+	if f.Patch != "" {
+		where = append(where, "patch = ?")
+		args = append(args, f.Patch)
+	}
+	if f.ChampionID > 0 {
+		where = append(where, "champion_id = ?")
+		args = append(args, f.ChampionID)
+	}
+
+	rows, err := s.db.Query(`
+		SELECT puuid, game_id, game_creation, game_duration, queue_id, game_mode, patch,
+			champion_id, role, win, kills, deaths, assists, cs, items, opponent_champion_id
+		FROM matches
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY game_creation DESC
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query matches: %w", err)
+	}
+	defer rows.Close()
+
+	var records []MatchRecord
+	for rows.Next() {
+		var r MatchRecord
+		var items string
+		if err := rows.Scan(&r.PUUID, &r.GameID, &r.GameCreation, &r.GameDuration, &r.QueueID, &r.GameMode, &r.Patch,
+			&r.ChampionID, &r.Role, &r.Win, &r.Kills, &r.Deaths, &r.Assists, &r.CS, &items, &r.OpponentChampionID); err != nil {
+			return nil, fmt.Errorf("failed to read match row: %w", err)
+		}
+		if err := json.Unmarshal([]byte(items), &r.Items); err != nil {
+			return nil, fmt.Errorf("failed to decode items of game %d: %w", r.GameID, err)
+		}
+		records = append(records, r)
+	}
+
+	return records, rows.Err()
+}
+
+// Patches returns the distinct patches stored for an account, newest first
+func (s *Store) Patches(puuid string) ([]string, error) {
+	rows, err := s.db.Query(`
+		SELECT patch FROM matches
+		WHERE puuid = ? AND patch != ''
+		GROUP BY patch
+		ORDER BY MAX(game_creation) DESC
+	`, puuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var patches []string
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, fmt.Errorf("failed to read patch row: %w", err)
+		}
+		patches = append(patches, p)
+	}
+	return patches, rows.Err()
+}
+
+// IsBackfillComplete reports whether the full LCU history has been paged for an account
+func (s *Store) IsBackfillComplete(puuid string) bool {
+	var done int
+	err := s.db.QueryRow(`SELECT backfill_complete FROM sync_state WHERE puuid = ?`, puuid).Scan(&done)
+	return err == nil && done == 1
+}
+
+// MarkSynced records a sync for an account, optionally flagging the backfill as complete
+func (s *Store) MarkSynced(puuid string, syncedAt int64, backfillComplete bool) error {
+	_, err := s.db.Exec(`
+		INSERT INTO sync_state (puuid, backfill_complete, last_sync) VALUES (?, ?, ?)
+		ON CONFLICT(puuid) DO UPDATE SET
+			backfill_complete = MAX(backfill_complete, excluded.backfill_complete),
+			last_sync = excluded.last_sync
+	`, puuid, backfillComplete, syncedAt)
+	return err
+}
+
+// ScoutedHistory returns the match history last fetched for a scouted player and when it
+// was fetched; ok is false if none is stored
+func (s *Store) ScoutedHistory(puuid string) (history *lcu.MatchHistoryResponse, fetchedAt time.Time, ok bool, err error) {
+	var fetchedMillis int64
+	var data string
+	err = s.db.QueryRow(`SELECT fetched_at, history FROM scouted_history WHERE puuid = ?`, puuid).Scan(&fetchedMillis, &data)
+	if err == sql.ErrNoRows {
+		return nil, time.Time{}, false, nil
+	}
+	if err != nil {
+		return nil, time.Time{}, false, err
+	}
+	history = &lcu.MatchHistoryResponse{}
+	if err := json.Unmarshal([]byte(data), history); err != nil {
+		return nil, time.Time{}, false, fmt.Errorf("failed to decode scouted history: %w", err)
+	}
+	return history, time.UnixMilli(fetchedMillis), true, nil
+}
+
+// SaveScoutedHistory stores a scouted player's match history, replacing the older one
+func (s *Store) SaveScoutedHistory(puuid string, history *lcu.MatchHistoryResponse, fetchedAt time.Time) error {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		INSERT INTO scouted_history (puuid, fetched_at, history) VALUES (?, ?, ?)
+		ON CONFLICT(puuid) DO UPDATE SET fetched_at = excluded.fetched_at, history = excluded.history
+	`, puuid, fetchedAt.UnixMilli(), string(data))
+	return err
+}
+
+// PruneScoutedHistory drops scouted histories fetched before cutoff
+func (s *Store) PruneScoutedHistory(cutoff time.Time) error {
+	_, err := s.db.Exec(`DELETE FROM scouted_history WHERE fetched_at < ?`, cutoff.UnixMilli())
+	return err
+}
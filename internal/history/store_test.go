@@ -0,0 +1,212 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ghostdraft/internal/lcu"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := OpenStore(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestStore_InsertIsIdempotent(t *testing.T) {
+	store := openTestStore(t)
+	r := MatchRecord{PUUID: "a", GameID: 1, QueueID: 420, Patch: "14.24", ChampionID: 103, Role: "MID", Items: []int{3089, 3020}}
+
+	inserted, err := store.Insert(r)
+	if err != nil || !inserted {
+		t.Fatalf("expected first insert to succeed, got inserted=%v err=%v", inserted, err)
+	}
+	inserted, err = store.Insert(r)
+	if err != nil || inserted {
+		t.Fatalf("expected duplicate insert to be ignored, got inserted=%v err=%v", inserted, err)
+	}
+
+	records, err := store.Query("a", Filter{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	if len(records[0].Items) != 2 || records[0].Items[0] != 3089 {
+		t.Errorf("items not round-tripped: %v", records[0].Items)
+	}
+}
+
+func TestStore_QueryReportsCorruptRows(t *testing.T) {
+	store := openTestStore(t)
+	store.Insert(MatchRecord{PUUID: "a", GameID: 1, ChampionID: 103, Items: []int{3089}})
+	store.Insert(MatchRecord{PUUID: "a", GameID: 2, ChampionID: 103, Items: []int{3020}})
+
+	if _, err := store.db.Exec(`UPDATE matches SET items = 'not json' WHERE game_id = 2`); err != nil {
+		t.Fatalf("failed to corrupt row: %v", err)
+	}
+	if records, err := store.Query("a", Filter{}); err == nil {
+		t.Fatalf("expected an error for a corrupt row, got %d records", len(records))
+	}
+	if _, err := store.PersonalStats("a", Filter{}, nil); err == nil {
+		t.Error("expected personal stats to fail rather than skip the corrupt row")
+	}
+}
+
+func TestStore_SeparatesAccounts(t *testing.T) {
+	store := openTestStore(t)
+	store.Insert(MatchRecord{PUUID: "main", GameID: 1, ChampionID: 103})
+	store.Insert(MatchRecord{PUUID: "smurf", GameID: 1, ChampionID: 157})
+	store.Insert(MatchRecord{PUUID: "smurf", GameID: 2, ChampionID: 157})
+
+	if n, _ := store.Count("main"); n != 1 {
+		t.Errorf("expected 1 game for main, got %d", n)
+	}
+	if n, _ := store.Count("smurf"); n != 2 {
+		t.Errorf("expected 2 games for smurf, got %d", n)
+	}
+}
+
+func TestStore_QueryFilters(t *testing.T) {
+	store := openTestStore(t)
+	store.Insert(MatchRecord{PUUID: "a", GameID: 1, GameCreation: 1, QueueID: 420, Patch: "14.23", ChampionID: 103})
+	store.Insert(MatchRecord{PUUID: "a", GameID: 2, GameCreation: 2, QueueID: 420, Patch: "14.24", ChampionID: 103})
+	store.Insert(MatchRecord{PUUID: "a", GameID: 3, GameCreation: 3, QueueID: 450, Patch: "14.24", ChampionID: 157})
+	store.Insert(MatchRecord{PUUID: "a", GameID: 4, GameCreation: 4, QueueID: 440, Patch: "14.24", ChampionID: 157})
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   int
+	}{
+		{"all", Filter{}, 4},
+		{"ranked", Filter{QueueIDs: RankedQueues}, 3},
+		{"patch", Filter{Patch: "14.24"}, 3},
+		{"champion", Filter{ChampionID: 157}, 2},
+		{"combined", Filter{QueueIDs: RankedQueues, Patch: "14.24", ChampionID: 157}, 1},
+	}
+
+	for _, tt := range tests {
+		records, err := store.Query("a", tt.filter)
+		if err != nil {
+			t.Fatalf("%s: Query failed: %v", tt.name, err)
+		}
+		if len(records) != tt.want {
+			t.Errorf("%s: expected %d records, got %d", tt.name, tt.want, len(records))
+		}
+	}
+
+	records, _ := store.Query("a", Filter{})
+	if records[0].GameID != 4 {
+		t.Errorf("expected newest game first, got game %d", records[0].GameID)
+	}
+
+	patches, _ := store.Patches("a")
+	if len(patches) != 2 || patches[0] != "14.24" {
+		t.Errorf("expected [14.24 14.23], got %v", patches)
+	}
+}
+
+func TestStore_BackfillState(t *testing.T) {
+	store := openTestStore(t)
+
+	if store.IsBackfillComplete("a") {
+		t.Fatal("expected backfill incomplete for unknown account")
+	}
+	store.MarkSynced("a", 100, true)
+	// A later partial sync must not reset the completed backfill
+	store.MarkSynced("a", 200, false)
+	if !store.IsBackfillComplete("a") {
+		t.Error("expected backfill to stay complete")
+	}
+}
+
+func TestStore_ScoutedHistory(t *testing.T) {
+	store := openTestStore(t)
+
+	if _, _, ok, err := store.ScoutedHistory("a"); ok || err != nil {
+		t.Fatalf("expected no stored history, got ok=%v err=%v", ok, err)
+	}
+
+	saved := &lcu.MatchHistoryResponse{}
+	saved.Games.Games = []lcu.MatchGame{{GameId: 7}}
+	fetchedAt := time.UnixMilli(1_700_000_000_000)
+	if err := store.SaveScoutedHistory("a", saved, fetchedAt); err != nil {
+		t.Fatalf("SaveScoutedHistory failed: %v", err)
+	}
+	got, gotAt, ok, err := store.ScoutedHistory("a")
+	if err != nil || !ok || !gotAt.Equal(fetchedAt) || len(got.Games.Games) != 1 || got.Games.Games[0].GameId != 7 {
+		t.Fatalf("ScoutedHistory = %+v, %v, %v, %v", got, gotAt, ok, err)
+	}
+
+	if err := store.PruneScoutedHistory(fetchedAt.Add(time.Second)); err != nil {
+		t.Fatalf("PruneScoutedHistory failed: %v", err)
+	}
+	if _, _, ok, _ := store.ScoutedHistory("a"); ok {
+		t.Error("expected the history to be pruned")
+	}
+}
+
+func TestStore_PersonalStatsAndMatchups(t *testing.T) {
+	store := openTestStore(t)
+	store.Insert(MatchRecord{PUUID: "a", GameID: 1, QueueID: 420, ChampionID: 103, Role: "MID", Win: true, Kills: 10, Deaths: 2, Assists: 5, CS: 200, GameDuration: 1800, OpponentChampionID: 238})
+	store.Insert(MatchRecord{PUUID: "a", GameID: 2, QueueID: 420, ChampionID: 103, Role: "MID", Win: false, Kills: 2, Deaths: 6, Assists: 3, CS: 180, GameDuration: 1800, OpponentChampionID: 238})
+	store.Insert(MatchRecord{PUUID: "a", GameID: 3, QueueID: 420, ChampionID: 157, Role: "MID", Win: true, Kills: 5, Deaths: 0, Assists: 5, CS: 220, GameDuration: 1800, OpponentChampionID: 7})
+
+	stats, err := store.PersonalStats("a", Filter{}, nil)
+	if err != nil {
+		t.Fatalf("PersonalStats failed: %v", err)
+	}
+	if !stats.HasData || stats.TotalGames != 3 || stats.Wins != 2 {
+		t.Errorf("unexpected totals: %+v", stats)
+	}
+	if len(stats.ChampionStats) != 2 || stats.ChampionStats[0].ChampionId != 103 {
+		t.Errorf("expected most played champion first, got %+v", stats.ChampionStats)
+	}
+
+	matchups, err := store.Matchups("a", Filter{ChampionID: 103}, nil)
+	if err != nil {
+		t.Fatalf("Matchups failed: %v", err)
+	}
+	if len(matchups) != 1 || matchups[0].ChampionID != 238 || matchups[0].Games != 2 || matchups[0].WinRate != 50 {
+		t.Errorf("unexpected matchups: %+v", matchups)
+	}
+}
+
+func TestLaneOpponent(t *testing.T) {
+	game := &lcu.MatchGame{
+		Participants: []lcu.MatchParticipant{
+			{ParticipantId: 1, TeamId: 100, ChampionId: 103, Timeline: lcu.ParticipantTimeline{Lane: "MIDDLE", Role: "SOLO"}},
+			{ParticipantId: 2, TeamId: 100, ChampionId: 64, Timeline: lcu.ParticipantTimeline{Lane: "JUNGLE", Role: "NONE"}},
+			{ParticipantId: 6, TeamId: 200, ChampionId: 238, Timeline: lcu.ParticipantTimeline{Lane: "MIDDLE", Role: "SOLO"}},
+			{ParticipantId: 7, TeamId: 200, ChampionId: 121, Timeline: lcu.ParticipantTimeline{Lane: "JUNGLE", Role: "NONE"}},
+		},
+	}
+	game.ParticipantIdentities = make([]lcu.ParticipantIdentity, 2)
+	game.ParticipantIdentities[0].ParticipantId = 1
+	game.ParticipantIdentities[0].Player.PUUID = "me"
+	game.ParticipantIdentities[1].ParticipantId = 6
+	game.ParticipantIdentities[1].Player.PUUID = "them"
+
+	if got := laneOpponent("me", game); got != 238 {
+		t.Errorf("expected lane opponent 238, got %d", got)
+	}
+	if got := laneOpponent("unknown", game); got != 0 {
+		t.Errorf("expected 0 for unknown player, got %d", got)
+	}
+}
+
+func TestPatchFromVersion(t *testing.T) {
+	if got := patchFromVersion("14.24.448.1234"); got != "14.24" {
+		t.Errorf("expected 14.24, got %s", got)
+	}
+	if got := patchFromVersion(""); got != "" {
+		t.Errorf("expected empty patch, got %s", got)
+	}
+}
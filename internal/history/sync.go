@@ -0,0 +1,214 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"ghostdraft/internal/lcu"
+)
+
+const (
+	// syncPageSize is how many games are requested per LCU match history page
+	syncPageSize = 20
+	// maxPagesPerSync bounds how far back a single sync pages (the LCU keeps a few hundred games at most)
+	maxPagesPerSync = 15
+)
+
+// Syncer incrementally copies LCU match history into the store
+type Syncer struct {
+	client *lcu.Client
+	store  *Store
+	mu     sync.Mutex
+}
+
+// NewSyncer creates a syncer for the given LCU client and store
+func NewSyncer(client *lcu.Client, store *Store) *Syncer {
+	return &Syncer{
+		client: client,
+		store:  store,
+	}
+}
+
+// Sync pages through the account's LCU match history, newest first, storing every unseen game.
+// New games are picked up until a page contains nothing new; until the first full backfill
+// completes it then skips ahead past the stored games and keeps paging older history.
+// Returns the number of games added.
+func (s *Syncer) Sync(puuid string) (int, error) {
+	if puuid == "" {
+		return 0, fmt.Errorf("no account PUUID")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	backfillDone := s.store.IsBackfillComplete(puuid)
+	added := 0
+	begIndex := 0
+
+	for page := 0; page < maxPagesPerSync; page++ {
+		resp, err := s.client.GetMatchHistoryPage(puuid, begIndex, begIndex+syncPageSize)
+		if err != nil {
+			return s.stopSync(puuid, added, err)
+		}
+
+		games := resp.Games.Games
+		if len(games) == 0 {
+			backfillDone = true
+			break
+		}
+
+		newInPage := 0
+		for _, game := range games {
+			known, err := s.store.HasGame(puuid, game.GameId)
+			if err != nil {
+				return s.stopSync(puuid, added, fmt.Errorf("failed to check stored game %d: %w", game.GameId, err))
+			}
+			if known {
+				continue
+			}
+
+			record, ok := recordFromGame(puuid, game)
+			if !ok {
+				continue
+			}
+			record.OpponentChampionID = s.findLaneOpponent(puuid, game.GameId)
+
+			// Stop at a game that can't be stored, so the next sync picks it up again
+			inserted, err := s.store.Insert(record)
+			if err != nil {
+				return s.stopSync(puuid, added, err)
+			}
+			if inserted {
+				newInPage++
+				added++
+			}
+		}
+
+		if len(games) < syncPageSize {
+			backfillDone = true
+			break
+		}
+
+		if newInPage == 0 {
+			if backfillDone {
+				break
+			}
+			// Everything here is known - jump past the stored block to continue the backfill
+			count, _ := s.store.Count(puuid)
+			if count > begIndex+syncPageSize {
+				begIndex = count
+				continue
+			}
+		}
+		begIndex += syncPageSize
+	}
+
+	if err := s.store.MarkSynced(puuid, time.Now().Unix(), backfillDone); err != nil {
+		return added, fmt.Errorf("failed to save sync state: %w", err)
+	}
+
+	if added > 0 {
+		fmt.Printf("[History] Stored %d new games for %s\n", added, shortPUUID(puuid))
+	}
+	return added, nil
+}
+
+// stopSync ends a sync early with err. Games added so far are recorded as a partial sync
+// that never completes the backfill; a failure to record it is returned along with err.
+func (s *Syncer) stopSync(puuid string, added int, err error) (int, error) {
+	if added > 0 {
+		if markErr := s.store.MarkSynced(puuid, time.Now().Unix(), false); markErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to save sync state: %w", markErr))
+		}
+	}
+	return added, err
+}
+
+// findLaneOpponent looks up the full game and returns the enemy champion in the same role
+func (s *Syncer) findLaneOpponent(puuid string, gameID int64) int {
+	details, err := s.client.GetMatchDetails(gameID)
+	if err != nil {
+		return 0
+	}
+	return laneOpponent(puuid, details)
+}
+
+// laneOpponent finds the enemy champion in the same role as the given player
+func laneOpponent(puuid string, game *lcu.MatchGame) int {
+	participantID := 0
+	for _, identity := range game.ParticipantIdentities {
+		if identity.Player.PUUID == puuid {
+			participantID = identity.ParticipantId
+			break
+		}
+	}
+	if participantID == 0 {
+		return 0
+	}
+
+	var me *lcu.MatchParticipant
+	for i := range game.Participants {
+		if game.Participants[i].ParticipantId == participantID {
+			me = &game.Participants[i]
+			break
+		}
+	}
+	if me == nil {
+		return 0
+	}
+
+	myRole := lcu.NormalizeRole(me.Timeline.Lane, me.Timeline.Role)
+	for _, p := range game.Participants {
+		if p.TeamId != me.TeamId && lcu.NormalizeRole(p.Timeline.Lane, p.Timeline.Role) == myRole {
+			return p.ChampionId
+		}
+	}
+	return 0
+}
+
+// recordFromGame converts a match history entry (player is the first participant) into a record
+func recordFromGame(puuid string, game lcu.MatchGame) (MatchRecord, bool) {
+	if len(game.Participants) == 0 {
+		return MatchRecord{}, false
+	}
+
+	p := game.Participants[0]
+	st := p.Stats
+	return MatchRecord{
+		PUUID:        puuid,
+		GameID:       game.GameId,
+		GameCreation: game.GameCreation,
+		GameDuration: game.GameDuration,
+		QueueID:      game.QueueId,
+		GameMode:     game.GameMode,
+		Patch:        patchFromVersion(game.GameVersion),
+		ChampionID:   p.ChampionId,
+		Role:         lcu.NormalizeRole(p.Timeline.Lane, p.Timeline.Role),
+		Win:          st.Win,
+		Kills:        st.Kills,
+		Deaths:       st.Deaths,
+		Assists:      st.Assists,
+		CS:           st.TotalMinionsKilled + st.NeutralMinionsKilled,
+		Items:        st.Items(),
+	}, true
+}
+
+// patchFromVersion converts a game version to major.minor ("14.24.448.1234" -> "14.24")
+func patchFromVersion(version string) string {
+	parts := strings.Split(version, ".")
+	if len(parts) >= 2 {
+		return parts[0] + "." + parts[1]
+	}
+	return version
+}
+
+// shortPUUID truncates a PUUID for logging
+func shortPUUID(puuid string) string {
+	if len(puuid) > 8 {
+		return puuid[:8] + "..."
+	}
+	return puuid
+}
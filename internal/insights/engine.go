@@ -0,0 +1,281 @@
+// Package insights turns scouted player stats into ranked, tagged observations
+// using prioritized rules loaded from JSON.
+package insights
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+//go:embed default_rules.json
+var defaultRules []byte
+
+// rulesFileName is the user-editable rules file in the GhostDraft config directory
+const rulesFileName = "insight_rules.json"
+
+// Condition compares one fact against a value
+type Condition struct {
+	Field string  `json:"field"`
+	Op    string  `json:"op"` // >, >=, <, <=, ==, !=, set, unset
+	Value float64 `json:"value"`
+}
+
+// Rule produces an insight when all of its conditions hold
+type Rule struct {
+	ID       string      `json:"id"`
+	Priority int         `json:"priority"` // Higher ranks first
+	Score    float64     `json:"score"`    // Weight added to each tag
+	Tags     []string    `json:"tags"`
+	Message  string      `json:"message"` // {field} or {field:N} (N decimals, k for thousands)
+	When     []Condition `json:"when"`
+}
+
+// RuleSet is the on-disk rules file
+type RuleSet struct {
+	Version int    `json:"version"`
+	Rules   []Rule `json:"rules"`
+}
+
+// Facts are the values rules can test and quote. Numbers hold stats (booleans as 0/1),
+// Strings hold text like champion names.
+type Facts struct {
+	Numbers map[string]float64
+	Strings map[string]string
+}
+
+// Insight is one matched rule with its rendered message
+type Insight struct {
+	RuleID   string   `json:"ruleId"`
+	Message  string   `json:"message"`
+	Tags     []string `json:"tags"`
+	Score    float64  `json:"score"`
+	Priority int      `json:"priority"`
+}
+
+// Result is every matching insight, best first, plus the summed score per tag
+type Result struct {
+	Insights  []Insight
+	TagScores map[string]float64
+}
+
+// Top returns the highest ranked insight's message, or "" if nothing matched
+func (r Result) Top() string {
+	if len(r.Insights) == 0 {
+		return ""
+	}
+	return r.Insights[0].Message
+}
+
+// TopTag returns whichever of the given tags scored highest, or "" if none matched
+func (r Result) TopTag(tags ...string) string {
+	var best string
+	var bestScore float64
+	for _, tag := range tags {
+		if score := r.TagScores[tag]; score > bestScore {
+			best, bestScore = tag, score
+		}
+	}
+	return best
+}
+
+// Engine evaluates a rule set against player facts
+type Engine struct {
+	rules []Rule
+}
+
+// NewEngine validates a rule set and creates an engine for it
+func NewEngine(set RuleSet) (*Engine, error) {
+	seen := make(map[string]bool)
+	for _, rule := range set.Rules {
+		if rule.ID == "" {
+			return nil, fmt.Errorf("rule missing id")
+		}
+		if seen[rule.ID] {
+			return nil, fmt.Errorf("duplicate rule id %q", rule.ID)
+		}
+		seen[rule.ID] = true
+
+		if rule.Message == "" {
+			return nil, fmt.Errorf("rule %q has no message", rule.ID)
+		}
+		for _, cond := range rule.When {
+			if !validOps[cond.Op] {
+				return nil, fmt.Errorf("rule %q: unknown op %q", rule.ID, cond.Op)
+			}
+		}
+	}
+
+	rules := append([]Rule(nil), set.Rules...)
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority > rules[j].Priority
+		}
+		return rules[i].Score > rules[j].Score
+	})
+
+	return &Engine{rules: rules}, nil
+}
+
+// Parse decodes and validates a JSON rule set
+func Parse(data []byte) (*Engine, error) {
+	var set RuleSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+	return NewEngine(set)
+}
+
+// Default returns an engine for the rules shipped with the app
+func Default() *Engine {
+	engine, err := Parse(defaultRules)
+	if err != nil {
+		panic(fmt.Sprintf("insights: invalid default rules: %v", err))
+	}
+	return engine
+}
+
+// LoadFile loads rules from path, writing the shipped defaults there first if it doesn't exist
+func LoadFile(path string) (*Engine, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create rules directory: %w", err)
+		}
+		if err := os.WriteFile(path, defaultRules, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write default rules: %w", err)
+		}
+		return Default(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules: %w", err)
+	}
+	return Parse(data)
+}
+
+// UserRulesPath returns where the user-editable rules file lives
+func UserRulesPath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = "."
+	}
+	return filepath.Join(configDir, "GhostDraft", rulesFileName)
+}
+
+// Load loads the user's rules, falling back to the shipped defaults if the file is broken
+func Load() *Engine {
+	path := UserRulesPath()
+	engine, err := LoadFile(path)
+	if err != nil {
+		fmt.Printf("[Insights] Using default rules, %s is invalid: %v\n", path, err)
+		return Default()
+	}
+	return engine
+}
+
+// Evaluate returns every rule that matches the facts, ranked by priority then score
+func (e *Engine) Evaluate(facts Facts) Result {
+	result := Result{TagScores: make(map[string]float64)}
+
+	for _, rule := range e.rules {
+		if !rule.matches(facts) {
+			continue
+		}
+		result.Insights = append(result.Insights, Insight{
+			RuleID:   rule.ID,
+			Message:  render(rule.Message, facts),
+			Tags:     rule.Tags,
+			Score:    rule.Score,
+			Priority: rule.Priority,
+		})
+		for _, tag := range rule.Tags {
+			result.TagScores[tag] += rule.Score
+		}
+	}
+
+	return result
+}
+
+// validOps are the comparison operators a condition may use
+var validOps = map[string]bool{
+	">": true, ">=": true, "<": true, "<=": true, "==": true, "!=": true, "set": true, "unset": true,
+}
+
+// matches reports whether every condition holds. Missing facts never match.
+func (r Rule) matches(facts Facts) bool {
+	for _, cond := range r.When {
+		if !cond.holds(facts) {
+			return false
+		}
+	}
+	return true
+}
+
+// holds evaluates a single condition
+func (c Condition) holds(facts Facts) bool {
+	if c.Op == "set" || c.Op == "unset" {
+		set := facts.Strings[c.Field] != "" || facts.Numbers[c.Field] != 0
+		return set == (c.Op == "set")
+	}
+
+	v, ok := facts.Numbers[c.Field]
+	if !ok {
+		return false
+	}
+	switch c.Op {
+	case ">":
+		return v > c.Value
+	case ">=":
+		return v >= c.Value
+	case "<":
+		return v < c.Value
+	case "<=":
+		return v <= c.Value
+	case "==":
+		return v == c.Value
+	case "!=":
+		return v != c.Value
+	}
+	return false
+}
+
+// placeholder matches {field} and {field:format}
+var placeholder = regexp.MustCompile(`\{(\w+)(?::(\w+))?\}`)
+
+// render fills a message template from the facts
+func render(message string, facts Facts) string {
+	return placeholder.ReplaceAllStringFunc(message, func(m string) string {
+		parts := placeholder.FindStringSubmatch(m)
+		field, format := parts[1], parts[2]
+
+		if s, ok := facts.Strings[field]; ok {
+			return s
+		}
+		v, ok := facts.Numbers[field]
+		if !ok {
+			return m
+		}
+
+		switch format {
+		case "":
+			if v == math.Trunc(v) {
+				return strconv.FormatFloat(v, 'f', 0, 64)
+			}
+			return strconv.FormatFloat(v, 'f', 1, 64)
+		case "k":
+			return strconv.FormatFloat(math.Round(v/1000), 'f', 0, 64) + "k"
+		default:
+			decimals, err := strconv.Atoi(format)
+			if err != nil {
+				return m
+			}
+			return strconv.FormatFloat(v, 'f', decimals, 64)
+		}
+	})
+}
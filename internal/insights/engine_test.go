@@ -0,0 +1,206 @@
+package insights
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// neutralFacts is an average player that no default rule fires on
+func neutralFacts() Facts {
+	return Facts{
+		Numbers: map[string]float64{
+			"games":         10,
+			"wins":          5,
+			"winRate":       50,
+			"kda":           2.5,
+			"avgDeaths":     5,
+			"recentLosses":  1,
+			"lossStreak":    1,
+			"winStreak":     0,
+			"summonerLevel": 200,
+			"soloWins":      30,
+			"soloLosses":    30,
+			"masteryPoints": 20000,
+			"championGames": 3,
+			"isSmurf":       0,
+			"isOneTrick":    0,
+			"isOffRole":     0,
+		},
+		Strings: map[string]string{
+			"championName": "Ahri",
+			"worstGame":    "",
+			"mainRoles":    "middle",
+		},
+	}
+}
+
+// ruleCases trigger each default rule from neutral facts
+var ruleCases = []struct {
+	rule    string
+	numbers map[string]float64
+	strings map[string]string
+	message string
+}{
+	{"smurf", map[string]float64{"isSmurf": 1, "summonerLevel": 34, "soloWins": 18, "soloLosses": 4}, nil,
+		"Level 34 with a 18W 4L record - likely smurf"},
+	{"one_trick_mastery", map[string]float64{"isOneTrick": 1, "masteryPoints": 812345}, nil,
+		"One-trick: 812k mastery on Ahri"},
+	{"one_trick_recent", map[string]float64{"isOneTrick": 1, "championGames": 8}, nil,
+		"One-trick: 8 of last 10 games on Ahri"},
+	{"off_role", map[string]float64{"isOffRole": 1}, map[string]string{"mainRoles": "top/jungle"},
+		"Off-role - usually plays top/jungle"},
+	{"recent_int", map[string]float64{"avgDeaths": 6}, map[string]string{"worstGame": "0/8/2 on Yasuo"},
+		"Recent int game: 0/8/2 on Yasuo"},
+	{"loss_streak", map[string]float64{"lossStreak": 4, "recentLosses": 3}, nil,
+		"Lost 4 in a row - probably tilted"},
+	{"win_streak", map[string]float64{"winStreak": 3}, nil,
+		"On a 3 game win streak!"},
+	{"dangerous_kda", map[string]float64{"kda": 4.26}, nil,
+		"4.3 KDA - this one's dangerous"},
+	{"low_kda", map[string]float64{"kda": 1.2}, nil,
+		"1.2 KDA - free gold"},
+	{"high_deaths", map[string]float64{"avgDeaths": 7.54}, nil,
+		"Dies 7.5 times per game on average"},
+	{"low_win_rate", map[string]float64{"winRate": 30}, nil,
+		"30% WR in 10 games... yikes"},
+	{"high_win_rate", map[string]float64{"winRate": 70}, nil,
+		"70% WR in 10 recent games"},
+	{"rough_start", map[string]float64{"recentLosses": 2}, nil,
+		"Rough start today"},
+	{"no_games", map[string]float64{"games": 0}, nil,
+		"No recent ranked games"},
+}
+
+func TestDefaultRules_NeutralPlayerMatchesNothing(t *testing.T) {
+	result := Default().Evaluate(neutralFacts())
+	if len(result.Insights) != 0 {
+		t.Errorf("expected no insights, got %+v", result.Insights)
+	}
+}
+
+func TestDefaultRules_EachRule(t *testing.T) {
+	for _, tc := range ruleCases {
+		t.Run(tc.rule, func(t *testing.T) {
+			facts := neutralFacts()
+			for k, v := range tc.numbers {
+				facts.Numbers[k] = v
+			}
+			for k, v := range tc.strings {
+				facts.Strings[k] = v
+			}
+
+			result := Default().Evaluate(facts)
+			var found *Insight
+			for i := range result.Insights {
+				if result.Insights[i].RuleID == tc.rule {
+					found = &result.Insights[i]
+				}
+			}
+			if found == nil {
+				t.Fatalf("expected rule %s to match, got %+v", tc.rule, result.Insights)
+			}
+			if found.Message != tc.message {
+				t.Errorf("expected message %q, got %q", tc.message, found.Message)
+			}
+		})
+	}
+}
+
+func TestDefaultRules_AllCovered(t *testing.T) {
+	covered := make(map[string]bool)
+	for _, tc := range ruleCases {
+		covered[tc.rule] = true
+	}
+	for _, rule := range Default().rules {
+		if !covered[rule.ID] {
+			t.Errorf("default rule %s has no test case", rule.ID)
+		}
+	}
+}
+
+func TestEvaluate_RanksAndScoresTags(t *testing.T) {
+	engine, err := NewEngine(RuleSet{Rules: []Rule{
+		{ID: "low", Priority: 1, Score: 100, Tags: []string{"tilted"}, Message: "low"},
+		{ID: "high", Priority: 5, Score: 10, Tags: []string{"on_fire"}, Message: "high"},
+		{ID: "high_heavy", Priority: 5, Score: 20, Tags: []string{"tilted"}, Message: "high heavy"},
+	}})
+	if err != nil {
+		t.Fatalf("NewEngine failed: %v", err)
+	}
+
+	result := engine.Evaluate(Facts{})
+	var order []string
+	for _, in := range result.Insights {
+		order = append(order, in.RuleID)
+	}
+	if len(order) != 3 || order[0] != "high_heavy" || order[1] != "high" || order[2] != "low" {
+		t.Errorf("expected [high_heavy high low], got %v", order)
+	}
+	if result.Top() != "high heavy" {
+		t.Errorf("expected top message 'high heavy', got %q", result.Top())
+	}
+	if result.TagScores["tilted"] != 120 {
+		t.Errorf("expected tilted score 120, got %v", result.TagScores["tilted"])
+	}
+	if got := result.TopTag("tilted", "on_fire"); got != "tilted" {
+		t.Errorf("expected top tag tilted, got %q", got)
+	}
+}
+
+func TestCondition_MissingFactNeverMatches(t *testing.T) {
+	c := Condition{Field: "missing", Op: "<", Value: 10}
+	if c.holds(Facts{}) {
+		t.Error("expected missing fact not to match")
+	}
+	if !(Condition{Field: "missing", Op: "unset"}).holds(Facts{}) {
+		t.Error("expected missing fact to be unset")
+	}
+}
+
+func TestNewEngine_RejectsInvalidRules(t *testing.T) {
+	tests := []struct {
+		name string
+		set  RuleSet
+	}{
+		{"missing id", RuleSet{Rules: []Rule{{Message: "x"}}}},
+		{"duplicate id", RuleSet{Rules: []Rule{{ID: "a", Message: "x"}, {ID: "a", Message: "y"}}}},
+		{"missing message", RuleSet{Rules: []Rule{{ID: "a"}}}},
+		{"bad op", RuleSet{Rules: []Rule{{ID: "a", Message: "x", When: []Condition{{Field: "kda", Op: "~"}}}}}},
+	}
+	for _, tt := range tests {
+		if _, err := NewEngine(tt.set); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestLoadFile_WritesDefaultsAndReadsEdits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "GhostDraft", rulesFileName)
+
+	if _, err := LoadFile(path); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected default rules to be written: %v", err)
+	}
+
+	edited := `{"version": 1, "rules": [{"id": "custom", "message": "{games} games", "when": [{"field": "games", "op": ">", "value": 1}]}]}`
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	engine, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed on edited rules: %v", err)
+	}
+	if got := engine.Evaluate(neutralFacts()).Top(); got != "10 games" {
+		t.Errorf("expected edited rule to apply, got %q", got)
+	}
+
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(path); err == nil {
+		t.Error("expected error for invalid rules file")
+	}
+}
@@ -1,21 +1,13 @@
 package lcu
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/http"
-	"strconv"
 	"strings"
 	"sync"
-	"time"
-)
 
-// ChampionData holds champion information
-type ChampionData struct {
-	ID   string `json:"id"`
-	Key  string `json:"key"`
-	Name string `json:"name"`
-}
+	"ghostdraft/ddragon"
+)
 
 // ChampionInfo holds champion name and icon ID
 type ChampionInfo struct {
@@ -25,87 +17,70 @@ type ChampionInfo struct {
 
 // ChampionRegistry holds the champion ID to name mapping
 type ChampionRegistry struct {
+	dd        *ddragon.Client
+	assetBase string               // Icon URLs point here: Data Dragon or the app's cached copy
 	champions map[int]ChampionInfo // key -> info (key is the numeric ID)
 	version   string
+	locale    string
 	mu        sync.RWMutex
 	loaded    bool
 }
 
-// NewChampionRegistry creates a new champion registry
-func NewChampionRegistry() *ChampionRegistry {
+// NewChampionRegistry creates a new champion registry. Icon URLs are built under
+// assetBase, e.g. ddragon.DefaultBaseURL or a local route serving dd.Handler().
+func NewChampionRegistry(dd *ddragon.Client, assetBase string) *ChampionRegistry {
 	return &ChampionRegistry{
+		dd:        dd,
+		assetBase: strings.TrimRight(assetBase, "/"),
 		champions: make(map[int]ChampionInfo),
 	}
 }
 
-// Load fetches champion data from Data Dragon
-func (r *ChampionRegistry) Load() error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	// Get latest version
-	versionsResp, err := client.Get("https://ddragon.leagueoflegends.com/api/versions.json")
-	if err != nil {
-		return fmt.Errorf("failed to fetch versions: %w", err)
-	}
-	defer versionsResp.Body.Close()
-
-	var versions []string
-	if err := json.NewDecoder(versionsResp.Body).Decode(&versions); err != nil {
-		return fmt.Errorf("failed to parse versions: %w", err)
-	}
-
-	if len(versions) == 0 {
-		return fmt.Errorf("no versions available")
-	}
-
-	latestVersion := versions[0]
-
-	// Get champion data
-	champURL := fmt.Sprintf("https://ddragon.leagueoflegends.com/cdn/%s/data/en_US/champion.json", latestVersion)
-	champResp, err := client.Get(champURL)
+// Load fetches champion data for a Data Dragon version and locale (from disk once cached)
+func (r *ChampionRegistry) Load(ctx context.Context, version, locale string) error {
+	data, err := r.dd.Champions(ctx, version, locale)
 	if err != nil {
-		return fmt.Errorf("failed to fetch champions: %w", err)
-	}
-	defer champResp.Body.Close()
-
-	var champData struct {
-		Data map[string]ChampionData `json:"data"`
-	}
-	if err := json.NewDecoder(champResp.Body).Decode(&champData); err != nil {
-		return fmt.Errorf("failed to parse champions: %w", err)
+		return err
 	}
 
 	// Build ID -> ChampionInfo map
-	for id, champ := range champData.Data {
-		key, err := strconv.Atoi(champ.Key)
-		if err != nil {
+	champions := make(map[int]ChampionInfo, len(data))
+	for id, champ := range data {
+		key := champ.NumericID()
+		if key == 0 {
 			continue
 		}
-		r.champions[key] = ChampionInfo{
+		champions[key] = ChampionInfo{
 			Name:   champ.Name,
 			IconID: id, // The map key is the icon ID (e.g., "Ahri", "MonkeyKing")
 		}
 	}
 
-	r.version = latestVersion
+	r.mu.Lock()
+	r.champions = champions
+	r.version = version
+	r.locale = locale
 	r.loaded = true
-	fmt.Printf("Loaded %d champions from Data Dragon (v%s)\n", len(r.champions), latestVersion)
-
-	// Debug: Check for new champions
-	for _, checkID := range []int{799, 800, 904} {
-		if info, ok := r.champions[checkID]; ok {
-			fmt.Printf("  ✓ Champion %d: %s (icon: %s)\n", checkID, info.Name, info.IconID)
-		} else {
-			fmt.Printf("  ✗ Champion %d: NOT FOUND\n", checkID)
-		}
-	}
+	r.mu.Unlock()
 
+	fmt.Printf("Loaded %d champions from Data Dragon (v%s, %s)\n", len(champions), version, locale)
 	return nil
 }
 
+// GetVersion returns the loaded Data Dragon version
+func (r *ChampionRegistry) GetVersion() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.version
+}
+
+// GetLocale returns the loaded locale
+func (r *ChampionRegistry) GetLocale() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.locale
+}
+
 // GetName returns the champion name for a given ID
 func (r *ChampionRegistry) GetName(id int) string {
 	r.mu.RLock()
@@ -123,7 +98,7 @@ func (r *ChampionRegistry) GetIconURL(id int) string {
 	defer r.mu.RUnlock()
 
 	if info, ok := r.champions[id]; ok {
-		return fmt.Sprintf("https://ddragon.leagueoflegends.com/cdn/%s/img/champion/%s.png", r.version, info.IconID)
+		return r.assetBase + "/" + ddragon.ChampionIcon(r.version, info.IconID)
 	}
 	return ""
 }
@@ -134,7 +109,7 @@ func (r *ChampionRegistry) GetSplashURL(id int) string {
 	defer r.mu.RUnlock()
 
 	if info, ok := r.champions[id]; ok {
-		return fmt.Sprintf("https://ddragon.leagueoflegends.com/cdn/img/champion/splash/%s_0.jpg", info.IconID)
+		return r.assetBase + "/" + ddragon.ChampionSplash(info.IconID)
 	}
 	return ""
 }
@@ -162,9 +137,9 @@ func (r *ChampionRegistry) GetIconURLByName(name string) string {
 	// Search for champion by IconID
 	for _, info := range r.champions {
 		if info.IconID == iconID {
-			return fmt.Sprintf("https://ddragon.leagueoflegends.com/cdn/%s/img/champion/%s.png", r.version, info.IconID)
+			return r.assetBase + "/" + ddragon.ChampionIcon(r.version, info.IconID)
 		}
 	}
 	// Fallback: use the extracted name directly as the icon ID
-	return fmt.Sprintf("https://ddragon.leagueoflegends.com/cdn/%s/img/champion/%s.png", r.version, iconID)
+	return r.assetBase + "/" + ddragon.ChampionIcon(r.version, iconID)
 }
@@ -1,6 +1,7 @@
 package lcu
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
@@ -33,6 +34,7 @@ type Credentials struct {
 type Client struct {
 	credentials *Credentials
 	httpClient  *http.Client
+	dataClient  *http.Client // Longer timeout for heavy endpoints (match history)
 	wsConn      *websocket.Conn
 	baseURL     string
 	authHeader  string
@@ -49,6 +51,14 @@ func NewClient() *Client {
 			},
 			Timeout: 2 * time.Second, // Short timeout for quick disconnect detection
 		},
+		dataClient: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: true, // LCU uses self-signed cert
+				},
+			},
+			Timeout: 10 * time.Second, // Match history for other players can be slow
+		},
 	}
 }
 
@@ -197,6 +207,20 @@ func (c *Client) Get(endpoint string) (*http.Response, error) {
 	return c.httpClient.Do(req)
 }
 
+// GetContext performs a cancellable GET request to the LCU API using the longer-timeout client
+func (c *Client) GetContext(ctx context.Context, endpoint string) (*http.Response, error) {
+	if c.credentials == nil {
+		return nil, ErrLeagueNotRunning
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", c.authHeader)
+	return c.dataClient.Do(req)
+}
+
 // GetGameflowPhase returns the current gameflow phase
 func (c *Client) GetGameflowPhase() (string, error) {
 	resp, err := c.Get("/lol-gameflow/v1/gameflow-phase")
@@ -221,6 +245,9 @@ func (c *Client) GetGameflowPhase() (string, error) {
 type GameSessionPlayer struct {
 	ChampionID int    `json:"championId"`
 	PUUID      string `json:"puuid"`
+	SummonerID int64  `json:"summonerId"`
+	GameName   string `json:"gameName"`
+	TagLine    string `json:"tagLine"`
 	Position   string `json:"selectedPosition"`
 }
 
@@ -230,6 +257,10 @@ type GameSession struct {
 		PlayerChampionSelections []GameSessionPlayer `json:"playerChampionSelections"`
 		TeamOne                  []GameSessionPlayer `json:"teamOne"`
 		TeamTwo                  []GameSessionPlayer `json:"teamTwo"`
+		Queue                    struct {
+			ID       int    `json:"id"`
+			GameMode string `json:"gameMode"`
+		} `json:"queue"`
 	} `json:"gameData"`
 }
 
@@ -255,6 +286,32 @@ func (c *Client) GetCurrentSummonerPUUID() (string, error) {
 	return summoner.PUUID, nil
 }
 
+// RegionLocale is the client's region and display language
+type RegionLocale struct {
+	Region string `json:"region"` // e.g. "NA", "EUW", "KR"
+	Locale string `json:"locale"` // e.g. "en_US", "ko_KR"
+}
+
+// GetRegionLocale returns the region the client is logged into and its display language
+func (c *Client) GetRegionLocale() (*RegionLocale, error) {
+	resp, err := c.Get("/riotclient/region-locale")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+
+	var regionLocale RegionLocale
+	if err := json.NewDecoder(resp.Body).Decode(&regionLocale); err != nil {
+		return nil, err
+	}
+
+	return &regionLocale, nil
+}
+
 // GetGameSession returns the current game session
 func (c *Client) GetGameSession() (*GameSession, error) {
 	resp, err := c.Get("/lol-gameflow/v1/session")
@@ -277,8 +334,13 @@ func (c *Client) GetGameSession() (*GameSession, error) {
 
 // GetMatchHistoryByPUUID returns match history for a player by PUUID
 func (c *Client) GetMatchHistoryByPUUID(puuid string, count int) (*MatchHistoryResponse, error) {
+	return c.GetMatchHistoryByPUUIDContext(context.Background(), puuid, count)
+}
+
+// GetMatchHistoryByPUUIDContext returns match history for a player by PUUID, honoring ctx cancellation
+func (c *Client) GetMatchHistoryByPUUIDContext(ctx context.Context, puuid string, count int) (*MatchHistoryResponse, error) {
 	endpoint := fmt.Sprintf("/lol-match-history/v1/products/lol/%s/matches?begIndex=0&endIndex=%d", puuid, count)
-	resp, err := c.Get(endpoint)
+	resp, err := c.GetContext(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -298,14 +360,18 @@ func (c *Client) GetMatchHistoryByPUUID(puuid string, count int) (*MatchHistoryR
 
 // GamePlayer represents a player in the current game
 type GamePlayer struct {
-	PUUID      string
-	GameName   string
-	TagLine    string
-	ChampionID int
-	Team       int // 1 or 2
+	PUUID         string
+	GameName      string
+	TagLine       string
+	SummonerID    int64
+	SummonerLevel int
+	ChampionID    int
+	Position      string // selectedPosition from the session (TOP, JUNGLE, ...), empty outside ranked
+	Team          int    // 1 or 2
 }
 
-// GetGamePlayers returns all players in the current game
+// GetGamePlayers returns all players in the current game as the game session lists them.
+// It makes no per-player calls; ResolveGamePlayer adds what the session doesn't carry.
 func (c *Client) GetGamePlayers() ([]GamePlayer, string, error) {
 	session, err := c.GetGameSession()
 	if err != nil {
@@ -316,22 +382,20 @@ func (c *Client) GetGamePlayers() ([]GamePlayer, string, error) {
 
 	var players []GamePlayer
 
-	for _, p := range session.GameData.TeamOne {
-		if p.PUUID != "" {
-			players = append(players, GamePlayer{
-				PUUID:      p.PUUID,
-				ChampionID: p.ChampionID,
-				Team:       1,
-			})
-		}
-	}
-
-	for _, p := range session.GameData.TeamTwo {
-		if p.PUUID != "" {
+	teams := [][]GameSessionPlayer{session.GameData.TeamOne, session.GameData.TeamTwo}
+	for i, team := range teams {
+		for _, p := range team {
+			if p.PUUID == "" {
+				continue
+			}
 			players = append(players, GamePlayer{
 				PUUID:      p.PUUID,
+				GameName:   p.GameName,
+				TagLine:    p.TagLine,
+				SummonerID: p.SummonerID,
 				ChampionID: p.ChampionID,
-				Team:       2,
+				Position:   p.Position,
+				Team:       i + 1,
 			})
 		}
 	}
@@ -339,6 +403,24 @@ func (c *Client) GetGamePlayers() ([]GamePlayer, string, error) {
 	return players, myPUUID, nil
 }
 
+// ResolveGamePlayer fills in the summoner level the game session doesn't carry, and the
+// Riot ID and summoner ID when the session left them out
+func (c *Client) ResolveGamePlayer(ctx context.Context, player *GamePlayer) error {
+	summoner, err := c.GetSummonerByPUUID(ctx, player.PUUID)
+	if err != nil {
+		return err
+	}
+	if player.GameName == "" {
+		player.GameName = summoner.GameName
+		player.TagLine = summoner.TagLine
+	}
+	if player.SummonerID == 0 {
+		player.SummonerID = summoner.SummonerID
+	}
+	player.SummonerLevel = summoner.SummonerLevel
+	return nil
+}
+
 // GetCurrentGameChampion returns the champion ID the current player is playing
 func (c *Client) GetCurrentGameChampion() (int, string, error) {
 	puuid, err := c.GetCurrentSummonerPUUID()
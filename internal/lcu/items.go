@@ -1,129 +1,82 @@
 package lcu
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/http"
+	"strings"
 	"sync"
-	"time"
-)
-
-// ItemData holds item information
-type ItemData struct {
-	Name string `json:"name"`
-	Gold struct {
-		Total int `json:"total"`
-	} `json:"gold"`
-}
 
-// ItemInfo holds item name and gold cost
-type ItemInfo struct {
-	Name string
-	Gold int
-}
+	"ghostdraft/ddragon"
+)
 
-// ItemRegistry holds item ID to name mapping
+// ItemRegistry holds the item catalog for the pinned patch
 type ItemRegistry struct {
-	items   map[int]ItemInfo
-	mu      sync.RWMutex
-	loaded  bool
-	version string
+	dd        *ddragon.Client
+	assetBase string // Icon URLs point here: Data Dragon or the app's cached copy
+	catalog   *ddragon.Catalog
+	mu        sync.RWMutex
+	locale    string
 }
 
-// NewItemRegistry creates a new item registry
-func NewItemRegistry() *ItemRegistry {
+// NewItemRegistry creates a new item registry. Icon URLs are built under assetBase.
+func NewItemRegistry(dd *ddragon.Client, assetBase string) *ItemRegistry {
 	return &ItemRegistry{
-		items: make(map[int]ItemInfo),
+		dd:        dd,
+		assetBase: strings.TrimRight(assetBase, "/"),
 	}
 }
 
-// Load fetches item data from Data Dragon
-func (r *ItemRegistry) Load() error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	// Get latest version
-	versionsResp, err := client.Get("https://ddragon.leagueoflegends.com/api/versions.json")
+// Load fetches item data for a Data Dragon version and locale (from disk once cached)
+func (r *ItemRegistry) Load(ctx context.Context, version, locale string) error {
+	catalog, err := r.dd.Catalog(ctx, version, locale)
 	if err != nil {
-		return fmt.Errorf("failed to fetch versions: %w", err)
-	}
-	defer versionsResp.Body.Close()
-
-	var versions []string
-	if err := json.NewDecoder(versionsResp.Body).Decode(&versions); err != nil {
-		return fmt.Errorf("failed to parse versions: %w", err)
-	}
-
-	if len(versions) == 0 {
-		return fmt.Errorf("no versions available")
-	}
-
-	r.version = versions[0]
-
-	// Get item data
-	itemURL := fmt.Sprintf("https://ddragon.leagueoflegends.com/cdn/%s/data/en_US/item.json", r.version)
-	itemResp, err := client.Get(itemURL)
-	if err != nil {
-		return fmt.Errorf("failed to fetch items: %w", err)
-	}
-	defer itemResp.Body.Close()
-
-	var itemData struct {
-		Data map[string]ItemData `json:"data"`
-	}
-	if err := json.NewDecoder(itemResp.Body).Decode(&itemData); err != nil {
-		return fmt.Errorf("failed to parse items: %w", err)
+		return err
 	}
 
-	// Build ID -> ItemInfo map
-	for idStr, item := range itemData.Data {
-		var id int
-		fmt.Sscanf(idStr, "%d", &id)
-		r.items[id] = ItemInfo{
-			Name: item.Name,
-			Gold: item.Gold.Total,
-		}
-	}
+	r.mu.Lock()
+	r.catalog = catalog
+	r.locale = locale
+	r.mu.Unlock()
 
-	r.loaded = true
-	fmt.Printf("Loaded %d items from Data Dragon (v%s)\n", len(r.items), r.version)
+	fmt.Printf("Loaded %d items from Data Dragon (v%s, %s)\n", catalog.Len(), version, locale)
 	return nil
 }
 
-// GetName returns the item name for a given ID
-func (r *ItemRegistry) GetName(id int) string {
+// Catalog returns the loaded item catalog, or nil before the first load.
+// A catalog is never modified, so callers can keep it for a whole operation.
+func (r *ItemRegistry) Catalog() *ddragon.Catalog {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
+	return r.catalog
+}
 
-	if info, ok := r.items[id]; ok {
-		return info.Name
+// GetName returns the item name for a given ID
+func (r *ItemRegistry) GetName(id int) string {
+	if item, ok := r.Catalog().Item(id); ok {
+		return item.Name
 	}
 	return fmt.Sprintf("Item %d", id)
 }
 
 // GetGold returns the gold cost for an item
 func (r *ItemRegistry) GetGold(id int) int {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-
-	if info, ok := r.items[id]; ok {
-		return info.Gold
-	}
-	return 0
+	item, _ := r.Catalog().Item(id)
+	return item.Gold.Total
 }
 
 // GetVersion returns the loaded version
 func (r *ItemRegistry) GetVersion() string {
+	return r.Catalog().Version()
+}
+
+// GetLocale returns the loaded locale
+func (r *ItemRegistry) GetLocale() string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	return r.version
+	return r.locale
 }
 
 // GetIconURL returns the Data Dragon icon URL for an item
 func (r *ItemRegistry) GetIconURL(id int) string {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	return fmt.Sprintf("https://ddragon.leagueoflegends.com/cdn/%s/img/item/%d.png", r.version, id)
+	return r.assetBase + "/" + ddragon.ItemIcon(r.GetVersion(), id)
 }
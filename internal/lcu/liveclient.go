@@ -4,106 +4,124 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
-// LiveClientPlayer represents a player from the live client API
-type LiveClientPlayer struct {
-	ChampionName    string `json:"championName"`
-	IsBot           bool   `json:"isBot"`
-	IsDead          bool   `json:"isDead"`
-	Items           []LiveClientItem `json:"items"`
-	Level           int    `json:"level"`
-	Position        string `json:"position"`
-	RawChampionName string `json:"rawChampionName"`
-	RespawnTimer    float64 `json:"respawnTimer"`
-	Scores          LiveClientScores `json:"scores"`
-	SummonerName    string `json:"summonerName"`
-	Team            string `json:"team"`
-}
-
-// LiveClientItem represents an item from the live client API
-type LiveClientItem struct {
-	CanUse       bool   `json:"canUse"`
-	Consumable   bool   `json:"consumable"`
-	Count        int    `json:"count"`
-	DisplayName  string `json:"displayName"`
-	ItemID       int    `json:"itemID"`
-	Price        int    `json:"price"`
-	RawDescription string `json:"rawDescription"`
-	RawDisplayName string `json:"rawDisplayName"`
-	Slot         int    `json:"slot"`
-}
+// DefaultLiveClientURL is where the game serves the Live Client Data API
+const DefaultLiveClientURL = "https://127.0.0.1:2999"
 
-// LiveClientScores represents player scores
-type LiveClientScores struct {
-	Assists    int     `json:"assists"`
-	CreepScore int     `json:"creepScore"`
-	Deaths     int     `json:"deaths"`
-	Kills      int     `json:"kills"`
-	WardScore  float64 `json:"wardScore"`
-}
-
-// LiveClient handles communication with the live client API (localhost:2999)
+// LiveClient reads the Live Client Data API of a running game
 type LiveClient struct {
+	baseURL    string
 	httpClient *http.Client
 }
 
 // NewLiveClient creates a new live client
 func NewLiveClient() *LiveClient {
+	return NewLiveClientAt(DefaultLiveClientURL)
+}
+
+// NewLiveClientAt creates a live client for another address, e.g. a LiveReplay server
+func NewLiveClientAt(baseURL string) *LiveClient {
 	return &LiveClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
 		httpClient: &http.Client{
 			Timeout: 2 * time.Second,
 			Transport: &http.Transport{
+				// The game serves a self-signed certificate
 				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 			},
 		},
 	}
 }
 
-// GetAllPlayers fetches all players from the live game
-func (c *LiveClient) GetAllPlayers() ([]LiveClientPlayer, error) {
-	resp, err := c.httpClient.Get("https://127.0.0.1:2999/liveclientdata/playerlist")
-	if err != nil {
-		return nil, fmt.Errorf("live client not available: %w", err)
+// GetAllGameData fetches everything the API offers in one request
+func (c *LiveClient) GetAllGameData() (*LiveClientGameData, error) {
+	var data LiveClientGameData
+	if err := c.get("allgamedata", &data); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
+	return &data, nil
+}
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
-	}
+// GetAllGameDataRaw fetches allgamedata undecoded, as the LiveRecorder saves it
+func (c *LiveClient) GetAllGameDataRaw() ([]byte, error) {
+	return c.fetch("allgamedata")
+}
 
+// GetAllPlayers fetches all players from the live game
+func (c *LiveClient) GetAllPlayers() ([]LiveClientPlayer, error) {
 	var players []LiveClientPlayer
-	if err := json.NewDecoder(resp.Body).Decode(&players); err != nil {
-		return nil, fmt.Errorf("failed to parse players: %w", err)
+	if err := c.get("playerlist", &players); err != nil {
+		return nil, err
 	}
-
 	return players, nil
 }
 
-// GetActivePlayer returns the active player's summoner name
-func (c *LiveClient) GetActivePlayer() (string, error) {
-	resp, err := c.httpClient.Get("https://127.0.0.1:2999/liveclientdata/activeplayername")
-	if err != nil {
-		return "", err
+// GetActivePlayer fetches the active player's stats, abilities, runes and gold
+func (c *LiveClient) GetActivePlayer() (*LiveClientActivePlayer, error) {
+	var player LiveClientActivePlayer
+	if err := c.get("activeplayer", &player); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
+	return &player, nil
+}
 
-	var name string
-	if err := json.NewDecoder(resp.Body).Decode(&name); err != nil {
-		return "", err
+// GetEvents fetches the game's events so far
+func (c *LiveClient) GetEvents() ([]LiveClientEvent, error) {
+	var events struct {
+		Events []LiveClientEvent `json:"Events"`
+	}
+	if err := c.get("eventdata", &events); err != nil {
+		return nil, err
 	}
+	return events.Events, nil
+}
 
-	return name, nil
+// GetGameStats fetches the game mode, time and map
+func (c *LiveClient) GetGameStats() (*LiveClientGameStats, error) {
+	var stats LiveClientGameStats
+	if err := c.get("gamestats", &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
 }
 
 // IsGameRunning checks if a live game is running
 func (c *LiveClient) IsGameRunning() bool {
-	resp, err := c.httpClient.Get("https://127.0.0.1:2999/liveclientdata/activeplayername")
+	resp, err := c.httpClient.Get(c.baseURL + "/liveclientdata/activeplayername")
 	if err != nil {
 		return false
 	}
 	resp.Body.Close()
 	return resp.StatusCode == 200
 }
+
+// get fetches and decodes an endpoint under /liveclientdata
+func (c *LiveClient) get(endpoint string, v any) error {
+	body, err := c.fetch(endpoint)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", endpoint, err)
+	}
+	return nil
+}
+
+// fetch reads an endpoint under /liveclientdata
+func (c *LiveClient) fetch(endpoint string) ([]byte, error) {
+	resp, err := c.httpClient.Get(c.baseURL + "/liveclientdata/" + endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("live client not available: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,233 @@
+package lcu
+
+import "strings"
+
+// LiveClientGameData is the whole /liveclientdata/allgamedata response
+type LiveClientGameData struct {
+	ActivePlayer LiveClientActivePlayer `json:"activePlayer"`
+	AllPlayers   []LiveClientPlayer     `json:"allPlayers"`
+	Events       struct {
+		Events []LiveClientEvent `json:"Events"`
+	} `json:"events"`
+	GameData LiveClientGameStats `json:"gameData"`
+}
+
+// Me returns the active player's entry in AllPlayers, or nil if it isn't there
+func (d *LiveClientGameData) Me() *LiveClientPlayer {
+	for i := range d.AllPlayers {
+		if d.AllPlayers[i].Is(d.ActivePlayer) {
+			return &d.AllPlayers[i]
+		}
+	}
+	return nil
+}
+
+// LiveClientActivePlayer is the player running this game client, with full stats
+type LiveClientActivePlayer struct {
+	Abilities          LiveClientAbilities     `json:"abilities"`
+	ChampionStats      LiveClientChampionStats `json:"championStats"`
+	CurrentGold        float64                 `json:"currentGold"`
+	FullRunes          LiveClientFullRunes     `json:"fullRunes"`
+	Level              int                     `json:"level"`
+	RiotID             string                  `json:"riotId"` // e.g. "Faker#KR1"
+	RiotIDGameName     string                  `json:"riotIdGameName"`
+	RiotIDTagLine      string                  `json:"riotIdTagLine"`
+	SummonerName       string                  `json:"summonerName"` // Older clients only
+	TeamRelativeColors bool                    `json:"teamRelativeColors"`
+}
+
+// LiveClientAbilities are the active player's passive and spells
+type LiveClientAbilities struct {
+	Passive LiveClientAbility `json:"Passive"`
+	Q       LiveClientAbility `json:"Q"`
+	W       LiveClientAbility `json:"W"`
+	E       LiveClientAbility `json:"E"`
+	R       LiveClientAbility `json:"R"`
+}
+
+// LiveClientAbility is one ability and its rank
+type LiveClientAbility struct {
+	AbilityLevel   int    `json:"abilityLevel"` // Always 0 for the passive
+	DisplayName    string `json:"displayName"`
+	ID             string `json:"id"`
+	RawDescription string `json:"rawDescription"`
+	RawDisplayName string `json:"rawDisplayName"`
+}
+
+// LiveClientChampionStats are the active player's current champion stats
+type LiveClientChampionStats struct {
+	AbilityHaste                 float64 `json:"abilityHaste"`
+	AbilityPower                 float64 `json:"abilityPower"`
+	Armor                        float64 `json:"armor"`
+	ArmorPenetrationFlat         float64 `json:"armorPenetrationFlat"`
+	ArmorPenetrationPercent      float64 `json:"armorPenetrationPercent"`
+	AttackDamage                 float64 `json:"attackDamage"`
+	AttackRange                  float64 `json:"attackRange"`
+	AttackSpeed                  float64 `json:"attackSpeed"`
+	BonusArmorPenetrationPercent float64 `json:"bonusArmorPenetrationPercent"`
+	BonusMagicPenetrationPercent float64 `json:"bonusMagicPenetrationPercent"`
+	CritChance                   float64 `json:"critChance"`
+	CritDamage                   float64 `json:"critDamage"`
+	CurrentHealth                float64 `json:"currentHealth"`
+	HealShieldPower              float64 `json:"healShieldPower"`
+	HealthRegenRate              float64 `json:"healthRegenRate"`
+	LifeSteal                    float64 `json:"lifeSteal"`
+	MagicLethality               float64 `json:"magicLethality"`
+	MagicPenetrationFlat         float64 `json:"magicPenetrationFlat"`
+	MagicPenetrationPercent      float64 `json:"magicPenetrationPercent"`
+	MagicResist                  float64 `json:"magicResist"`
+	MaxHealth                    float64 `json:"maxHealth"`
+	MoveSpeed                    float64 `json:"moveSpeed"`
+	Omnivamp                     float64 `json:"omnivamp"`
+	PhysicalLethality            float64 `json:"physicalLethality"`
+	PhysicalVamp                 float64 `json:"physicalVamp"`
+	ResourceMax                  float64 `json:"resourceMax"`
+	ResourceRegenRate            float64 `json:"resourceRegenRate"`
+	ResourceType                 string  `json:"resourceType"` // e.g. "MANA", "ENERGY", "NONE"
+	ResourceValue                float64 `json:"resourceValue"`
+	SpellVamp                    float64 `json:"spellVamp"`
+	Tenacity                     float64 `json:"tenacity"`
+}
+
+// LiveClientRune is a rune or rune tree
+type LiveClientRune struct {
+	DisplayName    string `json:"displayName"`
+	ID             int    `json:"id"`
+	RawDescription string `json:"rawDescription"`
+	RawDisplayName string `json:"rawDisplayName"`
+}
+
+// LiveClientFullRunes is the active player's complete rune page
+type LiveClientFullRunes struct {
+	GeneralRunes      []LiveClientRune `json:"generalRunes"` // Keystone and the other five runes
+	Keystone          LiveClientRune   `json:"keystone"`
+	PrimaryRuneTree   LiveClientRune   `json:"primaryRuneTree"`
+	SecondaryRuneTree LiveClientRune   `json:"secondaryRuneTree"`
+	StatRunes         []LiveClientRune `json:"statRunes"`
+}
+
+// LiveClientPlayerRunes is what every player can see of another's runes
+type LiveClientPlayerRunes struct {
+	Keystone          LiveClientRune `json:"keystone"`
+	PrimaryRuneTree   LiveClientRune `json:"primaryRuneTree"`
+	SecondaryRuneTree LiveClientRune `json:"secondaryRuneTree"`
+}
+
+// LiveClientPlayer is one of the ten players in the game
+type LiveClientPlayer struct {
+	ChampionName    string                   `json:"championName"` // Localized
+	IsBot           bool                     `json:"isBot"`
+	IsDead          bool                     `json:"isDead"`
+	Items           []LiveClientItem         `json:"items"`
+	Level           int                      `json:"level"`
+	Position        string                   `json:"position"` // TOP, JUNGLE, MIDDLE, BOTTOM, UTILITY or empty
+	RawChampionName string                   `json:"rawChampionName"`
+	RawSkinName     string                   `json:"rawSkinName"`
+	RespawnTimer    float64                  `json:"respawnTimer"`
+	RiotID          string                   `json:"riotId"`
+	RiotIDGameName  string                   `json:"riotIdGameName"`
+	RiotIDTagLine   string                   `json:"riotIdTagLine"`
+	Runes           LiveClientPlayerRunes    `json:"runes"`
+	Scores          LiveClientScores         `json:"scores"`
+	SkinID          int                      `json:"skinID"`
+	SkinName        string                   `json:"skinName"`
+	SummonerName    string                   `json:"summonerName"` // Older clients only
+	SummonerSpells  LiveClientSummonerSpells `json:"summonerSpells"`
+	Team            string                   `json:"team"` // ORDER or CHAOS
+}
+
+// Is reports whether this is the active player, matching by Riot ID
+func (p LiveClientPlayer) Is(active LiveClientActivePlayer) bool {
+	mine := riotID(p.RiotID, p.RiotIDGameName, p.RiotIDTagLine, p.SummonerName)
+	theirs := riotID(active.RiotID, active.RiotIDGameName, active.RiotIDTagLine, active.SummonerName)
+	return mine != "" && strings.EqualFold(mine, theirs)
+}
+
+// DisplayName returns the player's Riot ID game name (without the tag)
+func (p LiveClientPlayer) DisplayName() string {
+	if p.RiotIDGameName != "" {
+		return p.RiotIDGameName
+	}
+	if name, _, ok := strings.Cut(p.RiotID, "#"); ok {
+		return name
+	}
+	if p.RiotID != "" {
+		return p.RiotID
+	}
+	return p.SummonerName
+}
+
+// riotID returns "GameName#TAG" from whichever identity fields a client version filled in
+func riotID(id, gameName, tagLine, summonerName string) string {
+	switch {
+	case id != "":
+		return id
+	case gameName != "" && tagLine != "":
+		return gameName + "#" + tagLine
+	default:
+		return summonerName
+	}
+}
+
+// LiveClientItem is an item in a player's inventory
+type LiveClientItem struct {
+	CanUse         bool   `json:"canUse"`
+	Consumable     bool   `json:"consumable"`
+	Count          int    `json:"count"`
+	DisplayName    string `json:"displayName"`
+	ItemID         int    `json:"itemID"`
+	Price          int    `json:"price"`
+	RawDescription string `json:"rawDescription"`
+	RawDisplayName string `json:"rawDisplayName"`
+	Slot           int    `json:"slot"`
+}
+
+// LiveClientScores is a player's scoreboard line
+type LiveClientScores struct {
+	Assists    int     `json:"assists"`
+	CreepScore int     `json:"creepScore"`
+	Deaths     int     `json:"deaths"`
+	Kills      int     `json:"kills"`
+	WardScore  float64 `json:"wardScore"`
+}
+
+// LiveClientSummonerSpells are a player's two summoner spells
+type LiveClientSummonerSpells struct {
+	SummonerSpellOne LiveClientSummonerSpell `json:"summonerSpellOne"`
+	SummonerSpellTwo LiveClientSummonerSpell `json:"summonerSpellTwo"`
+}
+
+// LiveClientSummonerSpell is a summoner spell, e.g. Flash
+type LiveClientSummonerSpell struct {
+	DisplayName    string `json:"displayName"`
+	RawDescription string `json:"rawDescription"`
+	RawDisplayName string `json:"rawDisplayName"`
+}
+
+// LiveClientEvent is a game event. Which fields are set depends on EventName,
+// e.g. ChampionKill fills KillerName, VictimName and Assisters.
+type LiveClientEvent struct {
+	EventID      int      `json:"EventID"`
+	EventName    string   `json:"EventName"` // GameStart, ChampionKill, DragonKill, TurretKilled, Ace, GameEnd...
+	EventTime    float64  `json:"EventTime"` // Seconds since the game started
+	KillerName   string   `json:"KillerName,omitempty"`
+	VictimName   string   `json:"VictimName,omitempty"`
+	Assisters    []string `json:"Assisters,omitempty"`
+	DragonType   string   `json:"DragonType,omitempty"`
+	Stolen       string   `json:"Stolen,omitempty"` // "True" or "False"
+	TurretKilled string   `json:"TurretKilled,omitempty"`
+	InhibKilled  string   `json:"InhibKilled,omitempty"`
+	Acer         string   `json:"Acer,omitempty"`
+	AcingTeam    string   `json:"AcingTeam,omitempty"`
+	Recipient    string   `json:"Recipient,omitempty"` // FirstBlood
+	Result       string   `json:"Result,omitempty"`    // GameEnd: "Win" or "Lose"
+}
+
+// LiveClientGameStats describes the game itself
+type LiveClientGameStats struct {
+	GameMode   string  `json:"gameMode"` // e.g. "CLASSIC", "ARAM"
+	GameTime   float64 `json:"gameTime"` // Seconds since the game started
+	MapName    string  `json:"mapName"`  // e.g. "Map11"
+	MapNumber  int     `json:"mapNumber"`
+	MapTerrain string  `json:"mapTerrain"`
+}
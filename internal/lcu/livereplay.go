@@ -0,0 +1,169 @@
+package lcu
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LiveSnapshot is one recorded allgamedata response
+type LiveSnapshot struct {
+	Time time.Time       `json:"time"`
+	Data json.RawMessage `json:"data"`
+}
+
+// LiveRecorder saves allgamedata snapshots to a JSON lines file while a game is running,
+// so in-game features can be replayed later with LiveReplay
+type LiveRecorder struct {
+	client   *LiveClient
+	path     string
+	interval time.Duration
+}
+
+// NewLiveRecorder creates a recorder that polls every interval and appends to path
+func NewLiveRecorder(client *LiveClient, path string, interval time.Duration) *LiveRecorder {
+	return &LiveRecorder{client: client, path: path, interval: interval}
+}
+
+// Run records until ctx is cancelled. Polls while no game is running are skipped.
+func (r *LiveRecorder) Run(ctx context.Context) error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer file.Close()
+	fmt.Printf("[LiveRecorder] Recording games to %s every %v\n", r.path, r.interval)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	recorded := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		raw, err := r.client.GetAllGameDataRaw()
+		if err != nil || !json.Valid(raw) {
+			if recorded > 0 {
+				fmt.Printf("[LiveRecorder] Game over, saved %d snapshots\n", recorded)
+				recorded = 0
+			}
+			continue
+		}
+		line, err := json.Marshal(LiveSnapshot{Time: time.Now(), Data: raw})
+		if err != nil {
+			continue
+		}
+		if _, err := file.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("failed to write snapshot: %w", err)
+		}
+		recorded++
+	}
+}
+
+// LoadLiveSnapshots reads a recording made by LiveRecorder, oldest first
+func LoadLiveSnapshots(path string) ([]LiveSnapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var snapshots []LiveSnapshot
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var snapshot LiveSnapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snapshot); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", path, line, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("%s has no snapshots", path)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].Time.Before(snapshots[j].Time) })
+	return snapshots, nil
+}
+
+// LiveReplay is a fake Live Client Data API that plays back recorded snapshots in real
+// time from its first request, then keeps serving the last one. Point a client at it
+// with NewLiveClientAt.
+type LiveReplay struct {
+	snapshots []LiveSnapshot
+	now       func() time.Time
+
+	mu      sync.Mutex
+	started time.Time
+}
+
+// NewLiveReplay creates a replay of snapshots (oldest first)
+func NewLiveReplay(snapshots []LiveSnapshot) *LiveReplay {
+	return &LiveReplay{snapshots: snapshots, now: time.Now}
+}
+
+// current returns the snapshot for the time elapsed since the first request
+func (r *LiveReplay) current() LiveSnapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.started.IsZero() {
+		r.started = r.now()
+	}
+	at := r.snapshots[0].Time.Add(r.now().Sub(r.started))
+	i := sort.Search(len(r.snapshots), func(i int) bool { return r.snapshots[i].Time.After(at) })
+	return r.snapshots[max(i-1, 0)]
+}
+
+// ServeHTTP serves allgamedata and the endpoints that return parts of it
+func (r *LiveReplay) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var all struct {
+		ActivePlayer json.RawMessage `json:"activePlayer"`
+		AllPlayers   json.RawMessage `json:"allPlayers"`
+		Events       json.RawMessage `json:"events"`
+		GameData     json.RawMessage `json:"gameData"`
+	}
+	snapshot := r.current()
+	if err := json.Unmarshal(snapshot.Data, &all); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var body []byte
+	switch strings.TrimPrefix(req.URL.Path, "/liveclientdata/") {
+	case "allgamedata":
+		body = snapshot.Data
+	case "activeplayer":
+		body = all.ActivePlayer
+	case "activeplayername":
+		var active LiveClientActivePlayer
+		json.Unmarshal(all.ActivePlayer, &active)
+		body, _ = json.Marshal(riotID(active.RiotID, active.RiotIDGameName, active.RiotIDTagLine, active.SummonerName))
+	case "playerlist":
+		body = all.AllPlayers
+	case "eventdata":
+		body = all.Events
+	case "gamestats":
+		body = all.GameData
+	default:
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
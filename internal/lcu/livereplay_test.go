@@ -0,0 +1,236 @@
+package lcu
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testSnapshots returns the allgamedata fixture at 10:00 and again at 10:30 with more gold
+func testSnapshots(t *testing.T) []LiveSnapshot {
+	t.Helper()
+	first, err := os.ReadFile(filepath.Join("testdata", "allgamedata.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	later := bytes.Replace(first, []byte(`"currentGold": 1337.6`), []byte(`"currentGold": 1612.0`), 1)
+	later = bytes.Replace(later, []byte(`"gameTime": 600.0`), []byte(`"gameTime": 630.0`), 1)
+
+	start := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	return []LiveSnapshot{
+		{Time: start, Data: first},
+		{Time: start.Add(30 * time.Second), Data: later},
+	}
+}
+
+// fakeClock is a settable clock for LiveReplay
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestLiveClient_AllGameData(t *testing.T) {
+	server := httptest.NewServer(NewLiveReplay(testSnapshots(t)))
+	defer server.Close()
+	client := NewLiveClientAt(server.URL + "/")
+
+	game, err := client.GetAllGameData()
+	if err != nil {
+		t.Fatalf("GetAllGameData: %v", err)
+	}
+
+	active := game.ActivePlayer
+	if active.CurrentGold != 1337.6 || active.Level != 9 {
+		t.Errorf("active player gold/level = %v/%d", active.CurrentGold, active.Level)
+	}
+	if active.Abilities.Q.AbilityLevel != 3 || active.Abilities.Passive.ID != "AhriPassive" {
+		t.Errorf("abilities = %+v", active.Abilities)
+	}
+	if active.ChampionStats.ResourceType != "MANA" || active.ChampionStats.AbilityPower != 95 {
+		t.Errorf("champion stats = %+v", active.ChampionStats)
+	}
+	if active.FullRunes.Keystone.ID != 8112 || len(active.FullRunes.GeneralRunes) != 2 {
+		t.Errorf("runes = %+v", active.FullRunes)
+	}
+
+	// The player list only has lowercase game name and tag, and no summoner name
+	me := game.Me()
+	if me == nil {
+		t.Fatal("expected to find the active player by Riot ID")
+	}
+	if me.ChampionName != "Ahri" || me.DisplayName() != "kitsune" {
+		t.Errorf("me = %s (%s)", me.ChampionName, me.DisplayName())
+	}
+	if me.SummonerSpells.SummonerSpellTwo.DisplayName != "Ignite" || me.Runes.SecondaryRuneTree.ID != 8200 {
+		t.Errorf("spells/runes = %+v / %+v", me.SummonerSpells, me.Runes)
+	}
+	if len(me.Items) != 2 || me.Items[0].ItemID != 3802 {
+		t.Errorf("items = %+v", me.Items)
+	}
+	if zed := game.AllPlayers[1]; zed.Is(active) || zed.DisplayName() != "Shadow" || zed.RespawnTimer != 12.5 {
+		t.Errorf("enemy = %+v", zed)
+	}
+
+	if len(game.Events.Events) != 2 || game.Events.Events[1].KillerName != "Kitsune" {
+		t.Errorf("events = %+v", game.Events.Events)
+	}
+	if game.GameData.GameMode != "CLASSIC" || game.GameData.MapNumber != 11 {
+		t.Errorf("game stats = %+v", game.GameData)
+	}
+}
+
+func TestLiveClient_Endpoints(t *testing.T) {
+	server := httptest.NewServer(NewLiveReplay(testSnapshots(t)))
+	defer server.Close()
+	client := NewLiveClientAt(server.URL)
+
+	if !client.IsGameRunning() {
+		t.Error("expected a running game")
+	}
+	active, err := client.GetActivePlayer()
+	if err != nil || active.RiotID != "Kitsune#EUW" {
+		t.Errorf("GetActivePlayer = %+v, %v", active, err)
+	}
+	players, err := client.GetAllPlayers()
+	if err != nil || len(players) != 2 {
+		t.Errorf("GetAllPlayers = %d players, %v", len(players), err)
+	}
+	events, err := client.GetEvents()
+	if err != nil || len(events) != 2 || events[0].EventName != "GameStart" {
+		t.Errorf("GetEvents = %+v, %v", events, err)
+	}
+	stats, err := client.GetGameStats()
+	if err != nil || stats.GameTime != 600 {
+		t.Errorf("GetGameStats = %+v, %v", stats, err)
+	}
+	if _, err := client.fetch("nonexistent"); err == nil {
+		t.Error("expected an error for an unknown endpoint")
+	}
+
+	offline := NewLiveClientAt("http://127.0.0.1:1")
+	if offline.IsGameRunning() {
+		t.Error("expected no game without a server")
+	}
+}
+
+func TestPlayerIs_SummonerNameFallback(t *testing.T) {
+	active := LiveClientActivePlayer{SummonerName: "OldName"}
+	if !(LiveClientPlayer{SummonerName: "oldname"}).Is(active) {
+		t.Error("expected a summoner name match on older clients")
+	}
+	if (LiveClientPlayer{}).Is(LiveClientActivePlayer{}) {
+		t.Error("empty identities must not match")
+	}
+}
+
+func TestLiveReplay_PlaysBackInRealTime(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	replay := NewLiveReplay(testSnapshots(t))
+	replay.now = clock.Now
+	server := httptest.NewServer(replay)
+	defer server.Close()
+	client := NewLiveClientAt(server.URL)
+
+	gameTime := func() float64 {
+		t.Helper()
+		stats, err := client.GetGameStats()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return stats.GameTime
+	}
+
+	// Playback starts at the first request, not when the replay was created
+	clock.Advance(time.Hour)
+	if got := gameTime(); got != 600 {
+		t.Errorf("first request: game time %v, want 600", got)
+	}
+	clock.Advance(29 * time.Second)
+	if got := gameTime(); got != 600 {
+		t.Errorf("after 29s: game time %v, want 600", got)
+	}
+	clock.Advance(time.Second)
+	if got := gameTime(); got != 630 {
+		t.Errorf("after 30s: game time %v, want 630", got)
+	}
+	clock.Advance(time.Hour)
+	if got := gameTime(); got != 630 {
+		t.Errorf("after the recording: game time %v, want the last snapshot", got)
+	}
+}
+
+func TestLiveRecorder_RoundTrip(t *testing.T) {
+	server := httptest.NewServer(NewLiveReplay(testSnapshots(t)))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "game.jsonl")
+	recorder := NewLiveRecorder(NewLiveClientAt(server.URL), path, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- recorder.Run(ctx) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		data, _ := os.ReadFile(path)
+		if strings.Count(string(data), "\n") >= 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("recorder did not save 3 snapshots in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	snapshots, err := LoadLiveSnapshots(path)
+	if err != nil {
+		t.Fatalf("LoadLiveSnapshots: %v", err)
+	}
+	if len(snapshots) < 3 {
+		t.Fatalf("got %d snapshots, want at least 3", len(snapshots))
+	}
+	var game LiveClientGameData
+	if err := json.Unmarshal(snapshots[0].Data, &game); err != nil {
+		t.Fatal(err)
+	}
+	if game.Me() == nil || game.GameData.GameTime != 600 {
+		t.Errorf("recorded snapshot lost data: %+v", game.GameData)
+	}
+}
+
+func TestLoadLiveSnapshots_Errors(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.jsonl")
+	os.WriteFile(empty, []byte("\n"), 0644)
+	if _, err := LoadLiveSnapshots(empty); err == nil {
+		t.Error("expected an error for a recording without snapshots")
+	}
+
+	broken := filepath.Join(dir, "broken.jsonl")
+	os.WriteFile(broken, []byte("{\"time\":\"2026-01-01T00:00:00Z\",\"data\":{}}\nnot json\n"), 0644)
+	if _, err := LoadLiveSnapshots(broken); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected a line 2 error, got %v", err)
+	}
+}
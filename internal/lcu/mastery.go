@@ -0,0 +1,49 @@
+package lcu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ChampionMastery is a player's mastery on one champion
+type ChampionMastery struct {
+	ChampionID     int   `json:"championId"`
+	ChampionLevel  int   `json:"championLevel"`
+	ChampionPoints int   `json:"championPoints"`
+	LastPlayTime   int64 `json:"lastPlayTime"`
+}
+
+// GetChampionMasteries returns all of a player's champion masteries by summoner ID
+func (c *Client) GetChampionMasteries(ctx context.Context, summonerID int64) ([]ChampionMastery, error) {
+	resp, err := c.GetContext(ctx, fmt.Sprintf("/lol-collections/v1/inventories/%d/champion-mastery", summonerID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("champion mastery request failed with status %d", resp.StatusCode)
+	}
+
+	var masteries []ChampionMastery
+	if err := json.NewDecoder(resp.Body).Decode(&masteries); err != nil {
+		return nil, fmt.Errorf("failed to parse champion mastery: %w", err)
+	}
+
+	return masteries, nil
+}
+
+// GetChampionMastery returns a player's mastery on one champion (zero value if never played)
+func (c *Client) GetChampionMastery(ctx context.Context, summonerID int64, championID int) (ChampionMastery, error) {
+	masteries, err := c.GetChampionMasteries(ctx, summonerID)
+	if err != nil {
+		return ChampionMastery{ChampionID: championID}, err
+	}
+	for _, m := range masteries {
+		if m.ChampionID == championID {
+			return m, nil
+		}
+	}
+	return ChampionMastery{ChampionID: championID}, nil
+}
@@ -21,14 +21,29 @@ type MatchGame struct {
 	QueueId      int               `json:"queueId"`
 	GameMode     string            `json:"gameMode"`
 	GameType     string            `json:"gameType"`
+	GameVersion  string            `json:"gameVersion"`
 	Participants []MatchParticipant `json:"participants"`
+	// Only populated by the game details endpoint (all 10 players)
+	ParticipantIdentities []ParticipantIdentity `json:"participantIdentities"`
 }
 
 // MatchParticipant represents a participant in a match
 type MatchParticipant struct {
-	ChampionId int                `json:"championId"`
-	Stats      ParticipantStats   `json:"stats"`
-	Timeline   ParticipantTimeline `json:"timeline"`
+	ParticipantId int                 `json:"participantId"`
+	TeamId        int                 `json:"teamId"`
+	ChampionId    int                 `json:"championId"`
+	Stats         ParticipantStats    `json:"stats"`
+	Timeline      ParticipantTimeline `json:"timeline"`
+}
+
+// ParticipantIdentity links a participant ID to a player
+type ParticipantIdentity struct {
+	ParticipantId int `json:"participantId"`
+	Player        struct {
+		PUUID    string `json:"puuid"`
+		GameName string `json:"gameName"`
+		TagLine  string `json:"tagLine"`
+	} `json:"player"`
 }
 
 // ParticipantTimeline contains role/lane info
@@ -45,6 +60,36 @@ type ParticipantStats struct {
 	Assists                int  `json:"assists"`
 	TotalMinionsKilled     int  `json:"totalMinionsKilled"`
 	NeutralMinionsKilled   int  `json:"neutralMinionsKilled"`
+	Item0                  int  `json:"item0"`
+	Item1                  int  `json:"item1"`
+	Item2                  int  `json:"item2"`
+	Item3                  int  `json:"item3"`
+	Item4                  int  `json:"item4"`
+	Item5                  int  `json:"item5"`
+	Item6                  int  `json:"item6"`
+}
+
+// Items returns the non-empty item slots (trinket included)
+func (s ParticipantStats) Items() []int {
+	var items []int
+	for _, id := range []int{s.Item0, s.Item1, s.Item2, s.Item3, s.Item4, s.Item5, s.Item6} {
+		if id > 0 {
+			items = append(items, id)
+		}
+	}
+	return items
+}
+
+// PersonalGame is a single game from the player's perspective, used for aggregation
+type PersonalGame struct {
+	ChampionID int
+	Role       string
+	Win        bool
+	Kills      int
+	Deaths     int
+	Assists    int
+	CS         int
+	Duration   int // seconds
 }
 
 // PersonalStats represents aggregated personal statistics
@@ -93,8 +138,8 @@ var roleIconURLs = map[string]string{
 	"SUPPORT": "https://raw.communitydragon.org/latest/plugins/rcp-fe-lol-clash/global/default/assets/images/position-selector/positions/icon-position-utility.png",
 }
 
-// normalizeRole converts LCU lane/role to a standard role name
-func normalizeRole(lane, role string) string {
+// NormalizeRole converts LCU lane/role to a standard role name
+func NormalizeRole(lane, role string) string {
 	switch lane {
 	case "TOP":
 		return "TOP"
@@ -144,24 +189,57 @@ func (c *Client) FetchMatchHistory(count int) (*MatchHistoryResponse, error) {
 	return &history, nil
 }
 
-// CalculatePersonalStats calculates aggregated stats from match history
-func CalculatePersonalStats(history *MatchHistoryResponse, champRegistry *ChampionRegistry) *PersonalStats {
-	stats := &PersonalStats{
-		HasData:       false,
-		ChampionStats: []ChampionPersonalStats{},
+// GetMatchHistoryPage fetches one page of a player's match history (newest first)
+func (c *Client) GetMatchHistoryPage(puuid string, begIndex, endIndex int) (*MatchHistoryResponse, error) {
+	endpoint := fmt.Sprintf("/lol-match-history/v1/products/lol/%s/matches?begIndex=%d&endIndex=%d", puuid, begIndex, endIndex)
+	resp, err := c.Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch match history page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("match history page request failed with status %d", resp.StatusCode)
+	}
+
+	var history MatchHistoryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&history); err != nil {
+		return nil, fmt.Errorf("failed to parse match history page: %w", err)
+	}
+
+	return &history, nil
+}
+
+// GetMatchDetails fetches the full game (all 10 participants) by game ID
+func (c *Client) GetMatchDetails(gameID int64) (*MatchGame, error) {
+	resp, err := c.Get(fmt.Sprintf("/lol-match-history/v1/games/%d", gameID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch game %d: %w", gameID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("game %d request failed with status %d", gameID, resp.StatusCode)
+	}
+
+	var game MatchGame
+	if err := json.NewDecoder(resp.Body).Decode(&game); err != nil {
+		return nil, fmt.Errorf("failed to parse game %d: %w", gameID, err)
 	}
 
+	return &game, nil
+}
+
+// CalculatePersonalStats calculates aggregated stats from match history
+func CalculatePersonalStats(history *MatchHistoryResponse, champRegistry *ChampionRegistry) *PersonalStats {
 	if history == nil || len(history.Games.Games) == 0 {
-		return stats
+		return &PersonalStats{HasData: false, ChampionStats: []ChampionPersonalStats{}}
 	}
 
 	// Filter to only ranked games (queue IDs: 420=ranked solo, 440=ranked flex)
 	validQueues := map[int]bool{420: true, 440: true}
 
-	var totalKills, totalDeaths, totalAssists, totalCS int
-	var totalGameDuration int
-	champData := make(map[int]*ChampionPersonalStats)
-
+	var games []PersonalGame
 	for _, game := range history.Games.Games {
 		if !validQueues[game.QueueId] {
 			continue
@@ -174,22 +252,56 @@ func CalculatePersonalStats(history *MatchHistoryResponse, champRegistry *Champi
 
 		p := game.Participants[0]
 		s := p.Stats
+		games = append(games, PersonalGame{
+			ChampionID: p.ChampionId,
+			Role:       NormalizeRole(p.Timeline.Lane, p.Timeline.Role),
+			Win:        s.Win,
+			Kills:      s.Kills,
+			Deaths:     s.Deaths,
+			Assists:    s.Assists,
+			CS:         s.TotalMinionsKilled + s.NeutralMinionsKilled,
+			Duration:   game.GameDuration,
+		})
+	}
+
+	stats := AggregatePersonalStats(games, champRegistry)
+
+	// Keep top 5 champions
+	if len(stats.ChampionStats) > 5 {
+		stats.ChampionStats = stats.ChampionStats[:5]
+	}
 
+	return stats
+}
+
+// AggregatePersonalStats aggregates already-filtered games into personal stats.
+// Champion stats are sorted by games played (descending) and not truncated.
+func AggregatePersonalStats(games []PersonalGame, champRegistry *ChampionRegistry) *PersonalStats {
+	stats := &PersonalStats{
+		HasData:       false,
+		ChampionStats: []ChampionPersonalStats{},
+	}
+
+	var totalKills, totalDeaths, totalAssists, totalCS int
+	var totalGameDuration int
+	champData := make(map[int]*ChampionPersonalStats)
+
+	for _, g := range games {
 		stats.TotalGames++
-		if s.Win {
+		if g.Win {
 			stats.Wins++
 		} else {
 			stats.Losses++
 		}
 
-		totalKills += s.Kills
-		totalDeaths += s.Deaths
-		totalAssists += s.Assists
-		totalCS += s.TotalMinionsKilled + s.NeutralMinionsKilled
-		totalGameDuration += game.GameDuration
+		totalKills += g.Kills
+		totalDeaths += g.Deaths
+		totalAssists += g.Assists
+		totalCS += g.CS
+		totalGameDuration += g.Duration
 
 		// Track per-champion stats
-		champId := p.ChampionId
+		champId := g.ChampionID
 		if _, exists := champData[champId]; !exists {
 			champName := ""
 			iconURL := ""
@@ -209,18 +321,17 @@ func CalculatePersonalStats(history *MatchHistoryResponse, champRegistry *Champi
 		}
 		cd := champData[champId]
 		cd.Games++
-		if s.Win {
+		if g.Win {
 			cd.Wins++
 		}
-		cd.AvgKills += float64(s.Kills)
-		cd.AvgDeaths += float64(s.Deaths)
-		cd.AvgAssists += float64(s.Assists)
-		cd.AvgCS += float64(s.TotalMinionsKilled + s.NeutralMinionsKilled)
-		cd.TotalDuration += game.GameDuration
+		cd.AvgKills += float64(g.Kills)
+		cd.AvgDeaths += float64(g.Deaths)
+		cd.AvgAssists += float64(g.Assists)
+		cd.AvgCS += float64(g.CS)
+		cd.TotalDuration += g.Duration
 
 		// Track role
-		role := normalizeRole(p.Timeline.Lane, p.Timeline.Role)
-		cd.RoleCounts[role]++
+		cd.RoleCounts[g.Role]++
 	}
 
 	if stats.TotalGames == 0 {
@@ -282,7 +393,7 @@ func CalculatePersonalStats(history *MatchHistoryResponse, champRegistry *Champi
 		}
 	}
 
-	// Sort champion stats by games played (descending) and keep only the top one
+	// Sort champion stats by games played (descending)
 	for i := 0; i < len(stats.ChampionStats); i++ {
 		for j := i + 1; j < len(stats.ChampionStats); j++ {
 			if stats.ChampionStats[j].Games > stats.ChampionStats[i].Games {
@@ -291,10 +402,5 @@ func CalculatePersonalStats(history *MatchHistoryResponse, champRegistry *Champi
 		}
 	}
 
-	// Keep top 5 champions
-	if len(stats.ChampionStats) > 5 {
-		stats.ChampionStats = stats.ChampionStats[:5]
-	}
-
 	return stats
 }
@@ -0,0 +1,115 @@
+package lcu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RankedQueueStats is a player's standing in one ranked queue
+type RankedQueueStats struct {
+	QueueType    string `json:"queueType"` // RANKED_SOLO_5x5, RANKED_FLEX_SR, ...
+	Tier         string `json:"tier"`      // IRON..CHALLENGER, "" or NONE when unranked
+	Division     string `json:"division"`  // I..IV, NA for apex tiers
+	LeaguePoints int    `json:"leaguePoints"`
+	Wins         int    `json:"wins"`
+	Losses       int    `json:"losses"`
+}
+
+// IsRanked reports whether the player has a tier in this queue
+func (q RankedQueueStats) IsRanked() bool {
+	return q.Tier != "" && q.Tier != "NONE"
+}
+
+// Label formats the rank for display, e.g. "Gold II" or "Master"
+func (q RankedQueueStats) Label() string {
+	if !q.IsRanked() {
+		return "Unranked"
+	}
+	tier := strings.ToUpper(q.Tier[:1]) + strings.ToLower(q.Tier[1:])
+	switch q.Tier {
+	case "MASTER", "GRANDMASTER", "CHALLENGER":
+		return tier
+	}
+	if q.Division == "" || q.Division == "NA" {
+		return tier
+	}
+	return tier + " " + q.Division
+}
+
+// RankedStats holds a player's ranked queues from the LCU
+type RankedStats struct {
+	Queues []RankedQueueStats `json:"queues"`
+}
+
+// Queue returns the stats for a queue type (zero value if not present)
+func (r *RankedStats) Queue(queueType string) RankedQueueStats {
+	if r != nil {
+		for _, q := range r.Queues {
+			if q.QueueType == queueType {
+				return q
+			}
+		}
+	}
+	return RankedQueueStats{QueueType: queueType}
+}
+
+// SoloQueue returns the ranked solo/duo standing
+func (r *RankedStats) SoloQueue() RankedQueueStats {
+	return r.Queue("RANKED_SOLO_5x5")
+}
+
+// FlexQueue returns the ranked flex standing
+func (r *RankedStats) FlexQueue() RankedQueueStats {
+	return r.Queue("RANKED_FLEX_SR")
+}
+
+// GetRankedStats returns a player's ranked standings by PUUID
+func (c *Client) GetRankedStats(ctx context.Context, puuid string) (*RankedStats, error) {
+	resp, err := c.GetContext(ctx, "/lol-ranked/v1/ranked-stats/"+puuid)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("ranked stats request failed with status %d", resp.StatusCode)
+	}
+
+	var stats RankedStats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, fmt.Errorf("failed to parse ranked stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// Summoner holds a player's Riot ID and account info
+type Summoner struct {
+	PUUID         string `json:"puuid"`
+	SummonerID    int64  `json:"summonerId"`
+	GameName      string `json:"gameName"`
+	TagLine       string `json:"tagLine"`
+	SummonerLevel int    `json:"summonerLevel"`
+}
+
+// GetSummonerByPUUID returns a player's Riot ID and level by PUUID
+func (c *Client) GetSummonerByPUUID(ctx context.Context, puuid string) (*Summoner, error) {
+	resp, err := c.GetContext(ctx, "/lol-summoner/v2/summoners/puuid/"+puuid)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("summoner request failed with status %d", resp.StatusCode)
+	}
+
+	var summoner Summoner
+	if err := json.NewDecoder(resp.Body).Decode(&summoner); err != nil {
+		return nil, fmt.Errorf("failed to parse summoner: %w", err)
+	}
+
+	return &summoner, nil
+}
@@ -38,13 +38,25 @@ type ChampSelectTimer struct {
 type ChampSelectPlayer struct {
 	CellID           int    `json:"cellId"`
 	ChampionID       int    `json:"championId"`
+	ChampionPickIntent int  `json:"championPickIntent"` // Hover before it's the player's turn
 	SummonerID       int64  `json:"summonerId"`
+	PUUID            string `json:"puuid"`
+	GameName         string `json:"gameName"`
+	TagLine          string `json:"tagLine"`
 	AssignedPosition string `json:"assignedPosition"`
 	Position         string `json:"position"`         // Alternative field
 	SelectedPosition string `json:"selectedPosition"` // Another alternative
 	Team             int    `json:"team"`
 }
 
+// GetHoveredChampion returns the locked/hovered champion, or the pick intent if none
+func (p *ChampSelectPlayer) GetHoveredChampion() int {
+	if p.ChampionID > 0 {
+		return p.ChampionID
+	}
+	return p.ChampionPickIntent
+}
+
 // GetPosition returns the player's position from available fields
 func (p *ChampSelectPlayer) GetPosition() string {
 	if p.AssignedPosition != "" {
@@ -0,0 +1,72 @@
+package scouting
+
+import (
+	"ghostdraft/internal/lcu"
+)
+
+const (
+	// smurfMaxLevel is the account level below which a strong record looks like a smurf
+	smurfMaxLevel = 60
+	// smurfWinRate is the win rate (recent or season) that flags a low-level account
+	smurfWinRate = 65
+	// smurfMinRankedGames is how many season ranked games a win rate needs to count
+	smurfMinRankedGames = 15
+	// oneTrickShare is the share of recent games on one champion that makes a one-trick
+	oneTrickShare = 0.6
+	// oneTrickMasteryPoints flags one-tricks even when recent history is short
+	oneTrickMasteryPoints = 500000
+)
+
+// Signals are everything we know about a scouted player
+type Signals struct {
+	SummonerLevel int
+	Solo          lcu.RankedQueueStats
+	Flex          lcu.RankedQueueStats
+	Mastery       lcu.ChampionMastery // on the champion they're playing
+	History       HistorySummary      // recent games, including on the current champion
+	KDA           float64             // average KDA over recent games
+}
+
+// Classification is what the signals suggest about a player
+type Classification struct {
+	Smurf    bool
+	OneTrick bool
+	OffRole  bool
+}
+
+// Classify flags smurfs, one-tricks and off-role players from their signals
+func Classify(s Signals) Classification {
+	return Classification{
+		Smurf:    isSmurf(s),
+		OneTrick: isOneTrick(s),
+		OffRole:  s.History.Autofilled,
+	}
+}
+
+// isSmurf reports a low-level account with a win rate or KDA a new player wouldn't have
+func isSmurf(s Signals) bool {
+	if s.SummonerLevel == 0 || s.SummonerLevel >= smurfMaxLevel {
+		return false
+	}
+
+	seasonGames := s.Solo.Wins + s.Solo.Losses
+	if seasonGames >= smurfMinRankedGames && float64(s.Solo.Wins)/float64(seasonGames)*100 >= smurfWinRate {
+		return true
+	}
+
+	h := s.History
+	return h.RecentGames >= minSampleGames && h.RecentWinRate >= smurfWinRate && s.KDA >= 3.5
+}
+
+// isOneTrick reports a player who mostly plays the champion they're on
+func isOneTrick(s Signals) bool {
+	if s.Mastery.ChampionPoints >= oneTrickMasteryPoints {
+		return true
+	}
+
+	h := s.History
+	if h.RecentGames < minSampleGames {
+		return false
+	}
+	return float64(h.ChampionGames)/float64(h.RecentGames) >= oneTrickShare
+}
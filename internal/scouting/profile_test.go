@@ -0,0 +1,78 @@
+package scouting
+
+import (
+	"testing"
+
+	"ghostdraft/internal/lcu"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name    string
+		signals Signals
+		want    Classification
+	}{
+		{
+			name:    "no data",
+			signals: Signals{},
+			want:    Classification{},
+		},
+		{
+			name: "low level with high season win rate",
+			signals: Signals{
+				SummonerLevel: 35,
+				Solo:          lcu.RankedQueueStats{Tier: "GOLD", Wins: 18, Losses: 4},
+			},
+			want: Classification{Smurf: true},
+		},
+		{
+			name: "low level with strong recent games",
+			signals: Signals{
+				SummonerLevel: 42,
+				History:       HistorySummary{RecentGames: 10, RecentWinRate: 80},
+				KDA:           5.2,
+			},
+			want: Classification{Smurf: true},
+		},
+		{
+			name: "high level winner is not a smurf",
+			signals: Signals{
+				SummonerLevel: 450,
+				Solo:          lcu.RankedQueueStats{Tier: "GOLD", Wins: 40, Losses: 10},
+				History:       HistorySummary{RecentGames: 10, RecentWinRate: 80},
+				KDA:           5.2,
+			},
+			want: Classification{},
+		},
+		{
+			name: "one-trick from recent games",
+			signals: Signals{
+				SummonerLevel: 300,
+				History:       HistorySummary{RecentGames: 20, ChampionGames: 15},
+			},
+			want: Classification{OneTrick: true},
+		},
+		{
+			name: "one-trick from mastery",
+			signals: Signals{
+				SummonerLevel: 300,
+				Mastery:       lcu.ChampionMastery{ChampionPoints: 900000},
+			},
+			want: Classification{OneTrick: true},
+		},
+		{
+			name: "off-role",
+			signals: Signals{
+				SummonerLevel: 300,
+				History:       HistorySummary{RecentGames: 20, ChampionGames: 2, Autofilled: true},
+			},
+			want: Classification{OffRole: true},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := Classify(tt.signals); got != tt.want {
+			t.Errorf("%s: expected %+v, got %+v", tt.name, tt.want, got)
+		}
+	}
+}
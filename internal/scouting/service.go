@@ -0,0 +1,242 @@
+package scouting
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"ghostdraft/internal/lcu"
+)
+
+const (
+	// DefaultConcurrency is how many match history requests run in parallel
+	DefaultConcurrency = 4
+	// DefaultTTL is how long a player's history stays cached, in memory and in the store
+	// (covers duo partners and repeat opponents over a session of games)
+	DefaultTTL = 2 * time.Hour
+	// historyCount is how many recent games are fetched per player
+	historyCount = 20
+	// fetchTimeout bounds a single match history fetch
+	fetchTimeout = 30 * time.Second
+)
+
+// HistoryFetcher fetches a player's recent match history
+type HistoryFetcher interface {
+	GetMatchHistoryByPUUIDContext(ctx context.Context, puuid string, count int) (*lcu.MatchHistoryResponse, error)
+}
+
+// Store keeps fetched histories so the cache survives restarts
+type Store interface {
+	ScoutedHistory(puuid string) (*lcu.MatchHistoryResponse, time.Time, bool, error)
+	SaveScoutedHistory(puuid string, history *lcu.MatchHistoryResponse, fetchedAt time.Time) error
+	PruneScoutedHistory(cutoff time.Time) error
+}
+
+// entry is a cached (or in-flight) match history lookup
+type entry struct {
+	done      chan struct{}
+	history   *lcu.MatchHistoryResponse
+	err       error
+	fetchedAt time.Time
+}
+
+// Service fetches players' match history with bounded parallelism and a per-PUUID TTL cache,
+// backed by a Store when one is set. Concurrent requests for the same PUUID share a single
+// in-flight fetch.
+type Service struct {
+	fetcher HistoryFetcher
+	ttl     time.Duration
+	sem     chan struct{}
+	now     func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	store   Store
+}
+
+// NewService creates a scouting service. maxConcurrent and ttl fall back to defaults when <= 0.
+func NewService(fetcher HistoryFetcher, maxConcurrent int, ttl time.Duration) *Service {
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultConcurrency
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Service{
+		fetcher: fetcher,
+		ttl:     ttl,
+		sem:     make(chan struct{}, maxConcurrent),
+		now:     time.Now,
+		entries: make(map[string]*entry),
+	}
+}
+
+// SetStore backs the cache with a store: fresh histories in it are used instead of
+// fetching, and every fetch is saved to it
+func (s *Service) SetStore(store Store) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store = store
+}
+
+// History returns a player's recent match history, from cache if fresh. The fetch doesn't
+// end with the caller's ctx, so callers joining it don't inherit the first caller's deadline.
+func (s *Service) History(ctx context.Context, puuid string) (*lcu.MatchHistoryResponse, error) {
+	e, owner := s.claim(puuid)
+	if owner {
+		go s.complete(context.WithoutCancel(ctx), puuid, e)
+	}
+
+	select {
+	case <-e.done:
+		return e.history, e.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// claim returns a player's fresh or in-flight entry, or registers a new in-flight entry.
+// owner is true for a new entry, which the caller must finish with complete.
+func (s *Service) claim(puuid string) (e *entry, owner bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[puuid]; ok {
+		select {
+		case <-e.done:
+			if e.err == nil && s.now().Sub(e.fetchedAt) < s.ttl {
+				return e, false
+			}
+			// Expired or failed - replace it and refetch
+		default:
+			return e, false
+		}
+	}
+
+	e = &entry{done: make(chan struct{})}
+	s.entries[puuid] = e
+	return e, true
+}
+
+// complete fills a claimed entry from the store if it holds a fresh history, or fetches
+// it within fetchTimeout, then releases its waiters
+func (s *Service) complete(ctx context.Context, puuid string, e *entry) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	store := s.store
+	s.mu.Unlock()
+
+	if store != nil {
+		history, fetchedAt, ok, err := store.ScoutedHistory(puuid)
+		if err != nil {
+			fmt.Printf("[Scouting] Failed to read stored history: %v\n", err)
+		} else if ok && s.now().Sub(fetchedAt) < s.ttl {
+			e.history, e.fetchedAt = history, fetchedAt
+			close(e.done)
+			return
+		}
+	}
+
+	e.history, e.err = s.fetch(ctx, puuid)
+	e.fetchedAt = s.now()
+
+	if e.err == nil && store != nil {
+		if err := store.SaveScoutedHistory(puuid, e.history, e.fetchedAt); err != nil {
+			fmt.Printf("[Scouting] Failed to store history: %v\n", err)
+		}
+	}
+
+	if e.err != nil {
+		// Don't keep failures around - the next request should retry
+		s.mu.Lock()
+		if s.entries[puuid] == e {
+			delete(s.entries, puuid)
+		}
+		s.mu.Unlock()
+	}
+	close(e.done)
+}
+
+// fetch performs the request once a concurrency slot is free
+func (s *Service) fetch(ctx context.Context, puuid string) (*lcu.MatchHistoryResponse, error) {
+	select {
+	case s.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-s.sem }()
+
+	return s.fetcher.GetMatchHistoryByPUUIDContext(ctx, puuid, historyCount)
+}
+
+// Warm starts background fetches for players so later lookups hit the cache. The fetches
+// are registered before Warm returns, so lookups made after it never fetch again.
+func (s *Service) Warm(puuids []string) {
+	for _, puuid := range puuids {
+		if puuid == "" {
+			continue
+		}
+		// Claimed before the goroutine starts, so a lookup right after Warm joins this fetch
+		e, owner := s.claim(puuid)
+		if !owner {
+			continue
+		}
+		go s.complete(context.Background(), puuid, e)
+	}
+}
+
+// Scout fetches every player in parallel and calls onResult as each one finishes.
+// onResult may be called concurrently. Scout returns once all players are done.
+func (s *Service) Scout(ctx context.Context, puuids []string, onResult func(puuid string, history *lcu.MatchHistoryResponse, err error)) {
+	var wg sync.WaitGroup
+	for _, puuid := range puuids {
+		wg.Add(1)
+		go func(puuid string) {
+			defer wg.Done()
+			history, err := s.History(ctx, puuid)
+			onResult(puuid, history, err)
+		}(puuid)
+	}
+	wg.Wait()
+}
+
+// IsCached reports whether a fresh (or in-flight) entry exists for a player
+func (s *Service) IsCached(puuid string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[puuid]
+	if !ok {
+		return false
+	}
+	select {
+	case <-e.done:
+		return e.err == nil && s.now().Sub(e.fetchedAt) < s.ttl
+	default:
+		return true
+	}
+}
+
+// Prune drops expired entries, from the store too
+func (s *Service) Prune() {
+	s.mu.Lock()
+	store := s.store
+	for puuid, e := range s.entries {
+		select {
+		case <-e.done:
+			if s.now().Sub(e.fetchedAt) >= s.ttl {
+				delete(s.entries, puuid)
+			}
+		default:
+		}
+	}
+	s.mu.Unlock()
+
+	if store != nil {
+		if err := store.PruneScoutedHistory(s.now().Add(-s.ttl)); err != nil {
+			fmt.Printf("[Scouting] Failed to prune stored histories: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package scouting
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ghostdraft/internal/history"
+	"ghostdraft/internal/lcu"
+)
+
+// fakeFetcher records calls and tracks peak concurrency
+type fakeFetcher struct {
+	delay   time.Duration
+	fail    map[string]bool
+	calls   atomic.Int32
+	active  atomic.Int32
+	peak    atomic.Int32
+	mu      sync.Mutex
+	perUser map[string]int
+}
+
+func (f *fakeFetcher) GetMatchHistoryByPUUIDContext(ctx context.Context, puuid string, count int) (*lcu.MatchHistoryResponse, error) {
+	f.calls.Add(1)
+	f.mu.Lock()
+	if f.perUser == nil {
+		f.perUser = make(map[string]int)
+	}
+	f.perUser[puuid]++
+	f.mu.Unlock()
+
+	n := f.active.Add(1)
+	defer f.active.Add(-1)
+	for {
+		p := f.peak.Load()
+		if n <= p || f.peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if f.fail[puuid] {
+		return nil, errors.New("boom")
+	}
+	return &lcu.MatchHistoryResponse{}, nil
+}
+
+func TestService_CachesWithinTTL(t *testing.T) {
+	fetcher := &fakeFetcher{}
+	svc := NewService(fetcher, 2, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if _, err := svc.History(context.Background(), "a"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if fetcher.calls.Load() != 1 {
+		t.Errorf("expected 1 fetch, got %d", fetcher.calls.Load())
+	}
+}
+
+func TestService_RefetchesAfterTTL(t *testing.T) {
+	fetcher := &fakeFetcher{}
+	svc := NewService(fetcher, 2, time.Minute)
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	svc.History(context.Background(), "a")
+	now = now.Add(2 * time.Minute)
+	if svc.IsCached("a") {
+		t.Error("expected entry to be expired")
+	}
+	svc.History(context.Background(), "a")
+
+	if fetcher.calls.Load() != 2 {
+		t.Errorf("expected 2 fetches, got %d", fetcher.calls.Load())
+	}
+}
+
+func TestService_StoreSurvivesRestart(t *testing.T) {
+	store, err := history.OpenStore(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	defer store.Close()
+
+	fetcher := &fakeFetcher{}
+	first := NewService(fetcher, 2, time.Minute)
+	first.SetStore(store)
+	if _, err := first.History(context.Background(), "a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A new service (as after a restart) reads the stored history instead of fetching
+	second := NewService(fetcher, 2, time.Minute)
+	second.SetStore(store)
+	if h, err := second.History(context.Background(), "a"); err != nil || h == nil {
+		t.Fatalf("expected the stored history, got %v, %v", h, err)
+	}
+	if fetcher.calls.Load() != 1 {
+		t.Errorf("expected 1 fetch across restarts, got %d", fetcher.calls.Load())
+	}
+
+	// Once the stored history expires it is fetched again, and pruned from the store
+	now := time.Now().Add(2 * time.Minute)
+	third := NewService(fetcher, 2, time.Minute)
+	third.SetStore(store)
+	third.now = func() time.Time { return now }
+	third.Prune()
+	if _, _, ok, _ := store.ScoutedHistory("a"); ok {
+		t.Error("expected the expired history to be pruned")
+	}
+	third.History(context.Background(), "a")
+	if fetcher.calls.Load() != 2 {
+		t.Errorf("expected an expired stored history to be refetched, got %d fetches", fetcher.calls.Load())
+	}
+}
+
+func TestService_DoesNotCacheErrors(t *testing.T) {
+	fetcher := &fakeFetcher{fail: map[string]bool{"a": true}}
+	svc := NewService(fetcher, 2, time.Minute)
+
+	if _, err := svc.History(context.Background(), "a"); err == nil {
+		t.Fatal("expected error")
+	}
+	svc.History(context.Background(), "a")
+	if fetcher.calls.Load() != 2 {
+		t.Errorf("expected failed lookup to be retried, got %d fetches", fetcher.calls.Load())
+	}
+}
+
+func TestService_DedupesInFlight(t *testing.T) {
+	fetcher := &fakeFetcher{delay: 50 * time.Millisecond}
+	svc := NewService(fetcher, 4, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			svc.History(context.Background(), "a")
+		}()
+	}
+	wg.Wait()
+
+	if fetcher.calls.Load() != 1 {
+		t.Errorf("expected concurrent lookups to share one fetch, got %d", fetcher.calls.Load())
+	}
+}
+
+func TestService_ScoutBoundsConcurrencyAndReportsEachPlayer(t *testing.T) {
+	fetcher := &fakeFetcher{delay: 20 * time.Millisecond, fail: map[string]bool{"p3": true}}
+	svc := NewService(fetcher, 3, time.Minute)
+
+	puuids := []string{"p0", "p1", "p2", "p3", "p4", "p5", "p6", "p7", "p8", "p9"}
+	var mu sync.Mutex
+	results := make(map[string]error)
+	svc.Scout(context.Background(), puuids, func(puuid string, history *lcu.MatchHistoryResponse, err error) {
+		mu.Lock()
+		results[puuid] = err
+		mu.Unlock()
+	})
+
+	if len(results) != len(puuids) {
+		t.Fatalf("expected %d results, got %d", len(puuids), len(results))
+	}
+	if results["p3"] == nil {
+		t.Error("expected error for p3")
+	}
+	if results["p0"] != nil {
+		t.Errorf("unexpected error for p0: %v", results["p0"])
+	}
+	if peak := fetcher.peak.Load(); peak > 3 {
+		t.Errorf("expected at most 3 concurrent fetches, saw %d", peak)
+	}
+}
+
+func TestService_WarmPopulatesCache(t *testing.T) {
+	fetcher := &fakeFetcher{delay: 10 * time.Millisecond}
+	svc := NewService(fetcher, 2, time.Minute)
+
+	svc.Warm([]string{"a", "b"})
+
+	// Both fetches are in flight as soon as Warm returns
+	svc.mu.Lock()
+	inFlight := len(svc.entries)
+	svc.mu.Unlock()
+	if inFlight != 2 {
+		t.Fatalf("expected 2 in-flight entries after Warm, got %d", inFlight)
+	}
+
+	svc.History(context.Background(), "a")
+	svc.History(context.Background(), "b")
+
+	if fetcher.calls.Load() != 2 {
+		t.Errorf("expected warm fetches to be reused, got %d fetches", fetcher.calls.Load())
+	}
+}
+
+func TestService_HonorsContextWhileWaitingForSlot(t *testing.T) {
+	fetcher := &fakeFetcher{delay: 200 * time.Millisecond}
+	svc := NewService(fetcher, 1, time.Minute)
+
+	go svc.History(context.Background(), "slow")
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := svc.History(ctx, "other"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestService_JoinedFetchOutlivesFirstCaller(t *testing.T) {
+	fetcher := &fakeFetcher{delay: 100 * time.Millisecond}
+	svc := NewService(fetcher, 2, time.Minute)
+
+	// The first caller gives up before the fetch finishes
+	shortCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := svc.History(shortCtx, "a")
+		firstErr <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+
+	// A caller joining the same fetch still gets the history
+	if _, err := svc.History(context.Background(), "a"); err != nil {
+		t.Errorf("joined caller: expected history, got %v", err)
+	}
+	if err := <-firstErr; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("first caller: expected deadline exceeded, got %v", err)
+	}
+	if fetcher.calls.Load() != 1 {
+		t.Errorf("expected one shared fetch, got %d", fetcher.calls.Load())
+	}
+}
@@ -0,0 +1,119 @@
+package scouting
+
+import (
+	"sort"
+
+	"ghostdraft/internal/lcu"
+)
+
+const (
+	// minSampleGames is how many recent SR games are needed before flagging autofill or first-timers
+	minSampleGames = 5
+	// mainRoleShare is the share of recent games a role needs to count as one of the player's roles
+	mainRoleShare = 0.25
+)
+
+// HistorySummary is what a player's recent history says about their current pick
+type HistorySummary struct {
+	RecentGames     int
+	RecentWins      int
+	RecentWinRate   float64
+	ChampionGames   int // recent games on the hovered champion
+	ChampionWins    int
+	ChampionWinRate float64
+	FirstTime       bool     // no recent games on the hovered champion
+	MainRoles       []string // champ select positions (top, jungle, middle, bottom, utility), most played first
+	Autofilled      bool     // assigned position isn't one of their main roles
+}
+
+// SummarizeHistory summarizes a player's recent Summoner's Rift games for the champion
+// they're on and the position they were assigned
+func SummarizeHistory(history *lcu.MatchHistoryResponse, championID int, position string) HistorySummary {
+	var summary HistorySummary
+	if history == nil {
+		return summary
+	}
+
+	roleGames := make(map[string]int)
+	var roleOrder []string
+
+	for _, match := range history.Games.Games {
+		if match.GameMode != "CLASSIC" || len(match.Participants) == 0 {
+			continue
+		}
+
+		// The first participant is the player we're looking at
+		p := match.Participants[0]
+		summary.RecentGames++
+		if p.Stats.Win {
+			summary.RecentWins++
+		}
+
+		if championID > 0 && p.ChampionId == championID {
+			summary.ChampionGames++
+			if p.Stats.Win {
+				summary.ChampionWins++
+			}
+		}
+
+		role := positionFromRole(lcu.NormalizeRole(p.Timeline.Lane, p.Timeline.Role))
+		if roleGames[role] == 0 {
+			roleOrder = append(roleOrder, role)
+		}
+		roleGames[role]++
+	}
+
+	if summary.RecentGames > 0 {
+		summary.RecentWinRate = float64(summary.RecentWins) / float64(summary.RecentGames) * 100
+	}
+	if summary.ChampionGames > 0 {
+		summary.ChampionWinRate = float64(summary.ChampionWins) / float64(summary.ChampionGames) * 100
+	}
+
+	// Too few games to say anything about their habits
+	if summary.RecentGames < minSampleGames {
+		return summary
+	}
+
+	summary.FirstTime = championID > 0 && summary.ChampionGames == 0
+
+	for _, role := range roleOrder {
+		if float64(roleGames[role])/float64(summary.RecentGames) >= mainRoleShare {
+			summary.MainRoles = append(summary.MainRoles, role)
+		}
+	}
+	// Most played first; roleOrder keeps recency as the tiebreak
+	sort.SliceStable(summary.MainRoles, func(i, j int) bool {
+		return roleGames[summary.MainRoles[i]] > roleGames[summary.MainRoles[j]]
+	})
+
+	if position != "" {
+		summary.Autofilled = true
+		for _, role := range summary.MainRoles {
+			if role == position {
+				summary.Autofilled = false
+				break
+			}
+		}
+	}
+
+	return summary
+}
+
+// positionFromRole maps match history roles (TOP, MID, ADC...) to champ select positions
+func positionFromRole(role string) string {
+	switch role {
+	case "TOP":
+		return "top"
+	case "JUNGLE":
+		return "jungle"
+	case "MID":
+		return "middle"
+	case "ADC":
+		return "bottom"
+	case "SUPPORT":
+		return "utility"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,90 @@
+package scouting
+
+import (
+	"testing"
+
+	"ghostdraft/internal/lcu"
+)
+
+// game builds a single-participant SR match history entry
+func game(championID int, lane, role string, win bool) lcu.MatchGame {
+	p := lcu.MatchParticipant{ChampionId: championID}
+	p.Stats.Win = win
+	p.Timeline.Lane = lane
+	p.Timeline.Role = role
+	return lcu.MatchGame{GameMode: "CLASSIC", Participants: []lcu.MatchParticipant{p}}
+}
+
+func historyOf(games ...lcu.MatchGame) *lcu.MatchHistoryResponse {
+	h := &lcu.MatchHistoryResponse{}
+	h.Games.Games = games
+	return h
+}
+
+func TestSummarizeHistory_ChampionAndWinRate(t *testing.T) {
+	h := historyOf(
+		game(103, "MIDDLE", "SOLO", true),
+		game(103, "MIDDLE", "SOLO", false),
+		game(157, "MIDDLE", "SOLO", true),
+		game(157, "MIDDLE", "SOLO", true),
+		game(7, "MIDDLE", "SOLO", false),
+	)
+
+	s := SummarizeHistory(h, 103, "middle")
+	if s.RecentGames != 5 || s.RecentWins != 3 || s.RecentWinRate != 60 {
+		t.Errorf("unexpected recent stats: %+v", s)
+	}
+	if s.ChampionGames != 2 || s.ChampionWinRate != 50 {
+		t.Errorf("unexpected champion stats: %+v", s)
+	}
+	if s.FirstTime || s.Autofilled {
+		t.Errorf("expected mid main on a played champion, got %+v", s)
+	}
+}
+
+func TestSummarizeHistory_FlagsFirstTimeAndAutofill(t *testing.T) {
+	h := historyOf(
+		game(64, "JUNGLE", "NONE", true),
+		game(64, "JUNGLE", "NONE", true),
+		game(121, "JUNGLE", "NONE", false),
+		game(121, "JUNGLE", "NONE", true),
+		game(86, "TOP", "SOLO", false),
+		game(86, "TOP", "SOLO", false),
+	)
+
+	s := SummarizeHistory(h, 412, "utility")
+	if !s.FirstTime {
+		t.Error("expected first-time pick")
+	}
+	if !s.Autofilled {
+		t.Error("expected autofill for a jungle/top player on support")
+	}
+	if len(s.MainRoles) != 2 || s.MainRoles[0] != "jungle" || s.MainRoles[1] != "top" {
+		t.Errorf("expected main roles [jungle top], got %v", s.MainRoles)
+	}
+}
+
+func TestSummarizeHistory_NeedsEnoughGames(t *testing.T) {
+	h := historyOf(
+		game(64, "JUNGLE", "NONE", true),
+		game(64, "JUNGLE", "NONE", true),
+	)
+
+	s := SummarizeHistory(h, 412, "utility")
+	if s.FirstTime || s.Autofilled {
+		t.Errorf("expected no flags on a small sample, got %+v", s)
+	}
+	if s.RecentGames != 2 {
+		t.Errorf("expected 2 recent games, got %d", s.RecentGames)
+	}
+}
+
+func TestSummarizeHistory_IgnoresOtherModes(t *testing.T) {
+	aram := game(103, "NONE", "NONE", true)
+	aram.GameMode = "ARAM"
+
+	s := SummarizeHistory(historyOf(aram), 103, "middle")
+	if s.RecentGames != 0 || s.ChampionGames != 0 {
+		t.Errorf("expected ARAM games to be ignored, got %+v", s)
+	}
+}
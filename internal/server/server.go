@@ -0,0 +1,321 @@
+// Package server is the headless companion server. It streams every overlay event to
+// browsers over Server-Sent Events and serves the app's bound getters as JSON, so the
+// overlay can be viewed on a second monitor, a phone or an OBS browser source.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"ghostdraft/internal/events"
+)
+
+//go:embed viewer.html
+var viewerPage []byte
+
+// clientBuffer is how many events a slow client may fall behind before it's dropped
+const clientBuffer = 64
+
+// keepAliveInterval stops proxies and browsers from timing out idle streams
+const keepAliveInterval = 15 * time.Second
+
+// Server fans events out to connected browsers and exposes getters over REST.
+// It implements events.Emitter.
+type Server struct {
+	token string
+
+	mu      sync.Mutex
+	clients map[chan []byte]struct{}
+	latest  map[string][]byte // last frame per event, replayed to new clients
+	order   []string          // event names in the order they were first seen
+
+	getters map[string]reflect.Value
+	routes  map[string]http.Handler // extra public routes, e.g. cached icons
+}
+
+// New creates a server that requires token on every stream and API request
+func New(token string) *Server {
+	return &Server{
+		token:   token,
+		clients: make(map[chan []byte]struct{}),
+		latest:  make(map[string][]byte),
+		getters: make(map[string]reflect.Value),
+		routes:  make(map[string]http.Handler),
+	}
+}
+
+// Emit encodes the event and sends it to every connected client
+func (s *Server) Emit(e events.Event) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		fmt.Printf("[Server] Failed to encode %s: %v\n", e.EventName(), err)
+		return
+	}
+	frame := []byte(fmt.Sprintf("event: %s\ndata: %s\n\n", e.EventName(), data))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.latest[e.EventName()]; !ok {
+		s.order = append(s.order, e.EventName())
+	}
+	s.latest[e.EventName()] = frame
+
+	for ch := range s.clients {
+		select {
+		case ch <- frame:
+		default:
+			// Too far behind - drop it rather than block the app; the browser reconnects
+			delete(s.clients, ch)
+			close(ch)
+		}
+	}
+}
+
+// Bind exposes target's exported Get* methods at /api/<Method>. Methods may return a
+// value, or a value and an error, like Wails-bound methods.
+func (s *Server) Bind(target any) {
+	v := reflect.ValueOf(target)
+	t := v.Type()
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		if !strings.HasPrefix(m.Name, "Get") {
+			continue
+		}
+		out := m.Type.NumOut()
+		if out == 0 || out > 2 || (out == 2 && m.Type.Out(1) != errorType) {
+			continue
+		}
+		s.getters[m.Name] = v.Method(i)
+	}
+}
+
+// Handle serves h at pattern without requiring the token, for static assets the viewer
+// loads with plain <img> tags. Call it before Handler or ListenAndServe.
+func (s *Server) Handle(pattern string, h http.Handler) {
+	s.routes[pattern] = h
+}
+
+// Methods returns the names of the bound getters
+func (s *Server) Methods() []string {
+	names := make([]string, 0, len(s.getters))
+	for name := range s.getters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Handler returns the HTTP routes: the viewer at /, the event stream at /events and the getters under /api/
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleViewer)
+	mux.HandleFunc("/events", s.requireToken(s.handleEvents))
+	mux.HandleFunc("/api/", s.requireToken(s.handleAPI))
+	for pattern, h := range s.routes {
+		mux.Handle(pattern, h)
+	}
+	return mux
+}
+
+// ListenAndServe serves on addr until ctx is cancelled
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		s.closeClients()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// requireToken rejects requests without the pairing token, given either as
+// "Authorization: Bearer <token>" or ?token= (EventSource can't set headers)
+func (s *Server) requireToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			token = strings.TrimPrefix(auth, "Bearer ")
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "missing or invalid pairing token"})
+			return
+		}
+		next(w, r)
+	}
+}
+
+// handleViewer serves the embedded viewer page. It holds no data, so it needs no token.
+func (s *Server) handleViewer(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(viewerPage)
+}
+
+// handleEvents streams events as Server-Sent Events, starting with the latest of each
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch, replay := s.subscribe()
+	defer s.unsubscribe(ch)
+
+	fmt.Fprint(w, "retry: 2000\n\n")
+	for _, frame := range replay {
+		w.Write(frame)
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case frame, ok := <-ch:
+			if !ok {
+				return
+			}
+			w.Write(frame)
+			flusher.Flush()
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// handleAPI calls a bound getter. GET takes no arguments; POST takes a JSON array of
+// arguments in parameter order, the same shape the Wails bindings send.
+func (s *Server) handleAPI(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/api/")
+	if name == "" {
+		writeJSON(w, http.StatusOK, s.Methods())
+		return
+	}
+
+	method, ok := s.getters[name]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": fmt.Sprintf("unknown method %q", name)})
+		return
+	}
+
+	var raw []json.RawMessage
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "body must be a JSON array of arguments"})
+			return
+		}
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "use GET or POST"})
+		return
+	}
+
+	args, err := decodeArgs(method.Type(), raw)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	results := method.Call(args)
+	if len(results) == 2 && !results[1].IsNil() {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": results[1].Interface().(error).Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, results[0].Interface())
+}
+
+// errorType is the reflect type of the error interface
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// decodeArgs converts JSON arguments into a method's parameter types
+func decodeArgs(t reflect.Type, raw []json.RawMessage) ([]reflect.Value, error) {
+	if len(raw) != t.NumIn() {
+		return nil, fmt.Errorf("expected %d arguments, got %d", t.NumIn(), len(raw))
+	}
+	args := make([]reflect.Value, t.NumIn())
+	for i := range args {
+		arg := reflect.New(t.In(i))
+		if err := json.Unmarshal(raw[i], arg.Interface()); err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i+1, err)
+		}
+		args[i] = arg.Elem()
+	}
+	return args, nil
+}
+
+// subscribe registers a client and returns its channel plus the frames to replay
+func (s *Server) subscribe() (chan []byte, [][]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch := make(chan []byte, clientBuffer)
+	s.clients[ch] = struct{}{}
+
+	replay := make([][]byte, 0, len(s.order))
+	for _, name := range s.order {
+		replay = append(replay, s.latest[name])
+	}
+	return ch, replay
+}
+
+// unsubscribe removes a client unless Emit already dropped it
+func (s *Server) unsubscribe(ch chan []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.clients[ch]; ok {
+		delete(s.clients, ch)
+		close(ch)
+	}
+}
+
+// closeClients ends every open stream
+func (s *Server) closeClients() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.clients {
+		delete(s.clients, ch)
+		close(ch)
+	}
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
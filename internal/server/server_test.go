@@ -0,0 +1,216 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ghostdraft/internal/events"
+)
+
+// fakeApp stands in for the bound App
+type fakeApp struct{}
+
+func (fakeApp) GetPatch() string { return "15.1" }
+
+func (fakeApp) GetBuild(championID int, role string) map[string]any {
+	return map[string]any{"championId": championID, "role": role}
+}
+
+func (fakeApp) GetBroken() (string, error) { return "", errors.New("stats unavailable") }
+
+func (fakeApp) UpdateSomething() string { return "should not be exposed" }
+
+// newTestServer starts a server with the fake app bound
+func newTestServer(t *testing.T) (*Server, *httptest.Server) {
+	s := New("secret")
+	s.Bind(fakeApp{})
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(func() {
+		s.closeClients()
+		ts.Close()
+	})
+	return s, ts
+}
+
+// get performs a GET and returns the status and body
+func get(t *testing.T, url string) (int, string) {
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestServer_RequiresToken(t *testing.T) {
+	_, ts := newTestServer(t)
+
+	for _, path := range []string{"/api/GetPatch", "/api/GetPatch?token=wrong", "/events"} {
+		if status, _ := get(t, ts.URL+path); status != http.StatusUnauthorized {
+			t.Errorf("%s: expected 401, got %d", path, status)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/api/GetPatch", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected bearer token to be accepted, got %d", resp.StatusCode)
+	}
+
+	// The viewer page carries no data and loads without a token
+	if status, body := get(t, ts.URL+"/"); status != http.StatusOK || !strings.Contains(body, "EventSource") {
+		t.Errorf("expected viewer page, got %d", status)
+	}
+}
+
+func TestServer_ServesPublicRoutes(t *testing.T) {
+	s := New("secret")
+	s.Handle("/ddragon/", http.StripPrefix("/ddragon", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "icon "+r.URL.Path)
+	})))
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+
+	if status, body := get(t, ts.URL+"/ddragon/cdn/15.1.1/img/item/3020.png"); status != http.StatusOK || body != "icon /cdn/15.1.1/img/item/3020.png" {
+		t.Errorf("expected the icon route without a token, got %d %q", status, body)
+	}
+	if status, _ := get(t, ts.URL+"/api/GetPatch"); status != http.StatusUnauthorized {
+		t.Errorf("expected the API to still require the token, got %d", status)
+	}
+}
+
+func TestServer_CallsGetters(t *testing.T) {
+	_, ts := newTestServer(t)
+
+	if status, body := get(t, ts.URL+"/api/GetPatch?token=secret"); status != http.StatusOK || strings.TrimSpace(body) != `"15.1"` {
+		t.Errorf("GetPatch: got %d %s", status, body)
+	}
+
+	resp, err := http.Post(ts.URL+"/api/GetBuild?token=secret", "application/json", strings.NewReader(`[103, "middle"]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || strings.TrimSpace(string(body)) != `{"championId":103,"role":"middle"}` {
+		t.Errorf("GetBuild: got %d %s", resp.StatusCode, body)
+	}
+
+	if status, _ := get(t, ts.URL+"/api/GetBuild?token=secret"); status != http.StatusBadRequest {
+		t.Errorf("expected 400 for missing arguments, got %d", status)
+	}
+	if status, body := get(t, ts.URL+"/api/GetBroken?token=secret"); status != http.StatusInternalServerError || !strings.Contains(body, "stats unavailable") {
+		t.Errorf("expected getter error as 500, got %d %s", status, body)
+	}
+	if status, _ := get(t, ts.URL+"/api/UpdateSomething?token=secret"); status != http.StatusNotFound {
+		t.Errorf("expected non-getters to be hidden, got %d", status)
+	}
+	if _, body := get(t, ts.URL+"/api/?token=secret"); strings.TrimSpace(body) != `["GetBroken","GetBuild","GetPatch"]` {
+		t.Errorf("unexpected method list %s", body)
+	}
+}
+
+func TestServer_StreamsLatestThenLiveEvents(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	// Sent before anyone connects - only the latest of each event is replayed
+	s.Emit(events.GameflowUpdate{Phase: "Lobby"})
+	s.Emit(events.GameflowUpdate{Phase: "ChampSelect"})
+	s.Emit(events.LCUStatus{Connected: true, Message: "League Connected!"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/events?token=secret", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected event stream, got %q", ct)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	next := func() string {
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("stream ended: %v", err)
+			}
+			if strings.HasPrefix(line, "event: ") {
+				data, _ := reader.ReadString('\n')
+				return strings.TrimSpace(strings.TrimPrefix(line, "event: ")) + " " + strings.TrimSpace(data)
+			}
+		}
+	}
+
+	if got := next(); got != `gameflow:update data: {"phase":"ChampSelect"}` {
+		t.Errorf("unexpected first replayed event %s", got)
+	}
+	if got := next(); got != `lcu:status data: {"connected":true,"message":"League Connected!"}` {
+		t.Errorf("unexpected second replayed event %s", got)
+	}
+
+	s.Emit(events.GoldBoxShow(true))
+	if got := next(); got != `goldbox:show data: true` {
+		t.Errorf("unexpected live event %s", got)
+	}
+}
+
+func TestServer_DropsSlowClients(t *testing.T) {
+	s := New("secret")
+	ch, _ := s.subscribe()
+
+	for i := 0; i < clientBuffer+1; i++ {
+		s.Emit(events.GameflowUpdate{Phase: "InProgress"})
+	}
+
+	drained := 0
+	for range ch {
+		drained++
+	}
+	if drained != clientBuffer {
+		t.Errorf("expected %d buffered events before the client was dropped, got %d", clientBuffer, drained)
+	}
+	s.unsubscribe(ch) // already dropped - must not close twice
+}
+
+func TestLoadToken_PersistsBetweenRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "GhostDraft", tokenFileName)
+
+	first, err := LoadToken(path)
+	if err != nil {
+		t.Fatalf("LoadToken failed: %v", err)
+	}
+	if len(first) != 32 {
+		t.Errorf("expected 32 hex characters, got %q", first)
+	}
+
+	second, _ := LoadToken(path)
+	if second != first {
+		t.Errorf("expected the saved token to be reused, got %q then %q", first, second)
+	}
+
+	reset, _ := ResetToken(path)
+	if reset == first {
+		t.Error("expected ResetToken to generate a new token")
+	}
+	if data, _ := os.ReadFile(path); strings.TrimSpace(string(data)) != reset {
+		t.Errorf("expected reset token saved, got %q", data)
+	}
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// tokenFileName holds the pairing token in the GhostDraft config directory
+const tokenFileName = "server_token"
+
+// UserTokenPath returns where the pairing token is stored
+func UserTokenPath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = "."
+	}
+	return filepath.Join(configDir, "GhostDraft", tokenFileName)
+}
+
+// LoadToken reads the pairing token from path, creating a random one on first use.
+// Keeping it between runs means paired devices and OBS sources keep working.
+func LoadToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if token := strings.TrimSpace(string(data)); token != "" {
+			return token, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read pairing token: %w", err)
+	}
+
+	return ResetToken(path)
+}
+
+// ResetToken replaces the pairing token, unpairing every device
+func ResetToken(path string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate pairing token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create token directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to write pairing token: %w", err)
+	}
+	return token, nil
+}
@@ -0,0 +1,276 @@
+// Package settings stores the user's preferences as versioned JSON in the GhostDraft config directory.
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// CurrentVersion is the settings schema version written by this build
+const CurrentVersion = 1
+
+// Settings is everything the user can change without a rebuild
+type Settings struct {
+	Version  int              `json:"version"`
+	Window   WindowSettings   `json:"window"`
+	Hotkeys  HotkeySettings   `json:"hotkeys"`
+	Matchups MatchupSettings  `json:"matchups"`
+	TeamComp TeamCompSettings `json:"teamComp"`
+}
+
+// WindowSettings controls the overlay's size and position
+type WindowSettings struct {
+	Width          int  `json:"width"`          // 0 = 20% of screen width
+	Height         int  `json:"height"`         // 0 = 55% of screen height
+	CustomPosition bool `json:"customPosition"` // false = right edge, vertically centered
+	X              int  `json:"x"`
+	Y              int  `json:"y"`
+}
+
+// HotkeySettings controls the global hotkeys
+type HotkeySettings struct {
+	Toggle       string `json:"toggle"`       // e.g. "Ctrl+O"
+	TabHUDEnable bool   `json:"tabHudEnable"` // Show the gold/build HUD while Tab is held in game
+}
+
+// MatchupSettings are the win rate cutoffs (percent) for winning/losing matchups
+type MatchupSettings struct {
+	WinningThreshold float64 `json:"winningThreshold"` // at or above = winning, and counter picks must beat it
+	LosingThreshold  float64 `json:"losingThreshold"`  // at or below = losing, and recommended bans must be under it
+}
+
+// TeamCompSettings are the damage share ratios that trigger the team comp warning
+type TeamCompSettings struct {
+	WarningRatio  float64 `json:"warningRatio"`
+	CriticalRatio float64 `json:"criticalRatio"`
+}
+
+// Defaults returns the settings the app shipped with before they were configurable
+func Defaults() Settings {
+	return Settings{
+		Version: CurrentVersion,
+		Hotkeys: HotkeySettings{
+			Toggle:       "Ctrl+O",
+			TabHUDEnable: true,
+		},
+		Matchups: MatchupSettings{
+			WinningThreshold: 51,
+			LosingThreshold:  49,
+		},
+		TeamComp: TeamCompSettings{
+			WarningRatio:  0.75,
+			CriticalRatio: 0.9,
+		},
+	}
+}
+
+// Validate checks every section and returns the first problem found
+func (s Settings) Validate() error {
+	if err := s.Window.validate(); err != nil {
+		return fmt.Errorf("window: %w", err)
+	}
+	if _, err := ParseHotkey(s.Hotkeys.Toggle); err != nil {
+		return fmt.Errorf("hotkeys: %w", err)
+	}
+	if err := s.Matchups.validate(); err != nil {
+		return fmt.Errorf("matchups: %w", err)
+	}
+	if err := s.TeamComp.validate(); err != nil {
+		return fmt.Errorf("teamComp: %w", err)
+	}
+	return nil
+}
+
+// sanitize resets any invalid section to its default so a bad edit doesn't lose the rest
+func (s *Settings) sanitize() []string {
+	defaults := Defaults()
+	var reset []string
+
+	if s.Window.validate() != nil {
+		s.Window = defaults.Window
+		reset = append(reset, "window")
+	}
+	if _, err := ParseHotkey(s.Hotkeys.Toggle); err != nil {
+		s.Hotkeys.Toggle = defaults.Hotkeys.Toggle
+		reset = append(reset, "hotkeys")
+	}
+	if s.Matchups.validate() != nil {
+		s.Matchups = defaults.Matchups
+		reset = append(reset, "matchups")
+	}
+	if s.TeamComp.validate() != nil {
+		s.TeamComp = defaults.TeamComp
+		reset = append(reset, "teamComp")
+	}
+
+	return reset
+}
+
+// validate checks window dimensions are sane
+func (w WindowSettings) validate() error {
+	if w.Width < 0 || w.Height < 0 {
+		return fmt.Errorf("size can't be negative")
+	}
+	if (w.Width != 0 && w.Width < 200) || (w.Height != 0 && w.Height < 100) {
+		return fmt.Errorf("size must be at least 200x100")
+	}
+	if w.Width > 10000 || w.Height > 10000 {
+		return fmt.Errorf("size must be at most 10000x10000")
+	}
+	return nil
+}
+
+// validate checks thresholds are percentages on the right side of 50
+func (m MatchupSettings) validate() error {
+	if m.LosingThreshold <= 0 || m.LosingThreshold > 50 {
+		return fmt.Errorf("losing threshold must be between 0 and 50")
+	}
+	if m.WinningThreshold < 50 || m.WinningThreshold >= 100 {
+		return fmt.Errorf("winning threshold must be between 50 and 100")
+	}
+	if m.LosingThreshold >= m.WinningThreshold {
+		return fmt.Errorf("losing threshold must be below winning threshold")
+	}
+	return nil
+}
+
+// validate checks the warning ratio comes before the critical ratio
+func (t TeamCompSettings) validate() error {
+	if t.WarningRatio < 0.5 || t.WarningRatio > 1 {
+		return fmt.Errorf("warning ratio must be between 0.5 and 1")
+	}
+	if t.CriticalRatio < t.WarningRatio || t.CriticalRatio > 1 {
+		return fmt.Errorf("critical ratio must be between the warning ratio and 1")
+	}
+	return nil
+}
+
+// Hotkey is a parsed key combination
+type Hotkey struct {
+	Ctrl  bool
+	Alt   bool
+	Shift bool
+	Key   string // A-Z, 0-9 or F1-F12
+}
+
+// String formats the hotkey the way it's stored, e.g. "Ctrl+Shift+O"
+func (h Hotkey) String() string {
+	var parts []string
+	if h.Ctrl {
+		parts = append(parts, "Ctrl")
+	}
+	if h.Alt {
+		parts = append(parts, "Alt")
+	}
+	if h.Shift {
+		parts = append(parts, "Shift")
+	}
+	return strings.Join(append(parts, h.Key), "+")
+}
+
+// VirtualKey returns the Windows virtual-key code for the key
+func (h Hotkey) VirtualKey() uint32 {
+	if len(h.Key) == 1 {
+		return uint32(h.Key[0]) // VK codes for A-Z and 0-9 match ASCII
+	}
+	var n uint32
+	fmt.Sscanf(h.Key, "F%d", &n)
+	return 0x70 + n - 1 // VK_F1 = 0x70
+}
+
+// ParseHotkey parses a combination like "Ctrl+O". At least one modifier is required
+// so the hotkey can't swallow normal typing.
+func ParseHotkey(s string) (Hotkey, error) {
+	var h Hotkey
+	parts := strings.Split(s, "+")
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if i < len(parts)-1 {
+			switch strings.ToLower(part) {
+			case "ctrl", "control":
+				h.Ctrl = true
+			case "alt":
+				h.Alt = true
+			case "shift":
+				h.Shift = true
+			default:
+				return Hotkey{}, fmt.Errorf("unknown modifier %q in %q", part, s)
+			}
+			continue
+		}
+
+		key := strings.ToUpper(part)
+		switch {
+		case len(key) == 1 && (key[0] >= 'A' && key[0] <= 'Z' || key[0] >= '0' && key[0] <= '9'):
+		case isFunctionKey(key):
+		default:
+			return Hotkey{}, fmt.Errorf("unsupported key %q in %q", part, s)
+		}
+		h.Key = key
+	}
+
+	if !h.Ctrl && !h.Alt && !h.Shift {
+		return Hotkey{}, fmt.Errorf("hotkey %q needs Ctrl, Alt or Shift", s)
+	}
+	return h, nil
+}
+
+// isFunctionKey reports whether key is F1-F12
+func isFunctionKey(key string) bool {
+	for i := 1; i <= 12; i++ {
+		if key == fmt.Sprintf("F%d", i) {
+			return true
+		}
+	}
+	return false
+}
+
+// migrations upgrade a raw settings document from version N to N+1
+var migrations = map[int]func(raw map[string]json.RawMessage) error{
+	// Version 0 is any file written before settings were versioned; its fields
+	// already match version 1, so there is nothing to move.
+	0: func(raw map[string]json.RawMessage) error { return nil },
+}
+
+// decode parses a settings document, migrating older versions and filling
+// missing fields from the defaults
+func decode(data []byte) (Settings, bool, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Settings{}, false, fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	version := 0
+	if v, ok := raw["version"]; ok {
+		if err := json.Unmarshal(v, &version); err != nil {
+			return Settings{}, false, fmt.Errorf("invalid settings version: %w", err)
+		}
+	}
+	if version > CurrentVersion {
+		return Settings{}, false, fmt.Errorf("settings version %d is newer than supported version %d", version, CurrentVersion)
+	}
+
+	migrated := version < CurrentVersion
+	for v := version; v < CurrentVersion; v++ {
+		migrate, ok := migrations[v]
+		if !ok {
+			return Settings{}, false, fmt.Errorf("no migration from settings version %d", v)
+		}
+		if err := migrate(raw); err != nil {
+			return Settings{}, false, fmt.Errorf("failed to migrate settings from version %d: %w", v, err)
+		}
+	}
+
+	upgraded, err := json.Marshal(raw)
+	if err != nil {
+		return Settings{}, false, err
+	}
+	s := Defaults()
+	if err := json.Unmarshal(upgraded, &s); err != nil {
+		return Settings{}, false, fmt.Errorf("failed to parse settings: %w", err)
+	}
+	s.Version = CurrentVersion
+
+	return s, migrated, nil
+}
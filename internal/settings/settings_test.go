@@ -0,0 +1,183 @@
+package settings
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenStore_CreatesDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	store, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+
+	if store.Get() != Defaults() {
+		t.Errorf("expected defaults, got %+v", store.Get())
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected settings file to be written: %v", err)
+	}
+}
+
+func TestStore_UpdatePersistsAndNotifies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	store, _ := OpenStore(path)
+
+	var gotOld, gotNew Settings
+	calls := 0
+	store.Subscribe(func(old, new Settings) {
+		calls++
+		gotOld, gotNew = old, new
+	})
+
+	next := store.Get()
+	next.Matchups.WinningThreshold = 53
+	next.Hotkeys.Toggle = "Ctrl+Shift+G"
+	if _, err := store.Update(next); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if calls != 1 || gotOld.Matchups.WinningThreshold != 51 || gotNew.Matchups.WinningThreshold != 53 {
+		t.Errorf("unexpected notification: calls=%d old=%+v new=%+v", calls, gotOld, gotNew)
+	}
+
+	reopened, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	if reopened.Get().Matchups.WinningThreshold != 53 || reopened.Get().Hotkeys.Toggle != "Ctrl+Shift+G" {
+		t.Errorf("update not persisted: %+v", reopened.Get())
+	}
+}
+
+func TestStore_UpdateRejectsInvalid(t *testing.T) {
+	store, _ := OpenStore(filepath.Join(t.TempDir(), "settings.json"))
+	store.Subscribe(func(old, new Settings) { t.Error("subscriber called for rejected update") })
+
+	tests := []struct {
+		name   string
+		mutate func(*Settings)
+	}{
+		{"thresholds inverted", func(s *Settings) { s.Matchups.WinningThreshold, s.Matchups.LosingThreshold = 48, 52 }},
+		{"critical below warning", func(s *Settings) { s.TeamComp.CriticalRatio = 0.6 }},
+		{"negative window", func(s *Settings) { s.Window.Width = -1 }},
+		{"tiny window", func(s *Settings) { s.Window.Width = 50 }},
+		{"hotkey without modifier", func(s *Settings) { s.Hotkeys.Toggle = "O" }},
+		{"unknown key", func(s *Settings) { s.Hotkeys.Toggle = "Ctrl+Space" }},
+	}
+
+	for _, tt := range tests {
+		next := store.Get()
+		tt.mutate(&next)
+		if _, err := store.Update(next); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+	if store.Get() != Defaults() {
+		t.Errorf("rejected updates changed settings: %+v", store.Get())
+	}
+}
+
+func TestOpenStore_MigratesUnversionedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	os.WriteFile(path, []byte(`{"matchups": {"winningThreshold": 55}}`), 0644)
+
+	store, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	s := store.Get()
+	if s.Version != CurrentVersion {
+		t.Errorf("expected version %d, got %d", CurrentVersion, s.Version)
+	}
+	if s.Matchups.WinningThreshold != 55 || s.Matchups.LosingThreshold != 49 {
+		t.Errorf("expected edited value kept and missing value defaulted, got %+v", s.Matchups)
+	}
+
+	// The migrated file is rewritten with a version
+	data, _ := os.ReadFile(path)
+	var onDisk Settings
+	json.Unmarshal(data, &onDisk)
+	if onDisk.Version != CurrentVersion {
+		t.Errorf("expected migrated file to be saved, got version %d", onDisk.Version)
+	}
+}
+
+func TestOpenStore_SanitizesInvalidSections(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	os.WriteFile(path, []byte(`{"version": 1, "teamComp": {"warningRatio": 2}, "matchups": {"winningThreshold": 54, "losingThreshold": 46}}`), 0644)
+
+	store, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	s := store.Get()
+	if s.TeamComp != Defaults().TeamComp {
+		t.Errorf("expected invalid team comp section reset, got %+v", s.TeamComp)
+	}
+	if s.Matchups.WinningThreshold != 54 {
+		t.Errorf("expected valid sections kept, got %+v", s.Matchups)
+	}
+}
+
+func TestOpenStore_BacksUpCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	os.WriteFile(path, []byte(`{not json`), 0644)
+
+	store, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	if store.Get() != Defaults() {
+		t.Errorf("expected defaults, got %+v", store.Get())
+	}
+	if data, err := os.ReadFile(path + ".bak"); err != nil || string(data) != `{not json` {
+		t.Errorf("expected corrupt file backed up, got %q err=%v", data, err)
+	}
+}
+
+func TestOpenStore_RejectsNewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	os.WriteFile(path, []byte(`{"version": 99}`), 0644)
+
+	store, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	if store.Get() != Defaults() {
+		t.Errorf("expected defaults for unknown version, got %+v", store.Get())
+	}
+}
+
+func TestParseHotkey(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Hotkey
+		vk   uint32
+	}{
+		{"Ctrl+O", Hotkey{Ctrl: true, Key: "O"}, 0x4F},
+		{"ctrl + shift + g", Hotkey{Ctrl: true, Shift: true, Key: "G"}, 0x47},
+		{"Alt+F10", Hotkey{Alt: true, Key: "F10"}, 0x79},
+		{"Ctrl+1", Hotkey{Ctrl: true, Key: "1"}, 0x31},
+	}
+	for _, tt := range tests {
+		got, err := ParseHotkey(tt.in)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: expected %+v, got %+v", tt.in, tt.want, got)
+		}
+		if got.VirtualKey() != tt.vk {
+			t.Errorf("%q: expected VK 0x%X, got 0x%X", tt.in, tt.vk, got.VirtualKey())
+		}
+	}
+
+	if h, _ := ParseHotkey("shift+ctrl+o"); h.String() != "Ctrl+Shift+O" {
+		t.Errorf("expected normalized Ctrl+Shift+O, got %s", h.String())
+	}
+}
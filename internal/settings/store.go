@@ -0,0 +1,120 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store holds the current settings and persists every update
+type Store struct {
+	path string
+
+	mu          sync.RWMutex
+	current     Settings
+	subscribers []func(old, new Settings)
+}
+
+// NewStore loads settings.json from the GhostDraft config directory
+func NewStore() (*Store, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		configDir = "."
+	}
+
+	dir := filepath.Join(configDir, "GhostDraft")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create settings directory: %w", err)
+	}
+
+	return OpenStore(filepath.Join(dir, "settings.json"))
+}
+
+// OpenStore loads settings from path. A missing file starts from the defaults; an unreadable
+// one is set aside as path.bak and replaced with the defaults.
+func OpenStore(path string) (*Store, error) {
+	s := &Store{path: path, current: Defaults()}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, s.save(s.current)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	loaded, migrated, err := decode(data)
+	if err != nil {
+		fmt.Printf("[Settings] %v - backing up and using defaults\n", err)
+		os.WriteFile(path+".bak", data, 0644)
+		return s, s.save(s.current)
+	}
+
+	reset := loaded.sanitize()
+	if len(reset) > 0 {
+		fmt.Printf("[Settings] Reset invalid sections to defaults: %v\n", reset)
+	}
+	s.current = loaded
+
+	if migrated || len(reset) > 0 {
+		return s, s.save(s.current)
+	}
+	return s, nil
+}
+
+// Get returns a copy of the current settings
+func (s *Store) Get() Settings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+// Update validates and saves new settings, then notifies subscribers
+func (s *Store) Update(next Settings) (Settings, error) {
+	next.Version = CurrentVersion
+	if err := next.Validate(); err != nil {
+		return s.Get(), err
+	}
+
+	s.mu.Lock()
+	if err := s.save(next); err != nil {
+		current := s.current
+		s.mu.Unlock()
+		return current, err
+	}
+	old := s.current
+	s.current = next
+	subscribers := append([]func(old, new Settings){}, s.subscribers...)
+	s.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(old, next)
+	}
+	return next, nil
+}
+
+// Subscribe registers fn to be called after every successful update
+func (s *Store) Subscribe(fn func(old, new Settings)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, fn)
+}
+
+// save writes settings atomically so a crash can't leave a half-written file
+func (s *Store) save(settings Settings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to save settings: %w", err)
+	}
+	return nil
+}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"embed"
+	"flag"
 	"fmt"
+	"net/http"
+	"os"
 
 	"github.com/joho/godotenv"
 	"github.com/wailsapp/wails/v2"
@@ -15,6 +18,13 @@ import (
 var assets embed.FS
 
 func main() {
+	headless := flag.Bool("headless", false, "Run without a window and serve the overlay over HTTP")
+	listen := flag.String("listen", defaultListenAddr, "Companion server address in headless mode (use 0.0.0.0:7421 for other devices)")
+	resetToken := flag.Bool("reset-token", false, "Generate a new pairing token, unpairing every device")
+	recordLive := flag.String("record-live", "", "Save Live Client snapshots of every game to this file")
+	replayLive := flag.String("replay-live", "", "Read in-game data from a recording instead of the game")
+	flag.Parse()
+
 	// Load .env file for development (ignored if not present)
 	if err := godotenv.Load(); err != nil {
 		fmt.Println("[Config] No .env file found (using build-time or system env vars)")
@@ -24,6 +34,21 @@ func main() {
 
 	// Create an instance of the app structure
 	app := NewApp()
+	app.liveRecordPath = *recordLive
+	if *replayLive != "" {
+		if err := app.replayLiveGame(*replayLive); err != nil {
+			fmt.Printf("[LiveReplay] %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *headless {
+		if err := runHeadless(app, *listen, *resetToken); err != nil {
+			fmt.Printf("[Server] %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	// Create application with options
 	err := wails.Run(&options.App{
@@ -36,6 +61,8 @@ func main() {
 		DisableResize: true,
 		AssetServer: &assetserver.Options{
 			Assets: assets,
+			// Data Dragon icons, served from the disk cache so they work offline
+			Handler: http.StripPrefix(assetRoute, app.ddragon.Handler()),
 		},
 		BackgroundColour: &options.RGBA{R: 0, G: 0, B: 0, A: 0},
 		OnStartup:        app.startup,